@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/generator"
+	"github.com/spf13/cobra"
+)
+
+var appendCmd = &cobra.Command{
+	Use:   "append <tag>",
+	Short: "Add the releases a rolling CHANGELOG.md is missing, up to a tag",
+	Long: `Read the output file, find the newest release tag it documents, and generate
+one section for each release after it up to and including <tag>. The new
+sections are inserted above the existing ones, newest first, so the file keeps
+growing one release at a time. Nothing is written when <tag> is already
+documented.
+
+Version tags are ordered by semver like backfill. The output file must
+already document at least one release tag; start it with backfill or
+generate --prepend.
+
+Examples:
+  changelog-generator append v1.4.0
+  changelog-generator append v2.0.0-rc.1 --include-prereleases --output=docs/CHANGELOG.md`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAppend,
+}
+
+func init() {
+	addCommonFlags(appendCmd)
+	appendCmd.Flags().Bool("include-prereleases", false, "Treat prerelease tags (e.g., v2.0.0-rc.1) as releases")
+}
+
+func runAppend(cmd *cobra.Command, args []string) error {
+	ctx, cancel := withTimeout(cmd.Context())
+	defer cancel()
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	if err := cfg.ValidateRepository(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	if cfg.Format != "markdown" {
+		return fmt.Errorf("append only supports --format=markdown")
+	}
+	if cfg.Prepend {
+		return fmt.Errorf("append always inserts above the existing sections; drop --prepend")
+	}
+	if cfg.OutputPath == "" || cfg.OutputPath == "-" {
+		return fmt.Errorf("append needs an output file to extend (--output)")
+	}
+	includePrereleases, _ := cmd.Flags().GetBool("include-prereleases")
+
+	existing, err := os.ReadFile(cfg.OutputPath)
+	if err != nil {
+		return fmt.Errorf("read existing changelog: %w", err)
+	}
+
+	source, err := connectProvider(ctx)
+	if err != nil {
+		return err
+	}
+	tags, err := source.ListTags(ctx)
+	if err != nil {
+		return fmt.Errorf("list tags: %w", err)
+	}
+	pairs, err := generator.AppendPairs(tags, generator.DocumentedVersions(string(existing)), args[0], includePrereleases)
+	if err != nil {
+		return err
+	}
+	if len(pairs) == 0 {
+		fmt.Printf("%s already documents %s; nothing to append\n", cfg.OutputPath, args[0])
+		return nil
+	}
+	gen, err := buildGenerator(source)
+	if err != nil {
+		return err
+	}
+	logger.Info("appending releases", "path", cfg.OutputPath, "from", pairs[0].From, "to", args[0], "releases", len(pairs))
+
+	if cfg.DryRun {
+		return dryRunBackfill(ctx, gen, pairs, &generator.BackfillCheckpoint{Sections: map[string]string{}})
+	}
+
+	sections := make(map[string]string, len(pairs))
+	for i, pair := range pairs {
+		fmt.Printf("[%d/%d] %s..%s\n", i+1, len(pairs), pair.From, pair.To)
+		changelog, err := gen.Generate(ctx, pair.From, pair.To)
+		if err != nil {
+			return fmt.Errorf("generate %s..%s: %w", pair.From, pair.To, err)
+		}
+		sections[pair.To] = changelog.Markdown
+	}
+
+	content := generator.AppendReleases(string(existing), pairs, sections)
+	if err := writeOutput(content, fmt.Sprintf(" (%d releases appended)", len(pairs))); err != nil {
+		return err
+	}
+	return runPostGenerate(ctx, pairs[0].From, args[0], args[0])
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/keyring"
+	"github.com/spf13/cobra"
+)
+
+// authServices maps the names auth login accepts to keychain accounts
+var authServices = map[string]struct {
+	account string
+	prompt  string
+}{
+	"github": {keyring.GitHubToken, "GitHub token:"},
+	"openai": {keyring.OpenAIAPIKey, "OpenAI API key:"},
+}
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Keep the GitHub and OpenAI tokens in the OS keychain",
+	Long: `Store the GitHub token and OpenAI API key in the operating system's keychain
+(macOS Keychain, or the Secret Service through secret-tool on Linux) so they
+need not live in environment variables or config files. GITHUB_TOKEN and
+OPENAI_API_KEY still take precedence when set.`,
+}
+
+var authLoginCmd = &cobra.Command{
+	Use:   "login [github|openai]...",
+	Short: "Store tokens in the OS keychain",
+	Long: `Prompt for the GitHub token and OpenAI API key (or only the named ones) and
+store them in the keychain; a blank answer leaves that token unchanged.
+
+Examples:
+  changelog-generator auth login
+  gh auth token | changelog-generator auth login github --with-token`,
+	ValidArgs: []string{"github", "openai"},
+	Args:      cobra.OnlyValidArgs,
+	RunE:      runAuthLogin,
+}
+
+var authLogoutCmd = &cobra.Command{
+	Use:       "logout [github|openai]...",
+	Short:     "Remove stored tokens from the OS keychain",
+	ValidArgs: []string{"github", "openai"},
+	Args:      cobra.OnlyValidArgs,
+	RunE:      runAuthLogout,
+}
+
+func init() {
+	authLoginCmd.Flags().Bool("with-token", false, "Read the token from stdin instead of prompting (requires exactly one service)")
+	authCmd.AddCommand(authLoginCmd)
+	authCmd.AddCommand(authLogoutCmd)
+}
+
+// authTargets returns the services named on the command line, or all of them
+func authTargets(args []string) []string {
+	if len(args) == 0 {
+		return []string{"github", "openai"}
+	}
+	return args
+}
+
+func runAuthLogin(cmd *cobra.Command, args []string) error {
+	withToken, _ := cmd.Flags().GetBool("with-token")
+	if withToken {
+		if len(args) != 1 {
+			return fmt.Errorf("--with-token needs exactly one service (github or openai)")
+		}
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("read token from stdin: %w", err)
+		}
+		token := strings.TrimSpace(string(data))
+		if token == "" {
+			return fmt.Errorf("no token on stdin")
+		}
+		return storeToken(args[0], token)
+	}
+
+	for _, name := range authTargets(args) {
+		var token string
+		if err := survey.AskOne(&survey.Password{Message: authServices[name].prompt}, &token); err != nil {
+			return err
+		}
+		if token = strings.TrimSpace(token); token == "" {
+			fmt.Printf("Skipped %s\n", name)
+			continue
+		}
+		if err := storeToken(name, token); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func storeToken(name, token string) error {
+	if err := keyring.Set(authServices[name].account, token); err != nil {
+		return fmt.Errorf("store %s token: %w", name, err)
+	}
+	fmt.Printf("✓ Stored %s token in the keychain\n", name)
+	return nil
+}
+
+func runAuthLogout(cmd *cobra.Command, args []string) error {
+	for _, name := range authTargets(args) {
+		if err := keyring.Delete(authServices[name].account); err != nil {
+			return fmt.Errorf("remove %s token: %w", name, err)
+		}
+		fmt.Printf("✓ Removed %s token from the keychain\n", name)
+	}
+	return nil
+}
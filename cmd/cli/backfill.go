@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/generator"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/spf13/cobra"
+)
+
+var backfillCmd = &cobra.Command{
+	Use:   "backfill",
+	Short: "Generate a complete historical changelog from every tag pair",
+	Long: `Walk the repository's tags oldest first (or from --from-tag), generate a
+changelog for each consecutive pair, and assemble them newest first into one
+historical changelog.
+
+Every generated release is saved to a checkpoint file as soon as it is done,
+so an interrupted run, a failure, or a run stopped by --max-releases or
+--max-cost resumes where it left off when rerun with the same checkpoint.
+Version tags are ordered by semver; repositories without them are ordered by
+tag commit date. The first tag has no predecessor and gets no section.
+
+Examples:
+  changelog-generator backfill --owner=myorg --repo=myrepo --dry-run
+  changelog-generator backfill --owner=myorg --repo=myrepo --max-cost=5 --delay=2s
+  changelog-generator backfill --owner=myorg --repo=myrepo --from-tag=v1.0.0 --max-releases=20`,
+	Args: cobra.NoArgs,
+	RunE: runBackfill,
+}
+
+func init() {
+	addCommonFlags(backfillCmd)
+	backfillCmd.Flags().String("from-tag", "", "Start the history at this tag instead of the first tag")
+	backfillCmd.Flags().Bool("include-prereleases", false, "Include prerelease tags (e.g., v2.0.0-rc.1) in the history")
+	backfillCmd.Flags().String("checkpoint", ".changelog-backfill.json", "File recording generated releases so reruns resume where the last run stopped")
+	backfillCmd.Flags().Int("max-releases", 0, "Generate at most this many releases per run (0 = unlimited)")
+	backfillCmd.Flags().Float64("max-cost", 0, "Stop before the next release once the estimated LLM spend reaches this many US dollars (0 = unlimited)")
+	backfillCmd.Flags().Duration("delay", 0, "Wait this long between releases to stay under API rate limits, e.g. 2s")
+}
+
+func runBackfill(cmd *cobra.Command, args []string) error {
+	ctx, cancel := withTimeout(cmd.Context())
+	defer cancel()
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	if err := cfg.ValidateRepository(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	if cfg.Format != "markdown" {
+		return fmt.Errorf("backfill only supports --format=markdown")
+	}
+	fromTag, _ := cmd.Flags().GetString("from-tag")
+	includePrereleases, _ := cmd.Flags().GetBool("include-prereleases")
+	checkpointPath, _ := cmd.Flags().GetString("checkpoint")
+	maxReleases, _ := cmd.Flags().GetInt("max-releases")
+	maxCost, _ := cmd.Flags().GetFloat64("max-cost")
+	delay, _ := cmd.Flags().GetDuration("delay")
+
+	source, err := connectProvider(ctx)
+	if err != nil {
+		return err
+	}
+	tags, err := source.ListTags(ctx)
+	if err != nil {
+		return fmt.Errorf("list tags: %w", err)
+	}
+	pairs, err := generator.BackfillPairs(tags, includePrereleases, fromTag)
+	if err != nil {
+		return err
+	}
+	if len(pairs) == 0 {
+		return fmt.Errorf("backfill needs at least two release tags")
+	}
+	gen, err := buildGenerator(source)
+	if err != nil {
+		return err
+	}
+
+	repo := cfg.RepoOwner + "/" + cfg.RepoName
+	checkpoint, err := generator.LoadBackfillCheckpoint(checkpointPath, repo)
+	if err != nil {
+		return err
+	}
+	logger.Info("starting backfill", "repo", repo, "releases", len(pairs), "done", len(checkpoint.Sections), "checkpoint", checkpointPath)
+
+	if cfg.DryRun {
+		return dryRunBackfill(ctx, gen, pairs, checkpoint)
+	}
+
+	if _, known := llm.LookupPrice(cfg.OpenAIModel); maxCost > 0 && !known {
+		logger.Warn("no pricing for model; --max-cost cannot be enforced", "model", cfg.OpenAIModel)
+	}
+
+	generated := 0
+	for i, pair := range pairs {
+		if _, done := checkpoint.Sections[pair.To]; done {
+			continue
+		}
+		if maxReleases > 0 && generated >= maxReleases {
+			fmt.Printf("Stopping after %d releases (--max-releases); rerun to continue\n", generated)
+			break
+		}
+		if spent, known := llm.EstimateCost(cfg.OpenAIModel, checkpoint.Usage.InputTokens, checkpoint.Usage.OutputTokens); maxCost > 0 && known && spent >= maxCost {
+			fmt.Printf("Stopping at ~$%.2f of the $%.2f budget (--max-cost); rerun with a higher budget to continue\n", spent, maxCost)
+			break
+		}
+		if generated > 0 && delay > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+
+		fmt.Printf("[%d/%d] %s..%s\n", i+1, len(pairs), pair.From, pair.To)
+		before := gen.Usage()
+		changelog, err := gen.Generate(ctx, pair.From, pair.To)
+		if err != nil {
+			return fmt.Errorf("generate %s..%s (progress saved to %s; rerun to resume): %w", pair.From, pair.To, checkpointPath, err)
+		}
+		after := gen.Usage()
+		checkpoint.Usage.Calls += after.Calls - before.Calls
+		checkpoint.Usage.InputTokens += after.InputTokens - before.InputTokens
+		checkpoint.Usage.OutputTokens += after.OutputTokens - before.OutputTokens
+		checkpoint.Sections[pair.To] = changelog.Markdown
+		if err := checkpoint.Save(checkpointPath); err != nil {
+			return err
+		}
+		generated++
+	}
+
+	done := 0
+	for _, pair := range pairs {
+		if _, ok := checkpoint.Sections[pair.To]; ok {
+			done++
+		}
+	}
+	markdown := generator.AssembleBackfill(repo, pairs, checkpoint.Sections)
+	if err := writeOutput(markdown, fmt.Sprintf(" (%d of %d releases)", done, len(pairs))); err != nil {
+		return err
+	}
+	if spent, known := llm.EstimateCost(cfg.OpenAIModel, checkpoint.Usage.InputTokens, checkpoint.Usage.OutputTokens); known {
+		fmt.Printf("LLM usage so far: %d calls, ~$%.2f\n", checkpoint.Usage.Calls, spent)
+	}
+	return nil
+}
+
+// dryRunBackfill prints the prompt size and estimated cost of every release
+// the backfill still has to generate
+func dryRunBackfill(ctx context.Context, gen *generator.Generator, pairs []generator.BackfillPair, checkpoint *generator.BackfillCheckpoint) error {
+	var pending, inputTokens, outputTokens int
+	for _, pair := range pairs {
+		if _, done := checkpoint.Sections[pair.To]; done {
+			continue
+		}
+		report, err := gen.DryRun(ctx, pair.From, pair.To)
+		if err != nil {
+			return fmt.Errorf("dry run %s..%s: %w", pair.From, pair.To, err)
+		}
+		fmt.Printf("%s..%s: %d commits, ~%d input tokens\n", pair.From, pair.To, report.Commits, report.InputTokens)
+		pending++
+		inputTokens += report.InputTokens
+		outputTokens += report.OutputTokens
+	}
+
+	fmt.Printf("\n%d of %d releases to generate, ~%d input and ~%d output tokens\n", pending, len(pairs), inputTokens, outputTokens)
+	if cost, known := llm.EstimateCost(cfg.OpenAIModel, inputTokens, outputTokens); known {
+		fmt.Printf("Estimated cost: ~$%.2f\n", cost)
+	}
+	return nil
+}
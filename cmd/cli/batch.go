@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/generator"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/spf13/cobra"
+)
+
+// defaultBatchFile records the submitted batch between generate --batch runs
+const defaultBatchFile = ".changelog-batch.json"
+
+var batchCmd = &cobra.Command{
+	Use:   "batch",
+	Short: "Check on and collect timeline changelogs submitted with generate --batch",
+	Long: `generate --batch submits a timeline's per-release LLM requests to the OpenAI
+Batch API, which costs half as much and finishes within 24 hours, and records
+the job in a batch file. These commands check on that job and, once it is
+done, assemble the changelog from its results.
+
+Examples:
+  changelog-generator generate --owner=myorg --repo=myrepo --from-date=2023-01-01 --to-date=2025-12-31 --batch
+  changelog-generator batch poll
+  changelog-generator batch collect`,
+}
+
+var batchPollCmd = &cobra.Command{
+	Use:   "poll",
+	Short: "Show the progress of the submitted batch",
+	Args:  cobra.NoArgs,
+	RunE:  runBatchPoll,
+}
+
+var batchCollectCmd = &cobra.Command{
+	Use:   "collect",
+	Short: "Write the changelog from the finished batch",
+	Long: `Generate the timeline the batch was submitted for: its repository and date
+range, written in the recorded format to the recorded output path; other
+settings come from the config file and environment. Once the batch is done,
+it writes the changelog from the batch results, generating live only the
+releases the batch has no response for, and removes the batch file. While the
+batch is still running, it reports its progress instead.`,
+	Args: cobra.NoArgs,
+	RunE: runBatchCollect,
+}
+
+func init() {
+	batchCmd.AddCommand(batchPollCmd)
+	batchCmd.AddCommand(batchCollectCmd)
+	batchCmd.PersistentFlags().String("batch-file", defaultBatchFile, "File recording the batch submitted by generate --batch")
+}
+
+// loadBatchFile reads the batch file named by --batch-file, failing when there is none
+func loadBatchFile(cmd *cobra.Command) (*generator.TimelineBatch, error) {
+	path, _ := cmd.Flags().GetString("batch-file")
+	batch, err := generator.LoadTimelineBatch(path)
+	if err != nil {
+		return nil, err
+	}
+	if batch == nil {
+		return nil, fmt.Errorf("no batch file at %s; submit a batch with generate --batch first", path)
+	}
+	return batch, nil
+}
+
+func runBatchPoll(cmd *cobra.Command, args []string) error {
+	ctx, cancel := withTimeout(cmd.Context())
+	defer cancel()
+
+	batch, err := loadBatchFile(cmd)
+	if err != nil {
+		return err
+	}
+	cfg.ResolveCredentials()
+	if cfg.OpenAIAPIKey == "" {
+		return fmt.Errorf("configuration error: OPENAI_API_KEY is required")
+	}
+	client := llm.NewOpenAIClient(cfg.OpenAIAPIKey, cfg.OpenAIModel, cfg.MaxTokens, cfg.Temperature)
+	status, err := client.BatchStatus(ctx, batch.ID)
+	if err != nil {
+		return err
+	}
+	fmt.Print(formatBatchStatus(batch, status))
+	return nil
+}
+
+func runBatchCollect(cmd *cobra.Command, args []string) error {
+	batch, err := loadBatchFile(cmd)
+	if err != nil {
+		return err
+	}
+	path, _ := cmd.Flags().GetString("batch-file")
+	owner, name, ok := strings.Cut(batch.Repo, "/")
+	if !ok {
+		return fmt.Errorf("batch file %s does not name a repository", path)
+	}
+	cfg.RepoOwner, cfg.RepoName = owner, name
+	if batch.Format != "" {
+		cfg.Format = batch.Format
+	}
+	if batch.OutputPath != "" {
+		cfg.OutputPath = batch.OutputPath
+	}
+
+	ctx, cancel := withTimeout(cmd.Context())
+	defer cancel()
+	return runTimelineMode(ctx, batch.FromDate.Format("2006-01-02"), batch.ToDate.Format("2006-01-02"), path)
+}
+
+// runTimelineBatch submits the timeline's release requests as a Batch API job
+// when the batch file has none, and otherwise loads the job's results into the
+// generator once it is done. It reports whether the timeline can be generated.
+func runTimelineBatch(ctx context.Context, gen *generator.Generator, path string, from, to time.Time) (bool, error) {
+	batch, err := generator.LoadTimelineBatch(path)
+	if err != nil {
+		return false, err
+	}
+
+	if batch == nil {
+		batch, err = gen.SubmitTimelineBatch(ctx, from, to)
+		if err != nil {
+			return false, err
+		}
+		batch.Format, batch.OutputPath = cfg.Format, cfg.OutputPath
+		if err := batch.Save(path); err != nil {
+			return false, err
+		}
+		fmt.Printf("Submitted batch %s with %d release requests; it finishes within 24 hours.\n", batch.ID, len(batch.Releases))
+		fmt.Println("Check on it with `changelog-generator batch poll`, then write the changelog with `changelog-generator batch collect`.")
+		return false, nil
+	}
+
+	repo := cfg.RepoOwner + "/" + cfg.RepoName
+	if !batch.Matches(repo, from, to) {
+		return false, fmt.Errorf("batch file %s holds a batch for %s from %s to %s; collect it or remove the file before submitting another",
+			path, batch.Repo, batch.FromDate.Format("2006-01-02"), batch.ToDate.Format("2006-01-02"))
+	}
+	status, err := gen.BatchStatus(ctx, batch.ID)
+	if err != nil {
+		return false, err
+	}
+	if !status.Done() {
+		fmt.Print(formatBatchStatus(batch, status))
+		return false, nil
+	}
+	if status.Status != "completed" {
+		logger.Warn("batch did not complete; releases without a response are generated live", "id", batch.ID, "status", status.Status)
+	}
+	if err := gen.UseBatchResults(ctx, batch.ID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// formatBatchStatus renders the progress of a submitted batch
+func formatBatchStatus(batch *generator.TimelineBatch, status llm.BatchStatus) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Batch %s for %s (%s to %s), submitted %s\n", batch.ID, batch.Repo,
+		batch.FromDate.Format("2006-01-02"), batch.ToDate.Format("2006-01-02"), batch.SubmittedAt.Local().Format("2006-01-02 15:04")))
+	sb.WriteString(fmt.Sprintf("Status: %s, %d of %d requests completed", status.Status, status.Completed, status.Total))
+	if status.Failed > 0 {
+		sb.WriteString(fmt.Sprintf(", %d failed", status.Failed))
+	}
+	sb.WriteString("\n")
+	if status.Done() {
+		sb.WriteString("Write the changelog with `changelog-generator batch collect`.\n")
+	}
+	return sb.String()
+}
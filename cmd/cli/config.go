@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/generator"
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Create or inspect the configuration file",
+}
+
+var configInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Interactively write a starter .changelog.yaml",
+	Long: `Ask for the hosting provider, repository, model, and category mapping, and
+write a commented configuration file. Credentials are not written; the file
+names the environment variables to set instead.`,
+	Args: cobra.NoArgs,
+	RunE: runConfigInit,
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Print the effective configuration with secrets masked",
+	Long: `Print the configuration as merged from defaults, the config file, and
+CHANGELOG_* environment variables, as YAML. Values that are not defaults are
+annotated with their source; tokens, keys, and passwords are masked.`,
+	Args: cobra.NoArgs,
+	RunE: runConfigShow,
+}
+
+func init() {
+	configInitCmd.Flags().String("path", ".changelog.yaml", "File to write")
+	configInitCmd.Flags().Bool("force", false, "Overwrite an existing file")
+	configCmd.AddCommand(configInitCmd)
+	configCmd.AddCommand(configShowCmd)
+}
+
+func runConfigInit(cmd *cobra.Command, args []string) error {
+	path, _ := cmd.Flags().GetString("path")
+	force, _ := cmd.Flags().GetBool("force")
+	if _, err := os.Stat(path); err == nil && !force {
+		return fmt.Errorf("%s already exists (use --force to overwrite)", path)
+	}
+
+	answers := config.InitAnswers{
+		Provider:        cfg.Provider,
+		GiteaURL:        cfg.GiteaURL,
+		RepoOwner:       cfg.RepoOwner,
+		RepoName:        cfg.RepoName,
+		OpenAIModel:     cfg.OpenAIModel,
+		UnknownCategory: cfg.UnknownCategory,
+	}
+	if err := survey.AskOne(&survey.Select{
+		Message: "Hosting provider:",
+		Options: []string{"github", "bitbucket", "gitea"},
+		Default: answers.Provider,
+	}, &answers.Provider); err != nil {
+		return err
+	}
+	if answers.Provider == "gitea" {
+		if err := survey.AskOne(&survey.Input{
+			Message: "Gitea or Forgejo URL (e.g., https://codeberg.org):",
+			Default: answers.GiteaURL,
+		}, &answers.GiteaURL, survey.WithValidator(survey.Required)); err != nil {
+			return err
+		}
+	}
+	if err := survey.AskOne(&survey.Input{
+		Message: "Repository owner (organization, user, or Bitbucket workspace):",
+		Default: answers.RepoOwner,
+	}, &answers.RepoOwner, survey.WithValidator(survey.Required)); err != nil {
+		return err
+	}
+	if err := survey.AskOne(&survey.Input{
+		Message: "Repository name:",
+		Default: answers.RepoName,
+	}, &answers.RepoName, survey.WithValidator(survey.Required)); err != nil {
+		return err
+	}
+	if err := survey.AskOne(&survey.Input{
+		Message: "OpenAI model:",
+		Default: answers.OpenAIModel,
+	}, &answers.OpenAIModel, survey.WithValidator(survey.Required)); err != nil {
+		return err
+	}
+
+	var labels string
+	if err := survey.AskOne(&survey.Input{
+		Message: "Map PR labels to categories (label=Category, comma-separated; blank to skip):",
+		Help:    "Categories: " + strings.Join(generator.CategoryOrder, ", ") + ", or drop",
+	}, &labels, survey.WithValidator(func(answer interface{}) error {
+		_, err := config.ParseLabelCategories(answer.(string))
+		return err
+	})); err != nil {
+		return err
+	}
+	answers.LabelCategories, _ = config.ParseLabelCategories(labels)
+	if err := survey.AskOne(&survey.Select{
+		Message: "Where should categories the model invents go?",
+		Options: append(append([]string{}, generator.CategoryOrder...), "drop"),
+		Default: answers.UnknownCategory,
+	}, &answers.UnknownCategory); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(path, config.Scaffold(answers), 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	fmt.Printf("✓ Wrote %s\n", path)
+	return nil
+}
+
+func runConfigShow(cmd *cobra.Command, args []string) error {
+	cfg.ResolveCredentials()
+	out, err := cfg.Show()
+	if err != nil {
+		return err
+	}
+	fmt.Print(string(out))
+	return nil
+}
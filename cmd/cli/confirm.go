@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/generator"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+// confirmInput reads answers to --confirm prompts
+var confirmInput = bufio.NewReader(os.Stdin)
+
+// confirmChange shows the diff from current to proposed content of target and
+// asks whether to apply it. Identical content is accepted without asking.
+func confirmChange(target, current, proposed string) (bool, error) {
+	if cfg.Format == "xlsx" {
+		fmt.Printf("%s is binary xlsx output; no diff shown\n", target)
+		return askConfirmation(fmt.Sprintf("Write %s?", target))
+	}
+	diff := generator.FormatDiff(target+" (current)", target+" (new)", current, proposed, colorOutput())
+	if diff == "" {
+		fmt.Printf("%s is unchanged\n", target)
+		return true, nil
+	}
+	fmt.Print(diff)
+	return askConfirmation(fmt.Sprintf("Write %s?", target))
+}
+
+// confirmPublish previews what the sinks will publish and asks before any of
+// them runs. GitHub release bodies are diffed against the existing notes;
+// other sinks are listed.
+func confirmPublish(ctx context.Context, configs []config.SinkConfig, source provider.Provider, changelog *generator.Changelog) error {
+	for _, sinkConfig := range configs {
+		githubClient, ok := githubSource(source)
+		if sinkConfig.Type != "github-release" || !ok {
+			fmt.Printf("Will publish to %s sink\n", sinkConfig.Type)
+			continue
+		}
+		current, err := githubClient.ReleaseNotes(ctx, changelog.ToRef)
+		if err != nil {
+			return err
+		}
+		target := fmt.Sprintf("GitHub release %s", changelog.ToRef)
+		if diff := generator.FormatDiff(target+" (current)", target+" (new)", current, changelog.Markdown, colorOutput()); diff != "" {
+			fmt.Print(diff)
+		} else {
+			fmt.Printf("%s is unchanged\n", target)
+		}
+	}
+
+	ok, err := askConfirmation(fmt.Sprintf("Publish to %d sinks?", len(configs)))
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("aborted: changelog was not published")
+	}
+	return nil
+}
+
+// askConfirmation prompts on stdout and reads a yes/no answer from stdin;
+// anything but y or yes, including end of input, declines
+func askConfirmation(question string) (bool, error) {
+	fmt.Printf("%s [y/N] ", question)
+	answer, err := confirmInput.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, fmt.Errorf("read confirmation: %w", err)
+	}
+	if err == io.EOF {
+		fmt.Println()
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// colorOutput reports whether diffs should be colored: stdout is a terminal
+// and NO_COLOR is unset
+func colorOutput() bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/generator"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/sink"
+)
+
+// runSinceLastRun generates a short delta note for everything merged to the
+// branch since the previous invocation, tracked per repository and branch in
+// the state store rather than by tags
+func runSinceLastRun(ctx context.Context) error {
+	if err := requireGitHub("--since-last-run"); err != nil {
+		return err
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	if err := cfg.ValidateRepository(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	// A delta note is meant for a channel, not for overwriting CHANGELOG.md
+	if cfg.OutputPath == "CHANGELOG.md" {
+		cfg.OutputPath = "-"
+	}
+
+	githubClient, err := connectGitHub(ctx)
+	if err != nil {
+		return err
+	}
+	branch := cfg.Branch
+	if branch == "" {
+		if branch, err = githubClient.DefaultBranch(ctx); err != nil {
+			return fmt.Errorf("resolve default branch: %w", err)
+		}
+	}
+	head, err := githubClient.ResolveSHA(ctx, branch)
+	if err != nil {
+		return err
+	}
+
+	store, err := openStateStore()
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	repo := cfg.RepoOwner + "/" + cfg.RepoName
+	key := repo + "@" + branch
+	last, seen, err := store.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	switch {
+	case !seen:
+		fmt.Printf("%s: recording %s as the starting point\n", key, shortSHA(head))
+		return store.Set(ctx, key, head)
+	case last == head:
+		fmt.Printf("%s: no changes since the last run\n", key)
+		return nil
+	}
+
+	logger.Info("generating delta note", "repo", repo, "branch", branch, "from", last, "to", head)
+	gen, err := buildGenerator(githubClient)
+	if err != nil {
+		return err
+	}
+	if cfg.DryRun {
+		report, err := gen.DryRun(ctx, last, head)
+		if err != nil {
+			return fmt.Errorf("dry run: %w", err)
+		}
+		fmt.Print(generator.FormatDryRunReport(report))
+		return nil
+	}
+	changelog, err := gen.Generate(ctx, last, head)
+	if err != nil {
+		return fmt.Errorf("generate changelog: %w", err)
+	}
+
+	note := generator.FormatDeltaNote(changelog, repo, cfg.MinScore)
+	if err := writeOutput(note, ""); err != nil {
+		return err
+	}
+	if cfg.SlackWebhookURL != "" {
+		if err := sink.PostSlack(ctx, cfg.SlackWebhookURL, note); err != nil {
+			return err
+		}
+		fmt.Printf("%s: posted to Slack\n", key)
+	}
+
+	// Only advance once the note is out, so a failed run is retried next time
+	return store.Set(ctx, key, head)
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/generator"
+)
+
+// writeChangelog renders the changelog in the configured format and writes it
+// like writePrunedOutput, or writes its pages with --docs-site
+func writeChangelog(changelog any, markdown, suffix string, stale []string) error {
+	if cfg.DocsSite != "" {
+		return writeDocsSite(changelog)
+	}
+	content, err := renderOutput(changelog, markdown)
+	if err != nil {
+		return err
+	}
+	return writePrunedOutput(content, suffix, stale)
+}
+
+// writeDocsSite writes one page per release into the docs directory,
+// replacing the pages of earlier runs for the same releases
+func writeDocsSite(changelog any) error {
+	pages, err := generator.DocsPages(changelog, cfg.DocsSite, cfg.FrontMatter)
+	if err != nil {
+		return err
+	}
+	dir := cfg.DocsDir
+	if dir == "" {
+		dir = generator.DefaultDocsDir(cfg.DocsSite)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create docs directory: %w", err)
+	}
+	for _, page := range pages {
+		path := filepath.Join(dir, page.Path)
+		if err := os.WriteFile(path, []byte(page.Content), 0o644); err != nil {
+			return fmt.Errorf("write docs page: %w", err)
+		}
+		logger.Info("wrote docs page", "path", path)
+	}
+	fmt.Printf("Changelog written to %s (%d pages)\n", dir, len(pages))
+	return nil
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"github.com/rakshaksatsangi/changelog-generator/pkg/github"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+	"github.com/spf13/cobra"
+)
+
+var (
+	fixturePath     string             // --record-fixture destination (empty = off)
+	fixtureRecorder *provider.Recorder // Records the provider responses of this run
+)
+
+func init() {
+	generateCmd.Flags().StringVar(&fixturePath, "record-fixture", "", "Record the hosting provider's responses to this JSON file, for replaying the run offline in tests")
+	generateCmd.PostRunE = saveFixture
+}
+
+// recordFixture wraps source in a recorder when --record-fixture is set
+func recordFixture(source provider.Provider) provider.Provider {
+	if fixturePath == "" {
+		return source
+	}
+	fixtureRecorder = provider.NewRecorder(source, cfg.RepoOwner+"/"+cfg.RepoName)
+	return fixtureRecorder
+}
+
+// saveFixture writes the recorded responses once generate has succeeded
+func saveFixture(cmd *cobra.Command, args []string) error {
+	if fixtureRecorder == nil {
+		return nil
+	}
+	if err := fixtureRecorder.Save(fixturePath); err != nil {
+		return err
+	}
+	logger.Info("recorded fixture", "path", fixturePath)
+	return nil
+}
+
+// githubSource returns the GitHub client behind source, seeing through a
+// fixture recorder
+func githubSource(source provider.Provider) (*github.Client, bool) {
+	if recorder, ok := source.(*provider.Recorder); ok {
+		source = recorder.Source()
+	}
+	client, ok := source.(*github.Client)
+	return client, ok
+}
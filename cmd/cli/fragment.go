@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/generator"
+	"github.com/spf13/cobra"
+)
+
+var fragmentCmd = &cobra.Command{
+	Use:   "fragment",
+	Short: "Collect hand-written news fragments and assemble them into a release",
+	Long: `Towncrier-style changelog fragments: each pull request drops a small file
+describing its change into the fragment directory (default changelog.d), and at
+release time "fragment build" has the model polish the fragments, score them,
+and assemble them into the release section, then deletes them.
+
+Fragment files are named <pr>.<type>.md (or <slug>.<type>.md without a pull
+request). Types: ` + strings.Join(generator.FragmentTypeNames(), ", ") + `.
+
+Examples:
+  changelog-generator fragment add feature "Add SSO login with Okta" --pr=42
+  git log -1 --format=%B | changelog-generator fragment add bugfix -
+  changelog-generator fragment build v1.1.0..v1.2.0 --prepend`,
+}
+
+var fragmentAddCmd = &cobra.Command{
+	Use:   "add <type> <text|->",
+	Short: "Write a news fragment for one change",
+	Args:  cobra.MinimumNArgs(2),
+	RunE:  runFragmentAdd,
+}
+
+var fragmentBuildCmd = &cobra.Command{
+	Use:   "build <from..to>",
+	Short: "Polish the collected fragments into a release section",
+	Long: `Polish every fragment in the fragment directory into one release section and
+write it like "generate" does. The range only labels the section. Fragments are
+deleted once the changelog is written, unless --keep is set; --dry-run leaves
+them in place. No GitHub access is needed.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runFragmentBuild,
+}
+
+func init() {
+	fragmentCmd.PersistentFlags().StringVar(&cfg.FragmentDir, "dir", cfg.FragmentDir, "Directory holding the news fragments")
+	fragmentAddCmd.Flags().Int("pr", 0, "Pull request number to name the fragment after and reference")
+	addCommonFlags(fragmentBuildCmd)
+	fragmentBuildCmd.Flags().Bool("keep", false, "Keep the fragment files after building")
+	fragmentCmd.AddCommand(fragmentAddCmd)
+	fragmentCmd.AddCommand(fragmentBuildCmd)
+}
+
+func runFragmentAdd(cmd *cobra.Command, args []string) error {
+	pr, _ := cmd.Flags().GetInt("pr")
+
+	text := strings.Join(args[1:], " ")
+	if text == "-" {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("read fragment from stdin: %w", err)
+		}
+		text = string(data)
+	}
+
+	path, err := generator.AddFragment(cfg.FragmentDir, args[0], pr, text)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Wrote %s\n", path)
+	return nil
+}
+
+func runFragmentBuild(cmd *cobra.Command, args []string) error {
+	ctx, cancel := withTimeout(cmd.Context())
+	defer cancel()
+
+	parts := strings.Split(args[0], "..")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("invalid commit range format, expected 'from..to', got '%s'", args[0])
+	}
+	from, to := parts[0], parts[1]
+	keep, _ := cmd.Flags().GetBool("keep")
+
+	// Fragments are self-contained, like commits supplied on stdin
+	cfg.Stdin = true
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	if cfg.Confirm {
+		return fmt.Errorf("--confirm is not supported by fragment build")
+	}
+
+	fragments, err := generator.ReadFragments(cfg.FragmentDir)
+	if err != nil {
+		return err
+	}
+	if len(fragments) == 0 {
+		return fmt.Errorf("no fragments found in %s", cfg.FragmentDir)
+	}
+	// runRange skips documented versions, which must not consume the fragments
+	if cfg.Prepend {
+		documented, err := loadDocumentedVersions()
+		if err != nil {
+			return err
+		}
+		if generator.IsDocumented(documented, to) {
+			return fmt.Errorf("%s is already documented in %s; fragments left in %s", to, cfg.OutputPath, cfg.FragmentDir)
+		}
+	}
+
+	logger.Info("building changelog from fragments", "dir", cfg.FragmentDir, "fragments", len(fragments))
+	if err := runRange(ctx, nil, from, to, generator.FragmentCommits(fragments), nil); err != nil {
+		return err
+	}
+	if cfg.DryRun || keep {
+		return nil
+	}
+	for _, fragment := range fragments {
+		if err := os.Remove(fragment.Path); err != nil {
+			return fmt.Errorf("remove fragment: %w", err)
+		}
+	}
+	fmt.Printf("Removed %d fragments from %s\n", len(fragments), cfg.FragmentDir)
+	return nil
+}
@@ -1,26 +1,46 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
+	"os/signal"
+	"sort"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/bitbucket"
 	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
 	"github.com/rakshaksatsangi/changelog-generator/pkg/generator"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/gitea"
 	"github.com/rakshaksatsangi/changelog-generator/pkg/github"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/hooks"
 	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/logging"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/network"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/sink"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/tickets"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var (
 	version = "0.1.0"
-	cfg     *config.Config
+	cfg     = mustLoadConfig() // Loaded before any init() so every command file can bind flags to it
+	logger  = logging.Module("cli")
 )
 
 func main() {
-	if err := rootCmd.Execute(); err != nil {
+	// Ctrl-C and SIGTERM cancel in-flight API calls
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := rootCmd.ExecuteContext(ctx)
+	closeSharedCache()
+	stop()
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
@@ -32,6 +52,16 @@ var rootCmd = &cobra.Command{
 	Long: `Changelog Generator analyzes Git commits and generates structured,
 human-readable changelogs using OpenAI's language models.`,
 	Version: version,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := setupLogging(); err != nil {
+			return err
+		}
+		logSettingSources(cmd)
+		if err := network.Configure(cfg.Proxy, cfg.CABundle); err != nil {
+			return fmt.Errorf("configuration error: %w", err)
+		}
+		return nil
+	},
 }
 
 var generateCmd = &cobra.Command{
@@ -48,40 +78,165 @@ Examples:
   changelog-generator generate --show-scores v1.0.0..v1.1.0
   changelog-generator generate --min-score=7.0 v1.0.0..v1.1.0
 
+  # Release aliases: latest/previous resolve to the most recent published releases
+  changelog-generator generate latest..HEAD
+  changelog-generator generate previous..latest --include-prereleases
+
+  # Nightly note of whatever merged since the previous run, for a #dev-changes channel
+  changelog-generator generate --since-last-run --slack-webhook=$DEV_CHANGES_WEBHOOK
+
+  # Commits from another VCS, as a JSON array on stdin
+  svn-export-commits | changelog-generator generate --stdin r1200..r1300
+
+  # Commits picked by a script: SHAs fetched from GitHub, or a git log dump used as is
+  git rev-list v1.0.0..HEAD -- api/ | changelog-generator generate --from-stdin
+  git log --numstat v1.0.0..HEAD | changelog-generator generate --from-stdin v1.0.0..HEAD
+
   # Timeline mode (new)
   changelog-generator generate --from-date=2024-01-01 --to-date=2024-12-31 --owner=facebook --repo=react
-  changelog-generator generate --from-date=2024-01-01 --to-date=2024-12-31 --interactive`,
+  changelog-generator generate --from-date=2024-01-01 --to-date=2024-12-31 --interactive
+
+  # Everything an organization shipped this quarter, grouped by repository
+  changelog-generator generate --org=myorg --from-date=2024-07-01 --to-date=2024-09-30`,
 	Args: cobra.MaximumNArgs(1), // Allow 0 args for timeline mode, 1 for ref mode
 	RunE: runGenerate,
 }
 
-func init() {
-	// Load config
-	var err error
-	cfg, err = config.Load()
+// mustLoadConfig loads configuration or exits
+func mustLoadConfig() *config.Config {
+	loaded, err := config.Load()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
 		os.Exit(1)
 	}
+	return loaded
+}
 
-	// Add generate command
+func init() {
+	// Add commands
 	rootCmd.AddCommand(generateCmd)
+	rootCmd.AddCommand(unreleasedCmd)
+	rootCmd.AddCommand(watchCmd)
+	rootCmd.AddCommand(viewCmd)
+	rootCmd.AddCommand(serveCmd)
+	rootCmd.AddCommand(backfillCmd)
+	rootCmd.AddCommand(appendCmd)
+	rootCmd.AddCommand(fragmentCmd)
+	rootCmd.AddCommand(reviewCmd)
+	rootCmd.AddCommand(translateCmd)
+	rootCmd.AddCommand(schemaCmd)
+	rootCmd.AddCommand(batchCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(authCmd)
+
+	rootCmd.PersistentFlags().StringVar(&cfg.LogLevel, "log-level", cfg.LogLevel, "Log level on stderr: debug, info, warn, or error (default warn, or info with --verbose)")
+	rootCmd.PersistentFlags().StringVar(&cfg.LogFormat, "log-format", cfg.LogFormat, "Log record format on stderr: text or json (parseable CI logs)")
+	rootCmd.PersistentFlags().StringVar(&cfg.Proxy, "proxy", cfg.Proxy, "Send GitHub, OpenAI, and other API requests through this proxy URL (default: HTTPS_PROXY/HTTP_PROXY)")
+	rootCmd.PersistentFlags().StringVar(&cfg.CABundle, "ca-bundle", cfg.CABundle, "PEM file of extra CA certificates to trust, e.g. a TLS-intercepting proxy's")
+	rootCmd.PersistentFlags().DurationVar(&cfg.Timeout, "timeout", cfg.Timeout, "Abort the run after this long, e.g. 10m (0 = no limit; applies per poll in watch)")
 
 	// Flags for generate command
-	generateCmd.Flags().StringVar(&cfg.RepoOwner, "owner", cfg.RepoOwner, "Repository owner (required)")
-	generateCmd.Flags().StringVar(&cfg.RepoName, "repo", cfg.RepoName, "Repository name (required)")
-	generateCmd.Flags().StringVar(&cfg.OutputPath, "output", cfg.OutputPath, "Output file path")
-	generateCmd.Flags().StringVar(&cfg.OpenAIModel, "model", cfg.OpenAIModel, "OpenAI model to use")
-	generateCmd.Flags().BoolVar(&cfg.Verbose, "verbose", cfg.Verbose, "Verbose output")
-	generateCmd.Flags().BoolVar(&cfg.IncludeAuthors, "include-authors", cfg.IncludeAuthors, "Include commit authors")
-	generateCmd.Flags().BoolVar(&cfg.IncludeDates, "include-dates", cfg.IncludeDates, "Include commit dates")
-	generateCmd.Flags().BoolVar(&cfg.ShowScores, "show-scores", cfg.ShowScores, "Show importance scores for each commit")
-	generateCmd.Flags().Float64Var(&cfg.MinScore, "min-score", cfg.MinScore, "Minimum importance score to include (0-10)")
+	addCommonFlags(generateCmd)
 
 	// Timeline mode flags
 	generateCmd.Flags().String("from-date", "", "Start date for timeline mode (YYYY-MM-DD)")
 	generateCmd.Flags().String("to-date", "", "End date for timeline mode (YYYY-MM-DD)")
 	generateCmd.Flags().Bool("interactive", false, "Interactively select repository")
+	generateCmd.Flags().Bool("include-prereleases", false, "Let the latest/previous aliases resolve to prereleases")
+	generateCmd.Flags().StringVar(&cfg.Org, "org", cfg.Org, "Timeline mode across every non-archived repository in this GitHub organization")
+	generateCmd.Flags().StringVar(&cfg.TagPattern, "tag-pattern", cfg.TagPattern, "Timeline mode: only treat tags matching this regular expression in full as releases, e.g. 'v[0-9]+\\.[0-9]+\\.[0-9]+'")
+	generateCmd.Flags().BoolVar(&cfg.ExcludePrereleases, "exclude-prereleases", cfg.ExcludePrereleases, "Timeline mode: skip prerelease releases and rc/beta/nightly tags")
+	generateCmd.Flags().BoolVar(&cfg.IncludeBoundaries, "include-boundaries", cfg.IncludeBoundaries, "Timeline mode: start at the newest tag before --from-date and end with an Unreleased section up to the newest commit by --to-date")
+	generateCmd.Flags().IntVar(&cfg.LLMConcurrency, "llm-concurrency", cfg.LLMConcurrency, "Timeline mode: generate this many releases' changelogs at once; output keeps release order and rate-limited requests are retried")
+	generateCmd.Flags().Bool("batch", false, "Timeline mode: submit the per-release LLM requests to the OpenAI Batch API (half price, done within 24 hours) and exit; rerun or use `batch collect` to write the changelog")
+	generateCmd.Flags().String("batch-file", defaultBatchFile, "File recording the batch submitted with --batch until it is collected")
+	generateCmd.Flags().StringVar(&cfg.GroupBy, "group-by", cfg.GroupBy, "Timeline mode: fold releases into one section per semver series: minor (v1.4.x) or major (v1.x)")
+	generateCmd.Flags().BoolVar(&cfg.Stdin, "stdin", false, "Read commits as a JSON array (CommitInfo schema) from stdin instead of GitHub")
+	generateCmd.Flags().Bool("from-stdin", false, "Read commit SHAs (one per line) or `git log` output from stdin instead of enumerating the range")
+	generateCmd.Flags().Bool("since-last-run", false, "Write a short delta note for everything merged to the branch since the previous --since-last-run invocation (stdout unless --output is set)")
+	generateCmd.Flags().StringVar(&cfg.WatchStateFile, "state-file", cfg.WatchStateFile, "File recording the last processed commit per repository and branch (--since-last-run)")
+	addStateFlags(generateCmd)
+	generateCmd.Flags().BoolVar(&cfg.Confirm, "confirm", cfg.Confirm, "Show a colored diff against the existing output file and release notes, and ask before writing or publishing")
+	generateCmd.Flags().BoolVar(&cfg.UpdateReleaseNotes, "update-release-notes", cfg.UpdateReleaseNotes, "Replace only the marked generated block in the GitHub release (or draft) of the range end, keeping hand-written text around it")
+	generateCmd.Flags().StringVar(&cfg.SlackWebhookURL, "slack-webhook", cfg.SlackWebhookURL, "Also post the --since-last-run delta note to this Slack incoming webhook")
+}
+
+// addCommonFlags registers the repository, output, and integration flags
+// shared by every changelog-producing command
+func addCommonFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&cfg.Provider, "provider", cfg.Provider, "Repository hosting provider: github, bitbucket, or gitea")
+	cmd.Flags().StringVar(&cfg.RepoOwner, "owner", cfg.RepoOwner, "Repository owner (required)")
+	cmd.Flags().StringVar(&cfg.RepoName, "repo", cfg.RepoName, "Repository name (required)")
+	cmd.Flags().StringVar(&cfg.Branch, "branch", cfg.Branch, "Resolve HEAD, latest/previous, and discover tags on this branch instead of the default branch")
+	cmd.Flags().IntVar(&cfg.MaxCommits, "max-commits", cfg.MaxCommits, "GitHub only: fail instead of fetching ranges with more commits than this (0 = unlimited)")
+	cmd.Flags().BoolVar(&cfg.FastFetch, "fast-fetch", cfg.FastFetch, "GitHub only: fetch commits in GraphQL batches of 100 (messages, authors, line and file counts) without per-commit file lists and diffs")
+	cmd.Flags().IntVar(&cfg.MaxFilesInPrompt, "max-files-in-prompt", cfg.MaxFilesInPrompt, "File names listed per commit in the prompt")
+	cmd.Flags().IntVar(&cfg.DiffThreshold, "diff-threshold", cfg.DiffThreshold, "Summarize the diff of files changing more than this many lines (0 = every changed file)")
+	cmd.Flags().IntVar(&cfg.DiffFiles, "diff-files", cfg.DiffFiles, "Files per commit whose diffs are summarized in the prompt (0 = none)")
+	cmd.Flags().IntVar(&cfg.DiffLines, "diff-lines", cfg.DiffLines, "Patch lines sampled in each file's diff summary")
+	cmd.Flags().IntVar(&cfg.FullPatchLines, "full-patch-lines", cfg.FullPatchLines, "Send the complete patch of commits changing at most this many lines instead of summaries (0 = never)")
+	cmd.Flags().StringSliceVar(&cfg.IgnoreFiles, "ignore-files", cfg.IgnoreFiles, "Globs of files whose diffs and line counts are kept out of prompts and heuristic scores, besides built-in lockfiles, vendored, generated, and binary files; prefix with ! to keep a file (e.g. '!dist/**')")
+	cmd.Flags().StringVar(&cfg.OutputPath, "output", cfg.OutputPath, "Output file path")
+	cmd.Flags().StringVar(&cfg.Format, "format", cfg.Format, "Output format: markdown, json (includes one-liner/paragraph/full summaries per release), csv, xlsx (one row per entry), hugo (one page per release, same as --docs-site=hugo), announcement (short and long social posts per release), or widget-json (title, date, tags, and HTML body per release for in-app What's New widgets)")
+	cmd.Flags().StringVar(&cfg.DocsSite, "docs-site", cfg.DocsSite, "Write one page per release with front-matter for a docs site, docusaurus, mkdocs, or hugo, instead of a single output file")
+	cmd.Flags().StringVar(&cfg.DocsDir, "docs-dir", cfg.DocsDir, "Directory of the --docs-site pages (default: blog for docusaurus, docs/blog/posts for mkdocs, content/releases for hugo)")
+	cmd.Flags().StringVar(&cfg.FrontMatter, "front-matter", cfg.FrontMatter, "Front-matter of --docs-site pages: yaml, or toml for hugo (default: toml for hugo, yaml otherwise)")
+	cmd.Flags().BoolVar(&cfg.ValidateOutput, "validate-output", cfg.ValidateOutput, "Fail instead of writing output whose JSON encoding does not match the published schema (see the schema command)")
+	cmd.Flags().StringVar(&cfg.OpenAIModel, "model", cfg.OpenAIModel, "OpenAI model to use")
+	cmd.Flags().BoolVar(&cfg.Reproducible, "reproducible", cfg.Reproducible, "Sample at temperature 0 with a fixed seed and record the prompt hash and model snapshot in JSON metadata, so differences between runs trace back to inputs")
+	cmd.Flags().BoolVar(&cfg.ModelRouting, "model-routing", cfg.ModelRouting, "Send small releases to --small-model and large or complex ones to --large-model, instead of --model for everything")
+	cmd.Flags().StringVar(&cfg.SmallModel, "small-model", cfg.SmallModel, "Cheap model for small releases with --model-routing")
+	cmd.Flags().StringVar(&cfg.LargeModel, "large-model", cfg.LargeModel, "Stronger model for large releases with --model-routing (default: --model)")
+	cmd.Flags().IntVar(&cfg.SmallReleaseMax, "small-release-max", cfg.SmallReleaseMax, "With --model-routing, releases of at most this many commits (or PRs in timelines) are small")
+	cmd.Flags().IntVar(&cfg.LargeReleaseMin, "large-release-min", cfg.LargeReleaseMin, "With --model-routing, releases of at least this many commits (or PRs in timelines) are large")
+	cmd.Flags().IntVar(&cfg.LargeReleaseLines, "large-release-lines", cfg.LargeReleaseLines, "With --model-routing, releases changing at least this many lines are large, whatever their size in commits")
+	cmd.Flags().IntVar(&cfg.MaxAttempts, "llm-max-attempts", cfg.MaxAttempts, "Tries per LLM request when OpenAI answers with a rate limit (429) or server error (5xx), waiting as long as its Retry-After header asks or backing off exponentially (1 = no retries)")
+	cmd.Flags().IntVar(&cfg.Seed, "seed", cfg.Seed, fmt.Sprintf("Sampling seed sent with every request (0 = none; --reproducible defaults it to %d)", config.DefaultSeed))
+	cmd.Flags().BoolVar(&cfg.Verbose, "verbose", cfg.Verbose, "Log progress (same as --log-level=info)")
+	cmd.Flags().BoolVar(&cfg.DryRun, "dry-run", false, "Fetch commits and build prompts, then print token and cost estimates without calling OpenAI")
+	cmd.Flags().BoolVar(&cfg.Strict, "strict", cfg.Strict, "Fail on soft conditions: unknown categories, missing SHAs or scores, repaired LLM JSON (for CI)")
+	cmd.Flags().BoolVar(&cfg.IncludeAuthors, "include-authors", cfg.IncludeAuthors, "Include commit authors")
+	cmd.Flags().BoolVar(&cfg.IncludeDates, "include-dates", cfg.IncludeDates, "Include commit dates")
+	cmd.Flags().BoolVar(&cfg.IncludeContributors, "include-contributors", cfg.IncludeContributors, "Include a contributors section with commit and line stats")
+	cmd.Flags().BoolVar(&cfg.IncludeReviewStats, "include-review-stats", cfg.IncludeReviewStats, "GitHub only: include approvals, change requests, and comment counts per PR in timeline prompts")
+	cmd.Flags().BoolVar(&cfg.IncludeArtifacts, "include-artifacts", cfg.IncludeArtifacts, "Add an Artifacts subsection listing release assets and container image digests for tags with releases")
+	cmd.Flags().BoolVar(&cfg.ExistingNotes, "existing-notes", cfg.ExistingNotes, "Give the model the notes already published on each release so its summary agrees with them")
+	cmd.Flags().StringVar(&cfg.LinkStyle, "link-style", cfg.LinkStyle, "How entries link to their change: commit (SHA), pr (#1234 for commits merged through a pull request, the SHA otherwise), or both")
+	cmd.Flags().BoolVar(&cfg.PRImages, "pr-images", cfg.PRImages, "Show the first image or GIF from each entry's pull request description under the entry, e.g. screenshots of UI changes")
+	cmd.Flags().BoolVar(&cfg.IncludeStats, "include-stats", cfg.IncludeStats, "Start each timeline release with a stats line: commits, PRs, contributors, files changed, lines added/removed, and days since the previous release")
+	cmd.Flags().BoolVar(&cfg.CalibrateScores, "calibrate-scores", cfg.CalibrateScores, "Score the pull requests of all timeline releases together in a second LLM pass, so scores and --min-score mean the same in every release")
+	cmd.Flags().BoolVar(&cfg.PeriodSummary, "period-summary", cfg.PeriodSummary, "Open timeline output with a Period Summary: one more LLM pass that synthesizes the themes across all releases")
+	cmd.Flags().StringVar(&cfg.ActivityChart, "activity-chart", cfg.ActivityChart, "Open timeline output with a chart of release cadence and commits per release: mermaid (gantt) or ascii")
+	cmd.Flags().BoolVar(&cfg.FullChangelogLink, "full-changelog-link", cfg.FullChangelogLink, "End each release with a \"Full Changelog\" compare link, like GitHub's generated release notes")
+	cmd.Flags().BoolVar(&cfg.IncludeMetrics, "include-metrics", cfg.IncludeMetrics, "Append an engineering-metrics appendix (release frequency, PR time to merge and lead time) to timeline output")
+	cmd.Flags().BoolVar(&cfg.DetectStack, "detect-stack", cfg.DetectStack, "GitHub only: detect the repository's languages and frameworks and describe them in the prompt")
+	cmd.Flags().BoolVar(&cfg.ClusterCommits, "cluster-commits", cfg.ClusterCommits, "Group commits that touch the same subsystem so the model writes one higher-level entry per group")
+	cmd.Flags().BoolVar(&cfg.CollapseMerges, "collapse-merges", cfg.CollapseMerges, "Treat each merge commit as one change titled after its PR, instead of listing every commit of the merged branch")
+	cmd.Flags().BoolVar(&cfg.SecuritySection, "security-section", cfg.SecuritySection, "Flag commits touching auth, crypto, or dependency files or citing CVEs, and collect security fixes in a Security category with severities")
+	cmd.Flags().BoolVar(&cfg.SecurityAdvisories, "security-advisories", cfg.SecurityAdvisories, "GitHub only: cross-reference the repository's published security advisories (implies --security-section)")
+	cmd.Flags().StringToStringVar(&cfg.LabelCategories, "label-category", cfg.LabelCategories, "Categorize commits by their PR's labels, as label=category pairs (e.g. kind/feature=Features,skip-changelog=drop); replaces label_categories")
+	cmd.Flags().StringVar(&cfg.LabelCategoryMode, "label-category-mode", cfg.LabelCategoryMode, "How label categories apply: override (move entries after generation) or hint (tell the model, which may disagree)")
+	cmd.Flags().BoolVar(&cfg.GroupByArea, "group-by-area", cfg.GroupByArea, "Group entries by the product_areas their commits touched, with categories nested under each area")
+	cmd.Flags().StringVar(&cfg.BotCommits, "bot-commits", cfg.BotCommits, "How to treat commits by bot accounts such as dependabot[bot]: include, exclude, or group (own section)")
+	cmd.Flags().StringVar(&cfg.Reverts, "reverts", cfg.Reverts, "Changes reverted within the range and cherry-picked duplicates: drop (both sides of a revert, and the duplicate), annotate, or keep")
+	cmd.Flags().StringVar(&cfg.UnverifiedCommits, "unverified-commits", cfg.UnverifiedCommits, "How to treat commits whose signature is not verified: include, annotate (mark entries), or exclude")
+	cmd.Flags().StringVar(&cfg.Scoring, "scoring", cfg.Scoring, "Where importance scores come from: llm, or heuristic (lines changed, files touched, paths, conventional-commit type) for reproducible output")
+	cmd.Flags().BoolVar(&cfg.ShowScores, "show-scores", cfg.ShowScores, "Show importance scores for each commit")
+	cmd.Flags().BoolVar(&cfg.ShowScoreReasons, "show-score-reasons", cfg.ShowScoreReasons, "Show the model's reason for each importance score")
+	cmd.Flags().Float64Var(&cfg.MinScore, "min-score", cfg.MinScore, "Minimum importance score to include (0-10)")
+	cmd.Flags().IntVar(&cfg.Top, "top", cfg.Top, "Keep only the N highest-scoring entries across the whole changelog (0 = all)")
+	cmd.Flags().IntVar(&cfg.TopPerCategory, "top-per-category", cfg.TopPerCategory, "Keep only the N highest-scoring entries in each category (0 = all)")
+	cmd.Flags().StringVar(&cfg.MaxLength, "max-length", cfg.MaxLength, "Per-release length budget (e.g., 300w or 40lines); longer sections are condensed by the LLM")
+	cmd.Flags().StringVar(&cfg.RunSummaryPath, "run-summary", cfg.RunSummaryPath, "Write a markdown summary of the run (inputs, releases, entries, filters, cost, warnings) to this file, e.g. run-summary.md")
+	cmd.Flags().StringVar(&cfg.PromptLogDir, "save-prompts", cfg.PromptLogDir, "Save every prompt and raw LLM response, with secrets redacted, as a JSON file per call to this directory")
+	cmd.Flags().StringVar(&cfg.TrainingDataDir, "collect-training-data", cfg.TrainingDataDir, "Append prompt/response pairs (after post-processing fixes) as fine-tuning JSONL to this directory")
+	cmd.Flags().BoolVar(&cfg.Prepend, "prepend", cfg.Prepend, "Prepend to the existing output file, skipping versions it already documents")
+
+	// Issue tracker flags
+	cmd.Flags().StringVar(&cfg.JiraBaseURL, "jira-base-url", cfg.JiraBaseURL, "Jira base URL for linking ticket IDs (e.g., https://acme.atlassian.net)")
+	cmd.Flags().StringVar(&cfg.JiraProjectPattern, "jira-project-pattern", cfg.JiraProjectPattern, "Regex matching Jira ticket IDs (default: PROJ-123 style keys)")
+	cmd.Flags().StringSliceVar(&cfg.JiraProjects, "jira-projects", cfg.JiraProjects, "Jira project keys whose ticket IDs are linked, e.g. PROJ,OPS (instead of any PROJ-123 style key)")
+	cmd.Flags().BoolVar(&cfg.FetchTicketSummaries, "fetch-ticket-summaries", cfg.FetchTicketSummaries, "Fetch ticket summaries from the issue tracker API")
 }
 
 // promptForRepository prompts user to select a repository interactively
@@ -131,7 +286,51 @@ func promptForRepository(cfg *config.Config) (owner, repo string, err error) {
 	return owner, repo, nil
 }
 
+// setupLogging installs the stderr logger from --log-level/--log-format;
+// --verbose is shorthand for the info level
+func setupLogging() error {
+	level := cfg.LogLevel
+	if level == "" {
+		level = "warn"
+		if cfg.Verbose {
+			level = "info"
+		}
+	}
+	if err := logging.Setup(os.Stderr, level, cfg.LogFormat); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	return nil
+}
+
+// logSettingSources logs, at the info level, where every setting that is not
+// at its default came from: a flag, a CHANGELOG_* variable, or the config file
+func logSettingSources(cmd *cobra.Command) {
+	sources := config.Sources()
+	keys := make([]string, 0, len(sources))
+	for key := range sources {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		logger.Info("setting", "key", key, "source", sources[key])
+	}
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		logger.Info("setting", "flag", "--"+flag.Name, "source", "flag")
+	})
+}
+
+// withTimeout bounds ctx by --timeout (no limit when it is zero)
+func withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if cfg.Timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, cfg.Timeout)
+}
+
 func runGenerate(cmd *cobra.Command, args []string) error {
+	ctx, cancel := withTimeout(cmd.Context())
+	defer cancel()
+
 	// 1. Check for interactive mode first
 	interactive, _ := cmd.Flags().GetBool("interactive")
 	if interactive {
@@ -149,6 +348,48 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 	hasDateFlags := fromDateStr != "" || toDateStr != ""
 	hasRefArg := len(args) == 1
 
+	if cfg.Stdin {
+		if hasDateFlags {
+			return fmt.Errorf("--stdin cannot be combined with --from-date/--to-date")
+		}
+		if cfg.Confirm {
+			return fmt.Errorf("--confirm cannot be combined with --stdin, which already reads commits from stdin")
+		}
+		return runStdinMode(ctx, args)
+	}
+	if fromStdin, _ := cmd.Flags().GetBool("from-stdin"); fromStdin {
+		if hasDateFlags || cfg.Org != "" {
+			return fmt.Errorf("--from-stdin cannot be combined with --from-date/--to-date or --org")
+		}
+		if cfg.Confirm {
+			return fmt.Errorf("--confirm cannot be combined with --from-stdin, which already reads commits from stdin")
+		}
+		return runFromStdinMode(ctx, args)
+	}
+
+	if sinceLastRun, _ := cmd.Flags().GetBool("since-last-run"); sinceLastRun {
+		if hasDateFlags || hasRefArg || cfg.Org != "" {
+			return fmt.Errorf("--since-last-run cannot be combined with a ref range, --from-date/--to-date, or --org")
+		}
+		return runSinceLastRun(ctx)
+	}
+
+	if cfg.Prepend && cfg.Format != "markdown" {
+		return fmt.Errorf("--prepend is only supported with --format=markdown")
+	}
+
+	if cfg.UpdateReleaseNotes && (hasDateFlags || cfg.Org != "") {
+		return fmt.Errorf("--update-release-notes only supports a ref range ([from]..[to])")
+	}
+
+	if cfg.Org != "" && hasRefArg {
+		return fmt.Errorf("--org only supports timeline mode (--from-date/--to-date)")
+	}
+
+	if batch, _ := cmd.Flags().GetBool("batch"); batch && (!hasDateFlags || cfg.Org != "") {
+		return fmt.Errorf("--batch only supports timeline mode (--from-date/--to-date) of a single repository")
+	}
+
 	// Validate mode selection
 	if hasDateFlags && hasRefArg {
 		return fmt.Errorf("cannot use both date flags (--from-date/--to-date) and ref argument ([from]..[to])")
@@ -159,13 +400,99 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 
 	// 3. Route to appropriate mode
 	if hasDateFlags {
-		return runTimelineMode(cmd, fromDateStr, toDateStr)
+		batchFile := ""
+		if batch, _ := cmd.Flags().GetBool("batch"); batch {
+			batchFile, _ = cmd.Flags().GetString("batch-file")
+		}
+		return runTimelineMode(ctx, fromDateStr, toDateStr, batchFile)
+	}
+	return runRefMode(ctx, cmd, args[0])
+}
+
+// runStdinMode generates a changelog from a JSON array of commits on stdin.
+// An optional from..to argument labels the output; it defaults to the first
+// and last commit SHAs.
+func runStdinMode(ctx context.Context, args []string) error {
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	commits, err := generator.ReadCommits(os.Stdin)
+	if err != nil {
+		return fmt.Errorf("read commits from stdin: %w", err)
+	}
+	if len(commits) == 0 {
+		return fmt.Errorf("no commits provided on stdin")
+	}
+
+	from, to := shortSHA(commits[0].SHA), shortSHA(commits[len(commits)-1].SHA)
+	if len(args) == 1 {
+		parts := strings.Split(args[0], "..")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("invalid commit range format, expected 'from..to', got '%s'", args[0])
+		}
+		from, to = parts[0], parts[1]
+	}
+
+	return runRange(ctx, nil, from, to, commits, nil)
+}
+
+// runFromStdinMode generates a changelog from a list of commit SHAs or a
+// `git log` dump on stdin. SHAs are fetched from the provider one by one; a
+// dump needs no API access at all. An optional from..to argument labels the
+// output; it defaults to the first and last commit SHAs.
+func runFromStdinMode(ctx context.Context, args []string) error {
+	shas, commits, err := generator.ReadCommitList(os.Stdin)
+	if err != nil {
+		return fmt.Errorf("read commits from stdin: %w", err)
+	}
+	if len(shas) == 0 && len(commits) == 0 {
+		return fmt.Errorf("no commits provided on stdin")
+	}
+	if commits != nil {
+		// A git log dump is as self-contained as --stdin JSON
+		cfg.Stdin = true
+		shas = nil
+		for _, commit := range commits {
+			shas = append(shas, commit.SHA)
+		}
+	}
+
+	from, to := shortSHA(shas[0]), shortSHA(shas[len(shas)-1])
+	if len(args) == 1 {
+		parts := strings.Split(args[0], "..")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("invalid commit range format, expected 'from..to', got '%s'", args[0])
+		}
+		from, to = parts[0], parts[1]
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	if commits != nil {
+		return runRange(ctx, nil, from, to, commits, nil)
+	}
+	if err := cfg.ValidateRepository(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	source, err := connectProvider(ctx)
+	if err != nil {
+		return err
 	}
-	return runRefMode(cmd, args[0])
+	return runRange(ctx, source, from, to, nil, shas)
+}
+
+// shortSHA abbreviates a commit SHA for display
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
 }
 
 // runRefMode handles the original ref-based generation (v1.0.0..v1.1.0)
-func runRefMode(cmd *cobra.Command, commitRange string) error {
+func runRefMode(ctx context.Context, cmd *cobra.Command, commitRange string) error {
 	// Parse commit range
 	parts := strings.Split(commitRange, "..")
 	if len(parts) != 2 {
@@ -185,41 +512,134 @@ func runRefMode(cmd *cobra.Command, commitRange string) error {
 		return fmt.Errorf("configuration error: %w", err)
 	}
 
-	if cfg.Verbose {
-		fmt.Printf("Changelog Generator v%s (Ref Mode)\n", version)
-		fmt.Printf("Repository: %s/%s\n", cfg.RepoOwner, cfg.RepoName)
-		fmt.Printf("Range: %s..%s\n", from, to)
-		fmt.Printf("Model: %s\n", cfg.OpenAIModel)
-		fmt.Println()
+	source, err := connectProvider(ctx)
+	if err != nil {
+		return err
 	}
 
-	// Create clients
-	githubClient := github.NewClient(cfg.GitHubToken, cfg.RepoOwner, cfg.RepoName)
-	llmClient := llm.NewOpenAIClient(cfg.OpenAIAPIKey, cfg.OpenAIModel, cfg.MaxTokens, cfg.Temperature)
+	// Resolve latest/previous release aliases (GitHub releases only)
+	if githubClient, ok := githubSource(source); ok {
+		includePrereleases, _ := cmd.Flags().GetBool("include-prereleases")
+		if from, err = githubClient.ResolveReleaseAlias(ctx, from, includePrereleases); err != nil {
+			return fmt.Errorf("resolve 'from' ref: %w", err)
+		}
+		if to, err = githubClient.ResolveReleaseAlias(ctx, to, includePrereleases); err != nil {
+			return fmt.Errorf("resolve 'to' ref: %w", err)
+		}
+	}
+	// HEAD means the tip of the selected branch
+	if cfg.Branch != "" {
+		if from == "HEAD" {
+			from = cfg.Branch
+		}
+		if to == "HEAD" {
+			to = cfg.Branch
+		}
+	}
+	if parts[0] != from || parts[1] != to {
+		logger.Info("resolved range", "range", commitRange, "from", from, "to", to)
+	}
 
-	// Validate GitHub access
-	if cfg.Verbose {
-		fmt.Println("Validating GitHub access...")
+	return runRange(ctx, source, from, to, nil, nil)
+}
+
+// runRange generates and writes the changelog for a resolved from..to range.
+// When commits is non-nil they are used instead of fetching the range from GitHub;
+// when shas is non-nil exactly those commits are fetched.
+func runRange(ctx context.Context, source provider.Provider, from, to string, commits []llm.CommitInfo, shas []string) error {
+	started := time.Now()
+	if cfg.Format != "markdown" && cfg.OutputPath == "CHANGELOG.md" {
+		cfg.OutputPath = "CHANGELOG" + outputExtension()
 	}
-	if err := githubClient.ValidateAccess(); err != nil {
-		return fmt.Errorf("GitHub access validation failed: %w", err)
+
+	// Reruns are idempotent: nothing to do if the target already documents this version
+	if cfg.Prepend {
+		documented, err := loadDocumentedVersions()
+		if err != nil {
+			return err
+		}
+		if generator.IsDocumented(documented, to) {
+			fmt.Printf("%s is already documented in %s, skipping\n", to, cfg.OutputPath)
+			return nil
+		}
 	}
 
+	logger.Info("starting changelog generation", "version", version, "mode", "ref",
+		"repo", cfg.RepoOwner+"/"+cfg.RepoName, "from", from, "to", to, "model", cfg.OpenAIModel)
+
 	// Create generator
-	gen := generator.NewGenerator(githubClient, llmClient, cfg)
+	gen, err := buildGenerator(source)
+	if err != nil {
+		return err
+	}
+	gen.SetCommitSHAs(shas)
+
+	if cfg.DryRun {
+		var report *generator.DryRunReport
+		if commits != nil {
+			report, err = gen.DryRunCommits(ctx, commits, from, to)
+		} else {
+			report, err = gen.DryRun(ctx, from, to)
+		}
+		if err != nil {
+			return fmt.Errorf("dry run: %w", err)
+		}
+		fmt.Print(generator.FormatDryRunReport(report))
+		return nil
+	}
 
 	// Generate changelog
-	changelog, err := gen.Generate(from, to)
+	var changelog *generator.Changelog
+	if commits != nil {
+		changelog, err = gen.GenerateFromCommits(ctx, commits, from, to)
+	} else {
+		changelog, err = gen.Generate(ctx, from, to)
+	}
 	if err != nil {
 		return fmt.Errorf("generate changelog: %w", err)
 	}
 
 	// Write output
-	return writeOutput(changelog.Markdown, "")
+	if err := writeChangelog(changelog, changelog.Markdown, "", nil); err != nil {
+		return err
+	}
+	if len(cfg.Sinks) > 0 {
+		if cfg.Confirm {
+			if err := confirmPublish(ctx, cfg.Sinks, source, changelog); err != nil {
+				return err
+			}
+		}
+		var releases sink.ReleaseUpdater
+		if githubClient, ok := githubSource(source); ok {
+			releases = githubClient
+		}
+		names, err := publishToSinks(ctx, cfg.Sinks, releases, changelog)
+		if err != nil {
+			return err
+		}
+		logger.Info("published changelog", "sinks", names)
+	}
+	if cfg.UpdateReleaseNotes {
+		if err := updateReleaseNotes(ctx, source, to, changelog.Markdown); err != nil {
+			return err
+		}
+	}
+	if err := runPostGenerate(ctx, from, to, to); err != nil {
+		return err
+	}
+	summary := generator.NewRunSummary(cfg, fmt.Sprintf("%s..%s", from, to))
+	summary.AddChangelog(changelog, cfg.MinScore)
+	if err := writeRunSummary(gen, summary, started); err != nil {
+		return err
+	}
+	return saveTrainingData(gen)
 }
 
-// runTimelineMode handles timeline-based generation (date range)
-func runTimelineMode(cmd *cobra.Command, fromDateStr, toDateStr string) error {
+// runTimelineMode handles timeline-based generation (date range). With a
+// batchFile, the release requests go through the Batch API job it records.
+func runTimelineMode(ctx context.Context, fromDateStr, toDateStr, batchFile string) error {
+	started := time.Now()
+
 	// Parse dates
 	if fromDateStr == "" || toDateStr == "" {
 		return fmt.Errorf("both --from-date and --to-date are required for timeline mode")
@@ -247,40 +667,58 @@ func runTimelineMode(cmd *cobra.Command, fromDateStr, toDateStr string) error {
 	if err := cfg.ValidateTimeline(); err != nil {
 		return fmt.Errorf("configuration error: %w", err)
 	}
+	if cfg.Org != "" {
+		return runOrgTimeline(ctx, fromDate, toDate)
+	}
 	if err := cfg.ValidateRepository(); err != nil {
 		return fmt.Errorf("configuration error: %w", err)
 	}
 
-	if cfg.Verbose {
-		fmt.Printf("Changelog Generator v%s (Timeline Mode)\n", version)
-		fmt.Printf("Repository: %s/%s\n", cfg.RepoOwner, cfg.RepoName)
-		fmt.Printf("Timeline: %s to %s\n", fromDate.Format("2006-01-02"), toDate.Format("2006-01-02"))
-		fmt.Printf("Model: %s\n", cfg.OpenAIModel)
-		fmt.Println()
-	}
+	logger.Info("starting changelog generation", "version", version, "mode", "timeline",
+		"repo", cfg.RepoOwner+"/"+cfg.RepoName, "from_date", fromDate.Format("2006-01-02"),
+		"to_date", toDate.Format("2006-01-02"), "model", cfg.OpenAIModel)
 
-	// Create clients
-	githubClient := github.NewClient(cfg.GitHubToken, cfg.RepoOwner, cfg.RepoName)
-	llmClient := llm.NewOpenAIClient(cfg.OpenAIAPIKey, cfg.OpenAIModel, cfg.MaxTokens, cfg.Temperature)
+	// Create generator
+	source, err := connectProvider(ctx)
+	if err != nil {
+		return err
+	}
+	gen, err := buildGenerator(source)
+	if err != nil {
+		return err
+	}
 
-	// Validate GitHub access
-	if cfg.Verbose {
-		fmt.Println("Validating GitHub access...")
+	// Skip releases already present in the target file, unless their tag moved
+	if cfg.Prepend {
+		existing, err := readExistingOutput()
+		if err != nil {
+			return err
+		}
+		gen.SetDocumentedVersions(generator.DocumentedVersions(existing))
+		gen.SetDocumentedSHAs(generator.DocumentedSHAs(existing))
 	}
-	if err := githubClient.ValidateAccess(); err != nil {
-		return fmt.Errorf("GitHub access validation failed: %w", err)
+
+	if cfg.DryRun {
+		report, err := gen.DryRunTimeline(ctx, fromDate, toDate)
+		if err != nil {
+			return fmt.Errorf("dry run: %w", err)
+		}
+		fmt.Print(generator.FormatDryRunReport(report))
+		return nil
 	}
 
-	// Create generator
-	gen := generator.NewGenerator(githubClient, llmClient, cfg)
+	// Submit the release requests as a batch, or use its results once it is done
+	if batchFile != "" {
+		ready, err := runTimelineBatch(ctx, gen, batchFile, fromDate, toDate)
+		if err != nil || !ready {
+			return err
+		}
+	}
 
 	// Generate timeline changelog
-	if cfg.Verbose {
-		fmt.Printf("Discovering releases from %s to %s...\n",
-			fromDate.Format("2006-01-02"), toDate.Format("2006-01-02"))
-	}
+	logger.Info("discovering releases", "from_date", fromDate.Format("2006-01-02"), "to_date", toDate.Format("2006-01-02"))
 
-	changelog, err := gen.GenerateTimeline(fromDate, toDate)
+	changelog, err := gen.GenerateTimeline(ctx, fromDate, toDate)
 	if err != nil {
 		return fmt.Errorf("generate timeline changelog: %w", err)
 	}
@@ -288,7 +726,7 @@ func runTimelineMode(cmd *cobra.Command, fromDateStr, toDateStr string) error {
 	// Generate timestamped filename for timeline mode
 	// Format: {repo-name}-{day}-{day}-{month}-{year}-changelog.md
 	// Example: akto-5-9-feb-2026-changelog.md
-	if cfg.OutputPath == "CHANGELOG.md" || cfg.OutputPath == "" {
+	if !cfg.Prepend && (cfg.OutputPath == "CHANGELOG.md" || cfg.OutputPath == "") {
 		fromDay := fromDate.Day()
 		toDay := toDate.Day()
 		month := strings.ToLower(fromDate.Format("Jan"))
@@ -297,28 +735,493 @@ func runTimelineMode(cmd *cobra.Command, fromDateStr, toDateStr string) error {
 		// Use repo name (just the repo part, not owner)
 		repoName := cfg.RepoName
 
-		cfg.OutputPath = fmt.Sprintf("%s-%d-%d-%s-%d-changelog.md",
-			repoName, fromDay, toDay, month, year)
+		cfg.OutputPath = fmt.Sprintf("%s-%d-%d-%s-%d-changelog%s",
+			repoName, fromDay, toDay, month, year, outputExtension())
+	}
+
+	// Sections for deleted or moved tags are dropped; moved ones are regenerated below
+	stale := gen.StaleVersions()
+	if cfg.Prepend && len(changelog.Releases) == 0 {
+		if len(stale) > 0 {
+			if err := writePrunedOutput("", " (stale sections removed)", stale); err != nil {
+				return err
+			}
+		}
+		fmt.Printf("No new releases to add to %s\n", cfg.OutputPath)
+		return nil
 	}
 
 	// Write output
 	releaseCount := fmt.Sprintf(" (%d releases)", len(changelog.Releases))
-	return writeOutput(changelog.Markdown, releaseCount)
+	if err := writeChangelog(changelog, changelog.Markdown, releaseCount, stale); err != nil {
+		return err
+	}
+	if batchFile != "" {
+		if err := os.Remove(batchFile); err != nil {
+			return fmt.Errorf("remove collected batch file: %w", err)
+		}
+	}
+	if len(cfg.Sinks) > 0 {
+		logger.Warn("sinks only publish single-range changelogs; skipping them in timeline mode")
+	}
+	latest := ""
+	if len(changelog.Releases) > 0 {
+		latest = changelog.Releases[len(changelog.Releases)-1].ToRef
+	}
+	if err := runPostGenerate(ctx, fromDate.Format("2006-01-02"), toDate.Format("2006-01-02"), latest); err != nil {
+		return err
+	}
+	summary := generator.NewRunSummary(cfg, fmt.Sprintf("%s to %s",
+		fromDate.Format("2006-01-02"), toDate.Format("2006-01-02")))
+	summary.AddTimeline(changelog)
+	if err := writeRunSummary(gen, summary, started); err != nil {
+		return err
+	}
+	return saveTrainingData(gen)
+}
+
+// runPostGenerate runs the hooks.post_generate command once the changelog has
+// been written
+func runPostGenerate(ctx context.Context, from, to, version string) error {
+	if cfg.HookPostGenerate == "" {
+		return nil
+	}
+	return hooks.PostGenerate(ctx, cfg.HookPostGenerate, cfg.HookEnv, hooks.PostGenerateData{
+		OutputPath: cfg.OutputPath,
+		Format:     cfg.Format,
+		Repo:       cfg.RepoOwner + "/" + cfg.RepoName,
+		From:       from,
+		To:         to,
+		Version:    version,
+	})
+}
+
+// writeRunSummary completes summary with the run's usage and warnings and
+// writes it to --run-summary
+func writeRunSummary(gen *generator.Generator, summary *generator.RunSummary, started time.Time) error {
+	if cfg.RunSummaryPath == "" {
+		return nil
+	}
+	summary.Finish(started, gen.Usage(), gen.Warnings())
+	if err := os.WriteFile(cfg.RunSummaryPath, []byte(generator.FormatRunSummary(summary)), 0644); err != nil {
+		return fmt.Errorf("write run summary: %w", err)
+	}
+	logger.Info("run summary written", "path", cfg.RunSummaryPath)
+	return nil
+}
+
+// saveTrainingData appends the run's prompt/response pairs to the
+// --collect-training-data directory once the changelog has been written
+func saveTrainingData(gen *generator.Generator) error {
+	if cfg.TrainingDataDir == "" {
+		return nil
+	}
+	examples := gen.TrainingExamples()
+	if err := llm.AppendTrainingData(cfg.TrainingDataDir, examples); err != nil {
+		return fmt.Errorf("save training data: %w", err)
+	}
+	logger.Info("saved training examples", "count", len(examples), "dir", cfg.TrainingDataDir)
+	return nil
+}
+
+// connectProvider creates the client for the configured hosting provider and
+// validates repository access
+func connectProvider(ctx context.Context) (provider.Provider, error) {
+	filter, err := provider.NewTagFilter(cfg.TagPattern, cfg.ExcludePrereleases)
+	if err != nil {
+		return nil, fmt.Errorf("configuration error: %w", err)
+	}
+
+	switch cfg.Provider {
+	case "bitbucket":
+		client := bitbucket.NewClient(cfg.BitbucketUsername, cfg.BitbucketToken, cfg.RepoOwner, cfg.RepoName)
+		client.SetTagFilter(filter)
+		client.SetIncludeBoundaries(cfg.IncludeBoundaries)
+		logger.Info("validating access", "provider", "bitbucket")
+		if err := client.ValidateAccess(ctx); err != nil {
+			return nil, fmt.Errorf("Bitbucket access validation failed: %w", err)
+		}
+		return recordFixture(client), nil
+	case "gitea":
+		client := gitea.NewClient(cfg.GiteaURL, cfg.GiteaToken, cfg.RepoOwner, cfg.RepoName)
+		client.SetTagFilter(filter)
+		client.SetIncludeBoundaries(cfg.IncludeBoundaries)
+		logger.Info("validating access", "provider", "gitea")
+		if err := client.ValidateAccess(ctx); err != nil {
+			return nil, fmt.Errorf("Gitea access validation failed: %w", err)
+		}
+		return recordFixture(client), nil
+	}
+
+	githubClient, err := connectGitHub(ctx)
+	if err != nil {
+		return nil, err
+	}
+	githubClient.SetTagFilter(filter)
+	githubClient.SetIncludeBoundaries(cfg.IncludeBoundaries)
+	return recordFixture(githubClient), nil
+}
+
+// requireGitHub rejects commands that rely on GitHub-only features
+func requireGitHub(command string) error {
+	if cfg.Provider != "github" {
+		return fmt.Errorf("%s is only supported with --provider=github", command)
+	}
+	return nil
+}
+
+// warnFastFetch warns when --fast-fetch leaves enabled settings without the
+// per-commit file lists and diffs they read
+func warnFastFetch() {
+	if !cfg.FastFetch {
+		return
+	}
+	if settings := cfg.FileDataSettings(); len(settings) > 0 {
+		logger.Warn("--fast-fetch fetches no per-commit file lists or diffs; these settings will see none",
+			"settings", strings.Join(settings, ", "))
+	}
+}
+
+// connectGitHub creates the GitHub client and validates repository access
+func connectGitHub(ctx context.Context) (*github.Client, error) {
+	githubClient, err := newGitHubClient(cfg.RepoOwner, cfg.RepoName)
+	if err != nil {
+		return nil, err
+	}
+	githubClient.SetBranch(cfg.Branch)
+	githubClient.SetFastFetch(cfg.FastFetch)
+	warnFastFetch()
+	githubClient.SetReviewStats(cfg.IncludeReviewStats)
+	githubClient.SetMaxCommits(cfg.MaxCommits)
+
+	// Validate GitHub access
+	logger.Info("validating access", "provider", "github")
+	if err := githubClient.ValidateAccess(ctx); err != nil {
+		return nil, fmt.Errorf("GitHub access validation failed: %w", err)
+	}
+	return githubClient, nil
+}
+
+// newGitHubClient authenticates as the configured GitHub App installation,
+// or with the personal token otherwise
+func newGitHubClient(owner, repo string) (*github.Client, error) {
+	if cfg.GitHubAppID == 0 {
+		return github.NewClient(cfg.GitHubToken, owner, repo), nil
+	}
+	key := []byte(cfg.GitHubAppPrivateKey)
+	if len(key) == 0 {
+		var err error
+		if key, err = os.ReadFile(cfg.GitHubAppPrivateKeyPath); err != nil {
+			return nil, fmt.Errorf("read GitHub App private key: %w", err)
+		}
+	}
+	logger.Info("authenticating as GitHub App", "app_id", cfg.GitHubAppID)
+	return github.NewAppClient(github.AppAuth{
+		AppID:          cfg.GitHubAppID,
+		PrivateKey:     key,
+		InstallationID: cfg.GitHubAppInstallationID,
+	}, owner, repo)
+}
+
+// buildGenerator creates the LLM client and wires optional integrations
+// into a new generator reading from source (nil for caller-supplied commits)
+func buildGenerator(source provider.Provider) (*generator.Generator, error) {
+	// Category aliases must point into the taxonomy
+	for alias, target := range cfg.CategoryAliases {
+		if err := generator.ValidateCategoryTarget(target); err != nil {
+			return nil, fmt.Errorf("configuration error: category_aliases.%s: %w", alias, err)
+		}
+	}
+	if err := generator.ValidateCategoryTarget(cfg.UnknownCategory); err != nil {
+		return nil, fmt.Errorf("configuration error: unknown_category: %w", err)
+	}
+	for label, target := range cfg.LabelCategories {
+		if err := generator.ValidateCategoryTarget(target); err != nil {
+			return nil, fmt.Errorf("configuration error: label_categories.%s: %w", label, err)
+		}
+	}
+	if cfg.LabelCategoryMode != "override" && cfg.LabelCategoryMode != "hint" {
+		return nil, fmt.Errorf("configuration error: unsupported label category mode %q (expected override or hint)", cfg.LabelCategoryMode)
+	}
+	switch cfg.BotCommits {
+	case "include", "exclude", "group":
+	default:
+		return nil, fmt.Errorf("configuration error: unsupported bot commits mode %q (expected include, exclude, or group)", cfg.BotCommits)
+	}
+	if cfg.Top < 0 || cfg.TopPerCategory < 0 {
+		return nil, fmt.Errorf("configuration error: --top and --top-per-category must not be negative")
+	}
+	if cfg.MaxFilesInPrompt < 0 || cfg.DiffThreshold < 0 || cfg.DiffFiles < 0 || cfg.DiffLines < 0 || cfg.FullPatchLines < 0 {
+		return nil, fmt.Errorf("configuration error: --max-files-in-prompt, --diff-threshold, --diff-files, --diff-lines, and --full-patch-lines must not be negative")
+	}
+	if cfg.SmallReleaseMax < 0 || cfg.LargeReleaseMin < 0 || cfg.LargeReleaseLines < 0 {
+		return nil, fmt.Errorf("configuration error: --small-release-max, --large-release-min, and --large-release-lines must not be negative")
+	}
+	if cfg.ModelRouting && cfg.SmallReleaseMax >= cfg.LargeReleaseMin {
+		return nil, fmt.Errorf("configuration error: --small-release-max (%d) must be below --large-release-min (%d)", cfg.SmallReleaseMax, cfg.LargeReleaseMin)
+	}
+	if cfg.MaxAttempts < 1 {
+		return nil, fmt.Errorf("configuration error: --llm-max-attempts must be at least 1")
+	}
+	if cfg.Scoring != "llm" && cfg.Scoring != "heuristic" {
+		return nil, fmt.Errorf("configuration error: unsupported scoring %q (expected llm or heuristic)", cfg.Scoring)
+	}
+	switch cfg.Reverts {
+	case "drop", "annotate", "keep":
+	default:
+		return nil, fmt.Errorf("configuration error: unsupported reverts mode %q (expected drop, annotate, or keep)", cfg.Reverts)
+	}
+	if cfg.GroupByArea && len(cfg.ProductAreas) == 0 {
+		return nil, fmt.Errorf("configuration error: --group-by-area needs product_areas in the config file")
+	}
+	switch cfg.LinkStyle {
+	case "commit", "pr", "both":
+	default:
+		return nil, fmt.Errorf("configuration error: unsupported link style %q (expected commit, pr, or both)", cfg.LinkStyle)
+	}
+	if cfg.Format == "hugo" {
+		if cfg.DocsSite != "" && cfg.DocsSite != "hugo" {
+			return nil, fmt.Errorf("configuration error: --format=hugo conflicts with --docs-site=%s", cfg.DocsSite)
+		}
+		cfg.DocsSite = "hugo"
+	}
+	if cfg.DocsSite != "" && !generator.IsDocsSite(cfg.DocsSite) {
+		return nil, fmt.Errorf("configuration error: unsupported docs site %q (expected docusaurus, mkdocs, or hugo)", cfg.DocsSite)
+	}
+	switch cfg.FrontMatter {
+	case "", "yaml":
+	case "toml":
+		if cfg.DocsSite != "hugo" {
+			return nil, fmt.Errorf("configuration error: toml front-matter needs --docs-site=hugo or --format=hugo")
+		}
+	default:
+		return nil, fmt.Errorf("configuration error: unsupported front-matter %q (expected yaml or toml)", cfg.FrontMatter)
+	}
+	switch cfg.UnverifiedCommits {
+	case "include", "annotate", "exclude":
+	default:
+		return nil, fmt.Errorf("configuration error: unsupported unverified commits mode %q (expected include, annotate, or exclude)", cfg.UnverifiedCommits)
+	}
+
+	if cfg.Reproducible {
+		cfg.Temperature = 0
+		if cfg.Seed == 0 {
+			cfg.Seed = config.DefaultSeed
+		}
+	}
+
+	llmClient := llm.NewOpenAIClient(cfg.OpenAIAPIKey, cfg.OpenAIModel, cfg.MaxTokens, cfg.Temperature)
+	llmClient.SetSeed(int64(cfg.Seed))
+	llmClient.SetMaxAttempts(cfg.MaxAttempts)
+	if cfg.PromptLogDir != "" {
+		prompts, err := llm.NewPromptLog(cfg.PromptLogDir, cfg.Secrets()...)
+		if err != nil {
+			return nil, err
+		}
+		llmClient.SetPromptLog(prompts)
+	}
+
+	gen := generator.NewGenerator(source, llmClient, cfg)
+	gen.SetToolVersion(version)
+
+	// Issue tracker linking
+	linker, err := tickets.FromConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("configuration error: %w", err)
+	}
+	if linker.Enabled() && cfg.FetchTicketSummaries {
+		if cache := openSharedCache(); cache != nil {
+			linker.SetCache(cache)
+		}
+	}
+	gen.SetTicketLinker(linker)
+	gen.AddHooks(hooks.FromConfig(cfg))
+
+	return gen, nil
+}
+
+// loadDocumentedVersions returns the versions already documented in the output file
+func loadDocumentedVersions() (map[string]bool, error) {
+	existing, err := readExistingOutput()
+	if err != nil || existing == "" {
+		return nil, err
+	}
+	return generator.DocumentedVersions(existing), nil
+}
+
+// readExistingOutput returns the current contents of the output file, or an
+// empty string when there is none
+func readExistingOutput() (string, error) {
+	if cfg.OutputPath == "" || cfg.OutputPath == "-" {
+		return "", nil
+	}
+	existing, err := os.ReadFile(cfg.OutputPath)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("read existing output file: %w", err)
+	}
+	return string(existing), nil
+}
+
+// renderOutput serializes the changelog in the configured output format
+func renderOutput(changelog any, markdown string) (string, error) {
+	if cfg.ValidateOutput {
+		data, err := json.Marshal(changelog)
+		if err != nil {
+			return "", fmt.Errorf("encode JSON output: %w", err)
+		}
+		if err := generator.ValidateJSON(data); err != nil {
+			return "", fmt.Errorf("validate output: %w", err)
+		}
+	}
+
+	switch cfg.Format {
+	case "json":
+		data, err := json.MarshalIndent(changelog, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("encode JSON output: %w", err)
+		}
+		return string(data) + "\n", nil
+	case "csv", "xlsx":
+		rows, err := generator.ExportRows(changelog)
+		if err != nil {
+			return "", err
+		}
+		if cfg.Format == "csv" {
+			return generator.FormatCSV(rows)
+		}
+		if cfg.OutputPath == "-" || cfg.OutputPath == "" {
+			return "", fmt.Errorf("--format=xlsx needs an output file")
+		}
+		data, err := generator.FormatXLSX(rows)
+		if err != nil {
+			return "", fmt.Errorf("encode xlsx output: %w", err)
+		}
+		return string(data), nil
+	case "announcement":
+		announcements, err := generator.Announcements(changelog)
+		if err != nil {
+			return "", err
+		}
+		return generator.FormatAnnouncements(announcements), nil
+	case "widget-json":
+		items, err := generator.WidgetItems(changelog)
+		if err != nil {
+			return "", err
+		}
+		return generator.FormatWidgetJSON(items)
+	default:
+		return markdown, nil
+	}
+}
+
+// outputExtension returns the file extension for the configured output format
+func outputExtension() string {
+	switch cfg.Format {
+	case "markdown", "announcement":
+		return ".md"
+	case "widget-json":
+		return ".json"
+	}
+	return "." + cfg.Format
+}
+
+// updateReleaseNotes replaces the generated block in the body of the GitHub
+// release or draft for tag, leaving hand-written text outside the markers
+func updateReleaseNotes(ctx context.Context, source provider.Provider, tag, markdown string) error {
+	githubClient, ok := githubSource(source)
+	if !ok {
+		return fmt.Errorf("--update-release-notes requires --provider=github")
+	}
+	id, current, err := githubClient.EditableRelease(ctx, tag)
+	if err != nil {
+		return err
+	}
+	proposed := generator.ReplaceGeneratedBlock(current, markdown)
+	if proposed == current {
+		logger.Info("release notes unchanged", "tag", tag)
+		return nil
+	}
+	if cfg.Confirm {
+		target := fmt.Sprintf("GitHub release %s", tag)
+		fmt.Print(generator.FormatDiff(target+" (current)", target+" (new)", current, proposed, colorOutput()))
+		ok, err := askConfirmation(fmt.Sprintf("Update %s?", target))
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("aborted: release notes were not updated")
+		}
+	}
+	if err := githubClient.SetReleaseBody(ctx, id, proposed); err != nil {
+		return err
+	}
+	logger.Info("updated release notes", "tag", tag)
+	return nil
+}
+
+// publishToSinks fans a changelog out to every sink in configs and returns
+// their names. releases backs the github-release sink (nil off GitHub).
+func publishToSinks(ctx context.Context, configs []config.SinkConfig, releases sink.ReleaseUpdater, changelog *generator.Changelog) ([]string, error) {
+	sinks, err := sink.Build(configs, releases)
+	if err != nil {
+		return nil, fmt.Errorf("configuration error: %w", err)
+	}
+	if err := sink.WriteAll(ctx, sinks, changelog); err != nil {
+		return nil, fmt.Errorf("publish changelog: %w", err)
+	}
+	names := make([]string, len(sinks))
+	for i, s := range sinks {
+		names[i] = s.Name()
+	}
+	return names, nil
 }
 
 // writeOutput writes the changelog to file or stdout
 func writeOutput(markdown, suffix string) error {
+	return writePrunedOutput(markdown, suffix, nil)
+}
+
+// writePrunedOutput writes the changelog like writeOutput. With --prepend the
+// sections of stale versions are first removed from the existing file, and an
+// empty changelog only prunes. With --confirm the change is previewed as a
+// diff and written only once accepted.
+func writePrunedOutput(markdown, suffix string, stale []string) error {
 	if cfg.OutputPath == "-" || cfg.OutputPath == "" {
 		fmt.Println(markdown)
-	} else {
-		if err := os.WriteFile(cfg.OutputPath, []byte(markdown), 0644); err != nil {
-			return fmt.Errorf("write output file: %w", err)
+		return nil
+	}
+
+	existing, err := readExistingOutput()
+	if err != nil {
+		return err
+	}
+	content := markdown
+	if cfg.Prepend {
+		content = existing
+		if len(stale) > 0 {
+			content = generator.RemoveReleaseSections(existing, stale)
+			logger.Info("removing stale release sections", "versions", strings.Join(stale, ", "), "path", cfg.OutputPath)
 		}
-		if cfg.Verbose {
-			fmt.Printf("\n✓ Changelog written to %s%s\n", cfg.OutputPath, suffix)
-		} else {
-			fmt.Printf("Changelog written to %s%s\n", cfg.OutputPath, suffix)
+		if markdown != "" {
+			content = generator.PrependChangelog(content, markdown)
 		}
 	}
+
+	if cfg.Confirm {
+		ok, err := confirmChange(cfg.OutputPath, existing, content)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("aborted: %s was not written", cfg.OutputPath)
+		}
+	}
+	if err := os.WriteFile(cfg.OutputPath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("write output file: %w", err)
+	}
+	fmt.Printf("Changelog written to %s%s\n", cfg.OutputPath, suffix)
 	return nil
 }
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/generator"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+// runOrgTimeline generates a timeline for every non-archived repository in
+// cfg.Org and writes one consolidated document grouped by repository
+func runOrgTimeline(ctx context.Context, fromDate, toDate time.Time) error {
+	started := time.Now()
+	if err := requireGitHub("--org"); err != nil {
+		return err
+	}
+	if cfg.Prepend {
+		return fmt.Errorf("--prepend is not supported with --org")
+	}
+
+	filter, err := provider.NewTagFilter(cfg.TagPattern, cfg.ExcludePrereleases)
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	orgClient, err := newGitHubClient(cfg.Org, "")
+	if err != nil {
+		return err
+	}
+	orgClient.SetTagFilter(filter)
+	orgClient.SetIncludeBoundaries(cfg.IncludeBoundaries)
+	orgClient.SetBranch(cfg.Branch)
+	orgClient.SetFastFetch(cfg.FastFetch)
+	warnFastFetch()
+	orgClient.SetReviewStats(cfg.IncludeReviewStats)
+	orgClient.SetMaxCommits(cfg.MaxCommits)
+	repos, err := orgClient.ListOrgRepos(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("starting changelog generation", "version", version, "mode", "org-timeline",
+		"org", cfg.Org, "repos", len(repos), "from_date", fromDate.Format("2006-01-02"),
+		"to_date", toDate.Format("2006-01-02"), "model", cfg.OpenAIModel)
+
+	org := &generator.OrgTimelineChangelog{
+		Schema:       generator.SchemaVersion,
+		Org:          cfg.Org,
+		FromDate:     fromDate,
+		ToDate:       toDate,
+		ReposScanned: len(repos),
+	}
+
+	// The generator reads the repository name from cfg, so each repository
+	// is processed with cfg pointed at it
+	cfg.RepoOwner = cfg.Org
+	var usage llm.Usage
+	for i, repo := range repos {
+		cfg.RepoName = repo
+		logger.Info("processing repository", "repo", cfg.Org+"/"+repo, "index", i+1, "total", len(repos))
+
+		gen, err := buildGenerator(orgClient.ForRepo(repo))
+		if err != nil {
+			return err
+		}
+
+		if cfg.DryRun {
+			report, err := gen.DryRunTimeline(ctx, fromDate, toDate)
+			if errors.Is(err, provider.ErrNoReleases) {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("dry run %s: %w", repo, err)
+			}
+			fmt.Printf("%s/%s\n%s\n", cfg.Org, repo, generator.FormatDryRunReport(report))
+			continue
+		}
+
+		timeline, err := gen.GenerateTimeline(ctx, fromDate, toDate)
+		repoUsage := gen.Usage()
+		usage.Calls += repoUsage.Calls
+		usage.InputTokens += repoUsage.InputTokens
+		usage.OutputTokens += repoUsage.OutputTokens
+		if errors.Is(err, provider.ErrNoReleases) {
+			continue
+		}
+		if err != nil && ctx.Err() != nil {
+			return fmt.Errorf("generate timeline for %s: %w", repo, err)
+		}
+		if err != nil {
+			// One inaccessible or broken repository should not sink the whole report
+			logger.Warn("skipping repository", "repo", cfg.Org+"/"+repo, "error", err)
+			continue
+		}
+		if len(timeline.Releases) > 0 {
+			org.Repos = append(org.Repos, timeline)
+		}
+	}
+
+	if cfg.DryRun {
+		return nil
+	}
+	org.Markdown = generator.FormatOrgTimeline(org)
+	org.Metadata = generator.NewMetadata(cfg, version, started, usage, generator.Source{
+		Provider: cfg.Provider, Repo: cfg.Org, FromDate: fromDate, ToDate: toDate,
+	})
+
+	if cfg.OutputPath == "CHANGELOG.md" || cfg.OutputPath == "" {
+		cfg.OutputPath = fmt.Sprintf("%s-%d-%d-%s-%d-changelog%s",
+			cfg.Org, fromDate.Day(), toDate.Day(), strings.ToLower(fromDate.Format("Jan")),
+			fromDate.Year(), outputExtension())
+	}
+
+	return writeChangelog(org, org.Markdown, fmt.Sprintf(" (%d repositories)", len(org.Repos)), nil)
+}
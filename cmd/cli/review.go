@@ -0,0 +1,131 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/generator"
+	"github.com/spf13/cobra"
+)
+
+var reviewCmd = &cobra.Command{
+	Use:   "review <from..to>",
+	Short: "Check hand-written release notes against the commits they describe",
+	Long: `Feed existing, human-written release notes and the commits of the release to
+the model and report omissions (notable commits the notes do not mention) and
+inaccuracies (statements the commits do not support) as a gap report.
+
+The notes are read from --notes (a file, or - for stdin); without it the
+GitHub release of the "to" tag is reviewed. The report goes to stdout unless
+--output is set; --format=json writes it as JSON. Commits the notes cite by SHA
+or pull request number are never reported as omitted.
+
+Examples:
+  changelog-generator review v1.0.0..v1.1.0 --owner=myorg --repo=myrepo
+  changelog-generator review v1.0.0..v1.1.0 --notes=docs/release-1.1.md --fail-on-gaps
+  cat draft.md | changelog-generator review v1.0.0..HEAD --notes=- --format=json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReview,
+}
+
+func init() {
+	addCommonFlags(reviewCmd)
+	reviewCmd.Flags().String("notes", "", "Release notes to review: a file, or - for stdin (default: the GitHub release of the 'to' tag)")
+	reviewCmd.Flags().Bool("fail-on-gaps", false, "Exit non-zero when the review finds omissions or inaccuracies (for CI)")
+}
+
+func runReview(cmd *cobra.Command, args []string) error {
+	ctx, cancel := withTimeout(cmd.Context())
+	defer cancel()
+
+	parts := strings.Split(args[0], "..")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("invalid commit range format, expected 'from..to', got '%s'", args[0])
+	}
+	from, to := parts[0], parts[1]
+	notesPath, _ := cmd.Flags().GetString("notes")
+	failOnGaps, _ := cmd.Flags().GetBool("fail-on-gaps")
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	if err := cfg.ValidateRepository(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	if cfg.Format != "markdown" && cfg.Format != "json" {
+		return fmt.Errorf("review only supports --format=markdown or --format=json")
+	}
+	if cfg.DryRun {
+		return fmt.Errorf("review does not support --dry-run")
+	}
+	// A gap report is meant for reading, not for overwriting CHANGELOG.md
+	if cfg.OutputPath == "CHANGELOG.md" {
+		cfg.OutputPath = "-"
+	}
+
+	source, err := connectProvider(ctx)
+	if err != nil {
+		return err
+	}
+
+	var notes string
+	switch notesPath {
+	case "":
+		githubClient, ok := githubSource(source)
+		if !ok {
+			return fmt.Errorf("--notes is required with --provider=%s", cfg.Provider)
+		}
+		if notes, err = githubClient.ReleaseNotes(ctx, to); err != nil {
+			return err
+		}
+		if strings.TrimSpace(notes) == "" {
+			return fmt.Errorf("%s has no GitHub release notes to review; pass them with --notes", to)
+		}
+	case "-":
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("read release notes from stdin: %w", err)
+		}
+		notes = string(data)
+	default:
+		data, err := os.ReadFile(notesPath)
+		if err != nil {
+			return fmt.Errorf("read release notes: %w", err)
+		}
+		notes = string(data)
+	}
+
+	gen, err := buildGenerator(source)
+	if err != nil {
+		return err
+	}
+	report, err := gen.Review(ctx, notes, from, to)
+	if err != nil {
+		return err
+	}
+
+	content := generator.FormatReviewReport(report, source.CommitURL)
+	if cfg.Format == "json" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("encode review: %w", err)
+		}
+		content = string(data) + "\n"
+	}
+	if cfg.OutputPath == "-" {
+		fmt.Print(content)
+	} else {
+		if err := os.WriteFile(cfg.OutputPath, []byte(content), 0o644); err != nil {
+			return fmt.Errorf("write review: %w", err)
+		}
+		fmt.Printf("Review written to %s\n", cfg.OutputPath)
+	}
+
+	if failOnGaps && report.Gaps() > 0 {
+		return fmt.Errorf("review found %d gaps in the release notes for %s", report.Gaps(), to)
+	}
+	return nil
+}
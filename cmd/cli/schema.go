@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/generator"
+	"github.com/spf13/cobra"
+)
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema [changelog.json...]",
+	Short: "Print the JSON output schema, or validate changelogs against it",
+	Long: `Without arguments, print the JSON Schema (draft 2020-12) of --format=json
+output, for consumers that parse generated changelogs. Every document carries
+schema_version; it changes only when a field is removed or retyped, while new
+optional fields can appear within a version.
+
+With files, check each against the schema and exit non-zero when any does not
+match, listing the violations by JSON path.
+
+Examples:
+  changelog-generator schema > changelog.schema.json
+  changelog-generator schema changelog.json`,
+	RunE: runSchema,
+}
+
+func runSchema(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		_, err := os.Stdout.Write(generator.Schema)
+		return err
+	}
+
+	invalid := 0
+	for _, path := range args {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("read changelog: %w", err)
+		}
+		if err := generator.ValidateJSON(data); err != nil {
+			fmt.Printf("✗ %s: %v\n", path, err)
+			invalid++
+			continue
+		}
+		fmt.Printf("✓ %s matches schema version %s\n", path, generator.SchemaVersion)
+	}
+	if invalid > 0 {
+		return fmt.Errorf("%d of %d changelogs do not match the schema", invalid, len(args))
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/generator"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/grpcapi"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve changelog generation to other services over gRPC",
+	Long: `Start a gRPC server with the ChangelogService of
+proto/changelog/v1/changelog.proto. GenerateChangelog and GenerateTimeline
+stream progress events (fetching, generating, each timeline release) and end
+with the changelog, as structured fields plus the markdown and JSON that
+generate writes.
+
+Requests run with the flags and config file the server was started with; a
+request's owner and repo override --owner/--repo. The server has no
+authentication of its own, so keep it on a private address.
+
+Examples:
+  changelog-generator serve --owner=myorg --repo=api
+  changelog-generator serve --grpc-addr=0.0.0.0:50051 --fast-fetch`,
+	Args: cobra.NoArgs,
+	RunE: runServe,
+}
+
+func init() {
+	addCommonFlags(serveCmd)
+
+	serveCmd.Flags().String("grpc-addr", "127.0.0.1:50051", "Address to listen on")
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	addr, _ := cmd.Flags().GetString("grpc-addr")
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+	server := grpc.NewServer()
+	grpcapi.NewServer(serveGenerators(*cfg)).Register(server)
+	go func() {
+		<-cmd.Context().Done()
+		server.GracefulStop()
+	}()
+
+	fmt.Printf("Serving gRPC at %s\n", listener.Addr())
+	if err := server.Serve(listener); err != nil {
+		return fmt.Errorf("serve gRPC: %w", err)
+	}
+	return nil
+}
+
+// serveGenerators builds each request's generator on its own copy of base,
+// so requests for different repositories can run at once. connectProvider
+// and buildGenerator read the global cfg, which is swapped to the copy while
+// they run.
+func serveGenerators(base config.Config) grpcapi.GeneratorFactory {
+	var mu sync.Mutex
+	return func(ctx context.Context, owner, repo string) (*generator.Generator, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		requestCfg := base
+		if owner != "" {
+			requestCfg.RepoOwner = owner
+		}
+		if repo != "" {
+			requestCfg.RepoName = repo
+		}
+		global := cfg
+		cfg = &requestCfg
+		defer func() { cfg = global }()
+
+		if err := cfg.ValidateRepository(); err != nil {
+			return nil, fmt.Errorf("configuration error: %w", err)
+		}
+		source, err := connectProvider(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return buildGenerator(source)
+	}
+}
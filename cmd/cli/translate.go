@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var translateCmd = &cobra.Command{
+	Use:   "translate <changelog>",
+	Short: "Translate a generated changelog into other languages",
+	Long: `Translate an existing generated changelog, markdown or JSON, into one or more
+languages with the model. Nothing is fetched from the repository and nothing
+is re-analyzed, so a translation costs a fraction of regenerating the
+changelog per language.
+
+Markdown is translated heading by heading, keeping links, code, SHAs, and
+HTML comments intact. In JSON (files ending in .json) only the prose fields
+are translated: summaries, highlights, entry titles, descriptions and score
+reasons, and pull request summaries; refs, authors, URLs, and category names
+are kept so consumers can still parse the file.
+
+Each translation is written next to the input with the language inserted
+before the extension (CHANGELOG.md → CHANGELOG.de.md), or to --output, where
+{lang} is replaced by the language.
+
+Examples:
+  changelog-generator translate CHANGELOG.md --lang=de,fr,ja
+  changelog-generator translate changelog.json --lang=pt-BR --output=docs/{lang}/changelog.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTranslate,
+}
+
+func init() {
+	translateCmd.Flags().StringSlice("lang", nil, "Languages to translate into, as names or codes (e.g. de,fr,ja or \"Brazilian Portuguese\") (required)")
+	translateCmd.Flags().String("output", "", "Output path template; {lang} is replaced by each language (default: the input path with .<lang> before the extension)")
+	translateCmd.Flags().StringVar(&cfg.OpenAIModel, "model", cfg.OpenAIModel, "OpenAI model to use")
+	translateCmd.Flags().BoolVar(&cfg.Strict, "strict", cfg.Strict, "Fail when a translation reply needed JSON cleanup (for CI)")
+	translateCmd.MarkFlagRequired("lang")
+}
+
+func runTranslate(cmd *cobra.Command, args []string) error {
+	ctx, cancel := withTimeout(cmd.Context())
+	defer cancel()
+
+	languages, _ := cmd.Flags().GetStringSlice("lang")
+	output, _ := cmd.Flags().GetString("output")
+	if output != "" && len(languages) > 1 && !strings.Contains(output, "{lang}") {
+		return fmt.Errorf("--output must contain {lang} when translating into several languages")
+	}
+	cfg.ResolveCredentials()
+	if cfg.OpenAIAPIKey == "" {
+		return fmt.Errorf("configuration error: OpenAI API key is required (set OPENAI_API_KEY or run auth login)")
+	}
+
+	input := args[0]
+	data, err := os.ReadFile(input)
+	if err != nil {
+		return fmt.Errorf("read changelog: %w", err)
+	}
+	isJSON := strings.EqualFold(filepath.Ext(input), ".json")
+
+	gen, err := buildGenerator(nil)
+	if err != nil {
+		return err
+	}
+	for _, language := range languages {
+		language = strings.TrimSpace(language)
+		if language == "" {
+			continue
+		}
+
+		var translated []byte
+		if isJSON {
+			translated, err = gen.TranslateJSON(ctx, data, language)
+		} else {
+			var markdown string
+			markdown, err = gen.TranslateMarkdown(ctx, string(data), language)
+			translated = []byte(markdown)
+		}
+		if err != nil {
+			return err
+		}
+
+		path := translationPath(input, output, language)
+		if dir := filepath.Dir(path); dir != "." {
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				return fmt.Errorf("create output directory: %w", err)
+			}
+		}
+		if err := os.WriteFile(path, translated, 0o644); err != nil {
+			return fmt.Errorf("write translation: %w", err)
+		}
+		fmt.Printf("✓ %s translation written to %s\n", language, path)
+	}
+	usage := gen.Usage()
+	logger.Info("translation finished", "calls", usage.Calls, "input_tokens", usage.InputTokens, "output_tokens", usage.OutputTokens)
+	return nil
+}
+
+// translationPath returns where the translation into language is written:
+// the output template with {lang} replaced, or the input path with the
+// language inserted before its extension
+func translationPath(input, output, language string) string {
+	if output != "" {
+		return strings.ReplaceAll(output, "{lang}", language)
+	}
+	ext := filepath.Ext(input)
+	return strings.TrimSuffix(input, ext) + "." + language + ext
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var unreleasedCmd = &cobra.Command{
+	Use:   "unreleased",
+	Short: "Generate a changelog for changes since the latest tag",
+	Long: `Generate a changelog for everything merged to the default branch (or
+--branch) since the latest tag reachable from it, without looking up refs
+manually.
+
+The latest tag is the highest semantic version tag (prereleases are skipped
+unless --include-prereleases is set); repositories without version tags fall
+back to the most recently committed tag.
+
+Examples:
+  changelog-generator unreleased --owner=myorg --repo=myrepo
+  changelog-generator unreleased --output=- --include-prereleases`,
+	Args: cobra.NoArgs,
+	RunE: runUnreleased,
+}
+
+func init() {
+	addCommonFlags(unreleasedCmd)
+	unreleasedCmd.Flags().Bool("include-prereleases", false, "Consider prerelease tags (e.g., v2.0.0-rc.1) when resolving the latest tag")
+	unreleasedCmd.Flags().BoolVar(&cfg.Confirm, "confirm", cfg.Confirm, "Show a colored diff against the existing output file and release notes, and ask before writing or publishing")
+}
+
+func runUnreleased(cmd *cobra.Command, args []string) error {
+	ctx, cancel := withTimeout(cmd.Context())
+	defer cancel()
+
+	if err := requireGitHub("unreleased"); err != nil {
+		return err
+	}
+	// Validate configuration
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	if err := cfg.ValidateRepository(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	githubClient, err := connectGitHub(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Resolve latest-tag..default-branch
+	includePrereleases, _ := cmd.Flags().GetBool("include-prereleases")
+	from, err := githubClient.LatestTag(ctx, includePrereleases)
+	if err != nil {
+		return fmt.Errorf("resolve latest tag: %w", err)
+	}
+	to := cfg.Branch
+	if to == "" {
+		if to, err = githubClient.DefaultBranch(ctx); err != nil {
+			return fmt.Errorf("resolve default branch: %w", err)
+		}
+	}
+
+	logger.Info("resolved unreleased range", "from", from, "to", to)
+
+	return runRange(ctx, githubClient, from, to, nil, nil)
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/generator"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/viewer"
+	"github.com/spf13/cobra"
+)
+
+var viewCmd = &cobra.Command{
+	Use:   "view <changelog.json|directory>...",
+	Short: "Browse generated changelogs in a local web viewer",
+	Long: `Start a local HTTP server with a searchable view of one or more changelogs
+written with --format=json. A directory loads every .json file in it, so the
+output directory of "watch --format=json" shows the full release history.
+
+The page has free-text search, category filters, and importance score sliders.
+With --feedback-file, readers can rate each entry 👍/👎; every reaction is
+appended to that file as a JSON line for tuning prompts.
+
+Examples:
+  changelog-generator generate v1.0.0..v1.1.0 --format=json --output=changelog.json
+  changelog-generator view changelog.json
+  changelog-generator view changelogs/ --addr=:8080
+  changelog-generator view changelogs/ --feedback-file=feedback.jsonl`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runView,
+}
+
+func init() {
+	viewCmd.Flags().String("addr", "127.0.0.1:8080", "Address to listen on")
+	viewCmd.Flags().String("feedback-file", "", "Let readers rate entries and append each reaction to this JSON Lines file (empty = off)")
+}
+
+func runView(cmd *cobra.Command, args []string) error {
+	addr, _ := cmd.Flags().GetString("addr")
+	feedbackFile, _ := cmd.Flags().GetString("feedback-file")
+
+	files, err := changelogFiles(args)
+	if err != nil {
+		return err
+	}
+
+	var rows []generator.ExportRow
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("read changelog: %w", err)
+		}
+		changelog, err := generator.LoadChangelog(data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		fileRows, err := generator.ExportRows(changelog)
+		if err != nil {
+			return err
+		}
+		rows = append(rows, fileRows...)
+	}
+
+	title := "Changelog: " + filepath.Base(files[0])
+	if len(files) > 1 {
+		title = fmt.Sprintf("Changelogs: %d files", len(files))
+	}
+
+	var feedback *viewer.FeedbackLog
+	if feedbackFile != "" {
+		feedback = viewer.NewFeedbackLog(feedbackFile)
+	}
+
+	server := &http.Server{Addr: addr, Handler: viewer.Handler(title, rows, feedback)}
+	go func() {
+		<-cmd.Context().Done()
+		server.Close()
+	}()
+
+	fmt.Printf("Serving %d entries from %d changelog(s) at http://%s\n", len(rows), len(files), addr)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("serve viewer: %w", err)
+	}
+	return nil
+}
+
+// changelogFiles expands directory arguments to the JSON files they contain
+func changelogFiles(args []string) ([]string, error) {
+	var files []string
+	for _, arg := range args {
+		info, err := os.Stat(arg)
+		if err != nil {
+			return nil, fmt.Errorf("open changelog: %w", err)
+		}
+		if !info.IsDir() {
+			files = append(files, arg)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(arg, "*.json"))
+		if err != nil {
+			return nil, fmt.Errorf("list changelogs: %w", err)
+		}
+		files = append(files, matches...)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no .json changelogs found in %v", args)
+	}
+	return files, nil
+}
@@ -0,0 +1,272 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/generator"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/github"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/schedule"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/state"
+	"github.com/spf13/cobra"
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Poll repositories on a schedule and publish changelogs for new releases",
+	Long: `Run as a daemon that polls the configured repositories on a cron schedule.
+Whenever a repository has a new latest tag since the last run, a changelog for
+last-tag..new-tag is generated and pushed to the configured sinks: a directory
+of files, the GitHub release body, a Slack incoming webhook, and/or the
+sinks: list of .changelog.yaml.
+
+The first poll of a repository only records its latest tag. Progress is kept in
+the state store (the --state-file by default, or the SQLite or Redis backend
+from state: in .changelog.yaml) so restarts and other replicas do not
+regenerate anything.
+
+Examples:
+  changelog-generator watch --repos=myorg/api,myorg/web --schedule="0 9 * * 1" --output-dir=changelogs
+  changelog-generator watch --owner=myorg --repo=api --update-release --once`,
+	Args: cobra.NoArgs,
+	RunE: runWatch,
+}
+
+func init() {
+	addCommonFlags(watchCmd)
+
+	watchCmd.Flags().StringVar(&cfg.WatchSchedule, "schedule", cfg.WatchSchedule, "Cron expression (minute hour dom month dow), @daily/@weekly shorthand, or @every <duration>")
+	watchCmd.Flags().StringSliceVar(&cfg.WatchRepos, "repos", cfg.WatchRepos, "Repositories to poll as owner/repo (default: --owner/--repo)")
+	watchCmd.Flags().StringVar(&cfg.WatchStateFile, "state-file", cfg.WatchStateFile, "File recording the last processed tag per repository (file state backend)")
+	addStateFlags(watchCmd)
+	watchCmd.Flags().StringVar(&cfg.WatchOutputDir, "output-dir", cfg.WatchOutputDir, "Write each generated changelog to this directory")
+	watchCmd.Flags().BoolVar(&cfg.WatchUpdateRelease, "update-release", cfg.WatchUpdateRelease, "Set the GitHub release body of each new tag")
+	watchCmd.Flags().StringVar(&cfg.SlackWebhookURL, "slack-webhook", cfg.SlackWebhookURL, "Post a summary of each new release to this Slack incoming webhook")
+	watchCmd.Flags().Bool("once", false, "Poll once and exit instead of running on the schedule")
+}
+
+func runWatch(cmd *cobra.Command, args []string) error {
+	if err := requireGitHub("watch"); err != nil {
+		return err
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	repos := cfg.WatchRepos
+	if len(repos) == 0 && cfg.RepoOwner != "" && cfg.RepoName != "" {
+		repos = []string{cfg.RepoOwner + "/" + cfg.RepoName}
+	}
+	if len(repos) == 0 {
+		return fmt.Errorf("configuration error: no repositories to watch (set --repos or watch.repos)")
+	}
+	for _, repo := range repos {
+		if owner, name, ok := strings.Cut(repo, "/"); !ok || owner == "" || name == "" {
+			return fmt.Errorf("configuration error: invalid repository %q (expected owner/repo)", repo)
+		}
+	}
+	if len(watchSinks()) == 0 {
+		return fmt.Errorf("configuration error: no sinks configured (set --output-dir, --update-release, --slack-webhook, or sinks)")
+	}
+
+	sched, err := schedule.Parse(cfg.WatchSchedule)
+	if err != nil {
+		return fmt.Errorf("configuration error: schedule: %w", err)
+	}
+
+	ctx := cmd.Context()
+	once, _ := cmd.Flags().GetBool("once")
+	for {
+		pollCtx, cancel := withTimeout(ctx)
+		pollRepos(pollCtx, repos)
+		cancel()
+		if once {
+			return nil
+		}
+
+		next := sched.Next(time.Now())
+		fmt.Printf("Next poll at %s\n", next.Format(time.RFC1123))
+		select {
+		case <-ctx.Done():
+			fmt.Println("Stopping watch")
+			return nil
+		case <-time.After(time.Until(next)):
+		}
+	}
+}
+
+// pollRepos checks every repository for a new latest tag. Failures are
+// reported and retried on the next poll rather than stopping the daemon.
+func pollRepos(ctx context.Context, repos []string) {
+	store, err := openStateStore()
+	if err != nil {
+		logger.Warn("open state store", "error", err)
+		return
+	}
+	defer store.Close()
+
+	for _, repo := range repos {
+		if err := pollRepo(ctx, repo, store); err != nil {
+			logger.Warn("poll failed", "repo", repo, "error", err)
+		}
+	}
+}
+
+// pollRepo generates and publishes a changelog when repo has a new latest tag
+func pollRepo(ctx context.Context, repo string, store state.Store) error {
+	owner, name, _ := strings.Cut(repo, "/")
+	cfg.RepoOwner, cfg.RepoName = owner, name
+
+	githubClient, err := connectGitHub(ctx)
+	if err != nil {
+		return err
+	}
+	latest, err := githubClient.LatestTag(ctx, false)
+	if err != nil {
+		return fmt.Errorf("resolve latest tag: %w", err)
+	}
+
+	last, seen, err := store.Get(ctx, repo)
+	if err != nil {
+		return err
+	}
+	switch {
+	case !seen:
+		fmt.Printf("%s: recording %s as the starting point\n", repo, latest)
+		return store.Set(ctx, repo, latest)
+	case last == latest:
+		logger.Info("no new release", "repo", repo, "tag", last)
+		return nil
+	}
+
+	fmt.Printf("%s: generating changelog for %s..%s\n", repo, last, latest)
+	gen, err := buildGenerator(githubClient)
+	if err != nil {
+		return err
+	}
+	if cfg.DryRun {
+		report, err := gen.DryRun(ctx, last, latest)
+		if err != nil {
+			return fmt.Errorf("dry run: %w", err)
+		}
+		fmt.Print(generator.FormatDryRunReport(report))
+		return nil
+	}
+
+	// Replicas polling the same store race for each release; only the one that
+	// claims it publishes. Claims are kept after publishing so a replica that
+	// read the old tag just before the update cannot claim the release again.
+	claim := "claim:" + repo + "@" + latest
+	claimed, err := store.Claim(ctx, claim, replicaName())
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		logger.Info("release claimed by another replica", "repo", repo, "tag", latest)
+		return nil
+	}
+	if err := publishRelease(ctx, githubClient, gen, repo, last, latest); err != nil {
+		// Release the claim so the next poll retries
+		if deleteErr := store.Delete(ctx, claim); deleteErr != nil {
+			logger.Warn("release claim", "repo", repo, "tag", latest, "error", deleteErr)
+		}
+		return err
+	}
+	return store.Set(ctx, repo, latest)
+}
+
+// publishRelease generates the changelog of last..latest and publishes it to
+// the watch sinks
+func publishRelease(ctx context.Context, githubClient *github.Client, gen *generator.Generator, repo, last, latest string) error {
+	changelog, err := gen.Generate(ctx, last, latest)
+	if err != nil {
+		return fmt.Errorf("generate changelog: %w", err)
+	}
+	names, err := publishToSinks(ctx, watchSinks(), githubClient, changelog)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s: published %s to %s\n", repo, latest, strings.Join(names, ", "))
+	return nil
+}
+
+// replicaName identifies this process in release claims
+func replicaName() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s:%d", host, os.Getpid())
+}
+
+// watchSinks returns the destinations of the watch daemon: the watch.sinks
+// settings followed by the sinks: list
+func watchSinks() []config.SinkConfig {
+	var sinks []config.SinkConfig
+	if cfg.WatchOutputDir != "" {
+		sinks = append(sinks, config.SinkConfig{
+			Type:   "file",
+			Format: cfg.Format,
+			Path:   filepath.Join(cfg.WatchOutputDir, "{repo}-{tag}"+outputExtension()),
+		})
+	}
+	if cfg.WatchUpdateRelease {
+		sinks = append(sinks, config.SinkConfig{Type: "github-release"})
+	}
+	if cfg.SlackWebhookURL != "" {
+		sinks = append(sinks, config.SinkConfig{Type: "slack", URL: cfg.SlackWebhookURL})
+	}
+	return append(sinks, cfg.Sinks...)
+}
+
+// addStateFlags registers the state backend flags of commands that track progress
+func addStateFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&cfg.StateBackend, "state-backend", cfg.StateBackend, "Where progress is kept: file, sqlite, or redis (sqlite and redis also cache ticket summaries for every replica)")
+	cmd.Flags().StringVar(&cfg.StateURL, "state-url", cfg.StateURL, "SQLite database path, or redis://[[user]:password@]host:port[/db] URL (rediss:// for TLS), for the sqlite/redis state backends")
+}
+
+// sharedCache is the shared state store that caches lookups such as ticket
+// summaries, opened on first use and closed when the command exits
+var sharedCache state.Store
+
+// openSharedCache returns the sqlite or redis state store for caching lookups
+// across runs and replicas, or nil with the file backend or when it cannot
+// be opened, which only costs the lookups
+func openSharedCache() state.Store {
+	if sharedCache != nil || cfg.StateBackend == "file" {
+		return sharedCache
+	}
+	store, err := openStateStore()
+	if err != nil {
+		logger.Warn("not caching lookups in the state store", "error", err)
+		return nil
+	}
+	sharedCache = store
+	return sharedCache
+}
+
+// closeSharedCache closes the store opened by openSharedCache, if any
+func closeSharedCache() {
+	if sharedCache != nil {
+		sharedCache.Close()
+		sharedCache = nil
+	}
+}
+
+// openStateStore connects to the configured state backend
+func openStateStore() (state.Store, error) {
+	location := cfg.StateURL
+	if cfg.StateBackend == "file" {
+		location = cfg.WatchStateFile
+	}
+	store, err := state.Open(cfg.StateBackend, location)
+	if err != nil {
+		return nil, fmt.Errorf("configuration error: %w", err)
+	}
+	return store, nil
+}
@@ -0,0 +1,454 @@
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+// apiBaseURL is the Bitbucket Cloud REST API root
+const apiBaseURL = "https://api.bitbucket.org/2.0"
+
+// mergeCommitRe matches Bitbucket merge commit messages:
+// "Merged in feature/x (pull request #12)"
+var mergeCommitRe = regexp.MustCompile(`\(pull request #(\d+)\)`)
+
+// Client is a Bitbucket Cloud implementation of the provider interface
+type Client struct {
+	httpClient *http.Client
+	workspace  string
+	repo       string
+	username   string // With an app password; empty for bearer tokens
+	token      string
+
+	tagFilter provider.TagFilter // Which tags and releases timelines are built from
+	edges     bool               // Extend timelines to the tag before the window and the untagged head
+}
+
+// Client implements the hosting provider interface
+var (
+	_ provider.Provider          = (*Client)(nil)
+	_ provider.PullRequestLinker = (*Client)(nil)
+)
+
+// NewClient creates a Bitbucket Cloud client. With a username, token is used
+// as an app password (basic auth); otherwise it is sent as a bearer access token.
+func NewClient(username, token, workspace, repo string) *Client {
+	return &Client{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		workspace:  workspace,
+		repo:       repo,
+		username:   username,
+		token:      token,
+	}
+}
+
+// SetTagFilter restricts timeline discovery to the tags and releases that
+// pass filter
+func (c *Client) SetTagFilter(filter provider.TagFilter) {
+	c.tagFilter = filter
+}
+
+// SetIncludeBoundaries makes timelines start at the newest tag before the
+// window and end at the newest untagged commit in it, so commits at the
+// edges are not skipped
+func (c *Client) SetIncludeBoundaries(include bool) {
+	c.edges = include
+}
+
+// ValidateAccess checks if the client has access to the repository
+func (c *Client) ValidateAccess(ctx context.Context) error {
+	var repo struct {
+		Slug string `json:"slug"`
+	}
+	if err := c.getJSON(ctx, c.repoPath(""), &repo); err != nil {
+		return fmt.Errorf("validate repository access: %w", err)
+	}
+	return nil
+}
+
+// GetCommitRange fetches the commits reachable from to but not from, oldest first
+func (c *Client) GetCommitRange(ctx context.Context, from, to string) ([]provider.CommitData, error) {
+	type apiCommit struct {
+		Hash    string    `json:"hash"`
+		Message string    `json:"message"`
+		Date    time.Time `json:"date"`
+		Author  struct {
+			Raw  string `json:"raw"`
+			User struct {
+				Nickname string `json:"nickname"`
+			} `json:"user"`
+		} `json:"author"`
+		Parents []struct {
+			Hash string `json:"hash"`
+		} `json:"parents"`
+	}
+
+	var listed []apiCommit
+	next := c.repoPath("/commits/"+url.PathEscape(to)) + "?exclude=" + url.QueryEscape(from) + "&pagelen=100"
+	for next != "" {
+		var page struct {
+			Values []apiCommit `json:"values"`
+			Next   string      `json:"next"`
+		}
+		if err := c.getJSON(ctx, next, &page); err != nil {
+			return nil, fmt.Errorf("compare commits: %w", err)
+		}
+		listed = append(listed, page.Values...)
+		next = page.Next
+	}
+
+	// The API lists newest first
+	commits := make([]provider.CommitData, 0, len(listed))
+	for i := len(listed) - 1; i >= 0; i-- {
+		commit := listed[i]
+		author := commit.Author.User.Nickname
+		if author == "" {
+			author = strings.TrimSpace(strings.Split(commit.Author.Raw, "<")[0])
+		}
+
+		data := provider.CommitData{
+			SHA:       commit.Hash,
+			Message:   commit.Message,
+			Author:    author,
+			Date:      commit.Date,
+			CoAuthors: provider.CoAuthors(commit.Message),
+		}
+		for _, parent := range commit.Parents {
+			data.Parents = append(data.Parents, parent.Hash)
+		}
+		if err := c.addFileChanges(ctx, &data); err != nil {
+			return nil, err
+		}
+		commits = append(commits, data)
+	}
+
+	return commits, nil
+}
+
+// addFileChanges fills in a commit's changed files, line stats, and patches
+func (c *Client) addFileChanges(ctx context.Context, commit *provider.CommitData) error {
+	type path struct {
+		Path string `json:"path"`
+	}
+	type diffStat struct {
+		Status       string `json:"status"`
+		LinesAdded   int    `json:"lines_added"`
+		LinesRemoved int    `json:"lines_removed"`
+		Old          *path  `json:"old"`
+		New          *path  `json:"new"`
+	}
+
+	var stats []diffStat
+	next := c.repoPath("/diffstat/"+commit.SHA) + "?pagelen=100"
+	for next != "" {
+		var page struct {
+			Values []diffStat `json:"values"`
+			Next   string     `json:"next"`
+		}
+		if err := c.getJSON(ctx, next, &page); err != nil {
+			return fmt.Errorf("get diffstat for %s: %w", commit.SHA, err)
+		}
+		stats = append(stats, page.Values...)
+		next = page.Next
+	}
+
+	diff, err := c.get(ctx, c.repoPath("/diff/"+commit.SHA))
+	if err != nil {
+		return fmt.Errorf("get diff for %s: %w", commit.SHA, err)
+	}
+	patches := provider.SplitDiff(string(diff))
+
+	for _, stat := range stats {
+		name := ""
+		switch {
+		case stat.New != nil:
+			name = stat.New.Path
+		case stat.Old != nil:
+			name = stat.Old.Path
+		}
+		commit.FilesChanged = append(commit.FilesChanged, provider.FileChange{
+			Filename:  name,
+			Status:    fileStatus(stat.Status),
+			Additions: stat.LinesAdded,
+			Deletions: stat.LinesRemoved,
+			Patch:     patches[name],
+		})
+		commit.Stats.Additions += stat.LinesAdded
+		commit.Stats.Deletions += stat.LinesRemoved
+	}
+	commit.Stats.Total = commit.Stats.Additions + commit.Stats.Deletions
+	return nil
+}
+
+// fileStatus maps Bitbucket diffstat statuses onto the GitHub vocabulary
+func fileStatus(status string) string {
+	if status == "removed" {
+		return "deleted"
+	}
+	return status
+}
+
+// ListReleases returns no releases: Bitbucket Cloud only has tags
+func (c *Client) ListReleases(ctx context.Context) ([]provider.ReleaseInfo, error) {
+	return nil, nil
+}
+
+// ListTags fetches all tags with the commits they point to
+func (c *Client) ListTags(ctx context.Context) ([]provider.TagInfo, error) {
+	type apiTag struct {
+		Name    string `json:"name"`
+		Message string `json:"message"`
+		Target  struct {
+			Hash string    `json:"hash"`
+			Date time.Time `json:"date"`
+		} `json:"target"`
+	}
+
+	var tags []provider.TagInfo
+	next := c.repoPath("/refs/tags") + "?pagelen=100"
+	for next != "" {
+		var page struct {
+			Values []apiTag `json:"values"`
+			Next   string   `json:"next"`
+		}
+		if err := c.getJSON(ctx, next, &page); err != nil {
+			return nil, fmt.Errorf("list tags: %w", err)
+		}
+		for _, tag := range page.Values {
+			tags = append(tags, provider.TagInfo{
+				Name:       tag.Name,
+				SHA:        tag.Target.Hash,
+				CommitSHA:  tag.Target.Hash,
+				CommitDate: tag.Target.Date,
+				Message:    tag.Message,
+			})
+		}
+		next = page.Next
+	}
+
+	return tags, nil
+}
+
+// GetPRsBetween fetches the pull requests merged between two refs
+func (c *Client) GetPRsBetween(ctx context.Context, from, to string) ([]provider.PullRequestData, error) {
+	commits, err := c.GetCommitRange(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return c.extractPRs(ctx, commits)
+}
+
+// extractPRs scans merge commit messages for pull request IDs and fetches their details
+func (c *Client) extractPRs(ctx context.Context, commits []provider.CommitData) ([]provider.PullRequestData, error) {
+	seen := make(map[int]bool)
+	var prs []provider.PullRequestData
+
+	for _, commit := range commits {
+		matches := mergeCommitRe.FindStringSubmatch(commit.Message)
+		if len(matches) < 2 {
+			continue
+		}
+		id, err := strconv.Atoi(matches[1])
+		if err != nil || seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		pr, err := c.getPullRequest(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		prs = append(prs, *pr)
+	}
+
+	return prs, nil
+}
+
+// getPullRequest fetches a single pull request by ID
+func (c *Client) getPullRequest(ctx context.Context, id int) (*provider.PullRequestData, error) {
+	var pr struct {
+		ID          int       `json:"id"`
+		Title       string    `json:"title"`
+		Description string    `json:"description"`
+		State       string    `json:"state"`
+		CreatedOn   time.Time `json:"created_on"`
+		UpdatedOn   time.Time `json:"updated_on"`
+		Author      struct {
+			Nickname    string `json:"nickname"`
+			DisplayName string `json:"display_name"`
+		} `json:"author"`
+		Links struct {
+			HTML struct {
+				Href string `json:"href"`
+			} `json:"html"`
+		} `json:"links"`
+	}
+	if err := c.getJSON(ctx, c.repoPath(fmt.Sprintf("/pullrequests/%d", id)), &pr); err != nil {
+		return nil, fmt.Errorf("get pull request #%d: %w", id, err)
+	}
+
+	author := pr.Author.Nickname
+	if author == "" {
+		author = pr.Author.DisplayName
+	}
+	data := &provider.PullRequestData{
+		Number:    pr.ID,
+		Title:     pr.Title,
+		Author:    author,
+		URL:       pr.Links.HTML.Href,
+		Body:      pr.Description,
+		CreatedAt: pr.CreatedOn,
+	}
+	// Bitbucket has no merge timestamp; a merged PR's last update is the merge
+	if pr.State == "MERGED" {
+		data.MergedAt = pr.UpdatedOn
+	}
+	return data, nil
+}
+
+// GetTimelineReleases builds timeline releases for consecutive tags in a date range
+func (c *Client) GetTimelineReleases(ctx context.Context, from, to time.Time) ([]provider.TimelineRelease, error) {
+	tags, err := c.ListTags(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetch tags: %w", err)
+	}
+
+	refs := c.tagFilter.Apply(provider.ReleaseRefsInRange(tags, nil, from, to))
+	if c.edges {
+		head, err := c.headCommit(ctx, to)
+		if err != nil {
+			return nil, err
+		}
+		refs = provider.WithBoundaries(refs, tags, c.tagFilter, from, head)
+	}
+	if len(refs) == 0 {
+		return nil, fmt.Errorf("%w between %s and %s",
+			provider.ErrNoReleases, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	}
+
+	return provider.BuildTimeline(refs, func(from, to string) ([]provider.CommitData, []provider.PullRequestData, error) {
+		commits, err := c.GetCommitRange(ctx, from, to)
+		if err != nil {
+			return nil, nil, err
+		}
+		prs, err := c.extractPRs(ctx, commits)
+		if err != nil {
+			return nil, nil, fmt.Errorf("extract PRs: %w", err)
+		}
+		return commits, prs, nil
+	})
+}
+
+// headCommit returns the newest commit on the main branch dated on or before
+// until, or a zero ref when there is none. The commits API cannot filter by
+// date, so history is walked newest first.
+func (c *Client) headCommit(ctx context.Context, until time.Time) (provider.ReleaseRef, error) {
+	var repo struct {
+		MainBranch struct {
+			Name string `json:"name"`
+		} `json:"mainbranch"`
+	}
+	if err := c.getJSON(ctx, c.repoPath(""), &repo); err != nil {
+		return provider.ReleaseRef{}, fmt.Errorf("find main branch: %w", err)
+	}
+
+	next := c.repoPath("/commits/"+url.PathEscape(repo.MainBranch.Name)) + "?pagelen=100"
+	for next != "" {
+		var page struct {
+			Values []struct {
+				Hash string    `json:"hash"`
+				Date time.Time `json:"date"`
+			} `json:"values"`
+			Next string `json:"next"`
+		}
+		if err := c.getJSON(ctx, next, &page); err != nil {
+			return provider.ReleaseRef{}, fmt.Errorf("find head commit: %w", err)
+		}
+		for _, commit := range page.Values {
+			if !commit.Date.After(until) {
+				return provider.HeadRef(commit.Hash, commit.Date), nil
+			}
+		}
+		next = page.Next
+	}
+	return provider.ReleaseRef{}, nil
+}
+
+// HasCommitsBefore is not supported: the Bitbucket commits API cannot filter
+// by author or date
+func (c *Client) HasCommitsBefore(ctx context.Context, author string, before time.Time) (bool, error) {
+	return false, errors.ErrUnsupported
+}
+
+// CommitURL returns the bitbucket.org URL of a commit
+func (c *Client) CommitURL(sha string) string {
+	return fmt.Sprintf("https://bitbucket.org/%s/%s/commits/%s", c.workspace, c.repo, sha)
+}
+
+// PullRequestURL returns the bitbucket.org URL of a pull request
+func (c *Client) PullRequestURL(number int) string {
+	return fmt.Sprintf("https://bitbucket.org/%s/%s/pull-requests/%d", c.workspace, c.repo, number)
+}
+
+// CompareURL returns the bitbucket.org URL comparing two refs; Bitbucket
+// lists the newer ref first
+func (c *Client) CompareURL(from, to string) string {
+	return fmt.Sprintf("https://bitbucket.org/%s/%s/branches/compare/%s%%0D%s", c.workspace, c.repo, to, from)
+}
+
+// repoPath returns the API URL of a path under the repository
+func (c *Client) repoPath(path string) string {
+	return fmt.Sprintf("%s/repositories/%s/%s%s", apiBaseURL, url.PathEscape(c.workspace), url.PathEscape(c.repo), path)
+}
+
+// getJSON fetches an API URL and decodes the JSON response into v
+func (c *Client) getJSON(ctx context.Context, apiURL string, v any) error {
+	body, err := c.get(ctx, apiURL)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+// get fetches an API URL with authentication and returns the response body
+func (c *Client) get(ctx context.Context, apiURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	switch {
+	case c.username != "":
+		req.SetBasicAuth(c.username, c.token)
+	case c.token != "":
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request %s: %w", apiURL, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("bitbucket API returned %s for %s", resp.Status, apiURL)
+	}
+	return body, nil
+}
@@ -0,0 +1,10 @@
+package bitbucket
+
+import "testing"
+
+func TestCommitURL(t *testing.T) {
+	client := NewClient("", "", "acme", "api")
+	if got := client.CommitURL("abc123"); got != "https://bitbucket.org/acme/api/commits/abc123" {
+		t.Errorf("CommitURL() = %q", got)
+	}
+}
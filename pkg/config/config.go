@@ -3,38 +3,237 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/rakshaksatsangi/changelog-generator/pkg/keyring"
 	"github.com/spf13/viper"
 )
 
 // Config holds all configuration for the changelog generator
 type Config struct {
-	// GitHub
+	// Repository hosting
+	Provider    string // "github", "bitbucket", or "gitea"
 	GitHubToken string
-	RepoOwner   string
+	RepoOwner   string // Owner, Bitbucket workspace, or Gitea user/organization
 	RepoName    string
+	Branch      string // Branch HEAD and tag discovery resolve against (empty = default branch)
+	FastFetch   bool   // Fetch GitHub commits in GraphQL batches, with file counts but without file lists or diffs
+	MaxCommits  int    // Refuse ranges with more commits than this (0 = unlimited)
+
+	// GitHub App (github_app: section); used instead of GitHubToken when GitHubAppID is set
+	GitHubAppID             int64
+	GitHubAppInstallationID int64  // 0 = look up the installation on the repository or organization
+	GitHubAppPrivateKey     string // PEM contents (GITHUB_APP_PRIVATE_KEY)
+	GitHubAppPrivateKeyPath string // PEM file, read when GitHubAppPrivateKey is empty
+
+	// Bitbucket Cloud
+	BitbucketUsername string // With an app password; empty when BitbucketToken is an access token
+	BitbucketToken    string
+
+	// Gitea / Forgejo
+	GiteaURL   string // Instance web root, e.g. https://codeberg.org
+	GiteaToken string
 
 	// OpenAI
 	OpenAIAPIKey string
 	OpenAIModel  string
 	MaxTokens    int
 	Temperature  float64
+	Reproducible bool // Temperature 0 and a fixed seed, with prompt hashes recorded in the output
+	Seed         int  // Sampling seed sent with every request (0 = none)
+	MaxAttempts  int  // Tries per request on rate limits (429) and server errors (5xx)
+
+	// Model routing by release size, to cut costs on long timelines
+	ModelRouting      bool
+	SmallModel        string // Model for releases of at most SmallReleaseMax commits or PRs
+	LargeModel        string // Model for releases of at least LargeReleaseMin items or LargeReleaseLines changed lines (empty = OpenAIModel)
+	SmallReleaseMax   int
+	LargeReleaseMin   int
+	LargeReleaseLines int
 
 	// Output
-	OutputPath     string
-	IncludeAuthors bool
-	IncludeDates   bool
-	ShowScores     bool
-	MinScore       float64
+	OutputPath          string
+	Format              string // "markdown", "json", "csv", "xlsx", "announcement", "widget-json", or "hugo"
+	ValidateOutput      bool   // Check the JSON encoding of the output against the published schema before writing
+	DocsSite            string // Write one page per release for a docs site, "docusaurus", "mkdocs", or "hugo", instead of one file
+	DocsDir             string // Directory of the docs-site pages (empty = the site's default, e.g. blog)
+	FrontMatter         string // Front-matter of docs-site pages: "yaml" or "toml" (Hugo only); empty = the site's default
+	IncludeAuthors      bool
+	IncludeDates        bool
+	ShowScores          bool
+	ShowScoreReasons    bool // Render the model's rationale under each scored entry
+	IncludeContributors bool
+	IncludeReviewStats  bool // Give the model approval, change request, and comment counts per PR
+	IncludeMetrics      bool // Append an engineering-metrics appendix to timelines
+	IncludeArtifacts    bool // List release assets and image digests per release
+	ExistingNotes       bool // Quote the published release body in the prompt so summaries agree with it
+	PRImages            bool // Show the first image in each entry's pull request body
+	IncludeStats        bool // Add a comparison stats header to each timeline release
+	DetectStack         bool // Tell the model the repository's languages and frameworks
+	ClusterCommits      bool // Group commits touching the same subsystem in the prompt
+	CollapseMerges      bool // Treat each merge commit and its branch commits as one change
+	SecuritySection     bool // Flag security-relevant commits and ask for a Security category
+	SecurityAdvisories  bool // Cross-reference the repository's published security advisories
+	MinScore            float64
+	Top                 int    // Keep only the N highest-scoring entries of a changelog (0 = all)
+	TopPerCategory      int    // Keep only the N highest-scoring entries of each category (0 = all)
+	Scoring             string // Importance scores from the "llm" or a deterministic "heuristic"
+	LinkStyle           string // Entry links: "commit" SHAs, "pr" numbers of PR-backed commits, or "both"
+	CalibrateScores     bool   // Score all timeline pull requests together in a second LLM pass
+	PeriodSummary       bool   // Open timelines with an LLM digest of themes across all releases
+	Prepend             bool   // Insert new sections above the existing output file instead of overwriting
+	Confirm             bool   // Show a diff of the output and release notes and ask before writing or publishing
+	UpdateReleaseNotes  bool   // Replace the marked generated block in the GitHub release body of the range end
+	ActivityChart       string // Chart of release cadence atop timelines: mermaid, ascii, or empty for none
+	FullChangelogLink   bool   // End each release with a "Full Changelog" compare link
+	MaxLength           string // Per-release length budget, e.g. "300w" or "40lines" (empty = unlimited)
+
+	// Diffs in commit prompts
+	MaxFilesInPrompt int // File names listed per commit
+	DiffThreshold    int // Lines a file must change by (more than) before its diff is summarized
+	DiffFiles        int // Files per commit whose diffs are summarized
+	DiffLines        int // Patch lines sampled per summarized file
+	FullPatchLines   int // Send the whole patch of commits changing at most this many lines (0 = never)
+
+	IgnoreFiles []string // Globs of files kept out of prompts and heuristic scores besides the built-in ones; "!glob" keeps files
+
+	// Categories
+	CategoryAliases map[string]string // Invented category → taxonomy category (e.g., chores: Internal)
+	ProductAreas    []ProductArea     // Path globs → product area (product_areas: list)
+	GroupByArea     bool              // Group entries by product area, with categories nested under each area
+	UnknownCategory string            // Target for unmapped categories, or "drop"
+
+	LabelCategories   map[string]string // PR label → category (or "drop"), e.g. kind/feature: Features
+	LabelCategoryMode string            // "override" moves labeled entries after generation; "hint" tells the model
+	BotCommits        string            // Commits by [bot] accounts: "include", "exclude", or "group" into their own section
+	UnverifiedCommits string            // Commits without a verified signature: "include", "annotate", or "exclude"
+	Reverts           string            // Reverted changes and cherry-picked duplicates: "drop", "annotate", or "keep"
 
 	// Behavior
-	Verbose bool
+	Verbose   bool
+	LogLevel  string        // debug, info, warn, or error (empty = warn, or info with --verbose)
+	LogFormat string        // Log record format on stderr: text or json
+	DryRun    bool          // Fetch and build prompts, report estimates, skip LLM calls and output
+	Timeout   time.Duration // Abort the run (or each watch poll) after this long (0 = no limit)
+	Strict    bool          // Fail on soft conditions (remapped categories, missing SHAs/scores, repaired JSON)
+	Stdin     bool          // Read commits as JSON from stdin instead of fetching them from GitHub
+
+	// Network, for every API client
+	Proxy    string // Proxy URL (empty = HTTPS_PROXY, HTTP_PROXY, and NO_PROXY)
+	CABundle string // PEM certificates to trust besides the system roots, e.g. a TLS-intercepting proxy's CA
+
+	// Artifacts
+	TrainingDataDir string // Append corrected prompt/response pairs as JSONL here (empty = off)
+	RunSummaryPath  string // Write a markdown run summary for CI here (empty = off)
+	PromptLogDir    string // Save every prompt and raw reply, secrets redacted, here (empty = off)
+
+	// Issue trackers (issue_trackers: section)
+	FetchTicketSummaries bool
+	JiraBaseURL          string
+	JiraProjectPattern   string
+	JiraProjects         []string // Project keys, e.g. [PROJ, OPS]; ignored when JiraProjectPattern is set
+	JiraEmail            string
+	JiraToken            string
+	LinearWorkspace      string
+	LinearTeamKeys       []string
+	LinearToken          string
+	ShortcutWorkspace    string
+	ShortcutToken        string
+
+	// Install snippet (install: section); patterns accept {tag} and {version}
+	InstallImage        string // e.g. ghcr.io/org/app:{tag}
+	InstallChart        string // Helm chart reference, e.g. oci://ghcr.io/org/charts/app
+	InstallChartVersion string // Chart version pattern (default: {version})
+	InstallReleaseName  string // Helm release name (default: chart name)
+
+	// Hooks (hooks: section): shell commands exchanging JSON with each hook point
+	HookPreFetch  string // Receives {"from", "to"}; a non-zero exit vetoes the run
+	HookPostFetch string // Filters the fetched commits
+	HookPreLLM    string // Filters the commits sent to the model
+	HookPostLLM   string // Edits the generated entries
+	HookPreWrite  string // Receives and may replace the markdown before it is written
+
+	// Post-generate hook (hooks.post_generate): a templated shell command run
+	// once the changelog is written, e.g. to upload it
+	HookPostGenerate string
+	HookEnv          []string // Extra KEY=value variables (hooks.env); values are templates too
+
+	// News fragments (fragments: section)
+	FragmentDir string // Where fragment add writes and fragment build reads (default: changelog.d)
+
+	// Watch daemon (watch: section)
+	WatchSchedule      string   // Cron expression or @weekly-style shorthand
+	WatchRepos         []string // owner/repo entries to poll
+	WatchStateFile     string   // Last processed tag per repository
+	WatchOutputDir     string   // File sink: write each changelog here (empty = off)
+	WatchUpdateRelease bool     // Release sink: set the GitHub release body for the new tag
+	SlackWebhookURL    string   // Slack sink: post a summary to this incoming webhook (empty = off)
+
+	// State store (state: section) for watch and --since-last-run progress;
+	// the shared backends also cache ticket summaries
+	StateBackend string // file (WatchStateFile), sqlite, or redis
+	StateURL     string // SQLite database path or Redis URL
+
+	// Output sinks (sinks: list); every generated changelog is also published to each
+	Sinks []SinkConfig
 
 	// Timeline mode
+	Org          string // Generate one timeline across every non-archived repo in this organization
 	TimelineMode bool
 	FromDate     time.Time
 	ToDate       time.Time
+
+	// Timeline releases
+	TagPattern         string // Regular expression tag names must match in full (empty = all)
+	ExcludePrereleases bool   // Skip prerelease releases and rc/beta/nightly tags
+	GroupBy            string // Fold releases into one section per "minor" or "major" series (empty = none)
+	IncludeBoundaries  bool   // Start at the tag before from-date and end at the untagged head
+	LLMConcurrency     int    // Releases whose changelogs are generated at once (0 or 1 = one at a time)
+}
+
+// ProductArea describes one entry of the product_areas: list
+type ProductArea struct {
+	Name  string   `mapstructure:"name"`
+	Paths []string `mapstructure:"paths"` // Globs such as api/** or *.proto
+	Docs  string   `mapstructure:"docs"`  // Documentation URL for "Learn more" links
+}
+
+// SinkConfig describes one entry of the sinks: list
+type SinkConfig struct {
+	Type    string            `mapstructure:"type"`    // file, stdout, github-release, s3, gcs, http, slack, notion, wordpress, or ghost
+	Format  string            `mapstructure:"format"`  // markdown (default), html, json, csv, or xlsx
+	Path    string            `mapstructure:"path"`    // file: destination; {repo}, {tag}/{to_ref}, {from_ref}, and {version} are expanded
+	URL     string            `mapstructure:"url"`     // http, slack: endpoint; wordpress, ghost: site URL
+	Headers map[string]string `mapstructure:"headers"` // http: extra request headers
+
+	// s3, gcs: object storage
+	Bucket   string `mapstructure:"bucket"`
+	Key      string `mapstructure:"key"`      // Object key; expanded like path
+	Endpoint string `mapstructure:"endpoint"` // s3: S3-compatible endpoint, e.g. MinIO or R2 (path-style)
+
+	// s3: objects are PUT with SigV4; credentials default to the AWS_* environment variables
+	Region          string `mapstructure:"region"`
+	AccessKeyID     string `mapstructure:"access_key_id"`
+	SecretAccessKey string `mapstructure:"secret_access_key"`
+	SessionToken    string `mapstructure:"session_token"`
+
+	// gcs: service account key file (default: GOOGLE_APPLICATION_CREDENTIALS)
+	CredentialsFile string `mapstructure:"credentials_file"`
+
+	// notion: one page per release in a database
+	Token      string            `mapstructure:"token"`       // Integration token (default: NOTION_TOKEN)
+	DatabaseID string            `mapstructure:"database_id"` // Target database
+	Properties map[string]string `mapstructure:"properties"`  // Changelog field → database property name
+
+	// wordpress, ghost: draft blog posts
+	Title       string  `mapstructure:"title"`         // Post title; expanded like path (default: "What's new in {tag}")
+	Audience    string  `mapstructure:"audience"`      // end-users (default: customer-facing entries only) or developers
+	MinScore    float64 `mapstructure:"min_score"`     // Leave out entries scoring below this
+	Username    string  `mapstructure:"username"`      // wordpress (default: WORDPRESS_USERNAME)
+	Password    string  `mapstructure:"password"`      // wordpress application password (default: WORDPRESS_APP_PASSWORD)
+	AdminAPIKey string  `mapstructure:"admin_api_key"` // ghost "<id>:<secret>" (default: GHOST_ADMIN_API_KEY)
 }
 
 // Load loads configuration from environment, config file, and defaults
@@ -56,57 +255,423 @@ func Load() (*Config, error) {
 	// Set up environment variable support
 	viper.SetEnvPrefix("CHANGELOG")
 	viper.AutomaticEnv()
+	if err := bindEnv(); err != nil {
+		return nil, err
+	}
+	if err := validateSettings(); err != nil {
+		return nil, err
+	}
 
 	// Create config with defaults
 	cfg := &Config{
-		GitHubToken:    getEnvOrViper("GITHUB_TOKEN", ""),
-		RepoOwner:      viper.GetString("repo_owner"),
-		RepoName:       viper.GetString("repo_name"),
-		OpenAIAPIKey:   getEnvOrViper("OPENAI_API_KEY", ""),
-		OpenAIModel:    viper.GetString("openai_model"),
-		MaxTokens:      viper.GetInt("max_tokens"),
-		Temperature:    viper.GetFloat64("temperature"),
-		OutputPath:     viper.GetString("output_path"),
-		IncludeAuthors: viper.GetBool("include_authors"),
-		IncludeDates:   viper.GetBool("include_dates"),
-		ShowScores:     viper.GetBool("show_scores"),
-		MinScore:       viper.GetFloat64("min_score"),
-		Verbose:        viper.GetBool("verbose"),
+		Provider:            viper.GetString("provider"),
+		GitHubToken:         getEnvOrViper("GITHUB_TOKEN", ""),
+		BitbucketUsername:   getEnvOrViper("BITBUCKET_USERNAME", "bitbucket.username"),
+		BitbucketToken:      getEnvOrViper("BITBUCKET_TOKEN", ""),
+		GiteaURL:            getEnvOrViper("GITEA_URL", "gitea.url"),
+		GiteaToken:          getEnvOrViper("GITEA_TOKEN", ""),
+		RepoOwner:           viper.GetString("repo_owner"),
+		RepoName:            viper.GetString("repo_name"),
+		FastFetch:           viper.GetBool("fast_fetch"),
+		MaxCommits:          viper.GetInt("max_commits"),
+		MaxFilesInPrompt:    viper.GetInt("max_files_in_prompt"),
+		DiffThreshold:       viper.GetInt("diff_threshold"),
+		DiffFiles:           viper.GetInt("diff_files"),
+		DiffLines:           viper.GetInt("diff_lines"),
+		FullPatchLines:      viper.GetInt("full_patch_lines"),
+		IgnoreFiles:         viper.GetStringSlice("ignore_files"),
+		OpenAIAPIKey:        getEnvOrViper("OPENAI_API_KEY", ""),
+		OpenAIModel:         viper.GetString("openai_model"),
+		MaxTokens:           viper.GetInt("max_tokens"),
+		Temperature:         viper.GetFloat64("temperature"),
+		Reproducible:        viper.GetBool("reproducible"),
+		Seed:                viper.GetInt("seed"),
+		MaxAttempts:         viper.GetInt("llm_max_attempts"),
+		ModelRouting:        viper.GetBool("model_routing"),
+		SmallModel:          viper.GetString("small_model"),
+		LargeModel:          viper.GetString("large_model"),
+		SmallReleaseMax:     viper.GetInt("small_release_max"),
+		LargeReleaseMin:     viper.GetInt("large_release_min"),
+		LargeReleaseLines:   viper.GetInt("large_release_lines"),
+		OutputPath:          viper.GetString("output_path"),
+		Format:              viper.GetString("format"),
+		ValidateOutput:      viper.GetBool("validate_output"),
+		IncludeAuthors:      viper.GetBool("include_authors"),
+		IncludeDates:        viper.GetBool("include_dates"),
+		IncludeContributors: viper.GetBool("include_contributors"),
+		IncludeReviewStats:  viper.GetBool("include_review_stats"),
+		IncludeMetrics:      viper.GetBool("include_metrics"),
+		DetectStack:         viper.GetBool("detect_stack"),
+		ClusterCommits:      viper.GetBool("cluster_commits"),
+		CollapseMerges:      viper.GetBool("collapse_merges"),
+		SecuritySection:     viper.GetBool("security_section"),
+		SecurityAdvisories:  viper.GetBool("security_advisories"),
+		IncludeArtifacts:    viper.GetBool("include_artifacts"),
+		ExistingNotes:       viper.GetBool("existing_notes"),
+		PRImages:            viper.GetBool("pr_images"),
+		LinkStyle:           viper.GetString("link_style"),
+		DocsSite:            viper.GetString("docs_site"),
+		DocsDir:             viper.GetString("docs_dir"),
+		FrontMatter:         viper.GetString("front_matter"),
+		IncludeStats:        viper.GetBool("include_stats"),
+		CalibrateScores:     viper.GetBool("calibrate_scores"),
+		PeriodSummary:       viper.GetBool("period_summary"),
+		Scoring:             viper.GetString("scoring"),
+		ActivityChart:       viper.GetString("activity_chart"),
+		FullChangelogLink:   viper.GetBool("full_changelog_link"),
+		ShowScores:          viper.GetBool("show_scores"),
+		ShowScoreReasons:    viper.GetBool("show_score_reasons"),
+		MinScore:            viper.GetFloat64("min_score"),
+		Top:                 viper.GetInt("top"),
+		TopPerCategory:      viper.GetInt("top_per_category"),
+		Prepend:             viper.GetBool("prepend"),
+		Confirm:             viper.GetBool("confirm"),
+		UpdateReleaseNotes:  viper.GetBool("update_release_notes"),
+		MaxLength:           viper.GetString("max_length"),
+		CategoryAliases:     viper.GetStringMapString("category_aliases"),
+		UnknownCategory:     viper.GetString("unknown_category"),
+		GroupByArea:         viper.GetBool("group_by_area"),
+		LabelCategories:     viper.GetStringMapString("label_categories"),
+		LabelCategoryMode:   viper.GetString("label_category_mode"),
+		BotCommits:          viper.GetString("bot_commits"),
+		UnverifiedCommits:   viper.GetString("unverified_commits"),
+		Reverts:             viper.GetString("reverts"),
+		Verbose:             viper.GetBool("verbose"),
+		LogLevel:            viper.GetString("log_level"),
+		LogFormat:           viper.GetString("log_format"),
+		Timeout:             viper.GetDuration("timeout"),
+		Strict:              viper.GetBool("strict"),
+		TrainingDataDir:     viper.GetString("collect_training_data"),
+		RunSummaryPath:      viper.GetString("run_summary"),
+		PromptLogDir:        viper.GetString("save_prompts"),
+		Proxy:               viper.GetString("proxy"),
+		CABundle:            viper.GetString("ca_bundle"),
+		Org:                 viper.GetString("org"),
+		TagPattern:          viper.GetString("tag_pattern"),
+		ExcludePrereleases:  viper.GetBool("exclude_prereleases"),
+		GroupBy:             viper.GetString("group_by"),
+		IncludeBoundaries:   viper.GetBool("include_boundaries"),
+		LLMConcurrency:      viper.GetInt("llm_concurrency"),
+
+		GitHubAppID:             viper.GetInt64("github_app.app_id"),
+		GitHubAppInstallationID: viper.GetInt64("github_app.installation_id"),
+		GitHubAppPrivateKey:     getEnvOrViper("GITHUB_APP_PRIVATE_KEY", ""),
+		GitHubAppPrivateKeyPath: viper.GetString("github_app.private_key_path"),
+
+		FetchTicketSummaries: viper.GetBool("issue_trackers.fetch_summaries"),
+		JiraBaseURL:          viper.GetString("issue_trackers.jira.base_url"),
+		JiraProjectPattern:   viper.GetString("issue_trackers.jira.project_pattern"),
+		JiraProjects:         viper.GetStringSlice("issue_trackers.jira.projects"),
+		JiraEmail:            getEnvOrViper("JIRA_EMAIL", "issue_trackers.jira.email"),
+		JiraToken:            getEnvOrViper("JIRA_API_TOKEN", ""),
+		LinearWorkspace:      viper.GetString("issue_trackers.linear.workspace"),
+		LinearTeamKeys:       viper.GetStringSlice("issue_trackers.linear.team_keys"),
+		LinearToken:          getEnvOrViper("LINEAR_API_KEY", ""),
+		ShortcutWorkspace:    viper.GetString("issue_trackers.shortcut.workspace"),
+		ShortcutToken:        getEnvOrViper("SHORTCUT_API_TOKEN", ""),
+
+		InstallImage:        viper.GetString("install.image"),
+		InstallChart:        viper.GetString("install.helm.chart"),
+		InstallChartVersion: viper.GetString("install.helm.version"),
+		InstallReleaseName:  viper.GetString("install.helm.release"),
+
+		HookPreFetch:  viper.GetString("hooks.pre_fetch"),
+		HookPostFetch: viper.GetString("hooks.post_fetch"),
+		HookPreLLM:    viper.GetString("hooks.pre_llm"),
+		HookPostLLM:   viper.GetString("hooks.post_llm"),
+		HookPreWrite:  viper.GetString("hooks.pre_write"),
+
+		HookPostGenerate: viper.GetString("hooks.post_generate"),
+		HookEnv:          viper.GetStringSlice("hooks.env"),
+
+		FragmentDir: viper.GetString("fragments.dir"),
+
+		WatchSchedule:      viper.GetString("watch.schedule"),
+		WatchRepos:         viper.GetStringSlice("watch.repos"),
+		WatchStateFile:     viper.GetString("watch.state_file"),
+		WatchOutputDir:     viper.GetString("watch.sinks.file"),
+		WatchUpdateRelease: viper.GetBool("watch.sinks.release"),
+		StateBackend:       viper.GetString("state.backend"),
+		StateURL:           getEnvOrViper("CHANGELOG_STATE_URL", "state.url"),
+		SlackWebhookURL:    getEnvOrViper("SLACK_WEBHOOK_URL", "watch.sinks.slack_webhook"),
+	}
+
+	if err := viper.UnmarshalKey("product_areas", &cfg.ProductAreas); err != nil {
+		return nil, fmt.Errorf("parse product_areas: %w", err)
+	}
+	if err := viper.UnmarshalKey("sinks", &cfg.Sinks); err != nil {
+		return nil, fmt.Errorf("parse sinks: %w", err)
+	}
+	for i := range cfg.Sinks {
+		sink := &cfg.Sinks[i]
+		if sink.Type == "gcs" && sink.CredentialsFile == "" {
+			sink.CredentialsFile = os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")
+		}
+		if sink.Type == "notion" && sink.Token == "" {
+			sink.Token = os.Getenv("NOTION_TOKEN")
+		}
+		if sink.Type == "wordpress" && sink.Username == "" {
+			sink.Username = os.Getenv("WORDPRESS_USERNAME")
+			sink.Password = os.Getenv("WORDPRESS_APP_PASSWORD")
+		}
+		if sink.Type == "ghost" && sink.AdminAPIKey == "" {
+			sink.AdminAPIKey = os.Getenv("GHOST_ADMIN_API_KEY")
+		}
+		if sink.Type != "s3" {
+			continue
+		}
+		if sink.Region == "" {
+			sink.Region = getEnvOrViper("AWS_REGION", "")
+		}
+		if sink.AccessKeyID == "" {
+			sink.AccessKeyID = os.Getenv("AWS_ACCESS_KEY_ID")
+			sink.SecretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+			sink.SessionToken = os.Getenv("AWS_SESSION_TOKEN")
+		}
 	}
 
 	// Set defaults if not configured
-	if cfg.OpenAIModel == "" {
-		cfg.OpenAIModel = "gpt-4o"
+	cfg.applyDefaults()
+	if !viper.IsSet("max_commits") {
+		cfg.MaxCommits = 1000
+	}
+	if !viper.IsSet("max_files_in_prompt") {
+		cfg.MaxFilesInPrompt = DefaultMaxFilesInPrompt
 	}
-	if cfg.MaxTokens == 0 {
-		cfg.MaxTokens = 4000
+	if !viper.IsSet("diff_threshold") {
+		cfg.DiffThreshold = DefaultDiffThreshold
 	}
-	if cfg.Temperature == 0 {
-		cfg.Temperature = 0.3
+	if !viper.IsSet("diff_files") {
+		cfg.DiffFiles = DefaultDiffFiles
 	}
-	if cfg.OutputPath == "" {
-		cfg.OutputPath = "CHANGELOG.md"
+	if !viper.IsSet("diff_lines") {
+		cfg.DiffLines = DefaultDiffLines
+	}
+	if !viper.IsSet("small_release_max") {
+		cfg.SmallReleaseMax = DefaultSmallReleaseMax
+	}
+	if !viper.IsSet("large_release_min") {
+		cfg.LargeReleaseMin = DefaultLargeReleaseMin
+	}
+	if !viper.IsSet("large_release_lines") {
+		cfg.LargeReleaseLines = DefaultLargeReleaseLines
 	}
 	if !viper.IsSet("include_authors") {
 		cfg.IncludeAuthors = true
 	}
+	if !viper.IsSet("detect_stack") {
+		cfg.DetectStack = true
+	}
 
 	return cfg, nil
 }
 
-// Validate checks that all required configuration is present
+// Defaults for how much of each commit's diff goes into the prompt
+const (
+	DefaultMaxFilesInPrompt = 20
+	DefaultDiffThreshold    = 10
+	DefaultDiffFiles        = 3
+	DefaultDiffLines        = 10
+)
+
+// Defaults for model routing: releases up to 5 commits or PRs are small,
+// from 50 or 5000 changed lines large
+const (
+	DefaultSmallModel        = "gpt-4o-mini"
+	DefaultSmallReleaseMax   = 5
+	DefaultLargeReleaseMin   = 50
+	DefaultLargeReleaseLines = 5000
+)
+
+// DefaultSeed is the sampling seed of --reproducible runs that set none
+const DefaultSeed = 42
+
+// Default returns a configuration with the defaults Load applies, for Go
+// programs that use the packages as a library without config files or flags
+func Default() *Config {
+	cfg := &Config{
+		MaxCommits:     1000,
+		IncludeAuthors: true,
+		DetectStack:    true,
+	}
+	cfg.MaxFilesInPrompt = DefaultMaxFilesInPrompt
+	cfg.DiffThreshold = DefaultDiffThreshold
+	cfg.DiffFiles = DefaultDiffFiles
+	cfg.DiffLines = DefaultDiffLines
+	cfg.SmallReleaseMax = DefaultSmallReleaseMax
+	cfg.LargeReleaseMin = DefaultLargeReleaseMin
+	cfg.LargeReleaseLines = DefaultLargeReleaseLines
+	cfg.applyDefaults()
+	return cfg
+}
+
+// applyDefaults fills in unset modes, paths, and model parameters
+func (c *Config) applyDefaults() {
+	if c.Provider == "" {
+		c.Provider = "github"
+	}
+	if c.OpenAIModel == "" {
+		c.OpenAIModel = "gpt-4o"
+	}
+	if c.MaxTokens == 0 {
+		c.MaxTokens = 4000
+	}
+	if c.Temperature == 0 {
+		c.Temperature = 0.3
+	}
+	if c.MaxAttempts == 0 {
+		c.MaxAttempts = 4
+	}
+	if c.SmallModel == "" {
+		c.SmallModel = DefaultSmallModel
+	}
+	if c.OutputPath == "" {
+		c.OutputPath = "CHANGELOG.md"
+	}
+	if c.Format == "" {
+		c.Format = "markdown"
+	}
+	if c.UnknownCategory == "" {
+		c.UnknownCategory = "Internal"
+	}
+	if c.LabelCategoryMode == "" {
+		c.LabelCategoryMode = "override"
+	}
+	if c.Scoring == "" {
+		c.Scoring = "llm"
+	}
+	if c.LinkStyle == "" {
+		c.LinkStyle = "commit"
+	}
+	if c.BotCommits == "" {
+		c.BotCommits = "include"
+	}
+	if c.UnverifiedCommits == "" {
+		c.UnverifiedCommits = "include"
+	}
+	if c.Reverts == "" {
+		c.Reverts = "drop"
+	}
+	if c.LogFormat == "" {
+		c.LogFormat = "text"
+	}
+	if c.InstallChartVersion == "" {
+		c.InstallChartVersion = "{version}"
+	}
+	if c.FragmentDir == "" {
+		c.FragmentDir = "changelog.d"
+	}
+	if c.WatchSchedule == "" {
+		c.WatchSchedule = "@weekly"
+	}
+	if c.StateBackend == "" {
+		c.StateBackend = "file"
+	}
+	if c.WatchStateFile == "" {
+		c.WatchStateFile = ".changelog-watch-state.json"
+	}
+}
+
+// ResolveCredentials fills the GitHub token and OpenAI API key that are not
+// in the environment from the keychain. Each lookup runs the keychain tool, so
+// Load leaves this to the commands that need the tokens.
+func (c *Config) ResolveCredentials() {
+	if c.GitHubToken == "" && c.Provider == "github" && c.GitHubAppID == 0 {
+		c.GitHubToken = getEnvOrKeyring("GITHUB_TOKEN", keyring.GitHubToken)
+	}
+	if c.OpenAIAPIKey == "" {
+		c.OpenAIAPIKey = getEnvOrKeyring("OPENAI_API_KEY", keyring.OpenAIAPIKey)
+	}
+}
+
+// Validate checks that all required configuration is present, first looking
+// up tokens missing from the environment in the keychain
 func (c *Config) Validate() error {
-	if c.GitHubToken == "" {
-		return fmt.Errorf("GitHub token is required (set GITHUB_TOKEN environment variable)")
+	c.ResolveCredentials()
+	switch c.Provider {
+	case "github":
+		if c.GitHubAppID != 0 {
+			if c.GitHubAppPrivateKey == "" && c.GitHubAppPrivateKeyPath == "" {
+				return fmt.Errorf("GitHub App private key is required (set GITHUB_APP_PRIVATE_KEY or github_app.private_key_path)")
+			}
+		} else if c.GitHubToken == "" && !c.Stdin {
+			return fmt.Errorf("GitHub token is required (set GITHUB_TOKEN, run auth login, or configure github_app)")
+		}
+	case "bitbucket":
+		// Public repositories work without credentials
+	case "gitea":
+		if c.GiteaURL == "" {
+			return fmt.Errorf("Gitea URL is required (set GITEA_URL or gitea.url)")
+		}
+	default:
+		return fmt.Errorf("unsupported provider %q (expected github, bitbucket, or gitea)", c.Provider)
 	}
 	// RepoOwner and RepoName are validated later (after interactive prompt if needed)
 	// This allows --interactive flag to work without requiring --owner/--repo upfront
-	if c.OpenAIAPIKey == "" {
-		return fmt.Errorf("OpenAI API key is required (set OPENAI_API_KEY environment variable)")
+	if c.OpenAIAPIKey == "" && !c.DryRun {
+		return fmt.Errorf("OpenAI API key is required (set OPENAI_API_KEY or run auth login)")
+	}
+	if _, _, err := c.LengthBudget(); err != nil {
+		return err
+	}
+	switch c.Format {
+	case "markdown", "json", "csv", "xlsx", "announcement", "widget-json", "hugo":
+	default:
+		return fmt.Errorf("unsupported format %q (expected markdown, json, csv, xlsx, announcement, widget-json, or hugo)", c.Format)
 	}
 	return nil
 }
 
+// LengthBudget parses MaxLength into a limit and unit ("words" or "lines").
+// A bare number is interpreted as words; an empty value returns a zero limit.
+func (c *Config) LengthBudget() (int, string, error) {
+	spec := strings.ToLower(strings.TrimSpace(c.MaxLength))
+	if spec == "" {
+		return 0, "", nil
+	}
+
+	digits := strings.TrimRightFunc(spec, func(r rune) bool { return r < '0' || r > '9' })
+	unit := strings.TrimSpace(strings.TrimPrefix(spec, digits))
+	limit, err := strconv.Atoi(digits)
+	if err != nil || limit <= 0 {
+		return 0, "", fmt.Errorf("invalid max-length %q (expected e.g. 300w or 40lines)", c.MaxLength)
+	}
+
+	switch unit {
+	case "", "w", "word", "words":
+		return limit, "words", nil
+	case "l", "line", "lines":
+		return limit, "lines", nil
+	default:
+		return 0, "", fmt.Errorf("invalid max-length unit %q (expected words or lines)", unit)
+	}
+}
+
+// FileDataSettings lists the enabled settings that read per-commit file
+// lists or diffs, which FastFetch does not fetch
+func (c *Config) FileDataSettings() []string {
+	var settings []string
+	if c.DiffFiles > 0 || c.FullPatchLines > 0 {
+		settings = append(settings, "diff summaries")
+	}
+	if c.Scoring == "heuristic" {
+		settings = append(settings, "heuristic scoring")
+	}
+	if len(c.IgnoreFiles) > 0 {
+		settings = append(settings, "ignore_files")
+	}
+	if len(c.ProductAreas) > 0 {
+		settings = append(settings, "product_areas")
+	}
+	if c.ClusterCommits {
+		settings = append(settings, "cluster_commits")
+	}
+	if c.SecuritySection || c.SecurityAdvisories {
+		settings = append(settings, "security detection")
+	}
+	return settings
+}
+
 // ValidateTimeline validates timeline-specific configuration
 func (c *Config) ValidateTimeline() error {
 	if c.FromDate.IsZero() {
@@ -118,6 +683,28 @@ func (c *Config) ValidateTimeline() error {
 	if c.FromDate.After(c.ToDate) {
 		return fmt.Errorf("from-date must be before to-date")
 	}
+	switch c.ActivityChart {
+	case "", "mermaid", "ascii":
+	default:
+		return fmt.Errorf("unsupported activity chart %q (expected mermaid or ascii)", c.ActivityChart)
+	}
+	switch c.GroupBy {
+	case "", "minor", "major":
+	default:
+		return fmt.Errorf("unsupported group-by %q (expected minor or major)", c.GroupBy)
+	}
+	if c.GroupBy != "" && c.Prepend {
+		return fmt.Errorf("--group-by cannot be combined with --prepend")
+	}
+	if c.IncludeBoundaries && c.Prepend {
+		return fmt.Errorf("--include-boundaries cannot be combined with --prepend")
+	}
+	if c.PeriodSummary && c.Prepend {
+		return fmt.Errorf("--period-summary cannot be combined with --prepend")
+	}
+	if c.LLMConcurrency < 0 {
+		return fmt.Errorf("--llm-concurrency must not be negative")
+	}
 	return nil
 }
 
@@ -151,3 +738,12 @@ func getEnvOrViper(envVar, viperKey string) string {
 	}
 	return ""
 }
+
+// getEnvOrKeyring gets a credential from its environment variable, falling
+// back to the secret auth login stored in the OS keychain
+func getEnvOrKeyring(envVar, account string) string {
+	if val := os.Getenv(envVar); val != "" {
+		return val
+	}
+	return keyring.Lookup(account)
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLengthBudget(t *testing.T) {
+	cases := []struct {
+		spec  string
+		limit int
+		unit  string
+		ok    bool
+	}{
+		{"", 0, "", true},
+		{"300", 300, "words", true},
+		{"300w", 300, "words", true},
+		{"300 Words", 300, "words", true},
+		{"40l", 40, "lines", true},
+		{"40lines", 40, "lines", true},
+		{"40 line", 40, "lines", true},
+		{"300x", 0, "", false},
+		{"300 paragraphs", 0, "", false},
+		{"words", 0, "", false},
+		{"0w", 0, "", false},
+		{"-5w", 0, "", false},
+		{"1.5k", 0, "", false},
+	}
+	for _, c := range cases {
+		cfg := Default()
+		cfg.MaxLength = c.spec
+		limit, unit, err := cfg.LengthBudget()
+		if (err == nil) != c.ok || limit != c.limit || unit != c.unit {
+			t.Errorf("LengthBudget(%q) = %d, %q, %v; want %d, %q, ok=%v", c.spec, limit, unit, err, c.limit, c.unit, c.ok)
+		}
+	}
+}
+
+func TestFileDataSettings(t *testing.T) {
+	cfg := Default()
+	cfg.DiffFiles = 0
+	if settings := cfg.FileDataSettings(); len(settings) != 0 {
+		t.Errorf("Expected no file data settings, got %v", settings)
+	}
+
+	cfg.DiffFiles = 3
+	cfg.Scoring = "heuristic"
+	cfg.IgnoreFiles = []string{"*.snap"}
+	want := []string{"diff summaries", "heuristic scoring", "ignore_files"}
+	if settings := cfg.FileDataSettings(); !reflect.DeepEqual(settings, want) {
+		t.Errorf("FileDataSettings() = %v, want %v", settings, want)
+	}
+}
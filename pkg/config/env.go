@@ -0,0 +1,273 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// settingKind is the type a setting's value must parse as
+type settingKind int
+
+const (
+	kindString settingKind = iota
+	kindInt
+	kindFloat
+	kindBool
+	kindDuration
+	kindList
+)
+
+// settings lists every scalar and list setting of the config file with its
+// type. Each can also be set as a CHANGELOG_* environment variable named
+// after its key (see EnvName).
+var settings = map[string]settingKind{
+	"provider":                            kindString,
+	"repo_owner":                          kindString,
+	"repo_name":                           kindString,
+	"fast_fetch":                          kindBool,
+	"max_commits":                         kindInt,
+	"max_files_in_prompt":                 kindInt,
+	"diff_threshold":                      kindInt,
+	"diff_files":                          kindInt,
+	"diff_lines":                          kindInt,
+	"full_patch_lines":                    kindInt,
+	"ignore_files":                        kindList,
+	"openai_model":                        kindString,
+	"max_tokens":                          kindInt,
+	"temperature":                         kindFloat,
+	"reproducible":                        kindBool,
+	"seed":                                kindInt,
+	"llm_max_attempts":                    kindInt,
+	"model_routing":                       kindBool,
+	"small_model":                         kindString,
+	"large_model":                         kindString,
+	"small_release_max":                   kindInt,
+	"large_release_min":                   kindInt,
+	"large_release_lines":                 kindInt,
+	"output_path":                         kindString,
+	"format":                              kindString,
+	"validate_output":                     kindBool,
+	"include_authors":                     kindBool,
+	"include_dates":                       kindBool,
+	"include_contributors":                kindBool,
+	"include_review_stats":                kindBool,
+	"include_metrics":                     kindBool,
+	"include_artifacts":                   kindBool,
+	"existing_notes":                      kindBool,
+	"pr_images":                           kindBool,
+	"link_style":                          kindString,
+	"docs_site":                           kindString,
+	"docs_dir":                            kindString,
+	"front_matter":                        kindString,
+	"include_stats":                       kindBool,
+	"detect_stack":                        kindBool,
+	"cluster_commits":                     kindBool,
+	"collapse_merges":                     kindBool,
+	"security_section":                    kindBool,
+	"security_advisories":                 kindBool,
+	"calibrate_scores":                    kindBool,
+	"period_summary":                      kindBool,
+	"scoring":                             kindString,
+	"activity_chart":                      kindString,
+	"full_changelog_link":                 kindBool,
+	"show_scores":                         kindBool,
+	"show_score_reasons":                  kindBool,
+	"min_score":                           kindFloat,
+	"top":                                 kindInt,
+	"top_per_category":                    kindInt,
+	"prepend":                             kindBool,
+	"confirm":                             kindBool,
+	"update_release_notes":                kindBool,
+	"max_length":                          kindString,
+	"unknown_category":                    kindString,
+	"group_by_area":                       kindBool,
+	"label_category_mode":                 kindString,
+	"bot_commits":                         kindString,
+	"unverified_commits":                  kindString,
+	"reverts":                             kindString,
+	"verbose":                             kindBool,
+	"log_level":                           kindString,
+	"log_format":                          kindString,
+	"timeout":                             kindDuration,
+	"strict":                              kindBool,
+	"org":                                 kindString,
+	"tag_pattern":                         kindString,
+	"exclude_prereleases":                 kindBool,
+	"group_by":                            kindString,
+	"include_boundaries":                  kindBool,
+	"llm_concurrency":                     kindInt,
+	"collect_training_data":               kindString,
+	"run_summary":                         kindString,
+	"save_prompts":                        kindString,
+	"proxy":                               kindString,
+	"ca_bundle":                           kindString,
+	"github_app.app_id":                   kindInt,
+	"github_app.installation_id":          kindInt,
+	"github_app.private_key_path":         kindString,
+	"bitbucket.username":                  kindString,
+	"gitea.url":                           kindString,
+	"issue_trackers.fetch_summaries":      kindBool,
+	"issue_trackers.jira.base_url":        kindString,
+	"issue_trackers.jira.project_pattern": kindString,
+	"issue_trackers.jira.projects":        kindList,
+	"issue_trackers.jira.email":           kindString,
+	"issue_trackers.linear.workspace":     kindString,
+	"issue_trackers.linear.team_keys":     kindList,
+	"issue_trackers.shortcut.workspace":   kindString,
+	"install.image":                       kindString,
+	"install.helm.chart":                  kindString,
+	"install.helm.version":                kindString,
+	"install.helm.release":                kindString,
+	"hooks.pre_fetch":                     kindString,
+	"hooks.post_fetch":                    kindString,
+	"hooks.pre_llm":                       kindString,
+	"hooks.post_llm":                      kindString,
+	"hooks.pre_write":                     kindString,
+	"hooks.post_generate":                 kindString,
+	"hooks.env":                           kindList,
+	"fragments.dir":                       kindString,
+	"watch.schedule":                      kindString,
+	"watch.repos":                         kindList,
+	"watch.state_file":                    kindString,
+	"watch.sinks.file":                    kindString,
+	"watch.sinks.release":                 kindBool,
+	"watch.sinks.slack_webhook":           kindString,
+	"state.backend":                       kindString,
+	"state.url":                           kindString,
+}
+
+// envAliases are shorter variable names accepted besides the derived ones
+var envAliases = map[string]string{
+	"openai_model": "CHANGELOG_MODEL",
+	"output_path":  "CHANGELOG_OUTPUT_PATH",
+
+	"github_app.app_id":           "GITHUB_APP_ID",
+	"github_app.installation_id":  "GITHUB_APP_INSTALLATION_ID",
+	"github_app.private_key_path": "GITHUB_APP_PRIVATE_KEY_PATH",
+}
+
+// EnvName returns the environment variable that sets a config key: the key
+// upper-cased with dots as underscores and a CHANGELOG_ prefix, e.g.
+// watch.state_file → CHANGELOG_WATCH_STATE_FILE
+func EnvName(key string) string {
+	return "CHANGELOG_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// bindEnv maps every setting to its environment variables
+func bindEnv() error {
+	for _, key := range settingKeys() {
+		names := []string{key, EnvName(key)}
+		if alias, ok := envAliases[key]; ok {
+			names = append(names, alias)
+		}
+		if err := viper.BindEnv(names...); err != nil {
+			return fmt.Errorf("bind %s: %w", EnvName(key), err)
+		}
+	}
+	return nil
+}
+
+// settingKeys returns the setting keys in a stable order
+func settingKeys() []string {
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// validateSettings checks that every value set in the environment or the
+// config file parses as its setting's type, so a typo such as
+// CHANGELOG_MAX_TOKENS=4k fails the run instead of silently becoming zero
+func validateSettings() error {
+	var problems []string
+	for _, key := range settingKeys() {
+		if !viper.IsSet(key) {
+			continue
+		}
+		if err := checkKind(settings[key], viper.Get(key)); err != nil {
+			problems = append(problems, fmt.Sprintf("%s (%s): %v", key, Source(key), err))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid settings:\n  - %s", strings.Join(problems, "\n  - "))
+	}
+	return nil
+}
+
+// checkKind reports whether value, as read from YAML or the environment,
+// parses as kind
+func checkKind(kind settingKind, value any) error {
+	text, isText := value.(string)
+	switch kind {
+	case kindInt:
+		if _, ok := value.(int); ok {
+			return nil
+		}
+		if _, err := strconv.Atoi(strings.TrimSpace(text)); !isText || err != nil {
+			return fmt.Errorf("expected a whole number, got %v", value)
+		}
+	case kindFloat:
+		switch value.(type) {
+		case int, float64:
+			return nil
+		}
+		if _, err := strconv.ParseFloat(strings.TrimSpace(text), 64); !isText || err != nil {
+			return fmt.Errorf("expected a number, got %v", value)
+		}
+	case kindBool:
+		if _, ok := value.(bool); ok {
+			return nil
+		}
+		if _, err := strconv.ParseBool(strings.TrimSpace(text)); !isText || err != nil {
+			return fmt.Errorf("expected true or false, got %v", value)
+		}
+	case kindDuration:
+		if _, err := time.ParseDuration(strings.TrimSpace(text)); !isText || err != nil {
+			return fmt.Errorf("expected a duration such as 90s or 10m, got %v", value)
+		}
+	case kindString:
+		switch value.(type) {
+		case map[string]any, []any:
+			return fmt.Errorf("expected a single value, got %v", value)
+		}
+	}
+	return nil
+}
+
+// Source describes where a setting's value comes from: its environment
+// variable, the config file, or the default
+func Source(key string) string {
+	names := []string{EnvName(key)}
+	if alias, ok := envAliases[key]; ok {
+		names = append(names, alias)
+	}
+	for _, name := range names {
+		if _, ok := os.LookupEnv(name); ok {
+			return "env " + name
+		}
+	}
+	if viper.InConfig(key) {
+		return "file " + viper.ConfigFileUsed()
+	}
+	return "default"
+}
+
+// Sources returns the source of every setting that is not at its default,
+// keyed by setting
+func Sources() map[string]string {
+	sources := make(map[string]string)
+	for _, key := range settingKeys() {
+		if source := Source(key); source != "default" {
+			sources[key] = source
+		}
+	}
+	return sources
+}
@@ -0,0 +1,42 @@
+package config
+
+import "testing"
+
+func TestEnvName(t *testing.T) {
+	cases := map[string]string{
+		"max_tokens":       "CHANGELOG_MAX_TOKENS",
+		"gitea.url":        "CHANGELOG_GITEA_URL",
+		"watch.state_file": "CHANGELOG_WATCH_STATE_FILE",
+	}
+	for key, want := range cases {
+		if got := EnvName(key); got != want {
+			t.Errorf("EnvName(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestCheckKind(t *testing.T) {
+	cases := []struct {
+		kind  settingKind
+		value any
+		ok    bool
+	}{
+		{kindInt, "4000", true},
+		{kindInt, "4k", false},
+		{kindInt, 4000, true},
+		{kindFloat, "0.3", true},
+		{kindFloat, "warm", false},
+		{kindBool, "true", true},
+		{kindBool, "yes please", false},
+		{kindDuration, "90s", true},
+		{kindDuration, "90", false},
+		{kindString, "gpt-4o", true},
+		{kindList, "a,b", true},
+	}
+	for _, c := range cases {
+		err := checkKind(c.kind, c.value)
+		if (err == nil) != c.ok {
+			t.Errorf("checkKind(%v, %v) error = %v, want ok=%v", c.kind, c.value, err, c.ok)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+//go:build linux
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fakeSecretTool puts a secret-tool on PATH that serves secrets and logs
+// every call
+func fakeSecretTool(t *testing.T, secrets map[string]string) (log string) {
+	t.Helper()
+	bin, store := t.TempDir(), t.TempDir()
+	for account, secret := range secrets {
+		if err := os.WriteFile(filepath.Join(store, account), []byte(secret), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	log = filepath.Join(bin, "calls.log")
+	script := `#!/bin/sh
+echo "$@" >> "` + log + `"
+[ "$1" = lookup ] && [ -f "` + store + `/$5" ] || exit 1
+cat "` + store + `/$5"
+`
+	if err := os.WriteFile(filepath.Join(bin, "secret-tool"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return log
+}
+
+func TestGetEnvOrKeyring(t *testing.T) {
+	fakeSecretTool(t, map[string]string{"github_token": "ghp_keychain"})
+
+	t.Setenv("GITHUB_TOKEN", "ghp_env")
+	if got := getEnvOrKeyring("GITHUB_TOKEN", "github_token"); got != "ghp_env" {
+		t.Errorf("getEnvOrKeyring() = %q, want the environment to win", got)
+	}
+	t.Setenv("GITHUB_TOKEN", "")
+	if got := getEnvOrKeyring("GITHUB_TOKEN", "github_token"); got != "ghp_keychain" {
+		t.Errorf("getEnvOrKeyring() = %q, want the keychain secret", got)
+	}
+	if got := getEnvOrKeyring("OPENAI_API_KEY", "openai_api_key"); got != "" {
+		t.Errorf("getEnvOrKeyring() = %q, want empty for a missing secret", got)
+	}
+}
+
+func TestLoadLeavesKeychainAlone(t *testing.T) {
+	log := fakeSecretTool(t, map[string]string{"github_token": "ghp_keychain", "openai_api_key": "sk-keychain"})
+	t.Setenv("HOME", t.TempDir())
+	t.Chdir(t.TempDir())
+	t.Setenv("GITHUB_TOKEN", "")
+	t.Setenv("OPENAI_API_KEY", "sk-env")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(log); !os.IsNotExist(err) {
+		t.Fatalf("Load() ran secret-tool")
+	}
+
+	cfg.ResolveCredentials()
+	if cfg.GitHubToken != "ghp_keychain" || cfg.OpenAIAPIKey != "sk-env" {
+		t.Errorf("ResolveCredentials() = %q, %q; want the keychain token and the environment key", cfg.GitHubToken, cfg.OpenAIAPIKey)
+	}
+}
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// InitAnswers are the choices config init asks for
+type InitAnswers struct {
+	Provider        string // github, bitbucket, or gitea
+	GiteaURL        string // Instance web root for gitea
+	RepoOwner       string
+	RepoName        string
+	OpenAIModel     string
+	LabelCategories map[string]string // PR label → category
+	UnknownCategory string            // Target for unmapped categories, or "drop"
+}
+
+// tokenVariables names the credentials each provider reads from the environment
+var tokenVariables = map[string]string{
+	"github":    "GITHUB_TOKEN",
+	"bitbucket": "BITBUCKET_TOKEN (and BITBUCKET_USERNAME for app passwords)",
+	"gitea":     "GITEA_TOKEN",
+}
+
+// Scaffold renders a commented .changelog.yaml from the init answers.
+// Credentials are never written; the file names the variables to set instead.
+func Scaffold(answers InitAnswers) []byte {
+	var b strings.Builder
+	b.WriteString("# Changelog generator configuration (see USAGE.md for every setting)\n")
+	fmt.Fprintf(&b, "# Credentials stay out of this file: set OPENAI_API_KEY and %s,\n", tokenVariables[answers.Provider])
+	b.WriteString("# or keep the GitHub and OpenAI tokens in the OS keychain with auth login\n\n")
+
+	b.WriteString("# Repository hosting\n")
+	fmt.Fprintf(&b, "provider: %s\n", answers.Provider)
+	if answers.Provider == "gitea" {
+		fmt.Fprintf(&b, "gitea:\n  url: %s\n", yamlString(answers.GiteaURL))
+	}
+	fmt.Fprintf(&b, "repo_owner: %s\n", yamlString(answers.RepoOwner))
+	fmt.Fprintf(&b, "repo_name: %s\n\n", yamlString(answers.RepoName))
+
+	b.WriteString("# OpenAI configuration\n")
+	fmt.Fprintf(&b, "openai_model: %s\n", yamlString(answers.OpenAIModel))
+	b.WriteString("max_tokens: 4000\ntemperature: 0.3\n\n")
+
+	b.WriteString("# Output\noutput_path: CHANGELOG.md\ninclude_authors: true\n\n")
+
+	b.WriteString("# Categories\n")
+	fmt.Fprintf(&b, "unknown_category: %s   # where invented categories go, or drop\n", yamlString(answers.UnknownCategory))
+	if len(answers.LabelCategories) > 0 {
+		labels := make([]string, 0, len(answers.LabelCategories))
+		for label := range answers.LabelCategories {
+			labels = append(labels, label)
+		}
+		sort.Strings(labels)
+		b.WriteString("label_categories:   # PR label → category, or drop\n")
+		for _, label := range labels {
+			fmt.Fprintf(&b, "  %s: %s\n", yamlString(label), yamlString(answers.LabelCategories[label]))
+		}
+	}
+	return []byte(b.String())
+}
+
+// ParseLabelCategories parses label=Category pairs separated by commas, as
+// typed at the config init prompt
+func ParseLabelCategories(text string) (map[string]string, error) {
+	mapping := make(map[string]string)
+	for _, pair := range strings.Split(text, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		label, category, ok := strings.Cut(pair, "=")
+		label, category = strings.TrimSpace(label), strings.TrimSpace(category)
+		if !ok || label == "" || category == "" {
+			return nil, fmt.Errorf("expected label=Category, got %q", pair)
+		}
+		mapping[label] = category
+	}
+	return mapping, nil
+}
+
+// yamlString quotes a value unless it is a plain YAML string
+func yamlString(value string) string {
+	if value == "" || strings.ContainsAny(value, ":#{}[],&*!|>'\"%@`") ||
+		strings.TrimSpace(value) != value || isYAMLKeyword(value) {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+// isYAMLKeyword reports whether an unquoted value would not read back as a string
+func isYAMLKeyword(value string) bool {
+	switch strings.ToLower(value) {
+	case "true", "false", "yes", "no", "on", "off", "null", "~":
+		return true
+	}
+	_, err := strconv.ParseFloat(value, 64)
+	return err == nil
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestScaffoldReadsBack(t *testing.T) {
+	data := Scaffold(InitAnswers{
+		Provider:        "gitea",
+		GiteaURL:        "https://codeberg.org",
+		RepoOwner:       "acme",
+		RepoName:        "2024",
+		OpenAIModel:     "gpt-4o",
+		LabelCategories: map[string]string{"kind/feature": "Features", "no": "drop"},
+		UnknownCategory: "Internal",
+	})
+
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(bytes.NewReader(data)); err != nil {
+		t.Fatalf("scaffold is not valid YAML: %v\n%s", err, data)
+	}
+	for key, want := range map[string]string{
+		"provider":         "gitea",
+		"gitea.url":        "https://codeberg.org",
+		"repo_owner":       "acme",
+		"repo_name":        "2024",
+		"openai_model":     "gpt-4o",
+		"unknown_category": "Internal",
+	} {
+		if got := v.GetString(key); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+	labels := v.GetStringMapString("label_categories")
+	if labels["kind/feature"] != "Features" || labels["no"] != "drop" {
+		t.Errorf("label_categories = %v", labels)
+	}
+	if strings.Contains(string(data), "token:") {
+		t.Errorf("scaffold should not contain credentials:\n%s", data)
+	}
+}
+
+func TestParseLabelCategories(t *testing.T) {
+	mapping, err := ParseLabelCategories(" bug = Bug Fixes, enhancement=Features ,")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mapping) != 2 || mapping["bug"] != "Bug Fixes" || mapping["enhancement"] != "Features" {
+		t.Errorf("mapping = %v", mapping)
+	}
+	if _, err := ParseLabelCategories("bug"); err == nil {
+		t.Error("expected an error for a pair without =")
+	}
+}
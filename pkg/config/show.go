@@ -0,0 +1,356 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/keyring"
+	"go.yaml.in/yaml/v3"
+)
+
+// secretSettings are the credentials Load reads, keyed by the name config
+// show prints them under, with the environment variable they come from
+var secretSettings = map[string]string{
+	"github_token":                  "GITHUB_TOKEN",
+	"github_app.private_key":        "GITHUB_APP_PRIVATE_KEY",
+	"openai_api_key":                "OPENAI_API_KEY",
+	"bitbucket.token":               "BITBUCKET_TOKEN",
+	"gitea.token":                   "GITEA_TOKEN",
+	"issue_trackers.jira.token":     "JIRA_API_TOKEN",
+	"issue_trackers.linear.token":   "LINEAR_API_KEY",
+	"issue_trackers.shortcut.token": "SHORTCUT_API_TOKEN",
+}
+
+// keychainAccounts are the credentials auth login can store in the keychain
+var keychainAccounts = map[string]bool{
+	keyring.GitHubToken:  true,
+	keyring.OpenAIAPIKey: true,
+}
+
+// secretFields are sink fields holding credentials
+var secretFields = map[string]bool{
+	"access_key_id":     true,
+	"secret_access_key": true,
+	"session_token":     true,
+	"token":             true,
+	"password":          true,
+	"admin_api_key":     true,
+}
+
+// Mask hides a secret, keeping the last four characters of long values so
+// the user can tell which credential is in use
+func Mask(secret string) string {
+	switch {
+	case secret == "":
+		return ""
+	case len(secret) <= 12:
+		return "****"
+	default:
+		return "****" + secret[len(secret)-4:]
+	}
+}
+
+// maskPEM hides a PEM-encoded key entirely; its tail is only the footer
+func maskPEM(key string) string {
+	if key == "" {
+		return ""
+	}
+	return "<PEM key>"
+}
+
+// Secrets returns every credential in the configuration, so output that may
+// echo them, such as saved prompts, can be redacted
+func (c *Config) Secrets() []string {
+	secrets := []string{c.GitHubToken, c.GitHubAppPrivateKey, c.BitbucketToken, c.GiteaToken, c.OpenAIAPIKey,
+		c.JiraToken, c.LinearToken, c.ShortcutToken}
+	for _, sink := range c.Sinks {
+		secrets = append(secrets, sink.AccessKeyID, sink.SecretAccessKey, sink.SessionToken, sink.Token, sink.Password, sink.AdminAPIKey)
+	}
+	var set []string
+	for _, secret := range secrets {
+		if secret != "" {
+			set = append(set, secret)
+		}
+	}
+	return set
+}
+
+// values returns the effective value of every setting, keyed like the
+// config file
+func (c *Config) values() map[string]any {
+	return map[string]any{
+		"provider":                            c.Provider,
+		"repo_owner":                          c.RepoOwner,
+		"repo_name":                           c.RepoName,
+		"fast_fetch":                          c.FastFetch,
+		"max_commits":                         c.MaxCommits,
+		"max_files_in_prompt":                 c.MaxFilesInPrompt,
+		"diff_threshold":                      c.DiffThreshold,
+		"diff_files":                          c.DiffFiles,
+		"diff_lines":                          c.DiffLines,
+		"full_patch_lines":                    c.FullPatchLines,
+		"ignore_files":                        c.IgnoreFiles,
+		"openai_model":                        c.OpenAIModel,
+		"max_tokens":                          c.MaxTokens,
+		"temperature":                         c.Temperature,
+		"reproducible":                        c.Reproducible,
+		"seed":                                c.Seed,
+		"llm_max_attempts":                    c.MaxAttempts,
+		"model_routing":                       c.ModelRouting,
+		"small_model":                         c.SmallModel,
+		"large_model":                         c.LargeModel,
+		"small_release_max":                   c.SmallReleaseMax,
+		"large_release_min":                   c.LargeReleaseMin,
+		"large_release_lines":                 c.LargeReleaseLines,
+		"output_path":                         c.OutputPath,
+		"format":                              c.Format,
+		"validate_output":                     c.ValidateOutput,
+		"include_authors":                     c.IncludeAuthors,
+		"include_dates":                       c.IncludeDates,
+		"include_contributors":                c.IncludeContributors,
+		"include_review_stats":                c.IncludeReviewStats,
+		"include_metrics":                     c.IncludeMetrics,
+		"include_artifacts":                   c.IncludeArtifacts,
+		"existing_notes":                      c.ExistingNotes,
+		"pr_images":                           c.PRImages,
+		"link_style":                          c.LinkStyle,
+		"docs_site":                           c.DocsSite,
+		"docs_dir":                            c.DocsDir,
+		"front_matter":                        c.FrontMatter,
+		"include_stats":                       c.IncludeStats,
+		"detect_stack":                        c.DetectStack,
+		"cluster_commits":                     c.ClusterCommits,
+		"collapse_merges":                     c.CollapseMerges,
+		"security_section":                    c.SecuritySection,
+		"security_advisories":                 c.SecurityAdvisories,
+		"calibrate_scores":                    c.CalibrateScores,
+		"period_summary":                      c.PeriodSummary,
+		"scoring":                             c.Scoring,
+		"activity_chart":                      c.ActivityChart,
+		"full_changelog_link":                 c.FullChangelogLink,
+		"show_scores":                         c.ShowScores,
+		"show_score_reasons":                  c.ShowScoreReasons,
+		"min_score":                           c.MinScore,
+		"top":                                 c.Top,
+		"top_per_category":                    c.TopPerCategory,
+		"prepend":                             c.Prepend,
+		"confirm":                             c.Confirm,
+		"update_release_notes":                c.UpdateReleaseNotes,
+		"max_length":                          c.MaxLength,
+		"unknown_category":                    c.UnknownCategory,
+		"group_by_area":                       c.GroupByArea,
+		"label_category_mode":                 c.LabelCategoryMode,
+		"bot_commits":                         c.BotCommits,
+		"unverified_commits":                  c.UnverifiedCommits,
+		"reverts":                             c.Reverts,
+		"verbose":                             c.Verbose,
+		"log_level":                           c.LogLevel,
+		"log_format":                          c.LogFormat,
+		"timeout":                             c.Timeout.String(),
+		"strict":                              c.Strict,
+		"org":                                 c.Org,
+		"tag_pattern":                         c.TagPattern,
+		"exclude_prereleases":                 c.ExcludePrereleases,
+		"group_by":                            c.GroupBy,
+		"include_boundaries":                  c.IncludeBoundaries,
+		"llm_concurrency":                     c.LLMConcurrency,
+		"collect_training_data":               c.TrainingDataDir,
+		"run_summary":                         c.RunSummaryPath,
+		"save_prompts":                        c.PromptLogDir,
+		"proxy":                               redactURL(c.Proxy),
+		"ca_bundle":                           c.CABundle,
+		"github_app.app_id":                   c.GitHubAppID,
+		"github_app.installation_id":          c.GitHubAppInstallationID,
+		"github_app.private_key_path":         c.GitHubAppPrivateKeyPath,
+		"bitbucket.username":                  c.BitbucketUsername,
+		"gitea.url":                           c.GiteaURL,
+		"issue_trackers.fetch_summaries":      c.FetchTicketSummaries,
+		"issue_trackers.jira.base_url":        c.JiraBaseURL,
+		"issue_trackers.jira.project_pattern": c.JiraProjectPattern,
+		"issue_trackers.jira.projects":        nonNil(c.JiraProjects),
+		"issue_trackers.jira.email":           c.JiraEmail,
+		"issue_trackers.linear.workspace":     c.LinearWorkspace,
+		"issue_trackers.linear.team_keys":     nonNil(c.LinearTeamKeys),
+		"issue_trackers.shortcut.workspace":   c.ShortcutWorkspace,
+		"install.image":                       c.InstallImage,
+		"install.helm.chart":                  c.InstallChart,
+		"install.helm.version":                c.InstallChartVersion,
+		"install.helm.release":                c.InstallReleaseName,
+		"hooks.pre_fetch":                     c.HookPreFetch,
+		"hooks.post_fetch":                    c.HookPostFetch,
+		"hooks.pre_llm":                       c.HookPreLLM,
+		"hooks.post_llm":                      c.HookPostLLM,
+		"hooks.pre_write":                     c.HookPreWrite,
+		"hooks.post_generate":                 c.HookPostGenerate,
+		"hooks.env":                           nonNil(c.HookEnv),
+		"fragments.dir":                       c.FragmentDir,
+		"watch.schedule":                      c.WatchSchedule,
+		"watch.repos":                         nonNil(c.WatchRepos),
+		"watch.state_file":                    c.WatchStateFile,
+		"watch.sinks.file":                    c.WatchOutputDir,
+		"watch.sinks.release":                 c.WatchUpdateRelease,
+		"watch.sinks.slack_webhook":           Mask(c.SlackWebhookURL),
+		"state.backend":                       c.StateBackend,
+		"state.url":                           redactURL(c.StateURL),
+
+		"github_token":                  Mask(c.GitHubToken),
+		"github_app.private_key":        maskPEM(c.GitHubAppPrivateKey),
+		"openai_api_key":                Mask(c.OpenAIAPIKey),
+		"bitbucket.token":               Mask(c.BitbucketToken),
+		"gitea.token":                   Mask(c.GiteaToken),
+		"issue_trackers.jira.token":     Mask(c.JiraToken),
+		"issue_trackers.linear.token":   Mask(c.LinearToken),
+		"issue_trackers.shortcut.token": Mask(c.ShortcutToken),
+	}
+}
+
+// Show renders the effective configuration as YAML, with secrets masked and
+// each value that is not a default annotated with its source
+func (c *Config) Show() ([]byte, error) {
+	root := &yaml.Node{Kind: yaml.MappingNode}
+	values := c.values()
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := setNode(root, key, values[key], secretSource(key, values[key])); err != nil {
+			return nil, err
+		}
+	}
+
+	// Structured settings are only read from the config file
+	structured := map[string]any{
+		"category_aliases": c.CategoryAliases,
+		"label_categories": c.LabelCategories,
+		"product_areas":    structList(c.ProductAreas),
+		"sinks":            structList(c.Sinks),
+	}
+	for _, key := range []string{"category_aliases", "label_categories", "product_areas", "sinks"} {
+		if reflect.ValueOf(structured[key]).Len() == 0 {
+			continue
+		}
+		if err := setNode(root, key, structured[key], Source(key)); err != nil {
+			return nil, err
+		}
+	}
+
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(root); err != nil {
+		return nil, fmt.Errorf("encode configuration: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("encode configuration: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+// secretSource describes where a setting comes from, including the
+// credentials that are read from fixed environment variables or the keychain
+func secretSource(key string, value any) string {
+	name, ok := secretSettings[key]
+	if !ok {
+		return Source(key)
+	}
+	switch {
+	case os.Getenv(name) != "":
+		return "env " + name
+	case value != "" && keychainAccounts[key]:
+		return "keychain"
+	}
+	return "default"
+}
+
+// setNode stores value under the dotted key in a YAML mapping, creating the
+// intermediate mappings, with source as a line comment unless it is a default
+func setNode(root *yaml.Node, key string, value any, source string) error {
+	parts := strings.Split(key, ".")
+	parent := root
+	for _, part := range parts[:len(parts)-1] {
+		parent = childMapping(parent, part)
+	}
+
+	valueNode := &yaml.Node{}
+	if err := valueNode.Encode(value); err != nil {
+		return fmt.Errorf("encode %s: %w", key, err)
+	}
+	keyNode := &yaml.Node{Kind: yaml.ScalarNode, Value: parts[len(parts)-1]}
+	if source != "default" {
+		if valueNode.Kind == yaml.ScalarNode || len(valueNode.Content) == 0 {
+			valueNode.LineComment = source
+		} else {
+			keyNode.LineComment = source
+		}
+	}
+	parent.Content = append(parent.Content, keyNode, valueNode)
+	return nil
+}
+
+// childMapping returns the mapping stored under name in parent, adding it if
+// missing
+func childMapping(parent *yaml.Node, name string) *yaml.Node {
+	for i := 0; i+1 < len(parent.Content); i += 2 {
+		if parent.Content[i].Value == name {
+			return parent.Content[i+1]
+		}
+	}
+	child := &yaml.Node{Kind: yaml.MappingNode}
+	parent.Content = append(parent.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: name}, child)
+	return child
+}
+
+// structList converts product areas or sinks to maps keyed like the config
+// file, leaving out empty fields and masking credentials
+func structList[T any](items []T) []map[string]any {
+	list := make([]map[string]any, 0, len(items))
+	for _, item := range items {
+		value := reflect.ValueOf(item)
+		fields := make(map[string]any)
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Field(i)
+			name := value.Type().Field(i).Tag.Get("mapstructure")
+			if name == "" || field.IsZero() {
+				continue
+			}
+			switch {
+			case secretFields[name]:
+				fields[name] = Mask(field.String())
+			case name == "headers":
+				headers := make(map[string]string)
+				for header, text := range field.Interface().(map[string]string) {
+					headers[header] = Mask(text)
+				}
+				fields[name] = headers
+			default:
+				fields[name] = field.Interface()
+			}
+		}
+		list = append(list, fields)
+	}
+	return list
+}
+
+// redactURL hides the password in a URL such as a Redis connection string
+func redactURL(raw string) string {
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.User == nil {
+		return raw
+	}
+	return parsed.Redacted()
+}
+
+// nonNil makes unset lists render as [] rather than null
+func nonNil(list []string) []string {
+	if list == nil {
+		return []string{}
+	}
+	return list
+}
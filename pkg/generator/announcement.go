@@ -0,0 +1,173 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+)
+
+// AnnouncementLimit is the length of a short announcement, in characters: a
+// post on X
+const AnnouncementLimit = 280
+
+// announcementHighlights caps the highlights listed in the long post
+const announcementHighlights = 5
+
+// Announcement is the social-media copy of one release
+type Announcement struct {
+	Repo    string `json:"repo"`
+	Version string `json:"version"`
+	Short   string `json:"short"` // At most AnnouncementLimit characters, e.g. for X
+	Long    string `json:"long"`  // Longer post, e.g. for Mastodon or LinkedIn
+}
+
+// Announcements builds the announcement of a Changelog, or of every release
+// of a TimelineChangelog or OrgTimelineChangelog, from the one-liners and
+// highlights the changelog already has. No model is called.
+func Announcements(changelog any) ([]Announcement, error) {
+	switch c := changelog.(type) {
+	case *Changelog:
+		return []Announcement{announce(c.RepoName, c.ToRef, c.Zoom.OneLiner, firstNonEmpty(c.Zoom.Paragraph, c.Summary),
+			releaseHighlights(c.Highlights, c.Categories, nil))}, nil
+	case *TimelineChangelog:
+		return timelineAnnouncements(c), nil
+	case *OrgTimelineChangelog:
+		var announcements []Announcement
+		for _, repo := range c.Repos {
+			announcements = append(announcements, timelineAnnouncements(repo)...)
+		}
+		return announcements, nil
+	default:
+		return nil, fmt.Errorf("unsupported changelog type %T", changelog)
+	}
+}
+
+// timelineAnnouncements announces every release of a timeline
+func timelineAnnouncements(timeline *TimelineChangelog) []Announcement {
+	var announcements []Announcement
+	for _, release := range timeline.Releases {
+		var prs []string
+		for _, pr := range release.PullRequests {
+			prs = append(prs, firstNonEmpty(release.PRSummaries[pr.Number], pr.Title))
+		}
+		announcements = append(announcements, announce(timeline.RepoName, release.ToRef, release.Zoom.OneLiner,
+			firstNonEmpty(release.Zoom.Paragraph, release.Summary), releaseHighlights(release.Highlights, release.Categories, prs)))
+	}
+	return announcements
+}
+
+// releaseHighlights returns a release's highlights; releases without any fall
+// back to their highest-scoring entries, then to their pull request summaries
+func releaseHighlights(highlights []string, categories map[string][]llm.ChangelogEntry, prs []string) []string {
+	if len(highlights) > 0 {
+		return highlights
+	}
+	var entries []llm.ChangelogEntry
+	for _, category := range categoriesByPriority(categories) {
+		entries = append(entries, categories[category]...)
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].ImportanceScore > entries[j].ImportanceScore })
+	for _, entry := range entries {
+		highlights = append(highlights, entry.Title)
+	}
+	if len(highlights) == 0 {
+		highlights = prs
+	}
+	return highlights[:min(announcementHighlights, len(highlights))]
+}
+
+// announce writes the short and long posts of a release
+func announce(repo, version, oneLiner, paragraph string, highlights []string) Announcement {
+	name := path.Base(repo)
+	headline := fmt.Sprintf("🚀 %s %s is out!", name, version)
+	oneLiner = plainText(oneLiner)
+	plain := make([]string, len(highlights))
+	for i, highlight := range highlights {
+		plain[i] = plainText(highlight)
+	}
+	highlights = plain
+
+	// Short: the headline, the one-liner (or else the top highlight), and as
+	// many highlights as still fit
+	short := headline
+	lead, rest := oneLiner, highlights
+	if lead == "" && len(rest) > 0 {
+		lead, rest = rest[0], rest[1:]
+	}
+	if lead != "" {
+		short = truncateChars(short+" "+lead, AnnouncementLimit)
+	}
+	for _, highlight := range rest {
+		next := short + "\n• " + highlight
+		if utf8.RuneCountInString(next) > AnnouncementLimit {
+			break
+		}
+		short = next
+	}
+
+	// Long: the headline, the paragraph summary, and the highlights
+	var long strings.Builder
+	long.WriteString(headline)
+	if summary := plainText(firstNonEmpty(paragraph, oneLiner)); summary != "" {
+		long.WriteString("\n\n" + summary)
+	}
+	if len(highlights) > 0 {
+		long.WriteString("\n\nHighlights:")
+		for _, highlight := range highlights[:min(announcementHighlights, len(highlights))] {
+			long.WriteString("\n• " + highlight)
+		}
+	}
+	return Announcement{Repo: repo, Version: version, Short: short, Long: long.String()}
+}
+
+// FormatAnnouncements renders announcements as markdown, one section per
+// release with the short post (and its length) and the long post
+func FormatAnnouncements(announcements []Announcement) string {
+	var sb strings.Builder
+	for i, a := range announcements {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(fmt.Sprintf("# %s %s\n\n", a.Repo, a.Version))
+		sb.WriteString(fmt.Sprintf("## Short (%d/%d characters)\n\n%s\n\n", utf8.RuneCountInString(a.Short), AnnouncementLimit, a.Short))
+		sb.WriteString(fmt.Sprintf("## Long\n\n%s\n", a.Long))
+	}
+	return sb.String()
+}
+
+// plainText strips the inline markdown social networks would show verbatim:
+// links keep their text, code and emphasis lose their markers
+func plainText(s string) string {
+	s = mdLinkRe.ReplaceAllString(s, "$1")
+	s = mdCodeRe.ReplaceAllString(s, "$1")
+	s = mdBoldRe.ReplaceAllString(s, "$1")
+	return strings.TrimSpace(s)
+}
+
+// truncateChars shortens s to at most limit characters, ending it with an
+// ellipsis at a word boundary when it is cut
+func truncateChars(s string, limit int) string {
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+	runes := []rune(s)[:limit-1]
+	cut := string(runes)
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " ,;:.") + "…"
+}
+
+// firstNonEmpty returns the first of values that is not empty
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
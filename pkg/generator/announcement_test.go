@@ -0,0 +1,96 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+func TestAnnouncementsChangelog(t *testing.T) {
+	changelog := &Changelog{
+		RepoName:   "acme/api",
+		ToRef:      "v1.1.0",
+		Summary:    "This release adds webhooks and fixes login.",
+		Highlights: []string{"**Webhooks** for every event", "Faster [search](https://example.com/search)"},
+		Zoom:       ZoomSummaries{OneLiner: "Webhooks arrive and search gets faster."},
+	}
+
+	announcements, err := Announcements(changelog)
+	if err != nil || len(announcements) != 1 {
+		t.Fatalf("Announcements() = %v, %v", announcements, err)
+	}
+	a := announcements[0]
+	wantShort := "🚀 api v1.1.0 is out! Webhooks arrive and search gets faster.\n• Webhooks for every event\n• Faster search"
+	if a.Short != wantShort {
+		t.Errorf("Short = %q, want %q", a.Short, wantShort)
+	}
+	wantLong := "🚀 api v1.1.0 is out!\n\nThis release adds webhooks and fixes login.\n\nHighlights:\n• Webhooks for every event\n• Faster search"
+	if a.Long != wantLong {
+		t.Errorf("Long = %q, want %q", a.Long, wantLong)
+	}
+	if changelog.Highlights[0] != "**Webhooks** for every event" {
+		t.Errorf("Announcements() modified the changelog's highlights: %q", changelog.Highlights[0])
+	}
+}
+
+func TestAnnouncementShortLimit(t *testing.T) {
+	changelog := &Changelog{
+		RepoName:   "acme/api",
+		ToRef:      "v2.0.0",
+		Highlights: []string{strings.Repeat("Rewrote the storage engine ", 15), "Dropped Go 1.20"},
+	}
+
+	announcements, err := Announcements(changelog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	short := announcements[0].Short
+	if n := utf8.RuneCountInString(short); n > AnnouncementLimit {
+		t.Errorf("Short is %d characters, want at most %d", n, AnnouncementLimit)
+	}
+	if !strings.HasPrefix(short, "🚀 api v2.0.0 is out! Rewrote the storage engine") || !strings.HasSuffix(short, "the…") {
+		t.Errorf("Short = %q, want the top highlight cut at a word boundary", short)
+	}
+	if !strings.Contains(announcements[0].Long, "• Dropped Go 1.20") {
+		t.Errorf("Long = %q, want every highlight", announcements[0].Long)
+	}
+}
+
+func TestAnnouncementsTimelineFallsBack(t *testing.T) {
+	timeline := &TimelineChangelog{
+		RepoName: "acme/api",
+		Releases: []ReleaseChangelog{
+			{
+				ToRef: "v1.2.0",
+				Categories: map[string][]llm.ChangelogEntry{
+					"Bug Fixes": {{Title: "Fix retries", ImportanceScore: 5}},
+					"Features":  {{Title: "Add `export` command", ImportanceScore: 8}},
+				},
+			},
+			{
+				ToRef:        "v1.3.0",
+				PullRequests: []provider.PullRequestData{{Number: 7, Title: "feat: dark mode"}, {Number: 8, Title: "fix: typo"}},
+				PRSummaries:  map[int]string{7: "Dark mode for the dashboard"},
+			},
+		},
+	}
+
+	announcements, err := Announcements(timeline)
+	if err != nil || len(announcements) != 2 {
+		t.Fatalf("Announcements() = %v, %v", announcements, err)
+	}
+	if want := "🚀 api v1.2.0 is out! Add export command\n• Fix retries"; announcements[0].Short != want {
+		t.Errorf("Short = %q, want %q", announcements[0].Short, want)
+	}
+	if want := "🚀 api v1.3.0 is out! Dark mode for the dashboard\n• fix: typo"; announcements[1].Short != want {
+		t.Errorf("Short = %q, want %q", announcements[1].Short, want)
+	}
+
+	markdown := FormatAnnouncements(announcements)
+	if !strings.Contains(markdown, "# acme/api v1.3.0\n\n## Short (") || !strings.Contains(markdown, "/280 characters)") {
+		t.Errorf("Unexpected announcement markdown:\n%s", markdown)
+	}
+}
@@ -0,0 +1,157 @@
+package generator
+
+import (
+	"path"
+	"strings"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+// MatchGlob reports whether a slash-separated file path matches pattern.
+// "**" matches any number of directories; other segments use path.Match
+// syntax. A pattern without a slash matches the file's base name.
+func MatchGlob(pattern, file string) bool {
+	file = strings.TrimPrefix(file, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	if !strings.Contains(pattern, "/") {
+		ok, _ := path.Match(pattern, path.Base(file))
+		return ok
+	}
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(file, "/"))
+}
+
+// matchSegments matches path segments against pattern segments
+func matchSegments(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := len(segments); i >= 0; i-- {
+				if matchSegments(pattern[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segments) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], segments[0]); !ok {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
+
+// AreasForFiles returns the names of the product areas whose path globs
+// match any of files, in configuration order
+func AreasForFiles(areas []config.ProductArea, files []string) []string {
+	var names []string
+	for _, area := range areas {
+		if areaMatches(area, files) {
+			names = append(names, area.Name)
+		}
+	}
+	return names
+}
+
+// areaMatches reports whether any file falls under the area's paths
+func areaMatches(area config.ProductArea, files []string) bool {
+	for _, file := range files {
+		for _, pattern := range area.Paths {
+			if MatchGlob(pattern, file) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// tagEntryAreas sets each entry's product areas from the files its commit touched
+func (g *Generator) tagEntryAreas(response *llm.ChangelogResponse, commits []provider.CommitData) {
+	if len(g.config.ProductAreas) == 0 {
+		return
+	}
+	for category, entries := range response.Categories {
+		for i := range entries {
+			commit := findCommit(commits, entries[i].SHA)
+			if commit == nil {
+				continue
+			}
+			files := make([]string, len(commit.FilesChanged))
+			for j, file := range commit.FilesChanged {
+				files[j] = file.Filename
+			}
+			entries[i].Areas = AreasForFiles(g.config.ProductAreas, files)
+			entries[i].DocsURL = areaDocs(g.config.ProductAreas, entries[i].Areas)
+		}
+		response.Categories[category] = entries
+	}
+}
+
+// areaDocs returns the documentation URL of the first named area that has one
+func areaDocs(areas []config.ProductArea, names []string) string {
+	for _, name := range names {
+		for _, area := range areas {
+			if area.Name == name && area.Docs != "" {
+				return area.Docs
+			}
+		}
+	}
+	return ""
+}
+
+// OtherArea collects the entries outside every product area when entries are
+// grouped by area
+const OtherArea = "Other"
+
+// hasAreas reports whether any entry is tagged with a product area
+func hasAreas(categories map[string][]llm.ChangelogEntry) bool {
+	for _, entries := range categories {
+		for _, entry := range entries {
+			if len(entry.Areas) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// groupByArea splits categorized entries by product area, returning the areas
+// that have entries in configuration order (OtherArea last) and each area's
+// categories. An entry in several areas is listed under the first.
+func groupByArea(categories map[string][]llm.ChangelogEntry, areas []config.ProductArea) ([]string, map[string]map[string][]llm.ChangelogEntry) {
+	grouped := make(map[string]map[string][]llm.ChangelogEntry)
+	for category, entries := range categories {
+		for _, entry := range entries {
+			area := OtherArea
+			if len(entry.Areas) > 0 {
+				area = entry.Areas[0]
+			}
+			if grouped[area] == nil {
+				grouped[area] = make(map[string][]llm.ChangelogEntry)
+			}
+			grouped[area][category] = append(grouped[area][category], entry)
+		}
+	}
+
+	var names []string
+	for _, area := range areas {
+		if grouped[area.Name] != nil && area.Name != OtherArea {
+			names = append(names, area.Name)
+		}
+	}
+	if grouped[OtherArea] != nil {
+		names = append(names, OtherArea)
+	}
+	return names, grouped
+}
+
+// formatDocsLink renders a " · [Learn more](url)" link to an entry's documentation
+func formatDocsLink(entry llm.ChangelogEntry) string {
+	if entry.DocsURL == "" {
+		return ""
+	}
+	return " · [Learn more](" + entry.DocsURL + ")"
+}
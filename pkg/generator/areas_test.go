@@ -0,0 +1,96 @@
+package generator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+)
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern, file string
+		want          bool
+	}{
+		{"api/**", "api/v1/users.go", true},
+		{"api/**", "api", true},
+		{"api/**", "web/api/users.go", false},
+		{"**/testdata/**", "pkg/llm/testdata/golden.json", true},
+		{"pkg/*/client.go", "pkg/github/client.go", true},
+		{"pkg/*/client.go", "pkg/github/v2/client.go", false},
+		{"*.proto", "proto/acme/v1/users.proto", true},
+		{"/docs/**", "docs/index.md", true},
+	}
+	for _, tt := range tests {
+		if got := MatchGlob(tt.pattern, tt.file); got != tt.want {
+			t.Errorf("MatchGlob(%q, %q) = %v, want %v", tt.pattern, tt.file, got, tt.want)
+		}
+	}
+}
+
+func TestAreasAndDocsLinks(t *testing.T) {
+	areas := []config.ProductArea{
+		{Name: "API", Paths: []string{"api/**"}},
+		{Name: "Authentication", Paths: []string{"pkg/auth/**"}, Docs: "https://docs.example.com/auth"},
+		{Name: "Dashboard", Paths: []string{"web/**"}, Docs: "https://docs.example.com/dashboard"},
+	}
+
+	names := AreasForFiles(areas, []string{"pkg/auth/sso.go", "api/login.go"})
+	if want := []string{"API", "Authentication"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("AreasForFiles() = %v, want %v", names, want)
+	}
+	docs := areaDocs(areas, names)
+	if docs != "https://docs.example.com/auth" {
+		t.Errorf("areaDocs() = %q", docs)
+	}
+
+	var sb strings.Builder
+	writeEntry(&sb, llm.ChangelogEntry{Title: "SSO login", SHA: "abc1234", DocsURL: docs}, &config.Config{}, nil)
+	if !strings.Contains(sb.String(), "- **SSO login** (`abc1234`) · [Learn more](https://docs.example.com/auth)") {
+		t.Errorf("Expected Learn more link, got:\n%s", sb.String())
+	}
+}
+
+func TestFormatMarkdownGroupsByArea(t *testing.T) {
+	cfg := &config.Config{
+		GroupByArea: true,
+		BotCommits:  "group",
+		ProductAreas: []config.ProductArea{
+			{Name: "API", Paths: []string{"api/**"}},
+			{Name: "Dashboard", Paths: []string{"web/**"}},
+			{Name: "CLI", Paths: []string{"cmd/**"}},
+		},
+	}
+	response := &llm.ChangelogResponse{Categories: map[string][]llm.ChangelogEntry{
+		"Features": {
+			{SHA: "aaaa111", Title: "Dark mode", Areas: []string{"Dashboard"}},
+			{SHA: "bbbb222", Title: "Webhooks API", Areas: []string{"API", "Dashboard"}},
+		},
+		"Bug Fixes": {
+			{SHA: "cccc333", Title: "Fix pagination", Areas: []string{"API"}},
+			{SHA: "dddd444", Title: "Fix typo in README"},
+		},
+		"Internal": {{SHA: "eeee555", Title: "Bump cobra", Areas: []string{"CLI"}, Bot: true}},
+	}}
+
+	markdown := FormatMarkdown(response, "v1.0.0", "v1.1.0", cfg, nil)
+	want := "## API\n\n" +
+		"### 🚀 Features\n\n- **Webhooks API** (`bbbb222`)\n\n" +
+		"### 🐛 Bug Fixes\n\n- **Fix pagination** (`cccc333`)\n\n" +
+		"## Dashboard\n\n" +
+		"### 🚀 Features\n\n- **Dark mode** (`aaaa111`)\n\n" +
+		"## Other\n\n" +
+		"### 🐛 Bug Fixes\n\n- **Fix typo in README** (`dddd444`)\n\n" +
+		"## " + BotHeading + "\n\n- **Bump cobra** (`eeee555`)\n\n"
+	if !strings.HasSuffix(markdown, want) {
+		t.Errorf("Expected area sections:\n%s\ngot:\n%s", want, markdown)
+	}
+
+	// Without area tags the categories stay at the top level
+	plain := &llm.ChangelogResponse{Categories: map[string][]llm.ChangelogEntry{"Features": {{SHA: "aaaa111", Title: "Dark mode"}}}}
+	if markdown := FormatMarkdown(plain, "v1.0.0", "v1.1.0", cfg, nil); !strings.Contains(markdown, "## 🚀 Features\n") || strings.Contains(markdown, "## Other") {
+		t.Errorf("Unexpected markdown without areas:\n%s", markdown)
+	}
+}
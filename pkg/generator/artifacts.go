@@ -0,0 +1,84 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+// releaseArtifacts returns the artifacts of the release published for tag,
+// or nil when the tag has no release
+func (g *Generator) releaseArtifacts(ctx context.Context, tag string) ([]provider.ReleaseAsset, error) {
+	releases, err := g.provider.ListReleases(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list releases: %w", err)
+	}
+	for _, release := range releases {
+		if release.TagName == tag && !release.Draft {
+			return provider.ReleaseArtifacts(release), nil
+		}
+	}
+	return nil, nil
+}
+
+// existingNotes returns the hand-written part of the release notes published
+// for tag when --existing-notes is set. Failures only warn.
+func (g *Generator) existingNotes(ctx context.Context, tag string) string {
+	if !g.config.ExistingNotes || g.provider == nil {
+		return ""
+	}
+	releases, err := g.provider.ListReleases(ctx)
+	if err != nil {
+		g.warn("could not list releases for existing notes: %v", err)
+		return ""
+	}
+	for _, release := range releases {
+		if release.TagName == tag && !release.Draft {
+			return HandWrittenNotes(release.Body)
+		}
+	}
+	return ""
+}
+
+// FormatArtifacts renders release artifacts as a download index with
+// headings at the given level
+func FormatArtifacts(artifacts []provider.ReleaseAsset, level int) string {
+	if len(artifacts) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s 📦 Artifacts\n\n", strings.Repeat("#", level)))
+	for _, artifact := range artifacts {
+		switch {
+		case artifact.Digest != "":
+			sb.WriteString(fmt.Sprintf("- %s `%s`\n", artifact.Name, artifact.Digest))
+		case artifact.URL != "":
+			sb.WriteString(fmt.Sprintf("- [%s](%s)", artifact.Name, artifact.URL))
+			if artifact.Size > 0 {
+				sb.WriteString(fmt.Sprintf(" (%s)", formatSize(artifact.Size)))
+			}
+			sb.WriteString("\n")
+		default:
+			sb.WriteString(fmt.Sprintf("- %s\n", artifact.Name))
+		}
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// formatSize renders a byte count with a binary unit, e.g. "4.2 MB"
+func formatSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	value, exp := float64(bytes)/unit, 0
+	for value >= unit && exp < 3 {
+		value /= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", value, "KMGT"[exp])
+}
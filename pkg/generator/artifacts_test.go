@@ -0,0 +1,31 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+func TestFormatArtifacts(t *testing.T) {
+	markdown := FormatArtifacts([]provider.ReleaseAsset{
+		{Name: "api_linux_amd64.tar.gz", URL: "https://example.com/api.tar.gz", Size: 4404019},
+		{Name: "checksums.txt", URL: "https://example.com/checksums.txt"},
+		{Name: "ghcr.io/acme/api", Digest: "sha256:abc"},
+	}, 3)
+
+	for _, want := range []string{
+		"### 📦 Artifacts",
+		"- [api_linux_amd64.tar.gz](https://example.com/api.tar.gz) (4.2 MB)",
+		"- [checksums.txt](https://example.com/checksums.txt)\n",
+		"- ghcr.io/acme/api `sha256:abc`",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Expected artifacts to contain %q\nGot:\n%s", want, markdown)
+		}
+	}
+
+	if FormatArtifacts(nil, 2) != "" {
+		t.Error("Expected no section without artifacts")
+	}
+}
@@ -0,0 +1,121 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+// BotHeading is the section that collects bot-authored entries when bot
+// commits are grouped
+const BotHeading = "🤖 Automated Updates"
+
+// attributeEntries copies each entry's authorship from its commit: the author
+// when the model left it out, the co-authors, whether a bot wrote it, whether
+// the provider reported the commit's signature as unverified, and the pull
+// request the commit merged
+func attributeEntries(response *llm.ChangelogResponse, commits []provider.CommitData) {
+	for category, entries := range response.Categories {
+		for i := range entries {
+			commit := findCommit(commits, entries[i].SHA)
+			if commit == nil {
+				continue
+			}
+			if entries[i].Author == "" {
+				entries[i].Author = commit.Author
+			}
+			entries[i].CoAuthors = commit.CoAuthors
+			entries[i].Bot = provider.IsBot(commit.Author)
+			entries[i].Unverified = unverified(*commit)
+			entries[i].PR = CommitPRNumber(commit.Message)
+		}
+		response.Categories[category] = entries
+	}
+}
+
+// unverified reports whether the provider says a commit's signature is not
+// verified; commits without verification data are not flagged
+func unverified(commit provider.CommitData) bool {
+	return commit.Verification != nil && !commit.Verification.Verified
+}
+
+// filterCommits drops the commits matching drop, returning the remaining
+// commits and their LLM counterparts
+func filterCommits(commits []provider.CommitData, infos []llm.CommitInfo, drop func(provider.CommitData) bool) ([]provider.CommitData, []llm.CommitInfo) {
+	dropped := make(map[string]bool)
+	var kept []provider.CommitData
+	for _, commit := range commits {
+		if drop(commit) {
+			dropped[commit.SHA] = true
+			continue
+		}
+		kept = append(kept, commit)
+	}
+	var keptInfos []llm.CommitInfo
+	for _, info := range infos {
+		if !dropped[info.SHA] {
+			keptInfos = append(keptInfos, info)
+		}
+	}
+	return kept, keptInfos
+}
+
+// excludeBotCommits drops commits authored by bot accounts
+func excludeBotCommits(commits []provider.CommitData, infos []llm.CommitInfo) ([]provider.CommitData, []llm.CommitInfo) {
+	return filterCommits(commits, infos, func(commit provider.CommitData) bool {
+		return provider.IsBot(commit.Author)
+	})
+}
+
+// excludeBotPullRequests drops pull requests and commits authored by bot
+// accounts from a timeline release
+func excludeBotPullRequests(release provider.TimelineRelease) provider.TimelineRelease {
+	var prs []provider.PullRequestData
+	for _, pr := range release.PullRequests {
+		if !provider.IsBot(pr.Author) {
+			prs = append(prs, pr)
+		}
+	}
+	release.PullRequests = prs
+	commits, _ := excludeBotCommits(release.Commits, nil)
+	release.CommitCount = max(release.CommitCount-(len(release.Commits)-len(commits)), len(commits))
+	release.Commits = commits
+	return release
+}
+
+// splitBotEntries separates bot-authored entries from the rest
+func splitBotEntries(entries []llm.ChangelogEntry) (humans, bots []llm.ChangelogEntry) {
+	for _, entry := range entries {
+		if entry.Bot {
+			bots = append(bots, entry)
+		} else {
+			humans = append(humans, entry)
+		}
+	}
+	return humans, bots
+}
+
+// formatAuthors renders " by @author, @co-author" for an entry
+func formatAuthors(author string, coAuthors []string) string {
+	var names []string
+	for _, name := range append([]string{author}, coAuthors...) {
+		if name != "" {
+			names = append(names, mention(name))
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" by %s", strings.Join(names, ", "))
+}
+
+// mention renders an author as an @-mention. Co-authors known only by name
+// (with spaces, so not an account) are left as plain text.
+func mention(name string) string {
+	if strings.Contains(name, " ") {
+		return name
+	}
+	return "@" + name
+}
@@ -0,0 +1,96 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+func TestAttributeEntries(t *testing.T) {
+	commits := []provider.CommitData{
+		{SHA: "aaaa1111", Author: "alice", CoAuthors: []string{"bob", "Jane Doe"}},
+		{SHA: "bbbb2222", Author: "dependabot[bot]"},
+	}
+	response := &llm.ChangelogResponse{Categories: map[string][]llm.ChangelogEntry{
+		"Features": {{SHA: "aaaa111", Title: "Login"}},
+		"Internal": {{SHA: "bbbb222", Title: "Bump x/net", Author: "dependabot[bot]"}},
+	}}
+
+	attributeEntries(response, commits)
+	login := response.Categories["Features"][0]
+	if login.Author != "alice" || len(login.CoAuthors) != 2 || login.Bot {
+		t.Errorf("Unexpected attribution: %+v", login)
+	}
+	if !response.Categories["Internal"][0].Bot {
+		t.Error("Expected the dependabot entry to be flagged as a bot")
+	}
+	if got := formatAuthors(login.Author, login.CoAuthors); got != " by @alice, @bob, Jane Doe" {
+		t.Errorf("formatAuthors() = %q", got)
+	}
+}
+
+func TestExcludeBotCommits(t *testing.T) {
+	commits := []provider.CommitData{{SHA: "a", Author: "alice"}, {SHA: "b", Author: "renovate[bot]"}}
+	infos := []llm.CommitInfo{{SHA: "a", Author: "alice"}, {SHA: "b", Author: "renovate[bot]"}}
+
+	commits, infos = excludeBotCommits(commits, infos)
+	if len(commits) != 1 || len(infos) != 1 || infos[0].SHA != "a" {
+		t.Errorf("Expected only alice's commit, got %v and %v", commits, infos)
+	}
+
+	release := excludeBotPullRequests(provider.TimelineRelease{
+		CommitCount:  5,
+		Commits:      []provider.CommitData{{Author: "alice"}, {Author: "dependabot[bot]"}},
+		PullRequests: []provider.PullRequestData{{Number: 1, Author: "alice"}, {Number: 2, Author: "dependabot[bot]"}},
+	})
+	if len(release.PullRequests) != 1 || len(release.Commits) != 1 || release.CommitCount != 4 {
+		t.Errorf("Unexpected release after excluding bots: %+v", release)
+	}
+}
+
+func TestFormatMarkdownGroupsBots(t *testing.T) {
+	response := &llm.ChangelogResponse{Categories: map[string][]llm.ChangelogEntry{
+		"Features": {{SHA: "aaaa1111", Title: "Login", Author: "alice"}},
+		"Internal": {{SHA: "bbbb2222", Title: "Bump x/net", Author: "dependabot[bot]", Bot: true}},
+	}}
+
+	markdown := FormatMarkdown(response, "v1.0.0", "v1.1.0", &config.Config{BotCommits: "group"}, nil)
+	if strings.Contains(markdown, "## 🔧 Internal") {
+		t.Errorf("Expected the bot-only category to be folded away\n%s", markdown)
+	}
+	section := "## " + BotHeading + "\n\n- **Bump x/net**"
+	if !strings.Contains(markdown, section) || strings.Index(markdown, section) < strings.Index(markdown, "Login") {
+		t.Errorf("Expected bot entries in a trailing %q section\n%s", BotHeading, markdown)
+	}
+
+	markdown = FormatMarkdown(response, "v1.0.0", "v1.1.0", &config.Config{BotCommits: "include"}, nil)
+	if strings.Contains(markdown, BotHeading) || !strings.Contains(markdown, "## 🔧 Internal") {
+		t.Errorf("Expected bot entries in place without grouping\n%s", markdown)
+	}
+}
+
+func TestUnverifiedCommits(t *testing.T) {
+	commits := []provider.CommitData{
+		{SHA: "aaaa1111", Verification: &provider.CommitVerification{Verified: true, Reason: "valid"}},
+		{SHA: "bbbb2222", Verification: &provider.CommitVerification{Reason: "unsigned"}},
+		{SHA: "cccc3333"}, // Provider without verification data
+	}
+	infos := []llm.CommitInfo{{SHA: "aaaa1111"}, {SHA: "bbbb2222"}, {SHA: "cccc3333"}}
+
+	kept, keptInfos := filterCommits(commits, infos, unverified)
+	if len(kept) != 2 || len(keptInfos) != 2 || keptInfos[1].SHA != "cccc3333" {
+		t.Errorf("Expected only the unsigned commit dropped, got %v", keptInfos)
+	}
+
+	response := &llm.ChangelogResponse{Categories: map[string][]llm.ChangelogEntry{
+		"Features": {{SHA: "aaaa1111", Title: "Signed"}, {SHA: "bbbb2222", Title: "Unsigned"}},
+	}}
+	attributeEntries(response, commits)
+	markdown := FormatMarkdown(response, "v1.0.0", "v1.1.0", &config.Config{UnverifiedCommits: "annotate"}, nil)
+	if !strings.Contains(markdown, "**Unsigned** (`bbbb222`) · ⚠️ unverified") || strings.Count(markdown, "unverified") != 1 {
+		t.Errorf("Expected only the unsigned entry annotated\n%s", markdown)
+	}
+}
@@ -0,0 +1,213 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/semver"
+)
+
+// BackfillPair is one historical release: the changes from the previous tag to To
+type BackfillPair struct {
+	From string
+	To   string
+}
+
+// BackfillPairs orders a repository's tags oldest first and pairs each tag
+// with its predecessor. Version tags are ordered by semver, ignoring other
+// tags; without any, tags are ordered by commit date. With fromTag set the
+// history starts at that tag.
+func BackfillPairs(tags []provider.TagInfo, includePrereleases bool, fromTag string) ([]BackfillPair, error) {
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+	}
+
+	var ordered []string
+	if versions := semver.Sort(names, includePrereleases); len(versions) > 0 {
+		for i := len(versions) - 1; i >= 0; i-- {
+			ordered = append(ordered, versions[i].Original)
+		}
+	} else {
+		byDate := append([]provider.TagInfo(nil), tags...)
+		sort.SliceStable(byDate, func(i, j int) bool {
+			return byDate[i].CommitDate.Before(byDate[j].CommitDate)
+		})
+		for _, tag := range byDate {
+			ordered = append(ordered, tag.Name)
+		}
+	}
+
+	if fromTag != "" {
+		start := -1
+		for i, name := range ordered {
+			if name == fromTag {
+				start = i
+			}
+		}
+		if start < 0 {
+			return nil, fmt.Errorf("tag %s not found among the repository's release tags", fromTag)
+		}
+		ordered = ordered[start:]
+	}
+
+	var pairs []BackfillPair
+	for i := 1; i < len(ordered); i++ {
+		pairs = append(pairs, BackfillPair{From: ordered[i-1], To: ordered[i]})
+	}
+	return pairs, nil
+}
+
+// BackfillCheckpoint records the releases a backfill has generated, so an
+// interrupted or budget-limited run resumes where it stopped
+type BackfillCheckpoint struct {
+	Repo     string            `json:"repo"`
+	Sections map[string]string `json:"sections"` // Release tag → generated markdown
+	Usage    llm.Usage         `json:"usage"`    // LLM usage summed across runs
+}
+
+// LoadBackfillCheckpoint reads the checkpoint at path, or starts an empty one
+// when the file does not exist. A checkpoint of another repository is an error.
+func LoadBackfillCheckpoint(path, repo string) (*BackfillCheckpoint, error) {
+	checkpoint := &BackfillCheckpoint{Repo: repo, Sections: make(map[string]string)}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return checkpoint, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read checkpoint: %w", err)
+	}
+	if err := json.Unmarshal(data, checkpoint); err != nil {
+		return nil, fmt.Errorf("parse checkpoint %s: %w", path, err)
+	}
+	if checkpoint.Repo != repo {
+		return nil, fmt.Errorf("checkpoint %s belongs to %s, not %s", path, checkpoint.Repo, repo)
+	}
+	if checkpoint.Sections == nil {
+		checkpoint.Sections = make(map[string]string)
+	}
+	return checkpoint, nil
+}
+
+// Save writes the checkpoint to path, replacing the previous file atomically
+func (c *BackfillCheckpoint) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode checkpoint: %w", err)
+	}
+	// Write then rename so a crash never leaves a truncated file
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".checkpoint-*")
+	if err != nil {
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+	return nil
+}
+
+// AssembleBackfill combines the generated releases into one historical
+// changelog, newest first. Each release keeps its "Changelog: from → to"
+// heading one level down, so later --prepend runs recognize it as documented.
+func AssembleBackfill(repo string, pairs []BackfillPair, sections map[string]string) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("# Changelog: %s\n\n", repo))
+
+	first := true
+	for i := len(pairs) - 1; i >= 0; i-- {
+		section, ok := sections[pairs[i].To]
+		if !ok {
+			continue
+		}
+		if !first {
+			b.WriteString("---\n\n")
+		}
+		first = false
+		b.WriteString(strings.TrimRight(demoteHeadings(section), "\n") + "\n\n")
+	}
+	return b.String()
+}
+
+// AppendPairs returns the releases a rolling changelog is missing: every tag
+// pair after the newest version it documents, up to and including to. It
+// fails when the changelog documents none of the release tags or to is not
+// one of them; nothing is missing when to is already documented or older.
+func AppendPairs(tags []provider.TagInfo, documented map[string]bool, to string, includePrereleases bool) ([]BackfillPair, error) {
+	pairs, err := BackfillPairs(tags, includePrereleases, "")
+	if err != nil {
+		return nil, err
+	}
+	ordered := make([]string, 0, len(pairs)+1)
+	if len(pairs) > 0 {
+		ordered = append(ordered, pairs[0].From)
+	}
+	for _, pair := range pairs {
+		ordered = append(ordered, pair.To)
+	}
+
+	newest, target := -1, -1
+	for i, name := range ordered {
+		if IsDocumented(documented, name) {
+			newest = i
+		}
+		if name == to {
+			target = i
+		}
+	}
+	if target < 0 {
+		return nil, fmt.Errorf("tag %s not found among the repository's release tags", to)
+	}
+	if newest < 0 {
+		return nil, fmt.Errorf("the changelog documents none of the repository's release tags; use backfill or generate --prepend to start it")
+	}
+	if target <= newest {
+		return nil, nil
+	}
+	return pairs[newest:target], nil
+}
+
+// AppendReleases inserts generated release sections, newest first, above the
+// existing changelog. Under a document title such as backfill's "# Changelog:
+// owner/repo" the sections are nested one heading level down, like
+// AssembleBackfill; a changelog made of per-release H1 sections gets them at
+// the same level.
+func AppendReleases(existing string, pairs []BackfillPair, sections map[string]string) string {
+	title, body := splitTitle(existing)
+	nested := title != "" && len(DocumentedVersions(title)) == 0
+
+	var parts []string
+	for i := len(pairs) - 1; i >= 0; i-- {
+		section, ok := sections[pairs[i].To]
+		if !ok {
+			continue
+		}
+		if nested {
+			section = demoteHeadings(section)
+		}
+		parts = append(parts, strings.TrimSpace(section))
+	}
+	generated := strings.Join(parts, "\n\n---\n\n")
+
+	switch {
+	case strings.TrimSpace(existing) == "":
+		return generated + "\n"
+	case nested:
+		return title + "\n\n" + generated + "\n\n---\n\n" + strings.TrimLeft(body, "\n")
+	default:
+		return generated + "\n\n---\n\n" + existing
+	}
+}
@@ -0,0 +1,136 @@
+package generator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+func TestBackfillPairs(t *testing.T) {
+	tags := []provider.TagInfo{{Name: "v1.10.0"}, {Name: "v1.2.0"}, {Name: "nightly"}, {Name: "v2.0.0-rc.1"}, {Name: "v1.9.0"}}
+
+	pairs, err := BackfillPairs(tags, false, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []BackfillPair{{"v1.2.0", "v1.9.0"}, {"v1.9.0", "v1.10.0"}}
+	if len(pairs) != len(want) || pairs[0] != want[0] || pairs[1] != want[1] {
+		t.Errorf("BackfillPairs() = %v, want %v", pairs, want)
+	}
+
+	pairs, err = BackfillPairs(tags, true, "v1.9.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = []BackfillPair{{"v1.9.0", "v1.10.0"}, {"v1.10.0", "v2.0.0-rc.1"}}
+	if len(pairs) != len(want) || pairs[0] != want[0] || pairs[1] != want[1] {
+		t.Errorf("BackfillPairs(from v1.9.0) = %v, want %v", pairs, want)
+	}
+
+	if _, err := BackfillPairs(tags, false, "v0.1.0"); err == nil {
+		t.Error("Expected an error for an unknown --from-tag")
+	}
+
+	// Without version tags, tags are ordered by commit date
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	pairs, _ = BackfillPairs([]provider.TagInfo{{Name: "beta", CommitDate: day(9)}, {Name: "alpha", CommitDate: day(2)}}, false, "")
+	if len(pairs) != 1 || pairs[0] != (BackfillPair{"alpha", "beta"}) {
+		t.Errorf("Expected alpha..beta by commit date, got %v", pairs)
+	}
+}
+
+func TestBackfillCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "backfill.json")
+	checkpoint, err := LoadBackfillCheckpoint(path, "acme/api")
+	if err != nil || len(checkpoint.Sections) != 0 {
+		t.Fatalf("Expected an empty checkpoint for a missing file, got %v, %v", checkpoint, err)
+	}
+
+	checkpoint.Sections["v1.1.0"] = "# Changelog: v1.0.0 → v1.1.0\n"
+	checkpoint.Usage.Calls = 2
+	if err := checkpoint.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadBackfillCheckpoint(path, "acme/api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Sections["v1.1.0"] == "" || loaded.Usage.Calls != 2 {
+		t.Errorf("Checkpoint did not round-trip: %+v", loaded)
+	}
+	if _, err := LoadBackfillCheckpoint(path, "acme/web"); err == nil {
+		t.Error("Expected an error loading another repository's checkpoint")
+	}
+}
+
+func TestAssembleBackfill(t *testing.T) {
+	pairs := []BackfillPair{{"v1.0.0", "v1.1.0"}, {"v1.1.0", "v1.2.0"}, {"v1.2.0", "v1.3.0"}}
+	sections := map[string]string{
+		"v1.1.0": "# Changelog: v1.0.0 → v1.1.0\n\n## 🚀 Features\n\n- Old\n",
+		"v1.2.0": "# Changelog: v1.1.0 → v1.2.0\n\n## 🐛 Bug Fixes\n\n- New\n",
+	}
+
+	markdown := AssembleBackfill("acme/api", pairs, sections)
+	want := "# Changelog: acme/api\n\n" +
+		"## Changelog: v1.1.0 → v1.2.0\n\n### 🐛 Bug Fixes\n\n- New\n\n" +
+		"---\n\n" +
+		"## Changelog: v1.0.0 → v1.1.0\n\n### 🚀 Features\n\n- Old\n\n"
+	if markdown != want {
+		t.Errorf("Unexpected backfill:\n%s\nwant:\n%s", markdown, want)
+	}
+
+	documented := DocumentedVersions(markdown)
+	if !documented["v1.1.0"] || !documented["v1.2.0"] || documented["v1.3.0"] {
+		t.Errorf("Expected generated releases to be documented, got %v", documented)
+	}
+	if strings.Contains(markdown, "v1.3.0") {
+		t.Error("Ungenerated releases should be left out")
+	}
+}
+
+func TestAppendPairs(t *testing.T) {
+	tags := []provider.TagInfo{{Name: "v1.0.0"}, {Name: "v1.1.0"}, {Name: "v1.2.0"}, {Name: "v1.3.0"}}
+
+	pairs, err := AppendPairs(tags, map[string]bool{"1.0.0": true, "v1.1.0": true}, "v1.3.0", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []BackfillPair{{"v1.1.0", "v1.2.0"}, {"v1.2.0", "v1.3.0"}}
+	if len(pairs) != len(want) || pairs[0] != want[0] || pairs[1] != want[1] {
+		t.Errorf("AppendPairs() = %v, want %v", pairs, want)
+	}
+
+	if pairs, err := AppendPairs(tags, map[string]bool{"v1.3.0": true}, "v1.2.0", false); err != nil || len(pairs) != 0 {
+		t.Errorf("Expected nothing to append when the target is documented, got %v, %v", pairs, err)
+	}
+	if _, err := AppendPairs(tags, map[string]bool{}, "v1.3.0", false); err == nil {
+		t.Error("Expected an error for a changelog documenting no release")
+	}
+	if _, err := AppendPairs(tags, map[string]bool{"v1.0.0": true}, "v9.0.0", false); err == nil {
+		t.Error("Expected an error for an unknown target tag")
+	}
+}
+
+func TestAppendReleases(t *testing.T) {
+	pairs := []BackfillPair{{"v1.1.0", "v1.2.0"}, {"v1.2.0", "v1.3.0"}}
+	sections := map[string]string{
+		"v1.2.0": "# Changelog: v1.1.0 → v1.2.0\n\n- two\n",
+		"v1.3.0": "# Changelog: v1.2.0 → v1.3.0\n\n- three\n",
+	}
+
+	existing := "# Changelog: acme/api\n\n## Changelog: v1.0.0 → v1.1.0\n\n- one\n"
+	got := AppendReleases(existing, pairs, sections)
+	want := "# Changelog: acme/api\n\n## Changelog: v1.2.0 → v1.3.0\n\n- three\n\n---\n\n## Changelog: v1.1.0 → v1.2.0\n\n- two\n\n---\n\n## Changelog: v1.0.0 → v1.1.0\n\n- one\n"
+	if got != want {
+		t.Errorf("nested:\ngot  %q\nwant %q", got, want)
+	}
+
+	existing = "# Changelog: v1.0.0 → v1.1.0\n\n- one\n"
+	got = AppendReleases(existing, pairs, sections)
+	if !strings.HasPrefix(got, "# Changelog: v1.2.0 → v1.3.0") || !strings.HasSuffix(got, "---\n\n"+existing) {
+		t.Errorf("flat:\n%s", got)
+	}
+}
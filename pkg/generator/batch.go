@@ -0,0 +1,132 @@
+package generator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+)
+
+// batchClient is implemented by LLM clients that can run PR changelog
+// requests through the OpenAI Batch API
+type batchClient interface {
+	SubmitBatch(ctx context.Context, items []llm.BatchItem) (string, error)
+	BatchStatus(ctx context.Context, id string) (llm.BatchStatus, error)
+	BatchResults(ctx context.Context, id string) (map[string]*llm.PRChangelogResponse, llm.Usage, error)
+}
+
+// TimelineBatch records a submitted Batch API job of a timeline, so a later
+// run can collect its results and assemble the changelog
+type TimelineBatch struct {
+	ID          string    `json:"id"`
+	Repo        string    `json:"repo"`
+	FromDate    time.Time `json:"from_date"`
+	ToDate      time.Time `json:"to_date"`
+	Releases    []string  `json:"releases"` // Release (ToRef) of each request
+	SubmittedAt time.Time `json:"submitted_at"`
+	Format      string    `json:"format,omitempty"` // Output format of the submitting run, reused by batch collect
+	OutputPath  string    `json:"output,omitempty"` // Output path of the submitting run, reused by batch collect
+}
+
+// LoadTimelineBatch reads the batch file at path, returning nil when there is none
+func LoadTimelineBatch(path string) (*TimelineBatch, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read batch file: %w", err)
+	}
+	var batch TimelineBatch
+	if err := json.Unmarshal(data, &batch); err != nil {
+		return nil, fmt.Errorf("parse batch file %s: %w", path, err)
+	}
+	return &batch, nil
+}
+
+// Save writes the batch file to path
+func (b *TimelineBatch) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode batch file: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write batch file: %w", err)
+	}
+	return nil
+}
+
+// Matches reports whether the batch was submitted for repo and date range
+func (b *TimelineBatch) Matches(repo string, from, to time.Time) bool {
+	return b.Repo == repo && b.FromDate.Equal(from) && b.ToDate.Equal(to)
+}
+
+// SubmitTimelineBatch builds the PR changelog request of every release between
+// from and to, as GenerateTimeline would, and submits them as one Batch API
+// job instead of calling the model
+func (g *Generator) SubmitTimelineBatch(ctx context.Context, from, to time.Time) (*TimelineBatch, error) {
+	client, ok := g.llmClient.(batchClient)
+	if !ok {
+		return nil, fmt.Errorf("the LLM client does not support the Batch API")
+	}
+	g.resetRun()
+	if g.config.ModelRouting {
+		g.warn("--model-routing does not apply to batches, which run on one model; every release uses %s", g.config.OpenAIModel)
+	}
+
+	releases, err := g.timelineReleases(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	batch := &TimelineBatch{
+		Repo:     g.config.RepoOwner + "/" + g.config.RepoName,
+		FromDate: from,
+		ToDate:   to,
+	}
+	var items []llm.BatchItem
+	for _, release := range releases {
+		if len(release.PullRequests) == 0 {
+			continue // No LLM call for releases without PRs
+		}
+		items = append(items, llm.BatchItem{ID: release.ToRef, Request: g.prChangelogRequest(ctx, release)})
+		batch.Releases = append(batch.Releases, release.ToRef)
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("no releases with pull requests between %s and %s", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	}
+
+	if batch.ID, err = client.SubmitBatch(ctx, items); err != nil {
+		return nil, fmt.Errorf("submit batch: %w", err)
+	}
+	batch.SubmittedAt = time.Now().UTC().Truncate(time.Second)
+	return batch, nil
+}
+
+// BatchStatus looks up the progress of a submitted Batch API job
+func (g *Generator) BatchStatus(ctx context.Context, id string) (llm.BatchStatus, error) {
+	client, ok := g.llmClient.(batchClient)
+	if !ok {
+		return llm.BatchStatus{}, fmt.Errorf("the LLM client does not support the Batch API")
+	}
+	return client.BatchStatus(ctx, id)
+}
+
+// UseBatchResults downloads the responses of a finished Batch API job, which
+// the next GenerateTimeline uses instead of calling the model. Releases the
+// batch has no response for are generated live.
+func (g *Generator) UseBatchResults(ctx context.Context, id string) error {
+	client, ok := g.llmClient.(batchClient)
+	if !ok {
+		return fmt.Errorf("the LLM client does not support the Batch API")
+	}
+	results, usage, err := client.BatchResults(ctx, id)
+	if err != nil {
+		return fmt.Errorf("collect batch %s: %w", id, err)
+	}
+	logger.Info("collected batch results", "id", id, "responses", len(results))
+	g.batch, g.batchUsage = results, usage
+	return nil
+}
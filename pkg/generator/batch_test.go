@@ -0,0 +1,31 @@
+package generator
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTimelineBatchFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "batch.json")
+	if batch, err := LoadTimelineBatch(path); batch != nil || err != nil {
+		t.Fatalf("missing file = %v, %v; want nil, nil", batch, err)
+	}
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+	saved := &TimelineBatch{ID: "batch_1", Repo: "acme/app", FromDate: from, ToDate: to, Releases: []string{"v1.0.0"}, Format: "json", OutputPath: "history.json"}
+	if err := saved.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadTimelineBatch(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.ID != "batch_1" || loaded.Format != "json" || loaded.OutputPath != "history.json" || !loaded.Matches("acme/app", from, to) {
+		t.Errorf("loaded = %+v", loaded)
+	}
+	if loaded.Matches("acme/app", from, to.AddDate(0, 0, 1)) || loaded.Matches("acme/web", from, to) {
+		t.Error("batch matched another run")
+	}
+}
@@ -0,0 +1,133 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+)
+
+// DropCategory as the fallback category drops entries in unmapped categories
+const DropCategory = "drop"
+
+// DefaultCategoryAliases maps categories the model commonly invents onto the
+// standard taxonomy. Keys are lowercase; config aliases take precedence.
+var DefaultCategoryAliases = map[string]string{
+	"feature":         "Features",
+	"new features":    "Features",
+	"enhancements":    "Improvements",
+	"enhancement":     "Improvements",
+	"performance":     "Improvements",
+	"ui":              "Improvements",
+	"ux":              "Improvements",
+	"fixes":           "Bug Fixes",
+	"bug fix":         "Bug Fixes",
+	"bugfixes":        "Bug Fixes",
+	"breaking":        "Breaking Changes",
+	"docs":            "Documentation",
+	"misc":            "Internal",
+	"miscellaneous":   "Internal",
+	"chore":           "Internal",
+	"chores":          "Internal",
+	"refactoring":     "Internal",
+	"refactor":        "Internal",
+	"dependencies":    "Internal",
+	"build":           "Internal",
+	"ci":              "Internal",
+	"tests":           "Internal",
+	"testing":         "Internal",
+	"maintenance":     "Internal",
+	"other":           "Internal",
+	"other changes":   "Internal",
+	"internal change": "Internal",
+}
+
+// CategoryRemap records how an out-of-taxonomy category was handled
+type CategoryRemap struct {
+	From    string
+	To      string // Empty when the entries were dropped
+	Entries int
+}
+
+// NormalizeCategories moves entries from categories outside CategoryOrder into
+// taxonomy categories. Aliases (case-insensitive, config first, then
+// DefaultCategoryAliases) are tried first; unmapped categories go to fallback,
+// or are dropped when fallback is DropCategory.
+func NormalizeCategories(categories map[string][]llm.ChangelogEntry, aliases map[string]string, fallback string) []CategoryRemap {
+	known := make(map[string]string)
+	for _, category := range CategoryOrder {
+		known[strings.ToLower(category)] = category
+	}
+
+	// Process unknown categories in a stable order
+	var unknown []string
+	for category := range categories {
+		if _, ok := known[strings.ToLower(category)]; !ok || known[strings.ToLower(category)] != category {
+			unknown = append(unknown, category)
+		}
+	}
+	sort.Strings(unknown)
+
+	var remaps []CategoryRemap
+	for _, category := range unknown {
+		entries := categories[category]
+		delete(categories, category)
+
+		target := resolveCategory(category, known, aliases, fallback)
+		remap := CategoryRemap{From: category, Entries: len(entries)}
+		if target != DropCategory {
+			categories[target] = append(categories[target], entries...)
+			remap.To = target
+		}
+		remaps = append(remaps, remap)
+	}
+
+	return remaps
+}
+
+// resolveCategory maps a category name onto the taxonomy
+func resolveCategory(category string, known, aliases map[string]string, fallback string) string {
+	key := strings.ToLower(strings.TrimSpace(category))
+
+	// Case or whitespace variant of a known category ("bug fixes")
+	if canonical, ok := known[key]; ok {
+		return canonical
+	}
+	for alias, target := range aliases {
+		if strings.ToLower(alias) == key {
+			return canonicalCategory(target, known)
+		}
+	}
+	if target, ok := DefaultCategoryAliases[key]; ok {
+		return target
+	}
+	return canonicalCategory(fallback, known)
+}
+
+// canonicalCategory returns the taxonomy spelling of a configured target,
+// passing DropCategory through
+func canonicalCategory(target string, known map[string]string) string {
+	if strings.EqualFold(target, DropCategory) {
+		return DropCategory
+	}
+	if canonical, ok := known[strings.ToLower(target)]; ok {
+		return canonical
+	}
+	return target
+}
+
+// ValidateCategoryTarget checks that a configured alias or fallback target is
+// part of the taxonomy (or DropCategory)
+func ValidateCategoryTarget(target string) error {
+	if strings.EqualFold(target, DropCategory) {
+		return nil
+	}
+	for _, category := range CategoryOrder {
+		if strings.EqualFold(category, target) {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown category %q (expected one of %s, or %q)",
+		target, strings.Join(CategoryOrder, ", "), DropCategory)
+}
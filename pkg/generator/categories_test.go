@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+)
+
+func TestNormalizeCategories(t *testing.T) {
+	categories := map[string][]llm.ChangelogEntry{
+		"Features":  {{SHA: "a1", Title: "Add export"}},
+		"bug fixes": {{SHA: "b1", Title: "Fix crash"}},
+		"Chores":    {{SHA: "c1", Title: "Bump deps"}},
+		"UI":        {{SHA: "d1", Title: "New sidebar"}},
+		"Telemetry": {{SHA: "e1", Title: "Add metrics"}},
+	}
+	aliases := map[string]string{"ui": "features"}
+
+	remaps := NormalizeCategories(categories, aliases, "Internal")
+
+	if len(remaps) != 4 {
+		t.Errorf("Expected 4 remaps, got %+v", remaps)
+	}
+	if len(categories["Features"]) != 2 {
+		t.Errorf("Expected UI entry aliased into Features, got %v", categories["Features"])
+	}
+	if len(categories["Bug Fixes"]) != 1 {
+		t.Errorf("Expected case variant folded into Bug Fixes, got %v", categories["Bug Fixes"])
+	}
+	if len(categories["Internal"]) != 2 {
+		t.Errorf("Expected Chores and unmapped Telemetry in Internal, got %v", categories["Internal"])
+	}
+	for _, invented := range []string{"bug fixes", "Chores", "UI", "Telemetry"} {
+		if _, ok := categories[invented]; ok {
+			t.Errorf("Expected category %q to be removed", invented)
+		}
+	}
+}
+
+func TestNormalizeCategoriesDrop(t *testing.T) {
+	categories := map[string][]llm.ChangelogEntry{
+		"Telemetry": {{SHA: "e1", Title: "Add metrics"}},
+	}
+
+	remaps := NormalizeCategories(categories, nil, DropCategory)
+
+	if len(categories) != 0 {
+		t.Errorf("Expected unmapped category to be dropped, got %v", categories)
+	}
+	if len(remaps) != 1 || remaps[0].To != "" || remaps[0].Entries != 1 {
+		t.Errorf("Unexpected remaps: %+v", remaps)
+	}
+}
+
+func TestValidateCategoryTarget(t *testing.T) {
+	for _, target := range []string{"Internal", "bug fixes", "drop"} {
+		if err := ValidateCategoryTarget(target); err != nil {
+			t.Errorf("Expected %q to be valid, got %v", target, err)
+		}
+	}
+	if err := ValidateCategoryTarget("Misc"); err == nil {
+		t.Error("Expected Misc to be rejected as a target")
+	}
+}
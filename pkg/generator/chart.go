@@ -0,0 +1,95 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// chartBarWidth is the length of the longest bar in the ASCII chart
+const chartBarWidth = 30
+
+// FormatActivityChart renders release cadence and commit volume across a
+// timeline: a mermaid gantt chart with one bar per release period, or an
+// ASCII bar chart of commits per release for renderers without mermaid. It
+// returns "" for an empty timeline or style.
+func FormatActivityChart(timeline *TimelineChangelog, style string) string {
+	if len(timeline.Releases) == 0 {
+		return ""
+	}
+	switch style {
+	case "mermaid":
+		return formatMermaidChart(timeline)
+	case "ascii":
+		return formatASCIIChart(timeline)
+	default:
+		return ""
+	}
+}
+
+// formatMermaidChart draws each release as a gantt task spanning the days
+// since the previous release, labelled with its commit count
+func formatMermaidChart(timeline *TimelineChangelog) string {
+	var sb strings.Builder
+	sb.WriteString("```mermaid\ngantt\n")
+	sb.WriteString("    title Release activity\n")
+	sb.WriteString("    dateFormat YYYY-MM-DD\n")
+	sb.WriteString("    axisFormat %b %d\n")
+	sb.WriteString("    section Releases\n")
+
+	for _, release := range timeline.Releases {
+		// The first period can start long before the window; clip it to the timeline
+		start := release.FromDate
+		if start.IsZero() || start.Before(timeline.FromDate) {
+			start = timeline.FromDate
+		}
+		end := release.ToDate
+		if !end.After(start) {
+			end = start.Add(24 * time.Hour)
+		}
+		// Colons and hashes end a gantt task name
+		name := strings.NewReplacer(":", " ", "#", "").Replace(release.ToRef)
+		sb.WriteString(fmt.Sprintf("    %s (%s) :done, %s, %s\n",
+			name, pluralize(releaseCommitCount(release), "commit"),
+			start.Format("2006-01-02"), end.Format("2006-01-02")))
+	}
+	sb.WriteString("```\n\n")
+	return sb.String()
+}
+
+// formatASCIIChart draws one bar per release, scaled to the busiest release,
+// followed by its commit count and the gap since the previous release
+func formatASCIIChart(timeline *TimelineChangelog) string {
+	nameWidth, most := 0, 0
+	for _, release := range timeline.Releases {
+		nameWidth = max(nameWidth, len(release.ToRef))
+		most = max(most, releaseCommitCount(release))
+	}
+
+	var sb strings.Builder
+	sb.WriteString("```text\n")
+	for _, release := range timeline.Releases {
+		commits := releaseCommitCount(release)
+		width := 0
+		if most > 0 {
+			width = (commits*chartBarWidth + most - 1) / most
+		}
+		gap := ""
+		if !release.FromDate.IsZero() && release.ToDate.After(release.FromDate) {
+			gap = fmt.Sprintf(" (+%s)", formatHours(release.ToDate.Sub(release.FromDate).Hours()))
+		}
+		sb.WriteString(fmt.Sprintf("%-*s  %s  %-*s %d%s\n",
+			nameWidth, release.ToRef, release.ToDate.Format("Jan 02"),
+			chartBarWidth, strings.Repeat("█", width), commits, gap))
+	}
+	sb.WriteString("```\n\n")
+	return sb.String()
+}
+
+// releaseCommitCount returns the number of commits in a release period
+func releaseCommitCount(release ReleaseChangelog) int {
+	if release.Stats != nil {
+		return release.Stats.Commits
+	}
+	return len(release.Commits)
+}
@@ -0,0 +1,52 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+func TestFormatActivityChart(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	timeline := &TimelineChangelog{
+		FromDate: day(1),
+		ToDate:   day(31),
+		Releases: []ReleaseChangelog{
+			{FromRef: "v0.9.0", ToRef: "v1.0.0", FromDate: day(1).AddDate(0, -1, 0), ToDate: day(10),
+				Commits: make([]provider.CommitData, 4)},
+			{FromRef: "v1.0.0", ToRef: "v1.0.1", FromDate: day(10), ToDate: day(10),
+				Commits: make([]provider.CommitData, 1)},
+			{FromRef: "v1.0.1", ToRef: "v1.1.0", FromDate: day(10), ToDate: day(24),
+				Stats: &ReleaseComparison{Commits: 8}},
+		},
+	}
+
+	mermaid := FormatActivityChart(timeline, "mermaid")
+	for _, want := range []string{
+		"```mermaid\ngantt\n",
+		"    v1.0.0 (4 commits) :done, 2024-01-01, 2024-01-10\n", // Clipped to the timeline start
+		"    v1.0.1 (1 commit) :done, 2024-01-10, 2024-01-11\n",  // Same-day releases span a day
+		"    v1.1.0 (8 commits) :done, 2024-01-10, 2024-01-24\n",
+	} {
+		if !strings.Contains(mermaid, want) {
+			t.Errorf("Expected mermaid chart to contain %q\nGot:\n%s", want, mermaid)
+		}
+	}
+
+	ascii := FormatActivityChart(timeline, "ascii")
+	for _, want := range []string{
+		"v1.0.0  Jan 10  " + strings.Repeat("█", 15) + strings.Repeat(" ", 15) + " 4 (+40d)\n",
+		"v1.0.1  Jan 10  " + strings.Repeat("█", 4) + strings.Repeat(" ", 26) + " 1\n",
+		"v1.1.0  Jan 24  " + strings.Repeat("█", 30) + " 8 (+14d)\n",
+	} {
+		if !strings.Contains(ascii, want) {
+			t.Errorf("Expected ASCII chart to contain %q\nGot:\n%s", want, ascii)
+		}
+	}
+
+	if FormatActivityChart(timeline, "") != "" || FormatActivityChart(&TimelineChangelog{}, "mermaid") != "" {
+		t.Error("Expected no chart without a style or releases")
+	}
+}
@@ -0,0 +1,87 @@
+package generator
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+)
+
+const (
+	clusterMinCommits = 3 // Smallest group worth presenting as one subsystem change
+	clusterAreaDepth  = 2 // Directory levels that identify a subsystem, e.g. pkg/auth
+)
+
+// ClusterCommits groups commits that mostly touch the same subsystem, as
+// identified by a shared directory prefix, so the model can describe them as
+// one higher-level change. Commits spread across areas are left unclustered.
+// Clusters are ordered largest first.
+func ClusterCommits(commits []llm.CommitInfo) []llm.CommitCluster {
+	byArea := make(map[string][]string)
+	for _, commit := range commits {
+		if area := commitArea(commit.FilesChanged); area != "" {
+			byArea[area] = append(byArea[area], commit.SHA)
+		}
+	}
+
+	var clusters []llm.CommitCluster
+	for area, shas := range byArea {
+		if len(shas) >= clusterMinCommits {
+			clusters = append(clusters, llm.CommitCluster{Area: area, SHAs: shas})
+		}
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		if len(clusters[i].SHAs) != len(clusters[j].SHAs) {
+			return len(clusters[i].SHAs) > len(clusters[j].SHAs)
+		}
+		return clusters[i].Area < clusters[j].Area
+	})
+	return clusters
+}
+
+// clusters returns the commit groups to present when --cluster-commits is on
+func (g *Generator) clusters(commits []llm.CommitInfo) []llm.CommitCluster {
+	if !g.config.ClusterCommits {
+		return nil
+	}
+	clusters := ClusterCommits(commits)
+	logger.Info("clustered related commits", "clusters", len(clusters))
+	return clusters
+}
+
+// commitArea returns the directory prefix shared by more than half of a
+// commit's files, or "" when there is none
+func commitArea(files []string) string {
+	counts := make(map[string]int)
+	for _, file := range files {
+		if area := fileArea(file); area != "" {
+			counts[area]++
+		}
+	}
+
+	best, bestCount := "", 0
+	for area, count := range counts {
+		if count > bestCount || (count == bestCount && area < best) {
+			best, bestCount = area, count
+		}
+	}
+	if bestCount*2 <= len(files) {
+		return ""
+	}
+	return best
+}
+
+// fileArea returns the first clusterAreaDepth directories of a file path;
+// files at the repository root belong to no area
+func fileArea(file string) string {
+	dir := path.Dir(strings.TrimPrefix(file, "/"))
+	if dir == "." {
+		return ""
+	}
+	parts := strings.Split(dir, "/")
+	if len(parts) > clusterAreaDepth {
+		parts = parts[:clusterAreaDepth]
+	}
+	return strings.Join(parts, "/")
+}
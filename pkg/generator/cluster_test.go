@@ -0,0 +1,38 @@
+package generator
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+)
+
+func TestClusterCommits(t *testing.T) {
+	commits := []llm.CommitInfo{
+		{SHA: "a1", FilesChanged: []string{"pkg/auth/token.go", "pkg/auth/token_test.go"}},
+		{SHA: "a2", FilesChanged: []string{"pkg/auth/oauth/client.go"}},
+		{SHA: "b1", FilesChanged: []string{"web/src/App.tsx", "web/src/theme.ts"}},
+		{SHA: "a3", FilesChanged: []string{"pkg/auth/session.go", "pkg/auth/session_test.go", "README.md"}},
+		{SHA: "x1", FilesChanged: []string{"pkg/auth/login.go", "web/src/Login.tsx"}}, // Cross-cutting
+		{SHA: "r1", FilesChanged: []string{"go.mod", "go.sum"}},
+		{SHA: "b2", FilesChanged: []string{"web/src/index.ts"}},
+	}
+
+	got := ClusterCommits(commits)
+	want := []llm.CommitCluster{{Area: "pkg/auth", SHAs: []string{"a1", "a2", "a3"}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ClusterCommits() = %+v, want %+v", got, want)
+	}
+}
+
+func TestFileArea(t *testing.T) {
+	for file, want := range map[string]string{
+		"pkg/auth/oauth/client.go": "pkg/auth",
+		"docs/guide.md":            "docs",
+		"Makefile":                 "",
+	} {
+		if got := fileArea(file); got != want {
+			t.Errorf("fileArea(%q) = %q, want %q", file, got, want)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package generator
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+var (
+	// shaLineRe matches a line starting with a commit SHA, as printed by
+	// `git rev-list`, `git log --format=%H`, or `git log --oneline`
+	shaLineRe = regexp.MustCompile(`^([0-9a-f]{7,40})(?:\s|$)`)
+	// logCommitRe matches the first line of a commit in `git log` output
+	logCommitRe = regexp.MustCompile(`^commit ([0-9a-f]{7,40})\b`)
+	// numstatRe matches a `git log --numstat` line: additions, deletions, path
+	numstatRe = regexp.MustCompile(`^(\d+|-)\t(\d+|-)\t(.+)$`)
+)
+
+// logDateLayouts are the author date formats of `git log` and its --date options
+var logDateLayouts = []string{
+	"Mon Jan 2 15:04:05 2006 -0700", // default
+	"2006-01-02 15:04:05 -0700",     // --date=iso
+	time.RFC3339,                    // --date=iso-strict
+	time.RFC1123Z,                   // --date=rfc
+}
+
+// ReadCommitList reads commits for --from-stdin: either a list of SHAs, one
+// per line (anything after the SHA, like `git log --oneline` subjects, is
+// ignored), or a `git log` dump in the default format, optionally with
+// --numstat. A SHA list returns shas for fetching from the provider; a dump
+// returns the parsed commits, oldest first.
+func ReadCommitList(r io.Reader) (shas []string, commits []llm.CommitInfo, err error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("read commit list: %w", err)
+	}
+	text := strings.TrimSpace(string(data))
+	if text == "" {
+		return nil, nil, nil
+	}
+
+	if logCommitRe.MatchString(text) {
+		commits, err := parseGitLog(text)
+		return nil, commits, err
+	}
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		m := shaLineRe.FindStringSubmatch(line)
+		if m == nil {
+			return nil, nil, fmt.Errorf("line %d: expected a commit SHA or `git log` output, got %q", i+1, line)
+		}
+		shas = append(shas, m[1])
+	}
+	return shas, nil, nil
+}
+
+// parseGitLog parses `git log` output in the default (medium) format
+func parseGitLog(text string) ([]llm.CommitInfo, error) {
+	var commits []llm.CommitInfo
+	var current *llm.CommitInfo
+	var message []string
+	var additions, deletions int
+
+	flush := func() {
+		if current == nil {
+			return
+		}
+		current.Message = strings.TrimSpace(strings.Join(message, "\n"))
+		current.Stats = fmt.Sprintf("+%d/-%d", additions, deletions)
+		commits = append(commits, *current)
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := logCommitRe.FindStringSubmatch(line); m != nil {
+			flush()
+			current = &llm.CommitInfo{SHA: m[1]}
+			message, additions, deletions = nil, 0, 0
+			continue
+		}
+		if current == nil {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(line, "    "):
+			message = append(message, strings.TrimPrefix(line, "    "))
+		case line == "":
+			// Blank lines within the message are not indented
+			if len(message) > 0 {
+				message = append(message, "")
+			}
+		case strings.HasPrefix(line, "Author:"):
+			name, _, _ := strings.Cut(strings.TrimSpace(strings.TrimPrefix(line, "Author:")), "<")
+			current.Author = strings.TrimSpace(name)
+		case strings.HasPrefix(line, "Date:") || strings.HasPrefix(line, "AuthorDate:"):
+			_, value, _ := strings.Cut(line, ":")
+			current.Date = parseLogDate(strings.TrimSpace(value))
+		case numstatRe.MatchString(line):
+			m := numstatRe.FindStringSubmatch(line)
+			// Binary files show "-" for both counts
+			added, _ := strconv.Atoi(m[1])
+			deleted, _ := strconv.Atoi(m[2])
+			additions += added
+			deletions += deleted
+			current.FilesChanged = append(current.FilesChanged, m[3])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read git log: %w", err)
+	}
+	flush()
+
+	for _, commit := range commits {
+		if commit.Message == "" {
+			return nil, fmt.Errorf("commit %s: missing message", commit.SHA)
+		}
+	}
+	// git log lists newest first
+	sort.SliceStable(commits, func(i, j int) bool {
+		return commits[i].Date.Before(commits[j].Date)
+	})
+	return commits, nil
+}
+
+// parseLogDate parses a git log date, returning the zero time for unknown formats
+func parseLogDate(value string) time.Time {
+	for _, layout := range logDateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date
+		}
+	}
+	return time.Time{}
+}
+
+// commitFetcher is implemented by providers that fetch single commits by SHA
+type commitFetcher interface {
+	GetCommitDetails(ctx context.Context, sha string) (*provider.CommitData, error)
+}
+
+// SetCommitSHAs makes Generate and DryRun fetch exactly these commits by SHA
+// instead of enumerating the from..to range, which then only labels the output
+func (g *Generator) SetCommitSHAs(shas []string) {
+	g.commitSHAs = shas
+}
+
+// fetchCommits fetches the commits of a range, or the commits set with
+// SetCommitSHAs ordered oldest first
+func (g *Generator) fetchCommits(ctx context.Context, from, to string) ([]provider.CommitData, error) {
+	if len(g.commitSHAs) == 0 {
+		return g.provider.GetCommitRange(ctx, from, to)
+	}
+	fetcher, ok := g.provider.(commitFetcher)
+	if !ok {
+		return nil, fmt.Errorf("this provider cannot fetch commits by SHA")
+	}
+
+	commits := make([]provider.CommitData, 0, len(g.commitSHAs))
+	for _, sha := range g.commitSHAs {
+		commit, err := fetcher.GetCommitDetails(ctx, sha)
+		if err != nil {
+			return nil, fmt.Errorf("fetch commit %s: %w", sha, err)
+		}
+		commits = append(commits, *commit)
+	}
+	sort.SliceStable(commits, func(i, j int) bool {
+		return commits[i].Date.Before(commits[j].Date)
+	})
+	return commits, nil
+}
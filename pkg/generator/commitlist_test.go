@@ -0,0 +1,61 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadCommitListSHAs(t *testing.T) {
+	input := "\n3f2a9c1d Add retry support\nabc1234\n\n"
+	shas, commits, err := ReadCommitList(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if commits != nil || len(shas) != 2 || shas[0] != "3f2a9c1d" || shas[1] != "abc1234" {
+		t.Errorf("Expected two SHAs, got %v, %v", shas, commits)
+	}
+
+	if _, _, err := ReadCommitList(strings.NewReader("abc1234\nnot a sha\n")); err == nil {
+		t.Error("Expected an error for a line without a SHA")
+	}
+}
+
+func TestReadCommitListGitLog(t *testing.T) {
+	input := `commit 2222222222222222222222222222222222222222
+Author: Bob Builder <bob@example.com>
+Date:   Tue Mar 5 09:30:00 2024 +0100
+
+    Fix upload timeout
+
+    The client now retries once.
+
+12	3	src/upload.go
+-	-	assets/logo.png
+
+commit 1111111111111111111111111111111111111111
+Merge: aaaaaaa bbbbbbb
+Author: Alice <alice@example.com>
+Date:   Mon Mar 4 10:00:00 2024 +0000
+
+    Add retry support
+`
+	shas, commits, err := ReadCommitList(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if shas != nil || len(commits) != 2 {
+		t.Fatalf("Expected two parsed commits, got %v, %v", shas, commits)
+	}
+
+	// Reordered oldest first
+	first, second := commits[0], commits[1]
+	if first.SHA != "1111111111111111111111111111111111111111" || first.Author != "Alice" || first.Message != "Add retry support" {
+		t.Errorf("Unexpected first commit: %+v", first)
+	}
+	if second.Author != "Bob Builder" || second.Message != "Fix upload timeout\n\nThe client now retries once." {
+		t.Errorf("Unexpected second commit: %+v", second)
+	}
+	if second.Date.IsZero() || second.Stats != "+12/-3" || len(second.FilesChanged) != 2 || second.FilesChanged[1] != "assets/logo.png" {
+		t.Errorf("Expected date, numstat files, and line counts, got %+v", second)
+	}
+}
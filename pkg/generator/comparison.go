@@ -0,0 +1,74 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+// ReleaseComparison compares a release with the previous one
+type ReleaseComparison struct {
+	Commits           int     `json:"commits"`
+	PullRequests      int     `json:"pull_requests"`
+	Contributors      int     `json:"contributors"`
+	FilesChanged      int     `json:"files_changed"` // Distinct files; 0 when file lists were not fetched
+	Additions         int     `json:"additions"`
+	Deletions         int     `json:"deletions"`
+	PreviousRelease   string  `json:"previous_release"`
+	DaysSincePrevious float64 `json:"days_since_previous"`
+}
+
+// CompareRelease summarizes a release from the commits and pull
+// requests already fetched for it
+func CompareRelease(release provider.TimelineRelease) *ReleaseComparison {
+	stats := &ReleaseComparison{
+		Commits:         max(release.CommitCount, len(release.Commits)),
+		PullRequests:    len(release.PullRequests),
+		PreviousRelease: release.FromRef,
+	}
+
+	authors := make(map[string]bool)
+	files := make(map[string]bool)
+	for _, commit := range release.Commits {
+		if commit.Author != "" {
+			authors[commit.Author] = true
+		}
+		for _, coAuthor := range commit.CoAuthors {
+			authors[coAuthor] = true
+		}
+		for _, file := range commit.FilesChanged {
+			files[file.Filename] = true
+		}
+		stats.Additions += commit.Stats.Additions
+		stats.Deletions += commit.Stats.Deletions
+	}
+	stats.Contributors = len(authors)
+	stats.FilesChanged = len(files)
+
+	if !release.FromDate.IsZero() && release.ToDate.After(release.FromDate) {
+		stats.DaysSincePrevious = release.ToDate.Sub(release.FromDate).Hours() / 24
+	}
+	return stats
+}
+
+// FormatReleaseComparison renders the stats as a one-line header:
+// "📊 12 commits · 4 PRs · 3 contributors · 27 files changed · +340/-120 lines · 6d since v1.1.0"
+func FormatReleaseComparison(stats *ReleaseComparison) string {
+	if stats == nil {
+		return ""
+	}
+	parts := []string{
+		pluralize(stats.Commits, "commit"),
+		pluralize(stats.PullRequests, "PR"),
+		pluralize(stats.Contributors, "contributor"),
+	}
+	if stats.FilesChanged > 0 {
+		parts = append(parts, pluralize(stats.FilesChanged, "file")+" changed")
+	}
+	parts = append(parts, fmt.Sprintf("+%d/-%d lines", stats.Additions, stats.Deletions))
+	if stats.PreviousRelease != "" {
+		parts = append(parts, fmt.Sprintf("%s since %s", formatHours(stats.DaysSincePrevious*24), stats.PreviousRelease))
+	}
+	return "📊 " + strings.Join(parts, " · ") + "\n\n"
+}
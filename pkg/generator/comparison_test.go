@@ -0,0 +1,49 @@
+package generator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+func TestCompareRelease(t *testing.T) {
+	release := provider.TimelineRelease{
+		FromRef:     "v1.1.0",
+		ToRef:       "v1.2.0",
+		FromDate:    time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		ToDate:      time.Date(2024, 3, 7, 0, 0, 0, 0, time.UTC),
+		CommitCount: 3,
+		Commits: []provider.CommitData{
+			{Author: "alice", Stats: provider.CommitStats{Additions: 10, Deletions: 2},
+				FilesChanged: []provider.FileChange{{Filename: "a.go"}, {Filename: "b.go"}}},
+			{Author: "bob", Stats: provider.CommitStats{Additions: 5},
+				FilesChanged: []provider.FileChange{{Filename: "a.go"}}},
+			{Author: "alice", Stats: provider.CommitStats{Deletions: 4}},
+		},
+		PullRequests: []provider.PullRequestData{{Number: 1}},
+	}
+
+	stats := CompareRelease(release)
+	if stats.Commits != 3 || stats.PullRequests != 1 || stats.Contributors != 2 || stats.FilesChanged != 2 {
+		t.Errorf("Unexpected counts: %+v", stats)
+	}
+	if stats.Additions != 15 || stats.Deletions != 6 || stats.DaysSincePrevious != 6 {
+		t.Errorf("Unexpected line or day totals: %+v", stats)
+	}
+
+	want := "📊 3 commits · 1 PR · 2 contributors · 2 files changed · +15/-6 lines · 6d since v1.1.0\n\n"
+	if got := FormatReleaseComparison(stats); got != want {
+		t.Errorf("FormatReleaseComparison() = %q, want %q", got, want)
+	}
+
+	// Without file lists (fast fetch) the file count is left out
+	stats.FilesChanged = 0
+	want = "📊 3 commits · 1 PR · 2 contributors · +15/-6 lines · 6d since v1.1.0\n\n"
+	if got := FormatReleaseComparison(stats); got != want {
+		t.Errorf("FormatReleaseComparison() = %q, want %q", got, want)
+	}
+	if FormatReleaseComparison(nil) != "" {
+		t.Error("Expected no header without stats")
+	}
+}
@@ -0,0 +1,162 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+// ContributorStats aggregates one author's activity within a range
+type ContributorStats struct {
+	Author    string `json:"author"`
+	Commits   int    `json:"commits"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+	FirstTime bool   `json:"first_time"` // No commits in the repository before this range
+}
+
+// ContributorsSummary aggregates contributor activity within a range
+type ContributorsSummary struct {
+	Contributors []ContributorStats `json:"contributors"` // Sorted by commit count, descending
+	Commits      int                `json:"commits"`
+	Additions    int                `json:"additions"`
+	Deletions    int                `json:"deletions"`
+}
+
+// ComputeContributors aggregates commit counts and line changes per author.
+// Co-authors are credited with the whole commit, like its author.
+func ComputeContributors(commits []provider.CommitData) *ContributorsSummary {
+	byAuthor := make(map[string]*ContributorStats)
+	summary := &ContributorsSummary{}
+
+	for _, commit := range commits {
+		author := commit.Author
+		if author == "" {
+			author = "unknown"
+		}
+		for _, name := range append([]string{author}, commit.CoAuthors...) {
+			stats, ok := byAuthor[name]
+			if !ok {
+				stats = &ContributorStats{Author: name}
+				byAuthor[name] = stats
+			}
+			stats.Commits++
+			stats.Additions += commit.Stats.Additions
+			stats.Deletions += commit.Stats.Deletions
+		}
+
+		summary.Commits++
+		summary.Additions += commit.Stats.Additions
+		summary.Deletions += commit.Stats.Deletions
+	}
+
+	for _, stats := range byAuthor {
+		summary.Contributors = append(summary.Contributors, *stats)
+	}
+	sort.Slice(summary.Contributors, func(i, j int) bool {
+		a, b := summary.Contributors[i], summary.Contributors[j]
+		if a.Commits != b.Commits {
+			return a.Commits > b.Commits
+		}
+		return a.Author < b.Author
+	})
+
+	return summary
+}
+
+// FirstTimeContributors returns the authors flagged as first-time contributors
+func (s *ContributorsSummary) FirstTimeContributors() []string {
+	var authors []string
+	for _, c := range s.Contributors {
+		if c.FirstTime {
+			authors = append(authors, c.Author)
+		}
+	}
+	return authors
+}
+
+// markFirstTimeContributors flags authors with no commits before the range start.
+// Lookup failures are reported in verbose mode and leave the author unflagged.
+func (g *Generator) markFirstTimeContributors(ctx context.Context, summary *ContributorsSummary, commits []provider.CommitData) {
+	since := earliestCommitDate(commits)
+	if since.IsZero() || g.provider == nil {
+		return
+	}
+
+	for i := range summary.Contributors {
+		contributor := &summary.Contributors[i]
+		// Bots and co-authors known only by name have no history to look up
+		if contributor.Author == "unknown" || provider.IsBot(contributor.Author) || strings.Contains(contributor.Author, " ") {
+			continue
+		}
+		hasPrior, err := g.provider.HasCommitsBefore(ctx, contributor.Author, since)
+		if errors.Is(err, errors.ErrUnsupported) || ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			logger.Warn("could not check contributor history", "author", contributor.Author, "error", err)
+			continue
+		}
+		contributor.FirstTime = !hasPrior
+	}
+}
+
+// earliestCommitDate returns the oldest commit date in the list
+func earliestCommitDate(commits []provider.CommitData) time.Time {
+	var earliest time.Time
+	for _, commit := range commits {
+		if earliest.IsZero() || commit.Date.Before(earliest) {
+			earliest = commit.Date
+		}
+	}
+	return earliest
+}
+
+// latestCommitDate returns the date of the newest commit
+func latestCommitDate(commits []provider.CommitData) time.Time {
+	var latest time.Time
+	for _, commit := range commits {
+		if commit.Date.After(latest) {
+			latest = commit.Date
+		}
+	}
+	return latest
+}
+
+// FormatContributors renders the contributors section at the given heading level
+func FormatContributors(summary *ContributorsSummary, level int) string {
+	if summary == nil || len(summary.Contributors) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	heading := strings.Repeat("#", level)
+
+	sb.WriteString(fmt.Sprintf("%s 👥 Contributors\n\n", heading))
+	sb.WriteString(fmt.Sprintf("**%d contributors** · %d commits · +%d/-%d lines\n\n",
+		len(summary.Contributors), summary.Commits, summary.Additions, summary.Deletions))
+
+	for _, c := range summary.Contributors {
+		noun := "commits"
+		if c.Commits == 1 {
+			noun = "commit"
+		}
+		sb.WriteString(fmt.Sprintf("- %s — %d %s (+%d/-%d)\n", mention(c.Author), c.Commits, noun, c.Additions, c.Deletions))
+	}
+	sb.WriteString("\n")
+
+	if firstTimers := summary.FirstTimeContributors(); len(firstTimers) > 0 {
+		sb.WriteString(fmt.Sprintf("%s# 🎉 First-time contributors\n\n", heading))
+		for _, author := range firstTimers {
+			sb.WriteString(fmt.Sprintf("- @%s\n", author))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
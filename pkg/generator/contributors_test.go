@@ -0,0 +1,72 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+func TestComputeContributors(t *testing.T) {
+	commits := []provider.CommitData{
+		{SHA: "a1", Author: "alice", Stats: provider.CommitStats{Additions: 10, Deletions: 2}},
+		{SHA: "b1", Author: "bob", Stats: provider.CommitStats{Additions: 5, Deletions: 5}},
+		{SHA: "a2", Author: "alice", Stats: provider.CommitStats{Additions: 1, Deletions: 0}},
+	}
+
+	summary := ComputeContributors(commits)
+
+	if summary.Commits != 3 || summary.Additions != 16 || summary.Deletions != 7 {
+		t.Errorf("Unexpected totals: %+v", summary)
+	}
+	if len(summary.Contributors) != 2 || summary.Contributors[0].Author != "alice" {
+		t.Fatalf("Expected alice first, got %+v", summary.Contributors)
+	}
+	if summary.Contributors[0].Commits != 2 || summary.Contributors[0].Additions != 11 {
+		t.Errorf("Unexpected stats for alice: %+v", summary.Contributors[0])
+	}
+}
+
+func TestFormatContributors(t *testing.T) {
+	summary := &ContributorsSummary{
+		Contributors: []ContributorStats{
+			{Author: "alice", Commits: 2, Additions: 11, Deletions: 2},
+			{Author: "carol", Commits: 1, Additions: 3, Deletions: 1, FirstTime: true},
+		},
+		Commits:   3,
+		Additions: 14,
+		Deletions: 3,
+	}
+
+	markdown := FormatContributors(summary, 2)
+
+	requiredStrings := []string{
+		"## 👥 Contributors",
+		"**2 contributors** · 3 commits · +14/-3 lines",
+		"- @alice — 2 commits (+11/-2)",
+		"- @carol — 1 commit (+3/-1)",
+		"### 🎉 First-time contributors",
+	}
+	for _, str := range requiredStrings {
+		if !strings.Contains(markdown, str) {
+			t.Errorf("Expected markdown to contain %q\nGot:\n%s", str, markdown)
+		}
+	}
+
+	if FormatContributors(nil, 2) != "" {
+		t.Error("Expected empty output for nil summary")
+	}
+}
+
+func TestComputeContributorsCoAuthors(t *testing.T) {
+	summary := ComputeContributors([]provider.CommitData{
+		{Author: "alice", CoAuthors: []string{"bob"}, Stats: provider.CommitStats{Additions: 10}},
+		{Author: "bob"},
+	})
+	if summary.Commits != 2 || summary.Additions != 10 {
+		t.Errorf("Co-authors should not inflate totals: %+v", summary)
+	}
+	if len(summary.Contributors) != 2 || summary.Contributors[0].Author != "bob" || summary.Contributors[0].Commits != 2 {
+		t.Errorf("Expected bob credited with both commits, got %+v", summary.Contributors)
+	}
+}
@@ -0,0 +1,115 @@
+package generator
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+)
+
+// titleSimilarityThreshold is the word-overlap ratio above which two titles
+// are considered the same change
+const titleSimilarityThreshold = 0.85
+
+// DedupeEntries removes entries the LLM listed under more than one category.
+// Two entries are duplicates when they share a commit SHA or have near-identical
+// titles; the placement in the highest-priority category (per CategoryOrder) is kept.
+// It returns the number of entries removed.
+func DedupeEntries(categories map[string][]llm.ChangelogEntry) int {
+	var kept []llm.ChangelogEntry
+	removed := 0
+
+	for _, category := range categoriesByPriority(categories) {
+		var unique []llm.ChangelogEntry
+		for _, entry := range categories[category] {
+			if isDuplicateEntry(entry, kept) {
+				removed++
+				continue
+			}
+			kept = append(kept, entry)
+			unique = append(unique, entry)
+		}
+		if len(unique) == 0 {
+			delete(categories, category)
+		} else {
+			categories[category] = unique
+		}
+	}
+
+	return removed
+}
+
+// categoriesByPriority returns the category names in CategoryOrder, followed
+// by any unknown categories in alphabetical order
+func categoriesByPriority(categories map[string][]llm.ChangelogEntry) []string {
+	var ordered []string
+	known := make(map[string]bool)
+	for _, category := range CategoryOrder {
+		known[category] = true
+		if _, ok := categories[category]; ok {
+			ordered = append(ordered, category)
+		}
+	}
+
+	var unknown []string
+	for category := range categories {
+		if !known[category] {
+			unknown = append(unknown, category)
+		}
+	}
+	sort.Strings(unknown)
+
+	return append(ordered, unknown...)
+}
+
+// isDuplicateEntry reports whether entry duplicates any of the kept entries
+func isDuplicateEntry(entry llm.ChangelogEntry, kept []llm.ChangelogEntry) bool {
+	for _, other := range kept {
+		if sameSHA(entry.SHA, other.SHA) {
+			return true
+		}
+		if titleSimilarity(entry.Title, other.Title) >= titleSimilarityThreshold {
+			return true
+		}
+	}
+	return false
+}
+
+// sameSHA compares two possibly abbreviated SHAs
+func sameSHA(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	return strings.HasPrefix(a, b) || strings.HasPrefix(b, a)
+}
+
+// titleSimilarity returns the Jaccard overlap of the normalized words in two titles
+func titleSimilarity(a, b string) float64 {
+	wordsA, wordsB := titleWords(a), titleWords(b)
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for word := range wordsA {
+		if wordsB[word] {
+			shared++
+		}
+	}
+	union := len(wordsA) + len(wordsB) - shared
+	return float64(shared) / float64(union)
+}
+
+// titleWords lowercases a title and splits it into a set of words,
+// ignoring punctuation and markdown. Hyphenated words are joined ("warm-up" → "warmup").
+func titleWords(title string) map[string]bool {
+	words := make(map[string]bool)
+	normalized := strings.ReplaceAll(strings.ToLower(title), "-", "")
+	for _, word := range strings.FieldsFunc(normalized, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		words[word] = true
+	}
+	return words
+}
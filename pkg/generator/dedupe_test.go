@@ -0,0 +1,45 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+)
+
+func TestDedupeEntries(t *testing.T) {
+	categories := map[string][]llm.ChangelogEntry{
+		"Improvements": {
+			{SHA: "abc1234", Title: "Add OAuth2 login"},
+			{SHA: "ccc3333", Title: "Speed up cache warmup"},
+		},
+		"Features": {
+			{SHA: "abc1234def", Title: "OAuth2 authentication support"},
+		},
+		"Bug Fixes": {
+			{SHA: "ddd4444", Title: "Speed up cache warm-up!"},
+		},
+		"Misc": {
+			{SHA: "eee5555", Title: "Fix typo"},
+		},
+	}
+
+	removed := DedupeEntries(categories)
+
+	if removed != 2 {
+		t.Errorf("Expected 2 duplicates removed, got %d", removed)
+	}
+	// Same SHA: kept under Features (higher priority than Improvements)
+	if len(categories["Features"]) != 1 {
+		t.Errorf("Expected Features entry to be kept, got %v", categories["Features"])
+	}
+	if len(categories["Improvements"]) != 1 || categories["Improvements"][0].SHA != "ccc3333" {
+		t.Errorf("Expected only the cache entry left in Improvements, got %v", categories["Improvements"])
+	}
+	// Near-identical title: Improvements outranks Bug Fixes, so Bug Fixes is emptied
+	if _, ok := categories["Bug Fixes"]; ok {
+		t.Errorf("Expected empty Bug Fixes category to be removed, got %v", categories["Bug Fixes"])
+	}
+	if len(categories["Misc"]) != 1 {
+		t.Error("Expected unrelated entry in unknown category to be kept")
+	}
+}
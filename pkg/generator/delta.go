@@ -0,0 +1,68 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// deltaNoteMaxEntries caps the entries listed in a delta note; the rest are counted
+const deltaNoteMaxEntries = 10
+
+// FormatDeltaNote renders a short Slack-style note of what merged since the
+// previous run: a header, the one-line summary, and the most important entries
+func FormatDeltaNote(changelog *Changelog, repo string, minScore float64) string {
+	var sb strings.Builder
+
+	noun := "commits"
+	if changelog.CommitCount == 1 {
+		noun = "commit"
+	}
+	sb.WriteString(fmt.Sprintf("*%s* · %d %s merged since the last run (`%s..%s`)\n",
+		repo, changelog.CommitCount, noun, shortRef(changelog.FromRef), shortRef(changelog.ToRef)))
+	if changelog.Zoom.OneLiner != "" {
+		sb.WriteString(changelog.Zoom.OneLiner + "\n")
+	}
+
+	type deltaEntry struct {
+		category, title, sha string
+		score                float64
+	}
+	var entries []deltaEntry
+	for _, category := range categoriesByPriority(changelog.Categories) {
+		for _, entry := range changelog.Categories[category] {
+			if entry.ImportanceScore < minScore {
+				continue
+			}
+			entries = append(entries, deltaEntry{category, entry.Title, entry.SHA, entry.ImportanceScore})
+		}
+	}
+	// Most important first; ties keep category order
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].score > entries[j].score })
+
+	for i, entry := range entries {
+		if i == deltaNoteMaxEntries {
+			sb.WriteString(fmt.Sprintf("… and %d more\n", len(entries)-deltaNoteMaxEntries))
+			break
+		}
+		sb.WriteString("• ")
+		if emoji := CategoryEmojis[entry.category]; emoji != "" {
+			sb.WriteString(emoji + " ")
+		}
+		sb.WriteString(entry.title)
+		if entry.sha != "" {
+			sb.WriteString(fmt.Sprintf(" (`%s`)", shortRef(entry.sha)))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// shortRef abbreviates full commit SHAs and leaves other refs untouched
+func shortRef(ref string) string {
+	if len(ref) == 40 && strings.Trim(ref, "0123456789abcdef") == "" {
+		return ref[:7]
+	}
+	return ref
+}
@@ -0,0 +1,48 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+)
+
+func TestFormatDeltaNote(t *testing.T) {
+	changelog := &Changelog{
+		FromRef:     "0123456789abcdef0123456789abcdef01234567",
+		ToRef:       "fedcba9876543210fedcba9876543210fedcba98",
+		CommitCount: 3,
+		Zoom:        ZoomSummaries{OneLiner: "Adds webhooks and fixes login."},
+		Categories: map[string][]llm.ChangelogEntry{
+			"Bug Fixes": {{Title: "Fix login redirect", SHA: "abcdef1234567890abcdef1234567890abcdef12", ImportanceScore: 6}},
+			"Features":  {{Title: "Add webhooks", ImportanceScore: 9}},
+			"Internal":  {{Title: "Bump linter", ImportanceScore: 1}},
+		},
+	}
+
+	note := FormatDeltaNote(changelog, "acme/api", 2)
+	want := "*acme/api* · 3 commits merged since the last run (`0123456..fedcba9`)\n" +
+		"Adds webhooks and fixes login.\n" +
+		"• 🚀 Add webhooks\n" +
+		"• 🐛 Fix login redirect (`abcdef1`)\n"
+	if note != want {
+		t.Errorf("FormatDeltaNote() =\n%s\nwant\n%s", note, want)
+	}
+}
+
+func TestFormatDeltaNoteTruncates(t *testing.T) {
+	var entries []llm.ChangelogEntry
+	for i := 0; i < deltaNoteMaxEntries+3; i++ {
+		entries = append(entries, llm.ChangelogEntry{Title: fmt.Sprintf("Change %d", i), ImportanceScore: 5})
+	}
+	changelog := &Changelog{FromRef: "main~5", ToRef: "main", CommitCount: 1, Categories: map[string][]llm.ChangelogEntry{"Features": entries}}
+
+	note := FormatDeltaNote(changelog, "acme/api", 0)
+	if !strings.Contains(note, "1 commit merged") || !strings.HasSuffix(note, "… and 3 more\n") {
+		t.Errorf("unexpected note:\n%s", note)
+	}
+	if strings.Count(note, "• ") != deltaNoteMaxEntries {
+		t.Errorf("expected %d entries in:\n%s", deltaNoteMaxEntries, note)
+	}
+}
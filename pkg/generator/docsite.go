@@ -0,0 +1,172 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// DocsPage is one file of a docs-site layout
+type DocsPage struct {
+	Path    string // Relative to the docs directory
+	Content string
+}
+
+// docsSite describes how a static-site generator lays out release pages
+type docsSite struct {
+	dir       string // Default directory, relative to the site's root
+	datedName bool   // File names start with the release date, as Docusaurus blog posts do
+	heading   bool   // The body starts with the title as a heading, which MkDocs Material uses as the title
+	excerpt   string // Marker ending the excerpt shown on index pages
+	toml      bool   // TOML front-matter is supported, and the default
+	drafts    bool   // Unreleased pages are drafts, which the site does not publish
+}
+
+// docsSites are the supported layouts, by name
+var docsSites = map[string]docsSite{
+	"docusaurus": {dir: "blog", datedName: true, excerpt: "<!-- truncate -->"},
+	"mkdocs":     {dir: "docs/blog/posts", heading: true, excerpt: "<!-- more -->"},
+	"hugo":       {dir: "content/releases", excerpt: "<!--more-->", toml: true, drafts: true},
+}
+
+// IsDocsSite reports whether name is a supported docs-site layout
+func IsDocsSite(name string) bool {
+	_, ok := docsSites[name]
+	return ok
+}
+
+// DefaultDocsDir returns where a docs site keeps its release pages, e.g.
+// blog for Docusaurus
+func DefaultDocsDir(site string) string {
+	return docsSites[site].dir
+}
+
+// frontMatter is the metadata block at the top of a release page
+type frontMatter struct {
+	Title       string   `yaml:"title"`
+	Date        string   `yaml:"date,omitempty"`
+	Slug        string   `yaml:"slug"`
+	Description string   `yaml:"description,omitempty"`
+	Tags        []string `yaml:"tags,omitempty"`       // Docusaurus and Hugo
+	Categories  []string `yaml:"categories,omitempty"` // MkDocs Material blog
+	Draft       bool     `yaml:"draft,omitempty"`
+}
+
+// toml encodes the front-matter as TOML, in the field order of the YAML encoding
+func (m frontMatter) toml() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("title = %s\n", tomlString(m.Title)))
+	if m.Date != "" {
+		sb.WriteString(fmt.Sprintf("date = %s\n", m.Date)) // A TOML local date
+	}
+	sb.WriteString(fmt.Sprintf("slug = %s\n", tomlString(m.Slug)))
+	if m.Description != "" {
+		sb.WriteString(fmt.Sprintf("description = %s\n", tomlString(m.Description)))
+	}
+	for _, list := range []struct {
+		key    string
+		values []string
+	}{{"tags", m.Tags}, {"categories", m.Categories}} {
+		if len(list.values) == 0 {
+			continue
+		}
+		quoted := make([]string, len(list.values))
+		for i, value := range list.values {
+			quoted[i] = tomlString(value)
+		}
+		sb.WriteString(fmt.Sprintf("%s = [%s]\n", list.key, strings.Join(quoted, ", ")))
+	}
+	if m.Draft {
+		sb.WriteString("draft = true\n")
+	}
+	return sb.String()
+}
+
+// tomlString quotes s as a TOML basic string
+func tomlString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`).Replace(s) + `"`
+}
+
+// DocsPages lays out a changelog as one page per release for a docs site
+// ("docusaurus", "mkdocs", or "hugo"): front-matter with the title, date,
+// slug, and the release's categories as tags, then the one-liner as the
+// excerpt and the release's notes. format is the front-matter's, "yaml" or
+// "toml" (Hugo only); empty picks the site's default.
+func DocsPages(changelog any, site, format string) ([]DocsPage, error) {
+	layout, ok := docsSites[site]
+	if !ok {
+		return nil, fmt.Errorf("unsupported docs site %q", site)
+	}
+	if format == "" {
+		format = "yaml"
+		if layout.toml {
+			format = "toml"
+		}
+	}
+	if format != "yaml" && (format != "toml" || !layout.toml) {
+		return nil, fmt.Errorf("%s does not support %s front-matter", site, format)
+	}
+	pages, err := releasePages(changelog)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pages of several repositories share the directory, so their slugs name the repository
+	repos := make(map[string]bool)
+	for _, page := range pages {
+		repos[page.repo] = true
+	}
+
+	docs := make([]DocsPage, 0, len(pages))
+	for _, page := range pages {
+		slug := slugify(page.version)
+		if len(repos) > 1 {
+			slug = slugify(path.Base(page.repo) + "-" + page.version)
+		}
+		meta := frontMatter{Title: page.title(), Slug: slug, Description: page.summary, Draft: layout.drafts && page.unreleased}
+		if !page.date.IsZero() {
+			meta.Date = page.date.Format("2006-01-02")
+		}
+		if site == "mkdocs" {
+			meta.Categories = page.tags
+		} else {
+			meta.Tags = page.tags
+		}
+		var sb strings.Builder
+		if format == "toml" {
+			sb.WriteString("+++\n" + meta.toml() + "+++\n\n")
+		} else {
+			header, err := yaml.Marshal(meta)
+			if err != nil {
+				return nil, fmt.Errorf("encode front-matter: %w", err)
+			}
+			sb.WriteString("---\n" + string(header) + "---\n\n")
+		}
+		if layout.heading {
+			sb.WriteString("# " + meta.Title + "\n\n")
+		}
+		if page.summary != "" {
+			sb.WriteString(page.summary + "\n\n" + layout.excerpt + "\n\n")
+		}
+		sb.WriteString(page.body())
+
+		name := slug + ".md"
+		if layout.datedName && meta.Date != "" {
+			name = meta.Date + "-" + name
+		}
+		docs = append(docs, DocsPage{Path: name, Content: sb.String()})
+	}
+	return docs, nil
+}
+
+// slugRe matches runs of characters that are not allowed in slugs
+var slugRe = regexp.MustCompile(`[^a-z0-9.]+`)
+
+// slugify lowercases s and joins its words with hyphens, e.g. "v1.4.x" stays
+// as is and "Unreleased changes" becomes "unreleased-changes"
+func slugify(s string) string {
+	return strings.Trim(slugRe.ReplaceAllString(strings.ToLower(s), "-"), "-.")
+}
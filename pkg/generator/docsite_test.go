@@ -0,0 +1,130 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+)
+
+// docsTimeline is a timeline of two releases, one of them unreleased
+func docsTimeline() *TimelineChangelog {
+	return &TimelineChangelog{
+		RepoName: "acme/api",
+		Releases: []ReleaseChangelog{
+			{
+				ToRef:      "v1.2.0",
+				ToDate:     time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+				Categories: map[string][]llm.ChangelogEntry{"Features": {{Title: "Add export"}}},
+				Zoom: ZoomSummaries{
+					OneLiner: "Exports: finally.",
+					Full:     "## [Release v1.2.0]\n" + releaseSHAComment("abc1234") + "\n\n- **Add export**\n",
+				},
+			},
+			{
+				ToRef:      "main",
+				Unreleased: true,
+				Zoom:       ZoomSummaries{Full: "## [Unreleased]\n\n- **Fix retries**\n"},
+			},
+		},
+	}
+}
+
+func TestDocsPagesDocusaurus(t *testing.T) {
+	pages, err := DocsPages(docsTimeline(), "docusaurus", "")
+	if err != nil || len(pages) != 2 {
+		t.Fatalf("DocsPages() = %v, %v", pages, err)
+	}
+	if pages[0].Path != "2024-03-01-v1.2.0.md" || pages[1].Path != "unreleased.md" {
+		t.Errorf("Paths = %q, %q", pages[0].Path, pages[1].Path)
+	}
+	want := "---\n" +
+		"title: api v1.2.0\n" +
+		"date: \"2024-03-01\"\n" +
+		"slug: v1.2.0\n" +
+		"description: 'Exports: finally.'\n" +
+		"tags:\n" +
+		"    - Features\n" +
+		"---\n\n" +
+		"Exports: finally.\n\n<!-- truncate -->\n\n" +
+		"- **Add export**\n"
+	if pages[0].Content != want {
+		t.Errorf("Content = %q, want %q", pages[0].Content, want)
+	}
+	if strings.Contains(pages[1].Content, "truncate") || !strings.HasSuffix(pages[1].Content, "---\n\n- **Fix retries**\n") {
+		t.Errorf("Unexpected page without a summary: %q", pages[1].Content)
+	}
+}
+
+func TestDocsPagesMkDocs(t *testing.T) {
+	pages, err := DocsPages(docsTimeline(), "mkdocs", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := pages[0].Content
+	if pages[0].Path != "v1.2.0.md" {
+		t.Errorf("Path = %q", pages[0].Path)
+	}
+	for _, want := range []string{"categories:\n    - Features\n", "---\n\n# api v1.2.0\n\nExports: finally.\n\n<!-- more -->\n\n- **Add export**\n"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("Expected %q in:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "tags:") {
+		t.Errorf("MkDocs pages list categories, not tags:\n%s", content)
+	}
+}
+
+func TestDocsPagesOrgSlugs(t *testing.T) {
+	web := docsTimeline()
+	web.RepoName = "acme/web"
+	org := &OrgTimelineChangelog{Repos: []*TimelineChangelog{docsTimeline(), web}}
+
+	pages, err := DocsPages(org, "mkdocs", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pages[0].Path != "api-v1.2.0.md" || pages[2].Path != "web-v1.2.0.md" {
+		t.Errorf("Paths = %q, %q; want the repository in the slug", pages[0].Path, pages[2].Path)
+	}
+	if _, err := DocsPages(org, "jekyll", ""); err == nil {
+		t.Error("Expected an error for an unsupported docs site")
+	}
+}
+
+func TestDocsPagesHugo(t *testing.T) {
+	timeline := docsTimeline()
+	timeline.Releases[0].Zoom.OneLiner = `Exports, "finally".`
+
+	pages, err := DocsPages(timeline, "hugo", "")
+	if err != nil || len(pages) != 2 {
+		t.Fatalf("DocsPages() = %v, %v", pages, err)
+	}
+	want := "+++\n" +
+		"title = \"api v1.2.0\"\n" +
+		"date = 2024-03-01\n" +
+		"slug = \"v1.2.0\"\n" +
+		"description = \"Exports, \\\"finally\\\".\"\n" +
+		"tags = [\"Features\"]\n" +
+		"+++\n\n" +
+		"Exports, \"finally\".\n\n<!--more-->\n\n" +
+		"- **Add export**\n"
+	if pages[0].Path != "v1.2.0.md" || pages[0].Content != want {
+		t.Errorf("%s = %q, want %q", pages[0].Path, pages[0].Content, want)
+	}
+	if !strings.Contains(pages[1].Content, "draft = true\n") {
+		t.Errorf("Expected the unreleased page to be a draft:\n%s", pages[1].Content)
+	}
+
+	pages, err = DocsPages(timeline, "hugo", "yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(pages[1].Content, "---\n") || !strings.Contains(pages[1].Content, "draft: true\n") {
+		t.Errorf("Unexpected YAML front-matter:\n%s", pages[1].Content)
+	}
+	if _, err := DocsPages(timeline, "mkdocs", "toml"); err == nil {
+		t.Error("Expected an error for TOML front-matter on MkDocs")
+	}
+}
@@ -0,0 +1,232 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+)
+
+// Rough output size per item, used to estimate completion tokens
+const (
+	outputTokensPerCommit = 80
+	outputTokensPerPR     = 40
+	outputTokensOverhead  = 150
+)
+
+// PlannedCall describes one LLM request a run would make
+type PlannedCall struct {
+	Label        string // Range or release the call covers
+	Items        int    // Commits or PRs in the prompt
+	ItemKind     string // "commits" or "PRs"
+	InputTokens  int
+	OutputTokens int
+	Model        string // Set when --model-routing sends the call to another model than the report's
+}
+
+// DryRunReport summarizes what a generation run would send to the LLM
+type DryRunReport struct {
+	Model         string
+	Commits       int
+	PullRequests  int
+	Calls         []PlannedCall
+	InputTokens   int
+	OutputTokens  int
+	EstimatedCost float64
+	PricingKnown  bool
+	Compression   bool // A length budget may add one compression call per oversized section
+}
+
+// DryRun fetches commits for a range and builds the prompt without calling
+// the LLM. It runs the same hooks and commit filters as Generate.
+func (g *Generator) DryRun(ctx context.Context, from, to string) (*DryRunReport, error) {
+	commits, err := g.fetchRange(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	commits, commitInfos, _, err := g.selectCommits(ctx, commits, g.prepareCommitsForLLM(commits), from, to)
+	if err != nil {
+		return nil, err
+	}
+	return g.dryRunCommits(commitInfos, g.changedLines(commits), from, to), nil
+}
+
+// DryRunCommits builds the prompt for caller-supplied commits without calling
+// the LLM, filtering them as GenerateFromCommits does
+func (g *Generator) DryRunCommits(ctx context.Context, commitInfos []llm.CommitInfo, from, to string) (*DryRunReport, error) {
+	if len(commitInfos) == 0 {
+		return nil, fmt.Errorf("no commits provided")
+	}
+	_, commitInfos, _, err := g.selectCommits(ctx, commitDataFromInfos(commitInfos), commitInfos, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return g.dryRunCommits(commitInfos, 0, from, to), nil
+}
+
+// dryRunCommits is DryRunCommits for commits changing lines lines, which
+// model routing takes into account
+func (g *Generator) dryRunCommits(commitInfos []llm.CommitInfo, lines int, from, to string) *DryRunReport {
+	prompt := llm.BuildChangelogPrompt(llm.ChangelogRequest{
+		Commits:  commitInfos,
+		RepoName: fmt.Sprintf("%s/%s", g.config.RepoOwner, g.config.RepoName),
+		FromRef:  from,
+		ToRef:    to,
+		Clusters: g.clusters(commitInfos),
+		Security: g.securityEnabled(),
+	})
+
+	report := g.newDryRunReport()
+	report.Commits = len(commitInfos)
+	if len(commitInfos) > 0 {
+		report.addCall(PlannedCall{
+			Label:        fmt.Sprintf("%s..%s", from, to),
+			Items:        len(commitInfos),
+			ItemKind:     "commits",
+			InputTokens:  llm.EstimateTokens(prompt),
+			OutputTokens: g.estimateOutputTokens(len(commitInfos), outputTokensPerCommit),
+			Model:        g.routeModel(len(commitInfos), lines),
+		})
+	}
+	report.finish()
+
+	return report
+}
+
+// DryRunTimeline discovers releases as GenerateTimeline does and builds
+// per-release prompts without calling the LLM
+func (g *Generator) DryRunTimeline(ctx context.Context, from, to time.Time) (*DryRunReport, error) {
+	timelineReleases, err := g.timelineReleases(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	report := g.newDryRunReport()
+	rescore := llm.RescoreRequest{RepoName: fmt.Sprintf("%s/%s", g.config.RepoOwner, g.config.RepoName)}
+	for _, release := range timelineReleases {
+		for _, pr := range release.PullRequests {
+			// Summaries are not written yet; the prompt estimate covers titles only
+			rescore.Items = append(rescore.Items, llm.RescoreItem{ID: rescoreID(release.ToRef, pr.Number), Release: release.ToRef, Title: pr.Title})
+		}
+		report.Commits += release.CommitCount
+		report.PullRequests += len(release.PullRequests)
+		if len(release.PullRequests) == 0 {
+			continue // No LLM call for releases without PRs
+		}
+
+		prompt := llm.BuildPRChangelogPrompt(g.prChangelogRequest(ctx, release))
+		report.addCall(PlannedCall{
+			Label:        fmt.Sprintf("%s → %s", release.FromRef, release.ToRef),
+			Items:        len(release.PullRequests),
+			ItemKind:     "PRs",
+			InputTokens:  llm.EstimateTokens(prompt),
+			OutputTokens: g.estimateOutputTokens(len(release.PullRequests), outputTokensPerPR),
+			Model:        g.routeModel(len(release.PullRequests), g.changedLines(release.Commits)),
+		})
+	}
+	if g.config.CalibrateScores && len(rescore.Items) > 0 {
+		report.addCall(PlannedCall{
+			Label:        "score calibration",
+			Items:        len(rescore.Items),
+			ItemKind:     "PRs",
+			InputTokens:  llm.EstimateTokens(llm.BuildRescorePrompt(rescore)),
+			OutputTokens: g.estimateOutputTokens(len(rescore.Items), outputTokensPerPR),
+		})
+	}
+	if g.config.PeriodSummary && len(timelineReleases) > 0 {
+		period := llm.PeriodSummaryRequest{RepoName: rescore.RepoName, FromDate: from, ToDate: to}
+		for _, release := range timelineReleases {
+			// Release summaries are not written yet; the estimate covers PR titles only
+			period.Releases = append(period.Releases, periodRelease(release.ToRef, release.ToDate, "", release.PullRequests))
+		}
+		report.addCall(PlannedCall{
+			Label:        "period summary",
+			Items:        len(period.Releases),
+			ItemKind:     "releases",
+			InputTokens:  llm.EstimateTokens(llm.BuildPeriodSummaryPrompt(period)),
+			OutputTokens: g.estimateOutputTokens(len(period.Releases), outputTokensPerPR),
+		})
+	}
+	report.finish()
+
+	return report, nil
+}
+
+// newDryRunReport creates an empty report for the configured model
+func (g *Generator) newDryRunReport() *DryRunReport {
+	limit, _, _ := g.config.LengthBudget()
+	return &DryRunReport{
+		Model:       g.config.OpenAIModel,
+		Compression: limit > 0,
+	}
+}
+
+// estimateOutputTokens estimates completion size, capped at the max tokens setting
+func (g *Generator) estimateOutputTokens(items, perItem int) int {
+	estimate := outputTokensOverhead + items*perItem
+	if g.config.MaxTokens > 0 && estimate > g.config.MaxTokens {
+		return g.config.MaxTokens
+	}
+	return estimate
+}
+
+// addCall records a planned LLM call and accumulates its token estimate
+func (r *DryRunReport) addCall(call PlannedCall) {
+	r.Calls = append(r.Calls, call)
+	r.InputTokens += call.InputTokens
+	r.OutputTokens += call.OutputTokens
+}
+
+// finish computes the estimated cost from the accumulated token counts,
+// pricing routed calls at their own model
+func (r *DryRunReport) finish() {
+	r.EstimatedCost, r.PricingKnown = llm.EstimateCost(r.Model, r.InputTokens, r.OutputTokens)
+	for _, call := range r.Calls {
+		if call.Model == "" {
+			continue
+		}
+		routed, known := llm.EstimateCost(call.Model, call.InputTokens, call.OutputTokens)
+		base, _ := llm.EstimateCost(r.Model, call.InputTokens, call.OutputTokens)
+		r.EstimatedCost += routed - base
+		r.PricingKnown = r.PricingKnown && known
+	}
+}
+
+// FormatDryRunReport renders a dry-run report for the terminal
+func FormatDryRunReport(r *DryRunReport) string {
+	var sb strings.Builder
+
+	sb.WriteString("Dry run — no LLM requests were made and no output was written\n\n")
+	sb.WriteString(fmt.Sprintf("Model:          %s\n", r.Model))
+	sb.WriteString(fmt.Sprintf("Commits:        %d\n", r.Commits))
+	if r.PullRequests > 0 {
+		sb.WriteString(fmt.Sprintf("Pull requests:  %d\n", r.PullRequests))
+	}
+	sb.WriteString(fmt.Sprintf("LLM calls:      %d\n", len(r.Calls)))
+	sb.WriteString(fmt.Sprintf("Input tokens:   ~%d\n", r.InputTokens))
+	sb.WriteString(fmt.Sprintf("Output tokens:  ~%d\n", r.OutputTokens))
+	if r.PricingKnown {
+		sb.WriteString(fmt.Sprintf("Estimated cost: ~$%.4f\n", r.EstimatedCost))
+	} else {
+		sb.WriteString("Estimated cost: unknown (no pricing for this model)\n")
+	}
+	if r.Compression {
+		sb.WriteString("Note: --max-length may add one compression call per oversized section\n")
+	}
+
+	if len(r.Calls) > 0 {
+		sb.WriteString("\nPlanned calls:\n")
+		for i, call := range r.Calls {
+			sb.WriteString(fmt.Sprintf("  %d. %s — %d %s, ~%d in / ~%d out tokens",
+				i+1, call.Label, call.Items, call.ItemKind, call.InputTokens, call.OutputTokens))
+			if call.Model != "" {
+				sb.WriteString(" on " + call.Model)
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,167 @@
+package generator
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm/mock"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+// countingLLM is the mock LLM counting the commits and PRs it is sent
+type countingLLM struct {
+	*mock.Client
+	commits, prs int
+}
+
+func (c *countingLLM) GenerateChangelog(ctx context.Context, req llm.ChangelogRequest) (*llm.ChangelogResponse, error) {
+	c.commits += len(req.Commits)
+	return c.Client.GenerateChangelog(ctx, req)
+}
+
+func (c *countingLLM) GeneratePRChangelog(ctx context.Context, req llm.PRChangelogRequest) (*llm.PRChangelogResponse, error) {
+	c.prs += len(req.PRs)
+	return c.Client.GeneratePRChangelog(ctx, req)
+}
+
+// dryRunFixture has a human, a bot, a reverted pair, and an unverified commit
+// in v1.0.0..v1.1.0, and a timeline with a bot PR
+func dryRunFixture() *provider.Fixture {
+	date := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	verified := &provider.CommitVerification{Verified: true, Reason: "valid"}
+	commits := []provider.CommitData{
+		{SHA: "aaaa1111", Message: "feat: add export", Author: "alice", Date: date, Verification: verified},
+		{SHA: "bbbb2222", Message: "chore(deps): bump lodash", Author: "dependabot[bot]", Date: date, Verification: verified},
+		{SHA: "cccc3333", Message: "fix: retry uploads", Author: "bob", Date: date, Verification: verified},
+		{SHA: "dddd4444", Message: "Revert \"fix: retry uploads\"\n\nThis reverts commit cccc3333.", Author: "bob", Date: date, Verification: verified},
+		{SHA: "eeee5555", Message: "docs: fix typo", Author: "carol", Date: date, Verification: &provider.CommitVerification{Reason: "unsigned"}},
+	}
+	return &provider.Fixture{
+		Repo:    "acme/widgets",
+		Commits: map[string][]provider.CommitData{"v1.0.0..v1.1.0": commits},
+		Timelines: map[string][]provider.TimelineRelease{
+			"2025-01-01T00:00:00Z..2025-03-31T00:00:00Z": {{
+				FromRef: "v1.0.0", ToRef: "v1.1.0", FromDate: date.AddDate(0, 0, -7), ToDate: date,
+				CommitCount: len(commits), Commits: commits,
+				PullRequests: []provider.PullRequestData{
+					{Number: 1, Title: "feat: add export", Author: "alice"},
+					{Number: 2, Title: "chore(deps): bump lodash", Author: "dependabot[bot]"},
+				},
+			}},
+		},
+		ListsReleases: true,
+		ListsTags:     true,
+	}
+}
+
+func TestDryRunMatchesGenerate(t *testing.T) {
+	dropDocs := Hooks{PreLLM: func(ctx context.Context, commits []llm.CommitInfo) ([]llm.CommitInfo, error) {
+		var kept []llm.CommitInfo
+		for _, commit := range commits {
+			if !strings.HasPrefix(commit.Message, "docs") {
+				kept = append(kept, commit)
+			}
+		}
+		return kept, nil
+	}}
+	dropFirst := Hooks{PostFetch: func(ctx context.Context, commits []provider.CommitData) ([]provider.CommitData, error) {
+		return commits[1:], nil
+	}}
+
+	tests := []struct {
+		name        string
+		configure   func(cfg *config.Config)
+		hooks       []Hooks
+		wantCommits int
+	}{
+		{name: "no filters", wantCommits: 5},
+		{name: "bot exclusion", configure: func(cfg *config.Config) { cfg.BotCommits = "exclude" }, wantCommits: 4},
+		{name: "dropped reverts", configure: func(cfg *config.Config) { cfg.Reverts = "drop" }, wantCommits: 3},
+		{name: "unverified exclusion", configure: func(cfg *config.Config) { cfg.UnverifiedCommits = "exclude" }, wantCommits: 4},
+		{name: "post-fetch hook", hooks: []Hooks{dropFirst}, wantCommits: 4},
+		{name: "pre-LLM hook", hooks: []Hooks{dropDocs}, wantCommits: 4},
+		{
+			name: "all filters",
+			configure: func(cfg *config.Config) {
+				cfg.BotCommits, cfg.Reverts, cfg.UnverifiedCommits = "exclude", "drop", "exclude"
+			},
+			hooks:       []Hooks{dropFirst, dropDocs},
+			wantCommits: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newGenerator := func(client llm.Client) *Generator {
+				cfg := config.Default()
+				cfg.RepoOwner, cfg.RepoName = "acme", "widgets"
+				cfg.Reverts = "keep"
+				if tt.configure != nil {
+					tt.configure(cfg)
+				}
+				options := []Option{WithConfig(cfg)}
+				for _, hooks := range tt.hooks {
+					options = append(options, WithHooks(hooks))
+				}
+				return New(dryRunFixture(), client, options...)
+			}
+
+			client := &countingLLM{Client: mock.New()}
+			_, genErr := newGenerator(client).Generate(context.Background(), "v1.0.0", "v1.1.0")
+			report, dryErr := newGenerator(mock.New()).DryRun(context.Background(), "v1.0.0", "v1.1.0")
+			if tt.wantCommits == 0 {
+				if genErr == nil || dryErr == nil || genErr.Error() != dryErr.Error() {
+					t.Fatalf("Expected both runs to fail alike, got %v and %v", genErr, dryErr)
+				}
+				return
+			}
+			if genErr != nil || dryErr != nil {
+				t.Fatalf("Generate() error = %v, DryRun() error = %v", genErr, dryErr)
+			}
+			if client.commits != tt.wantCommits || report.Commits != tt.wantCommits {
+				t.Errorf("Generate sent %d commits and DryRun counted %d, want %d", client.commits, report.Commits, tt.wantCommits)
+			}
+			if len(report.Calls) != 1 || report.Calls[0].InputTokens != llm.EstimateTokens(client.Prompts()[0]) {
+				t.Errorf("DryRun planned %+v, want one call sized like %q", report.Calls, client.Prompts()[0])
+			}
+		})
+	}
+}
+
+func TestDryRunTimelineMatchesGenerateTimeline(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		botCommits string
+		wantPRs    int
+	}{
+		{"include", 2},
+		{"exclude", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.botCommits, func(t *testing.T) {
+			cfg := config.Default()
+			cfg.RepoOwner, cfg.RepoName = "acme", "widgets"
+			cfg.BotCommits = tt.botCommits
+
+			client := &countingLLM{Client: mock.New()}
+			if _, err := New(dryRunFixture(), client, WithConfig(cfg)).GenerateTimeline(context.Background(), from, to); err != nil {
+				t.Fatal(err)
+			}
+			report, err := New(dryRunFixture(), mock.New(), WithConfig(cfg)).DryRunTimeline(context.Background(), from, to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if client.prs != tt.wantPRs || report.PullRequests != tt.wantPRs {
+				t.Errorf("GenerateTimeline sent %d PRs and DryRunTimeline counted %d, want %d", client.prs, report.PullRequests, tt.wantPRs)
+			}
+			if len(report.Calls) != 1 || report.Calls[0].InputTokens != llm.EstimateTokens(client.Prompts()[0]) {
+				t.Errorf("DryRunTimeline planned %+v, want one call sized like %q", report.Calls, client.Prompts()[0])
+			}
+		})
+	}
+}
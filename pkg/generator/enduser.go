@@ -0,0 +1,66 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// endUserCategories are the categories customers care about, in display order;
+// Documentation and Internal changes are left out
+var endUserCategories = []struct {
+	category string
+	heading  string
+}{
+	{"Breaking Changes", "⚠️ Action required"},
+	{"Security", "🔒 Security"},
+	{"Features", "🚀 New"},
+	{"Improvements", "⚡ Improved"},
+	{"Bug Fixes", "🐛 Fixed"},
+}
+
+// FormatEndUserMarkdown renders a customer-facing version of a changelog:
+// the summary, highlights, and user-visible entries with their descriptions,
+// without SHAs, authors, scores, or internal changes. Entries scoring below
+// minScore are dropped.
+func FormatEndUserMarkdown(changelog *Changelog, minScore float64) string {
+	var sb strings.Builder
+
+	if changelog.Summary != "" {
+		sb.WriteString(changelog.Summary + "\n\n")
+	}
+
+	if len(changelog.Highlights) > 0 {
+		sb.WriteString("## Highlights\n\n")
+		for _, highlight := range changelog.Highlights {
+			sb.WriteString(fmt.Sprintf("- %s\n", highlight))
+		}
+		sb.WriteString("\n")
+	}
+
+	for _, section := range endUserCategories {
+		var items []string
+		for _, entry := range changelog.Categories[section.category] {
+			if minScore > 0 && entry.ImportanceScore < minScore {
+				continue
+			}
+			item := fmt.Sprintf("- **%s**", entry.Title)
+			if entry.Severity != "" {
+				item += fmt.Sprintf(" (%s severity)", strings.ToLower(entry.Severity))
+			}
+			if description := strings.Join(strings.Fields(entry.Description), " "); description != "" {
+				item += " — " + description
+			}
+			item += formatDocsLink(entry)
+			if entry.Image != "" {
+				item += "\n\n  " + formatImage(entry.Title, entry.Image)
+			}
+			items = append(items, item)
+		}
+		if len(items) == 0 {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("## %s\n\n%s\n\n", section.heading, strings.Join(items, "\n")))
+	}
+
+	return strings.TrimRight(sb.String(), "\n") + "\n"
+}
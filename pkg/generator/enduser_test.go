@@ -0,0 +1,29 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+)
+
+func TestFormatEndUserMarkdown(t *testing.T) {
+	changelog := &Changelog{
+		Summary:    "Faster search and dark mode.",
+		Highlights: []string{"Dark mode"},
+		Categories: map[string][]llm.ChangelogEntry{
+			"Features":  {{Title: "Dark mode", Description: "Switch themes\nin settings.", SHA: "abc1234", Author: "alice", ImportanceScore: 8}},
+			"Bug Fixes": {{Title: "Fix typo in footer", ImportanceScore: 1}},
+			"Internal":  {{Title: "Refactor cache", ImportanceScore: 6}},
+		},
+	}
+
+	got := FormatEndUserMarkdown(changelog, 2)
+	want := "Faster search and dark mode.\n\n## Highlights\n\n- Dark mode\n\n## 🚀 New\n\n- **Dark mode** — Switch themes in settings.\n"
+	if got != want {
+		t.Errorf("FormatEndUserMarkdown() =\n%q\nwant\n%q", got, want)
+	}
+	if !strings.Contains(FormatEndUserMarkdown(changelog, 0), "## 🐛 Fixed") {
+		t.Error("Expected bug fixes without a score threshold")
+	}
+}
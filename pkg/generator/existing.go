@@ -0,0 +1,185 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// releaseHeadingRe matches timeline section headings: "## [Release v1.2.0]"
+	releaseHeadingRe = regexp.MustCompile(`^#+\s*\[Release\s+([^\]\s]+)\]`)
+	// versionTokenRe matches version-looking tokens: v1.2.0, 1.2, v2.0.0-rc.1
+	versionTokenRe = regexp.MustCompile(`^v?[0-9]+(\.[0-9]+)*([-+][0-9A-Za-z.\-]+)?$`)
+	// releaseSHARe matches the commit marker written under release headings
+	releaseSHARe = regexp.MustCompile(`^<!--\s*release-sha:\s*([0-9a-fA-F]+)\s*-->$`)
+)
+
+// DocumentedVersions parses the headings of an existing changelog and returns
+// the set of versions it already documents. It recognizes the headings this
+// tool writes ("## [Release v1.2.0]", "# Changelog: v1.1.0 → v1.2.0") as well as
+// common hand-written styles ("## [1.2.0] - 2024-01-01", "## v1.2.0").
+func DocumentedVersions(markdown string) map[string]bool {
+	versions := make(map[string]bool)
+
+	for _, line := range strings.Split(markdown, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if m := releaseHeadingRe.FindStringSubmatch(line); m != nil {
+			versions[m[1]] = true
+			continue
+		}
+
+		heading := strings.TrimSpace(strings.TrimLeft(line, "#"))
+		// Ref-mode title: the range end is the documented version
+		if idx := strings.LastIndex(heading, "→"); idx >= 0 {
+			heading = strings.TrimSpace(heading[idx+len("→"):])
+		}
+
+		fields := strings.Fields(heading)
+		if len(fields) == 0 {
+			continue
+		}
+		token := strings.Trim(fields[0], "[]")
+		if versionTokenRe.MatchString(token) {
+			versions[token] = true
+		}
+	}
+
+	return versions
+}
+
+// DocumentedSHAs returns the commit recorded under each "## [Release X]"
+// heading of an existing changelog, keyed by version. Sections written before
+// markers were added, or by hand, have no entry.
+func DocumentedSHAs(markdown string) map[string]string {
+	shas := make(map[string]string)
+
+	current := ""
+	for _, line := range strings.Split(markdown, "\n") {
+		line = strings.TrimSpace(line)
+		if m := releaseHeadingRe.FindStringSubmatch(line); m != nil {
+			current = m[1]
+			continue
+		}
+		if strings.HasPrefix(line, "#") && headingLevel(line) <= 2 {
+			current = ""
+			continue
+		}
+		if m := releaseSHARe.FindStringSubmatch(line); m != nil && current != "" {
+			if _, seen := shas[current]; !seen {
+				shas[current] = m[1]
+			}
+		}
+	}
+
+	return shas
+}
+
+// RemoveReleaseSections deletes the "## [Release X]" sections for the given
+// versions, along with the separator that followed each of them
+func RemoveReleaseSections(markdown string, versions []string) string {
+	remove := make(map[string]bool, len(versions))
+	for _, version := range versions {
+		remove[version] = true
+	}
+
+	var kept []string
+	skipLevel := 0 // Heading level of the section being removed, 0 when keeping
+	for _, line := range strings.Split(markdown, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "#") {
+			level := headingLevel(trimmed)
+			if skipLevel > 0 && level <= skipLevel {
+				skipLevel = 0
+			}
+			if m := releaseHeadingRe.FindStringSubmatch(trimmed); m != nil && IsDocumented(remove, m[1]) {
+				skipLevel = level
+			}
+		}
+		if skipLevel == 0 {
+			kept = append(kept, line)
+		}
+	}
+
+	result := strings.TrimRight(strings.Join(kept, "\n"), "\n ")
+	// A removed final section leaves the separator before it dangling
+	result = strings.TrimRight(strings.TrimSuffix(result, "---"), "\n ")
+	return result + "\n"
+}
+
+// headingLevel returns the number of leading '#' characters of a heading line
+func headingLevel(line string) int {
+	return len(line) - len(strings.TrimLeft(line, "#"))
+}
+
+// releaseSHAComment renders the marker that records which commit a release
+// section was generated from
+func releaseSHAComment(sha string) string {
+	return fmt.Sprintf("<!-- release-sha: %s -->", sha)
+}
+
+// withSHAComment ensures a rendered release section carries its commit marker
+// (a compressed section may have lost it)
+func withSHAComment(section, sha string) string {
+	if sha == "" || strings.Contains(section, releaseSHAComment(sha)) {
+		return section
+	}
+	heading, rest, _ := strings.Cut(section, "\n")
+	return heading + "\n" + releaseSHAComment(sha) + "\n" + rest
+}
+
+// documentedSHA looks up the recorded commit for version or its
+// v-prefixed/unprefixed twin
+func documentedSHA(shas map[string]string, version string) string {
+	if sha, ok := shas[version]; ok {
+		return sha
+	}
+	if strings.HasPrefix(version, "v") {
+		return shas[strings.TrimPrefix(version, "v")]
+	}
+	return shas["v"+version]
+}
+
+// IsDocumented reports whether version (or its v-prefixed/unprefixed twin)
+// is present in the documented set
+func IsDocumented(documented map[string]bool, version string) bool {
+	if documented[version] {
+		return true
+	}
+	if strings.HasPrefix(version, "v") {
+		return documented[strings.TrimPrefix(version, "v")]
+	}
+	return documented["v"+version]
+}
+
+// PrependChangelog inserts newly generated markdown above an existing changelog.
+// When both documents open with the same H1 title, the title is kept once.
+func PrependChangelog(existing, generated string) string {
+	if strings.TrimSpace(existing) == "" {
+		return generated
+	}
+
+	existingTitle, existingBody := splitTitle(existing)
+	generatedTitle, generatedBody := splitTitle(generated)
+	if existingTitle != "" && existingTitle == generatedTitle {
+		return existingTitle + "\n\n" +
+			strings.TrimSpace(generatedBody) + "\n\n---\n\n" +
+			strings.TrimLeft(existingBody, "\n")
+	}
+
+	return strings.TrimRight(generated, "\n") + "\n\n---\n\n" + existing
+}
+
+// splitTitle separates a leading "# " title line from the rest of the document
+func splitTitle(markdown string) (title, body string) {
+	trimmed := strings.TrimLeft(markdown, "\n")
+	if !strings.HasPrefix(trimmed, "# ") {
+		return "", markdown
+	}
+	title, body, _ = strings.Cut(trimmed, "\n")
+	return strings.TrimSpace(title), body
+}
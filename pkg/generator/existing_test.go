@@ -0,0 +1,136 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDocumentedVersions(t *testing.T) {
+	markdown := `# Release Notes: org/repo
+
+## [Release v1.3.0]
+
+- Something
+
+## [1.2.0] - 2024-01-01
+
+## v1.1.0
+
+# Changelog: v0.9.0 → v1.0.0
+
+## Summary
+
+## 🚀 Features
+`
+
+	versions := DocumentedVersions(markdown)
+	for _, expected := range []string{"v1.3.0", "1.2.0", "v1.1.0", "v1.0.0"} {
+		if !versions[expected] {
+			t.Errorf("Expected %s to be documented, got %v", expected, versions)
+		}
+	}
+	for _, unexpected := range []string{"Summary", "v0.9.0", "🚀"} {
+		if versions[unexpected] {
+			t.Errorf("Did not expect %q to be treated as a version", unexpected)
+		}
+	}
+}
+
+func TestIsDocumentedIgnoresVPrefix(t *testing.T) {
+	documented := map[string]bool{"1.2.0": true, "v2.0.0": true}
+
+	if !IsDocumented(documented, "v1.2.0") {
+		t.Error("Expected v1.2.0 to match documented 1.2.0")
+	}
+	if !IsDocumented(documented, "2.0.0") {
+		t.Error("Expected 2.0.0 to match documented v2.0.0")
+	}
+	if IsDocumented(documented, "v3.0.0") {
+		t.Error("Did not expect v3.0.0 to be documented")
+	}
+}
+
+func TestPrependChangelog(t *testing.T) {
+	existing := "# Release Notes: org/repo\n\n## [Release v1.0.0]\n\n- Old\n"
+	generated := "# Release Notes: org/repo\n\n## [Release v1.1.0]\n\n- New\n"
+
+	merged := PrependChangelog(existing, generated)
+
+	if strings.Count(merged, "# Release Notes: org/repo") != 1 {
+		t.Errorf("Expected shared title once, got:\n%s", merged)
+	}
+	newIdx := strings.Index(merged, "v1.1.0")
+	oldIdx := strings.Index(merged, "v1.0.0")
+	if newIdx < 0 || oldIdx < 0 || newIdx > oldIdx {
+		t.Errorf("Expected new release above old release, got:\n%s", merged)
+	}
+
+	if got := PrependChangelog("", generated); got != generated {
+		t.Errorf("Expected generated markdown unchanged for empty file, got:\n%s", got)
+	}
+}
+
+func TestDocumentedSHAs(t *testing.T) {
+	markdown := `# Release Notes: org/repo
+
+## [Release v1.1.0]
+<!-- release-sha: 2222222 -->
+
+- New
+
+---
+
+## [Release v1.0.0]
+
+- Old
+<!-- release-sha: 1111111 -->
+
+## Engineering Metrics
+<!-- release-sha: 3333333 -->
+`
+	shas := DocumentedSHAs(markdown)
+	want := map[string]string{"v1.1.0": "2222222", "v1.0.0": "1111111"}
+	if len(shas) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, shas)
+	}
+	for version, sha := range want {
+		if shas[version] != sha {
+			t.Errorf("Expected %s → %s, got %q", version, sha, shas[version])
+		}
+	}
+}
+
+func TestRemoveReleaseSections(t *testing.T) {
+	markdown := `# Release Notes: org/repo
+
+## [Release v1.2.0]
+
+- Newest
+
+---
+
+## [Release v1.1.0]
+
+- Moved
+### 👥 Contributors
+- @alice
+
+---
+
+## [Release v1.0.0]
+
+- Old
+`
+	got := RemoveReleaseSections(markdown, []string{"1.1.0"})
+	if strings.Contains(got, "Moved") || strings.Contains(got, "@alice") {
+		t.Errorf("Expected v1.1.0 section removed, got:\n%s", got)
+	}
+	if strings.Count(got, "---") != 1 || !strings.Contains(got, "Newest") || !strings.Contains(got, "Old") {
+		t.Errorf("Expected the other sections and one separator kept, got:\n%s", got)
+	}
+
+	got = RemoveReleaseSections(markdown, []string{"v1.0.0"})
+	if strings.Contains(got, "Old") || strings.HasSuffix(strings.TrimSpace(got), "---") {
+		t.Errorf("Expected last section and its separator removed, got:\n%s", got)
+	}
+}
@@ -0,0 +1,201 @@
+package generator
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// commitPRRe matches pull request references in commit messages:
+// "Fix login (#123)" or "Merge pull request #123 from ..."
+var commitPRRe = regexp.MustCompile(`(?:\(#|pull request #)(\d+)`)
+
+// exportHeader lists the columns of spreadsheet exports
+var exportHeader = []string{"Repository", "Version", "Date", "Category", "Title", "Score", "SHA", "Author", "PR", "Score Reason"}
+
+// ExportRow is one changelog entry flattened for spreadsheets
+type ExportRow struct {
+	Repository  string    `json:"repository"`
+	Version     string    `json:"version"`
+	Date        time.Time `json:"date"`
+	Category    string    `json:"category"`
+	Title       string    `json:"title"`
+	Score       float64   `json:"score"`
+	HasScore    bool      `json:"has_score"` // Timeline PR rows have no score
+	ScoreReason string    `json:"score_reason,omitempty"`
+	SHA         string    `json:"sha,omitempty"`
+	Author      string    `json:"author,omitempty"`
+	PR          int       `json:"pr,omitempty"`
+}
+
+// ExportRows flattens a Changelog, TimelineChangelog, or OrgTimelineChangelog
+// into one row per entry (commit entries, or pull requests in timelines)
+func ExportRows(changelog any) ([]ExportRow, error) {
+	switch c := changelog.(type) {
+	case *Changelog:
+		return changelogRows(c), nil
+	case *TimelineChangelog:
+		return timelineRows(c), nil
+	case *OrgTimelineChangelog:
+		var rows []ExportRow
+		for _, repo := range c.Repos {
+			rows = append(rows, timelineRows(repo)...)
+		}
+		return rows, nil
+	default:
+		return nil, fmt.Errorf("unsupported changelog type %T", changelog)
+	}
+}
+
+// changelogRows returns a row per entry of a range changelog, in category order
+func changelogRows(changelog *Changelog) []ExportRow {
+	var rows []ExportRow
+	for _, category := range categoriesByPriority(changelog.Categories) {
+		for _, entry := range changelog.Categories[category] {
+			row := ExportRow{
+				Repository:  changelog.RepoName,
+				Version:     changelog.ToRef,
+				Date:        changelog.Date,
+				Category:    category,
+				Title:       entry.Title,
+				Score:       entry.ImportanceScore,
+				HasScore:    true,
+				ScoreReason: entry.ScoreReason,
+				SHA:         entry.SHA,
+				Author:      entry.Author,
+			}
+			if commit := findCommit(changelog.Commits, entry.SHA); commit != nil {
+				if m := commitPRRe.FindStringSubmatch(commit.Message); m != nil {
+					row.PR, _ = strconv.Atoi(m[1])
+				}
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// timelineRows returns a row per pull request of every release in a timeline
+func timelineRows(timeline *TimelineChangelog) []ExportRow {
+	var rows []ExportRow
+	for _, release := range timeline.Releases {
+		for _, pr := range release.PullRequests {
+			rows = append(rows, ExportRow{
+				Repository: timeline.RepoName,
+				Version:    release.ToRef,
+				Date:       release.ToDate,
+				Title:      pr.Title,
+				Author:     pr.Author,
+				PR:         pr.Number,
+			})
+		}
+	}
+	return rows
+}
+
+// values returns the row's cells as strings in exportHeader order
+func (r ExportRow) values() []string {
+	values := []string{r.Repository, r.Version, "", r.Category, r.Title, "", r.SHA, r.Author, "", r.ScoreReason}
+	if !r.Date.IsZero() {
+		values[2] = r.Date.Format("2006-01-02")
+	}
+	if r.HasScore {
+		values[5] = strconv.FormatFloat(r.Score, 'f', -1, 64)
+	}
+	if r.PR > 0 {
+		values[8] = strconv.Itoa(r.PR)
+	}
+	return values
+}
+
+// FormatCSV renders rows as CSV with a header line
+func FormatCSV(rows []ExportRow) (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(exportHeader); err != nil {
+		return "", fmt.Errorf("write CSV header: %w", err)
+	}
+	for _, row := range rows {
+		if err := w.Write(row.values()); err != nil {
+			return "", fmt.Errorf("write CSV row: %w", err)
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", fmt.Errorf("write CSV: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// xlsxPart is a file inside an .xlsx zip package
+type xlsxPart struct {
+	name    string
+	content string
+}
+
+// xlsxParts are the fixed parts of a single-sheet workbook
+var xlsxParts = []xlsxPart{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+	{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Changelog" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`},
+}
+
+// FormatXLSX renders rows as a single-sheet Excel workbook. Scores and PR
+// numbers are written as numbers so they can be pivoted and sorted.
+func FormatXLSX(rows []ExportRow) ([]byte, error) {
+	var sheet bytes.Buffer
+	sheet.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	sheet.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	writeXLSXRow(&sheet, exportHeader, nil)
+	numeric := map[int]bool{5: true, 8: true} // Score, PR
+	for _, row := range rows {
+		writeXLSXRow(&sheet, row.values(), numeric)
+	}
+	sheet.WriteString(`</sheetData></worksheet>`)
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	parts := append(xlsxParts[:len(xlsxParts):len(xlsxParts)], xlsxPart{"xl/worksheets/sheet1.xml", sheet.String()})
+	for _, part := range parts {
+		w, err := zw.Create(part.name)
+		if err != nil {
+			return nil, fmt.Errorf("create %s: %w", part.name, err)
+		}
+		if _, err := w.Write([]byte(part.content)); err != nil {
+			return nil, fmt.Errorf("write %s: %w", part.name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("write workbook: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// writeXLSXRow writes one sheet row; cells in numeric columns are written as
+// numbers, everything else as inline strings
+func writeXLSXRow(sheet *bytes.Buffer, values []string, numeric map[int]bool) {
+	sheet.WriteString("<row>")
+	for i, value := range values {
+		switch {
+		case value == "":
+			sheet.WriteString("<c/>")
+		case numeric[i]:
+			sheet.WriteString(`<c t="n"><v>` + value + `</v></c>`)
+		default:
+			sheet.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+			xml.EscapeText(sheet, []byte(value))
+			sheet.WriteString(`</t></is></c>`)
+		}
+	}
+	sheet.WriteString("</row>")
+}
@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+func TestExportRowsCSV(t *testing.T) {
+	changelog := &Changelog{
+		RepoName: "acme/api",
+		ToRef:    "v1.1.0",
+		Date:     time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Categories: map[string][]llm.ChangelogEntry{
+			"Features": {{SHA: "abc123", Title: "Add webhooks, finally", Author: "alice", ImportanceScore: 8.5, ScoreReason: "New integration point"}},
+		},
+		Commits: []provider.CommitData{{SHA: "abc123", Message: "Add webhooks (#42)"}},
+	}
+
+	rows, err := ExportRows(changelog)
+	if err != nil {
+		t.Fatalf("ExportRows() error = %v", err)
+	}
+	csv, err := FormatCSV(rows)
+	if err != nil {
+		t.Fatalf("FormatCSV() error = %v", err)
+	}
+
+	want := "Repository,Version,Date,Category,Title,Score,SHA,Author,PR,Score Reason\n" +
+		"acme/api,v1.1.0,2024-03-01,Features,\"Add webhooks, finally\",8.5,abc123,alice,42,New integration point\n"
+	if csv != want {
+		t.Errorf("FormatCSV() = %q, want %q", csv, want)
+	}
+}
+
+func TestFormatXLSX(t *testing.T) {
+	data, err := FormatXLSX([]ExportRow{{Version: "v1.0.0", Title: "Fix <login>", PR: 7}})
+	if err != nil {
+		t.Fatalf("FormatXLSX() error = %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("Expected a zip package: %v", err)
+	}
+	var sheet string
+	for _, f := range zr.File {
+		if f.Name == "xl/worksheets/sheet1.xml" {
+			rc, _ := f.Open()
+			content, _ := io.ReadAll(rc)
+			rc.Close()
+			sheet = string(content)
+		}
+	}
+	if !strings.Contains(sheet, "Fix &lt;login&gt;") || !strings.Contains(sheet, `<c t="n"><v>7</v></c>`) {
+		t.Errorf("Unexpected sheet content: %s", sheet)
+	}
+}
@@ -3,9 +3,12 @@ package generator
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
 	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/tickets"
 )
 
 // CategoryEmojis maps category names to emoji prefixes
@@ -14,6 +17,7 @@ var CategoryEmojis = map[string]string{
 	"Improvements":     "⚡",
 	"Bug Fixes":        "🐛",
 	"Breaking Changes": "💥",
+	"Security":         "🔒",
 	"Documentation":    "📚",
 	"Internal":         "🔧",
 }
@@ -21,6 +25,7 @@ var CategoryEmojis = map[string]string{
 // CategoryOrder defines the order in which categories appear
 var CategoryOrder = []string{
 	"Breaking Changes",
+	"Security",
 	"Features",
 	"Improvements",
 	"Bug Fixes",
@@ -28,8 +33,10 @@ var CategoryOrder = []string{
 	"Internal",
 }
 
-// FormatMarkdown generates GitHub-flavored markdown from the changelog response
-func FormatMarkdown(response *llm.ChangelogResponse, from, to string, cfg *config.Config) string {
+// FormatMarkdown generates GitHub-flavored markdown from the changelog response.
+// commitURL links each entry's SHA; with a nil commitURL SHAs are not linked.
+// cfg.LinkStyle picks whether entries show SHAs, pull request numbers, or both.
+func FormatMarkdown(response *llm.ChangelogResponse, from, to string, cfg *config.Config, commitURL func(sha string) string) string {
 	var sb strings.Builder
 
 	// Title
@@ -51,9 +58,46 @@ func FormatMarkdown(response *llm.ChangelogResponse, from, to string, cfg *confi
 		sb.WriteString("\n")
 	}
 
-	// Categories in order
+	// Categories in order, nested under product areas when grouping by area;
+	// grouped bot entries are held back for their own section
+	var botEntries []llm.ChangelogEntry
+	if cfg.GroupByArea && hasAreas(response.Categories) {
+		areas, grouped := groupByArea(response.Categories, cfg.ProductAreas)
+		for _, area := range areas {
+			var section strings.Builder
+			botEntries = append(botEntries, writeCategories(&section, grouped[area], "###", cfg, commitURL)...)
+			if section.Len() > 0 { // Areas of only grouped bot entries get no section
+				sb.WriteString(fmt.Sprintf("## %s\n\n", area))
+				sb.WriteString(section.String())
+			}
+		}
+	} else {
+		botEntries = writeCategories(&sb, response.Categories, "##", cfg, commitURL)
+	}
+
+	if len(botEntries) > 0 {
+		sb.WriteString(fmt.Sprintf("## %s\n\n", BotHeading))
+		for _, entry := range botEntries {
+			writeEntry(&sb, entry, cfg, commitURL)
+		}
+	}
+
+	return sb.String()
+}
+
+// writeCategories renders a section per category, with headings at the given
+// level, in CategoryOrder and then any other categories. With grouped bot
+// commits, bot entries are left out and returned for their own section.
+func writeCategories(sb *strings.Builder, categories map[string][]llm.ChangelogEntry, level string, cfg *config.Config, commitURL func(sha string) string) []llm.ChangelogEntry {
+	grouped := cfg.BotCommits == "group"
+	var botEntries []llm.ChangelogEntry
 	for _, category := range CategoryOrder {
-		entries, exists := response.Categories[category]
+		entries, exists := categories[category]
+		if grouped {
+			var bots []llm.ChangelogEntry
+			entries, bots = splitBotEntries(entries)
+			botEntries = append(botEntries, bots...)
+		}
 		if !exists || len(entries) == 0 {
 			continue
 		}
@@ -63,60 +107,15 @@ func FormatMarkdown(response *llm.ChangelogResponse, from, to string, cfg *confi
 			emoji = "•"
 		}
 
-		sb.WriteString(fmt.Sprintf("## %s %s\n\n", emoji, category))
+		sb.WriteString(fmt.Sprintf("%s %s %s\n\n", level, emoji, category))
 
 		for _, entry := range entries {
-			// Skip entries below minimum score threshold
-			if cfg.MinScore > 0 && entry.ImportanceScore < cfg.MinScore {
-				continue
-			}
-
-			// Format: **Title** ([SHA](link))
-			commitLink := fmt.Sprintf("https://github.com/%s/%s/commit/%s",
-				cfg.RepoOwner, cfg.RepoName, entry.SHA)
-
-			// Get short SHA (first 7 chars or full if shorter)
-			shortSHA := entry.SHA
-			if len(shortSHA) > 7 {
-				shortSHA = shortSHA[:7]
-			}
-
-			sb.WriteString(fmt.Sprintf("- **%s** ([`%s`](%s))",
-				entry.Title,
-				shortSHA,
-				commitLink,
-			))
-
-			// Add score if configured
-			if cfg.ShowScores {
-				scoreIndicator := getScoreIndicator(entry.ImportanceScore)
-				sb.WriteString(fmt.Sprintf(" %s **[%.1f]**", scoreIndicator, entry.ImportanceScore))
-			}
-
-			// Add author if configured
-			if cfg.IncludeAuthors && entry.Author != "" {
-				sb.WriteString(fmt.Sprintf(" by @%s", entry.Author))
-			}
-
-			sb.WriteString("\n")
-
-			// Add description if present
-			if entry.Description != "" {
-				// Indent description
-				lines := strings.Split(entry.Description, "\n")
-				for _, line := range lines {
-					if line != "" {
-						sb.WriteString(fmt.Sprintf("  %s\n", line))
-					}
-				}
-			}
-
-			sb.WriteString("\n")
+			writeEntry(sb, entry, cfg, commitURL)
 		}
 	}
 
 	// Add any categories that weren't in our predefined order
-	for category, entries := range response.Categories {
+	for category, entries := range categories {
 		// Skip if already processed
 		alreadyProcessed := false
 		for _, knownCategory := range CategoryOrder {
@@ -125,60 +124,111 @@ func FormatMarkdown(response *llm.ChangelogResponse, from, to string, cfg *confi
 				break
 			}
 		}
-		if alreadyProcessed || len(entries) == 0 {
+		if alreadyProcessed {
+			continue
+		}
+		if grouped {
+			var bots []llm.ChangelogEntry
+			entries, bots = splitBotEntries(entries)
+			botEntries = append(botEntries, bots...)
+		}
+		if len(entries) == 0 {
 			continue
 		}
 
 		// Use default emoji for unknown categories
-		sb.WriteString(fmt.Sprintf("## • %s\n\n", category))
+		sb.WriteString(fmt.Sprintf("%s • %s\n\n", level, category))
 
 		for _, entry := range entries {
-			// Skip entries below minimum score threshold
-			if cfg.MinScore > 0 && entry.ImportanceScore < cfg.MinScore {
-				continue
-			}
+			writeEntry(sb, entry, cfg, commitURL)
+		}
+	}
+	return botEntries
+}
 
-			commitLink := fmt.Sprintf("https://github.com/%s/%s/commit/%s",
-				cfg.RepoOwner, cfg.RepoName, entry.SHA)
+// writeEntry renders a single changelog entry as a markdown list item
+func writeEntry(sb *strings.Builder, entry llm.ChangelogEntry, cfg *config.Config, commitURL func(sha string) string) {
+	// Skip entries below minimum score threshold
+	if cfg.MinScore > 0 && entry.ImportanceScore < cfg.MinScore {
+		return
+	}
 
-			// Get short SHA (first 7 chars or full if shorter)
-			shortSHA := entry.SHA
-			if len(shortSHA) > 7 {
-				shortSHA = shortSHA[:7]
-			}
+	// Format: **Title** ([SHA](link)), or ([#PR](link)) by link style
+	sb.WriteString(fmt.Sprintf("- **%s**%s", entry.Title, formatEntryLinks(entry, cfg.LinkStyle, commitURL)))
 
-			sb.WriteString(fmt.Sprintf("- **%s** ([`%s`](%s))",
-				entry.Title,
-				shortSHA,
-				commitLink,
-			))
+	// Add linked tickets
+	sb.WriteString(formatTickets(entry.Tickets))
 
-			// Add score if configured
-			if cfg.ShowScores {
-				scoreIndicator := getScoreIndicator(entry.ImportanceScore)
-				sb.WriteString(fmt.Sprintf(" %s **[%.1f]**", scoreIndicator, entry.ImportanceScore))
-			}
+	// Point readers at the documentation of the entry's product area
+	sb.WriteString(formatDocsLink(entry))
 
-			if cfg.IncludeAuthors && entry.Author != "" {
-				sb.WriteString(fmt.Sprintf(" by @%s", entry.Author))
-			}
+	// Point at reverts and cherry-picks of the same change
+	sb.WriteString(formatRelations(entry))
 
-			sb.WriteString("\n")
+	// Flag commits whose signature the provider could not verify
+	if cfg.UnverifiedCommits == "annotate" && entry.Unverified {
+		sb.WriteString(" · ⚠️ unverified")
+	}
 
-			if entry.Description != "" {
-				lines := strings.Split(entry.Description, "\n")
-				for _, line := range lines {
-					if line != "" {
-						sb.WriteString(fmt.Sprintf("  %s\n", line))
-					}
-				}
-			}
+	// Security entries carry the model's severity rating
+	if entry.Severity != "" {
+		sb.WriteString(fmt.Sprintf(" · severity: **%s**", strings.ToLower(entry.Severity)))
+	}
 
-			sb.WriteString("\n")
+	// Add score if configured
+	if cfg.ShowScores {
+		scoreIndicator := getScoreIndicator(entry.ImportanceScore)
+		sb.WriteString(fmt.Sprintf(" %s **[%.1f]**", scoreIndicator, entry.ImportanceScore))
+	}
+
+	// Add authors, including co-authors, if configured
+	if cfg.IncludeAuthors {
+		sb.WriteString(formatAuthors(entry.Author, entry.CoAuthors))
+	}
+
+	sb.WriteString("\n")
+
+	// Add description if present
+	if entry.Description != "" {
+		// Indent description
+		lines := strings.Split(entry.Description, "\n")
+		for _, line := range lines {
+			if line != "" {
+				sb.WriteString(fmt.Sprintf("  %s\n", line))
+			}
 		}
 	}
 
-	return sb.String()
+	// Add the score rationale so filtering decisions can be reviewed
+	if cfg.ShowScoreReasons && entry.ScoreReason != "" {
+		sb.WriteString(fmt.Sprintf("  _Score %.1f: %s_\n", entry.ImportanceScore, entry.ScoreReason))
+	}
+
+	// Show the screenshot from the entry's pull request
+	if entry.Image != "" {
+		sb.WriteString(fmt.Sprintf("  %s\n", formatImage(entry.Title, entry.Image)))
+	}
+
+	sb.WriteString("\n")
+}
+
+// formatTickets renders linked tickets as " · [ID](url)" links.
+// The ticket summary, when known, becomes the link title (shown on hover).
+func formatTickets(linked []tickets.Ticket) string {
+	if len(linked) == 0 {
+		return ""
+	}
+
+	links := make([]string, 0, len(linked))
+	for _, ticket := range linked {
+		if ticket.Summary != "" {
+			title := strings.ReplaceAll(ticket.Summary, `"`, `'`)
+			links = append(links, fmt.Sprintf("[%s](%s \"%s\")", ticket.ID, ticket.URL, title))
+		} else {
+			links = append(links, fmt.Sprintf("[%s](%s)", ticket.ID, ticket.URL))
+		}
+	}
+	return " · " + strings.Join(links, ", ")
 }
 
 // getScoreIndicator returns a visual indicator based on the importance score
@@ -207,31 +257,145 @@ func (g *Generator) formatTimelineAsMarkdown(timeline *TimelineChangelog) string
 		timeline.FromDate.Format("January 2, 2006"),
 		timeline.ToDate.Format("January 2, 2006")))
 
+	// Release cadence and commit volume across the window
+	b.WriteString(FormatActivityChart(timeline, g.config.ActivityChart))
+
+	// Themes across all releases, before the per-release detail
+	b.WriteString(FormatPeriodSummary(timeline.Period))
+
 	// Each release section
-	for i, release := range timeline.Releases {
-		b.WriteString(fmt.Sprintf("## [Release %s]\n\n", release.ToRef))
-
-		if len(release.PullRequests) > 0 {
-			for _, pr := range release.PullRequests {
-				// Format: - PR title by @author in PR_URL
-				b.WriteString(fmt.Sprintf("- %s by @%s in %s\n", pr.Title, pr.Author, pr.URL))
-
-				// Add LLM summary indented
-				if summary, ok := release.PRSummaries[pr.Number]; ok && summary != "" {
-					b.WriteString(fmt.Sprintf("    - %s\n", summary))
-				}
-			}
+	for i := range timeline.Releases {
+		release := &timeline.Releases[i]
+		if release.Markdown != "" {
+			b.WriteString(withSHAComment(strings.TrimRight(release.Markdown, "\n"), release.ToSHA) + "\n\n")
 		} else {
-			b.WriteString("_No pull requests in this release._\n")
+			b.WriteString(g.formatReleaseSection(release))
 		}
 
-		b.WriteString("\n")
-
 		// Separator between releases
 		if i < len(timeline.Releases)-1 {
 			b.WriteString("---\n\n")
 		}
 	}
 
+	// Engineering metrics appendix
+	if timeline.Metrics != nil {
+		b.WriteString("---\n\n")
+		b.WriteString(FormatMetrics(timeline.Metrics))
+	}
+
 	return b.String()
 }
+
+// formatReleaseSection renders a single release section of a timeline changelog
+func (g *Generator) formatReleaseSection(release *ReleaseChangelog) string {
+	var b strings.Builder
+
+	compareURL := g.compareURL(release.FromRef, release.ToRef)
+	if release.Unreleased {
+		// The untagged head has no release date and no install instructions
+		b.WriteString("## [Unreleased]\n\n")
+		b.WriteString(formatReleaseMeta(time.Time{}, release.FromRef, shortRef(release.ToRef), compareURL))
+	} else {
+		title := release.ToRef
+		if release.Series != "" {
+			title = release.Series
+		}
+		b.WriteString(fmt.Sprintf("## [Release %s]\n", title))
+		if release.ToSHA != "" {
+			b.WriteString(releaseSHAComment(release.ToSHA) + "\n")
+		}
+		b.WriteString("\n")
+		b.WriteString(formatReleaseMeta(release.ToDate, release.FromRef, release.ToRef, compareURL))
+	}
+	if len(release.Versions) > 1 {
+		b.WriteString(fmt.Sprintf("**Includes:** %s\n\n", strings.Join(release.Versions, ", ")))
+	}
+	b.WriteString(FormatReleaseComparison(release.Stats))
+
+	if len(release.PullRequests) > 0 {
+		var botPRs []provider.PullRequestData
+		for _, pr := range release.PullRequests {
+			if g.config.BotCommits == "group" && provider.IsBot(pr.Author) {
+				botPRs = append(botPRs, pr)
+				continue
+			}
+			writeReleasePR(&b, release, pr, g.config)
+		}
+		if len(botPRs) > 0 {
+			b.WriteString(fmt.Sprintf("\n**%s**\n\n", BotHeading))
+			for _, pr := range botPRs {
+				writeReleasePR(&b, release, pr, g.config)
+			}
+		}
+	} else {
+		b.WriteString("_No pull requests in this release._\n")
+	}
+
+	b.WriteString("\n")
+
+	b.WriteString(FormatContributors(release.Contributors, 3))
+	b.WriteString(FormatArtifacts(release.Artifacts, 3))
+	if !release.Unreleased {
+		b.WriteString(FormatInstallSnippet(g.config, release.ToRef, 3))
+	}
+	if g.config.FullChangelogLink {
+		b.WriteString(formatFullChangelog(compareURL))
+	}
+
+	return b.String()
+}
+
+// writeReleasePR renders a pull request of a timeline release with its summary.
+// Calibrated scores are shown and filtered like commit entry scores.
+func writeReleasePR(b *strings.Builder, release *ReleaseChangelog, pr provider.PullRequestData, cfg *config.Config) {
+	score, scored := release.PRScores[pr.Number]
+	if scored && cfg.MinScore > 0 && score.ImportanceScore < cfg.MinScore {
+		return
+	}
+
+	// Format: - PR title by @author in PR_URL
+	b.WriteString(fmt.Sprintf("- %s by @%s in %s%s",
+		pr.Title, pr.Author, pr.URL, formatTickets(release.PRTickets[pr.Number])))
+	if scored && cfg.ShowScores {
+		b.WriteString(fmt.Sprintf(" %s **[%.1f]**", getScoreIndicator(score.ImportanceScore), score.ImportanceScore))
+	}
+	b.WriteString("\n")
+
+	// Add LLM summary indented
+	if summary, ok := release.PRSummaries[pr.Number]; ok && summary != "" {
+		b.WriteString(fmt.Sprintf("    - %s\n", summary))
+	}
+	if scored && cfg.ShowScoreReasons && score.ScoreReason != "" {
+		b.WriteString(fmt.Sprintf("    _Score %.1f: %s_\n", score.ImportanceScore, score.ScoreReason))
+	}
+	if image := release.PRImages[pr.Number]; image != "" {
+		b.WriteString(fmt.Sprintf("    %s\n", formatImage(pr.Title, image)))
+	}
+}
+
+// formatReleaseMeta renders the line under a version heading: the release
+// date and a [from..to] link to the provider's comparison of the two refs.
+// Unknown parts are left out.
+func formatReleaseMeta(date time.Time, from, to, compareURL string) string {
+	var parts []string
+	if !date.IsZero() {
+		parts = append(parts, "Released "+date.Format("2006-01-02"))
+	}
+	if compareURL != "" {
+		parts = append(parts, fmt.Sprintf("[%s..%s](%s)", from, to, compareURL))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "_" + strings.Join(parts, " · ") + "_\n\n"
+}
+
+// formatFullChangelog renders the "Full Changelog" footer of GitHub's
+// generated release notes
+func formatFullChangelog(compareURL string) string {
+	if compareURL == "" {
+		return ""
+	}
+	return fmt.Sprintf("**Full Changelog**: %s\n\n", compareURL)
+}
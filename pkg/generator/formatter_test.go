@@ -3,8 +3,10 @@ package generator
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/github"
 	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
 )
 
@@ -43,7 +45,7 @@ func TestFormatMarkdown(t *testing.T) {
 		IncludeAuthors: true,
 	}
 
-	markdown := FormatMarkdown(response, "v1.0.0", "v1.1.0", cfg)
+	markdown := FormatMarkdown(response, "v1.0.0", "v1.1.0", cfg, github.NewClient("", cfg.RepoOwner, cfg.RepoName).CommitURL)
 
 	// Verify markdown structure
 	if markdown == "" {
@@ -98,7 +100,7 @@ func TestFormatMarkdownWithoutAuthors(t *testing.T) {
 		IncludeAuthors: false, // Disabled
 	}
 
-	markdown := FormatMarkdown(response, "v1.0.0", "v1.1.0", cfg)
+	markdown := FormatMarkdown(response, "v1.0.0", "v1.1.0", cfg, github.NewClient("", cfg.RepoOwner, cfg.RepoName).CommitURL)
 
 	// Should not contain author
 	if strings.Contains(markdown, "@john") {
@@ -132,6 +134,7 @@ func TestCategoryEmojis(t *testing.T) {
 func TestCategoryOrder(t *testing.T) {
 	expectedOrder := []string{
 		"Breaking Changes",
+		"Security",
 		"Features",
 		"Improvements",
 		"Bug Fixes",
@@ -151,3 +154,47 @@ func TestCategoryOrder(t *testing.T) {
 		}
 	}
 }
+
+func TestFormatMarkdownScoreReasons(t *testing.T) {
+	response := &llm.ChangelogResponse{
+		Categories: map[string][]llm.ChangelogEntry{
+			"Bug Fixes": {
+				{SHA: "abc123", Title: "Fix crash", ImportanceScore: 7, ScoreReason: "Crash affected every login"},
+			},
+		},
+	}
+
+	cfg := &config.Config{RepoOwner: "org", RepoName: "repo"}
+	if markdown := FormatMarkdown(response, "v1.0.0", "v1.1.0", cfg, nil); strings.Contains(markdown, "Crash affected") {
+		t.Error("Expected score reasons to be hidden by default")
+	}
+
+	cfg.ShowScoreReasons = true
+	markdown := FormatMarkdown(response, "v1.0.0", "v1.1.0", cfg, nil)
+	if !strings.Contains(markdown, "_Score 7.0: Crash affected every login_") {
+		t.Errorf("Expected markdown to contain the score reason\nGot:\n%s", markdown)
+	}
+}
+
+func TestFormatReleaseMeta(t *testing.T) {
+	compare := github.NewClient("", "org", "repo").CompareURL("v1.0.0", "v1.1.0")
+	if compare != "https://github.com/org/repo/compare/v1.0.0...v1.1.0" {
+		t.Errorf("Unexpected compare URL %s", compare)
+	}
+
+	date := time.Date(2024, 3, 7, 15, 0, 0, 0, time.UTC)
+	want := "_Released 2024-03-07 · [v1.0.0..v1.1.0](" + compare + ")_\n\n"
+	if got := formatReleaseMeta(date, "v1.0.0", "v1.1.0", compare); got != want {
+		t.Errorf("formatReleaseMeta() = %q, want %q", got, want)
+	}
+	if got := formatReleaseMeta(date, "v1.0.0", "v1.1.0", ""); got != "_Released 2024-03-07_\n\n" {
+		t.Errorf("Expected only the date without a provider, got %q", got)
+	}
+	if got := formatReleaseMeta(time.Time{}, "v1.0.0", "v1.1.0", ""); got != "" {
+		t.Errorf("Expected no line without a date or link, got %q", got)
+	}
+
+	if got := formatFullChangelog(compare); got != "**Full Changelog**: "+compare+"\n\n" {
+		t.Errorf("Unexpected footer %q", got)
+	}
+}
@@ -0,0 +1,181 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+)
+
+// FragmentTypes maps news fragment types, towncrier's plus a few of our own,
+// onto changelog categories
+var FragmentTypes = map[string]string{
+	"breaking":    "Breaking Changes",
+	"removal":     "Breaking Changes",
+	"security":    "Security",
+	"feature":     "Features",
+	"improvement": "Improvements",
+	"bugfix":      "Bug Fixes",
+	"doc":         "Documentation",
+	"misc":        "Internal",
+}
+
+// fragmentNameRe matches fragment file names: <id>.<type>.md, where id is a
+// pull request number or a short slug
+var fragmentNameRe = regexp.MustCompile(`^([A-Za-z0-9_-]+)\.([a-z]+)\.md$`)
+
+// Fragment is one news fragment file describing a single change
+type Fragment struct {
+	Path string
+	ID   string // Pull request number or slug
+	Type string
+	Text string
+}
+
+// FragmentTypeNames lists the known fragment types alphabetically
+func FragmentTypeNames() []string {
+	names := make([]string, 0, len(FragmentTypes))
+	for name := range FragmentTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// AddFragment writes a news fragment to dir and returns its path. Fragments
+// are named after the pull request number when there is one, otherwise after
+// the first words of the text; an existing file is never overwritten.
+func AddFragment(dir, kind string, pr int, text string) (string, error) {
+	if _, ok := FragmentTypes[kind]; !ok {
+		return "", fmt.Errorf("unknown fragment type %q (expected %s)", kind, strings.Join(FragmentTypeNames(), ", "))
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", fmt.Errorf("fragment text is empty")
+	}
+
+	id := fragmentSlug(text)
+	if pr > 0 {
+		id = fmt.Sprintf("%d", pr)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("create fragment directory: %w", err)
+	}
+	for n := 1; ; n++ {
+		name := id
+		if n > 1 {
+			name = fmt.Sprintf("%s-%d", id, n)
+		}
+		path := filepath.Join(dir, name+"."+kind+".md")
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if os.IsExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("write fragment: %w", err)
+		}
+		if _, err := file.WriteString(text + "\n"); err != nil {
+			file.Close()
+			return "", fmt.Errorf("write fragment: %w", err)
+		}
+		if err := file.Close(); err != nil {
+			return "", fmt.Errorf("write fragment: %w", err)
+		}
+		return path, nil
+	}
+}
+
+// fragmentSlug builds a file name from the first few words of a fragment
+func fragmentSlug(text string) string {
+	var words []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	}) {
+		if words = append(words, word); len(words) == 5 {
+			break
+		}
+	}
+	if len(words) == 0 {
+		return "change"
+	}
+	return strings.Join(words, "-")
+}
+
+// ReadFragments reads the news fragments in dir, ordered by pull request
+// number and then name. Files that are not fragments, like a README, are
+// skipped; fragments of unknown types are an error.
+func ReadFragments(dir string) ([]Fragment, error) {
+	files, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read fragment directory: %w", err)
+	}
+
+	var fragments []Fragment
+	for _, file := range files {
+		m := fragmentNameRe.FindStringSubmatch(file.Name())
+		if file.IsDir() || m == nil {
+			continue
+		}
+		if _, ok := FragmentTypes[m[2]]; !ok {
+			return nil, fmt.Errorf("fragment %s: unknown type %q (expected %s)", file.Name(), m[2], strings.Join(FragmentTypeNames(), ", "))
+		}
+		path := filepath.Join(dir, file.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read fragment: %w", err)
+		}
+		text := strings.TrimSpace(string(data))
+		if text == "" {
+			return nil, fmt.Errorf("fragment %s is empty", file.Name())
+		}
+		fragments = append(fragments, Fragment{Path: path, ID: m[1], Type: m[2], Text: text})
+	}
+
+	sort.SliceStable(fragments, func(i, j int) bool {
+		a, b := fragmentPR(fragments[i]), fragmentPR(fragments[j])
+		if a != b {
+			// Numbered fragments first, in merge order
+			return b == 0 || (a > 0 && a < b)
+		}
+		return fragments[i].ID < fragments[j].ID
+	})
+	return fragments, nil
+}
+
+// fragmentPR returns the pull request number a fragment is named after, or 0
+func fragmentPR(fragment Fragment) int {
+	var number int
+	if _, err := fmt.Sscanf(fragment.ID, "%d", &number); err != nil || fmt.Sprint(number) != fragment.ID {
+		return 0
+	}
+	return number
+}
+
+// FragmentCommits turns fragments into the commits the changelog pipeline
+// polishes and assembles. The fragment type is passed to the model as the
+// labeled category, and fragments named after a pull request reference it.
+func FragmentCommits(fragments []Fragment) []llm.CommitInfo {
+	commits := make([]llm.CommitInfo, 0, len(fragments))
+	for _, fragment := range fragments {
+		commit := llm.CommitInfo{
+			SHA:           fragment.ID,
+			Message:       fragment.Text,
+			LabelCategory: FragmentTypes[fragment.Type],
+		}
+		if number := fragmentPR(fragment); number > 0 {
+			commit.SHA = fmt.Sprintf("#%d", number)
+			// Keep the reference on the subject line, where CommitPRNumber looks
+			subject, body, _ := strings.Cut(fragment.Text, "\n")
+			commit.Message = strings.TrimSpace(fmt.Sprintf("%s (#%d)\n%s", subject, number, body))
+		}
+		commits = append(commits, commit)
+	}
+	return commits
+}
@@ -0,0 +1,65 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFragments(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "changelog.d")
+
+	for _, add := range []struct {
+		kind string
+		pr   int
+		text string
+		want string
+	}{
+		{"bugfix", 42, "Fix upload timeouts\n\nThe client now retries once.", "42.bugfix.md"},
+		{"feature", 7, "Add SSO login", "7.feature.md"},
+		{"misc", 0, "Bump the Go toolchain to 1.22!", "bump-the-go-toolchain-to.misc.md"},
+		{"misc", 0, "Bump the Go toolchain to 1.23", "bump-the-go-toolchain-to-2.misc.md"}, // Never overwrites
+	} {
+		path, err := AddFragment(dir, add.kind, add.pr, add.text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if filepath.Base(path) != add.want {
+			t.Errorf("AddFragment(%s, %d) = %s, want %s", add.kind, add.pr, filepath.Base(path), add.want)
+		}
+	}
+	if _, err := AddFragment(dir, "feat", 1, "x"); err == nil {
+		t.Error("Expected an error for an unknown fragment type")
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("Fragments go here"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	fragments, err := ReadFragments(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, fragment := range fragments {
+		ids = append(ids, fragment.ID)
+	}
+	want := []string{"7", "42", "bump-the-go-toolchain-to", "bump-the-go-toolchain-to-2"}
+	if len(ids) != len(want) || ids[0] != want[0] || ids[1] != want[1] || ids[2] != want[2] || ids[3] != want[3] {
+		t.Fatalf("ReadFragments() = %v, want %v", ids, want)
+	}
+
+	commits := FragmentCommits(fragments)
+	if commits[1].SHA != "#42" || commits[1].Message != "Fix upload timeouts (#42)\n\nThe client now retries once." || commits[1].LabelCategory != "Bug Fixes" {
+		t.Errorf("Unexpected fragment commit: %+v", commits[1])
+	}
+	if CommitPRNumber(commits[1].Message) != 42 || commits[2].SHA != "bump-the-go-toolchain-to" || commits[2].LabelCategory != "Internal" {
+		t.Errorf("Unexpected fragment commits: %+v", commits)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "9.feat.md"), []byte("Typo"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadFragments(dir); err == nil {
+		t.Error("Expected an error for a fragment of unknown type")
+	}
+}
@@ -1,127 +1,434 @@
+// Package generator turns commits from a hosting provider, or supplied by the
+// caller, into categorized changelogs. It can be used as a library: New takes
+// any provider.Provider and llm.Client, and settings come from a
+// config.Config rather than files, flags, or the environment.
 package generator
 
 import (
+	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
-	"github.com/rakshaksatsangi/changelog-generator/pkg/github"
 	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/logging"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/tickets"
 )
 
+var logger = logging.Module("generator")
+
 // Generator orchestrates the changelog generation workflow
 type Generator struct {
-	githubClient *github.Client
-	llmClient    *llm.OpenAIClient
-	config       *config.Config
+	provider   provider.Provider // Nil when commits are supplied by the caller
+	llmClient  llm.Client
+	config     *config.Config
+	tickets    *tickets.Linker
+	documented map[string]bool
+	shas       map[string]string // Documented version → commit recorded in its section
+	stale      []string          // Documented versions whose tag was deleted or moved
+	stack      *string           // Detected languages and frameworks, nil until detected
+	warnings   []string          // Everything noteworthy in the last run
+	violations []string          // Soft failures that fail the run in strict mode
+	training   []llm.TrainingExample
+	commitSHAs []string // Commits to fetch instead of enumerating a range
+	hooks      []Hooks
+	version    string    // Calling program's version, for metadata
+	started    time.Time // When the current run started
+	baseUsage  llm.Usage // LLM usage before the current run
+	baseTrace  int       // Prompts the LLM client had hashed before the current run
+
+	// Batch API responses by release and their tokens, from UseBatchResults
+	batch      map[string]*llm.PRChangelogResponse
+	batchUsage llm.Usage
+
+	onProgress func(ProgressEvent) // From SetProgress; nil = no reports
+}
+
+// NewGenerator creates a new changelog generator reading from a hosting
+// provider; it is New with WithConfig(cfg)
+func NewGenerator(source provider.Provider, llmClient llm.Client, cfg *config.Config) *Generator {
+	return New(source, llmClient, WithConfig(cfg))
+}
+
+// SetTicketLinker enables issue tracker linking for generated entries
+func (g *Generator) SetTicketLinker(linker *tickets.Linker) {
+	g.tickets = linker
+}
+
+// SetDocumentedVersions sets the versions already present in the target
+// changelog; timeline generation skips releases ending at one of them
+func (g *Generator) SetDocumentedVersions(versions map[string]bool) {
+	g.documented = versions
+}
+
+// SetDocumentedSHAs sets the commits recorded for documented versions; a
+// release whose tag now points elsewhere is regenerated instead of skipped
+func (g *Generator) SetDocumentedSHAs(shas map[string]string) {
+	g.shas = shas
+}
+
+// StaleVersions returns the documented versions from the last run whose tag
+// was deleted or moved; their existing sections should be removed
+func (g *Generator) StaleVersions() []string {
+	return g.stale
+}
+
+// TrainingExamples returns the prompt/response pairs from the last run, with
+// responses as corrected by post-processing
+func (g *Generator) TrainingExamples() []llm.TrainingExample {
+	return g.training
+}
+
+// Usage returns the LLM token usage so far
+func (g *Generator) Usage() llm.Usage {
+	return g.llmClient.Usage()
+}
+
+// resetRun clears per-run state before a new generation run
+func (g *Generator) resetRun() {
+	g.warnings = nil
+	g.violations = nil
+	g.training = nil
+	g.stale = nil
+	g.started = time.Now()
+	g.baseUsage = g.llmClient.Usage()
+	if t, ok := g.llmClient.(tracer); ok {
+		g.baseTrace = len(t.Trace().PromptHashes)
+	}
 }
 
-// NewGenerator creates a new changelog generator
-func NewGenerator(githubClient *github.Client, llmClient *llm.OpenAIClient, cfg *config.Config) *Generator {
-	return &Generator{
-		githubClient: githubClient,
-		llmClient:    llmClient,
-		config:       cfg,
+// recordExample keeps a prompt/response pair for --collect-training-data
+func (g *Generator) recordExample(prompt string, response any) {
+	if g.config.TrainingDataDir == "" {
+		return
 	}
+	example, err := llm.NewTrainingExample(prompt, response)
+	if err != nil {
+		g.warn("skipping training example: %v", err)
+		return
+	}
+	g.training = append(g.training, example)
 }
 
 // Generate creates a changelog for the specified commit range
-func (g *Generator) Generate(from, to string) (*Changelog, error) {
-	if g.config.Verbose {
-		fmt.Printf("Fetching commits from %s to %s...\n", from, to)
+func (g *Generator) Generate(ctx context.Context, from, to string) (*Changelog, error) {
+	g.resetRun()
+
+	commits, err := g.fetchRange(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// 2. Prepare commits for LLM (with diffs summarized to fit token limits)
+	return g.generate(ctx, commits, g.prepareCommitsForLLM(commits), from, to)
+}
+
+// fetchRange fetches the commits of a range through the fetch hooks, with
+// merged branches collapsed as configured
+func (g *Generator) fetchRange(ctx context.Context, from, to string) ([]provider.CommitData, error) {
+	if err := g.runPreFetch(ctx, from, to); err != nil {
+		return nil, err
 	}
+	logger.Info("fetching commits", "from", from, "to", to)
+	g.progress(StageFetching, fmt.Sprintf("fetching commits %s..%s", from, to), 0, 0)
 
 	// 1. Fetch commits from GitHub
-	commits, err := g.githubClient.GetCommitRange(from, to)
+	commits, err := g.fetchCommits(ctx, from, to)
 	if err != nil {
 		return nil, fmt.Errorf("fetch commits: %w", err)
 	}
+	if commits, err = g.runPostFetch(ctx, commits); err != nil {
+		return nil, err
+	}
 
 	if len(commits) == 0 {
 		return nil, fmt.Errorf("no commits found in range %s..%s", from, to)
 	}
 
-	if g.config.Verbose {
-		fmt.Printf("Found %d commits\n", len(commits))
-		fmt.Println("Preparing commits for LLM analysis...")
+	logger.Info("fetched commits", "count", len(commits))
+	g.progress(StageFetched, fmt.Sprintf("fetched %d commits", len(commits)), 0, 0)
+	return g.collapseMerges(commits), nil
+}
+
+// collapseMerges folds feature branch commits into their merge commits when
+// merge collapsing is enabled
+func (g *Generator) collapseMerges(commits []provider.CommitData) []provider.CommitData {
+	if !g.config.CollapseMerges {
+		return commits
 	}
+	collapsed := CollapseMerges(commits)
+	logger.Info("collapsed merged branches", "commits", len(commits), "changes", len(collapsed))
+	return collapsed
+}
 
-	// 2. Prepare commits for LLM (with diffs summarized to fit token limits)
-	commitInfos := g.prepareCommitsForLLM(commits)
+// GenerateFromCommits creates a changelog from commits supplied by the caller
+// (e.g., read from stdin) instead of fetching them from GitHub. from and to
+// only label the output.
+func (g *Generator) GenerateFromCommits(ctx context.Context, commitInfos []llm.CommitInfo, from, to string) (*Changelog, error) {
+	g.resetRun()
+
+	if len(commitInfos) == 0 {
+		return nil, fmt.Errorf("no commits provided")
+	}
+	return g.generate(ctx, commitDataFromInfos(commitInfos), commitInfos, from, to)
+}
 
-	if g.config.Verbose {
-		fmt.Println("Sending to OpenAI for changelog generation...")
+// generate runs the LLM, cleanup, and formatting steps for a set of commits
+func (g *Generator) generate(ctx context.Context, commits []provider.CommitData, commitInfos []llm.CommitInfo, from, to string) (*Changelog, error) {
+	commits, commitInfos, labelCategories, err := g.selectCommits(ctx, commits, commitInfos, from, to)
+	if err != nil {
+		return nil, err
 	}
 
 	// 3. Send to OpenAI for changelog generation
-	response, err := g.llmClient.GenerateChangelog(llm.ChangelogRequest{
-		Commits:  commitInfos,
-		RepoName: fmt.Sprintf("%s/%s", g.config.RepoOwner, g.config.RepoName),
-		FromRef:  from,
-		ToRef:    to,
-	})
+	logger.Info("requesting changelog from LLM", "commits", len(commitInfos))
+	g.progress(StageGenerating, fmt.Sprintf("requesting changelog for %d commits", len(commitInfos)), 0, 0)
+	request := llm.ChangelogRequest{
+		Commits:    commitInfos,
+		RepoName:   fmt.Sprintf("%s/%s", g.config.RepoOwner, g.config.RepoName),
+		FromRef:    from,
+		ToRef:      to,
+		Stack:      g.repoStack(ctx),
+		Clusters:   g.clusters(commitInfos),
+		Security:   g.securityEnabled(),
+		Advisories: g.securityAdvisories(ctx, commitInfos, to),
+
+		ExistingNotes: g.existingNotes(ctx, to),
+	}
+	model := g.routeModel(len(commitInfos), g.changedLines(commits))
+	if model != "" {
+		logger.Info("routing changelog to model", "model", model, "commits", len(commitInfos))
+	}
+	response, err := g.llmClient.GenerateChangelog(llm.WithModel(ctx, model), request)
 	if err != nil {
 		return nil, fmt.Errorf("generate changelog: %w", err)
 	}
 
-	if g.config.Verbose {
-		fmt.Println("Formatting changelog as markdown...")
+	// 4. Clean up the response: fold invented categories into the taxonomy,
+	// drop cross-category duplicates, and check inline code references
+	// against the actual diffs
+	if response.Repaired {
+		g.softFail("LLM response for %s..%s needed JSON cleanup before parsing", from, to)
+	}
+	for _, remap := range NormalizeCategories(response.Categories, g.config.CategoryAliases, g.config.UnknownCategory) {
+		if remap.To == "" {
+			g.softFail("dropped %d entries in unknown category %q", remap.Entries, remap.From)
+		} else {
+			g.softFail("moved %d entries from unknown category %q to %q", remap.Entries, remap.From, remap.To)
+		}
+	}
+	if removed := DedupeEntries(response.Categories); removed > 0 {
+		logger.Info("removed duplicate entries listed under multiple categories", "count", removed)
+	}
+	if changed := ApplyLabelCategories(response.Categories, labelCategories); changed > 0 {
+		logger.Info("recategorized entries by PR label", "count", changed)
+	}
+	g.normalizeReferences(response, commits)
+	if g.config.Scoring == "heuristic" {
+		scored := ApplyHeuristicScores(response.Categories, g.scoringCommits(commits))
+		logger.Info("replaced model scores with heuristic scores", "entries", scored)
+	}
+	g.checkEntries(response, commits)
+	if dropped := SelectTopEntries(response.Categories, g.config.Top, g.config.TopPerCategory); dropped > 0 {
+		logger.Info("kept only the top-scoring entries", "dropped", dropped)
+	}
+	attributeEntries(response, commits)
+	g.linkPullRequests(response)
+	if g.config.Reverts == "annotate" {
+		annotateRelations(response, commits, FindCommitRelations(commits))
+	}
+	g.recordExample(llm.BuildChangelogPrompt(request), response)
+
+	g.tagEntryAreas(response, commits)
+
+	// Link issue tracker tickets referenced in commit messages
+	if g.tickets.Enabled() {
+		logger.Info("linking issue tracker tickets")
+		g.linkEntryTickets(ctx, response, commits)
+	}
+
+	// Carry screenshots of UI changes from pull request bodies
+	if g.config.PRImages {
+		g.attachEntryImages(ctx, response, commits)
+	}
+	if err := g.runPostLLM(ctx, response); err != nil {
+		return nil, err
 	}
 
-	// 4. Format as markdown
-	markdown := g.formatAsMarkdown(response, from, to)
+	logger.Debug("formatting changelog as markdown")
 
-	return &Changelog{
-		Summary:    response.Summary,
-		Highlights: response.Highlights,
-		Categories: response.Categories,
-		Markdown:   markdown,
-		FromRef:    from,
-		ToRef:      to,
-		RepoName:   fmt.Sprintf("%s/%s", g.config.RepoOwner, g.config.RepoName),
-	}, nil
+	// 5. Format as markdown, condensing it if it exceeds the length budget
+	markdown, err := g.enforceLengthBudget(ctx, g.formatAsMarkdown(response, from, to, latestCommitDate(commits)), to)
+	if err != nil {
+		return nil, err
+	}
+
+	// 6. Optional contributors section
+	var contributors *ContributorsSummary
+	if g.config.IncludeContributors {
+		contributors = ComputeContributors(commits)
+		g.markFirstTimeContributors(ctx, contributors, commits)
+		markdown += FormatContributors(contributors, 2)
+	}
+
+	// 7. Optional download index from the release published for the target tag
+	var artifacts []provider.ReleaseAsset
+	if g.config.IncludeArtifacts && g.provider != nil {
+		if artifacts, err = g.releaseArtifacts(ctx, to); err != nil {
+			return nil, err
+		}
+		markdown += FormatArtifacts(artifacts, 2)
+	}
+	markdown += FormatInstallSnippet(g.config, to, 2)
+	if g.config.FullChangelogLink {
+		markdown += formatFullChangelog(g.compareURL(from, to))
+	}
+
+	if err := g.strictError(); err != nil {
+		return nil, err
+	}
+
+	changelog := &Changelog{
+		Schema: SchemaVersion,
+		Zoom: ZoomSummaries{
+			OneLiner:  response.OneLiner,
+			Paragraph: response.Summary,
+			Full:      markdown,
+		},
+		Summary:      response.Summary,
+		Highlights:   response.Highlights,
+		Categories:   response.Categories,
+		Markdown:     markdown,
+		Contributors: contributors,
+		Artifacts:    artifacts,
+		CommitCount:  len(commits),
+		Commits:      commits,
+		Date:         latestCommitDate(commits),
+		FromRef:      from,
+		ToRef:        to,
+		RepoName:     fmt.Sprintf("%s/%s", g.config.RepoOwner, g.config.RepoName),
+	}
+	changelog.Metadata = g.metadata(Source{FromRef: from, ToRef: to})
+	if model != "" {
+		changelog.Metadata.Model = model
+	}
+	if err := g.runPreWrite(ctx, changelog); err != nil {
+		return nil, err
+	}
+	changelog.Zoom.Full = changelog.Markdown // Hooks edit Markdown
+	g.progress(StageDone, fmt.Sprintf("changelog for %s..%s ready", from, to), 0, 0)
+	return changelog, nil
+}
+
+// selectCommits applies the commit filters, security flags, PR label
+// categories, and pre-LLM hooks, returning the commits the changelog prompt
+// covers and the label categories by SHA. Dry runs share it so their
+// estimates match the real run.
+func (g *Generator) selectCommits(ctx context.Context, commits []provider.CommitData, commitInfos []llm.CommitInfo, from, to string) ([]provider.CommitData, []llm.CommitInfo, map[string]string, error) {
+	if g.config.BotCommits == "exclude" {
+		total := len(commitInfos)
+		commits, commitInfos = excludeBotCommits(commits, commitInfos)
+		logger.Info("excluded bot commits", "count", total-len(commitInfos))
+		if len(commitInfos) == 0 {
+			return nil, nil, nil, fmt.Errorf("no commits left in range %s..%s after excluding bot commits", from, to)
+		}
+	}
+	if g.config.Reverts == "drop" {
+		relations := FindCommitRelations(commits)
+		total := len(commitInfos)
+		commits, commitInfos = filterCommits(commits, commitInfos, func(commit provider.CommitData) bool {
+			return relations.Cancelled(commit.SHA)
+		})
+		if dropped := total - len(commitInfos); dropped > 0 {
+			logger.Info("dropped reverted, reverting, and cherry-picked duplicate commits", "count", dropped)
+		}
+		if len(commitInfos) == 0 {
+			return nil, nil, nil, fmt.Errorf("no commits left in range %s..%s: every change was reverted", from, to)
+		}
+	}
+	if g.config.UnverifiedCommits == "exclude" {
+		total := len(commitInfos)
+		commits, commitInfos = filterCommits(commits, commitInfos, unverified)
+		if excluded := total - len(commitInfos); excluded > 0 {
+			g.warn("excluded %d unverified commits from %s..%s", excluded, from, to)
+		}
+		if len(commitInfos) == 0 {
+			return nil, nil, nil, fmt.Errorf("no commits left in range %s..%s after excluding unverified commits", from, to)
+		}
+	}
+
+	g.flagSecurityCommits(commitInfos)
+	labelCategories := g.labelCommits(ctx, commitInfos)
+	commits, commitInfos, err := g.runPreLLM(ctx, commits, commitInfos)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(commitInfos) == 0 {
+		return nil, nil, nil, fmt.Errorf("no commits left in range %s..%s after the pre-LLM hooks", from, to)
+	}
+	return commits, commitInfos, labelCategories, nil
+}
+
+// diffSummary describes a commit's diffs for the prompt: its whole patch when
+// the commit is small enough, otherwise summaries of the files with the most
+// significant changes
+func (g *Generator) diffSummary(commit provider.CommitData) string {
+	changed := 0
+	for _, file := range commit.FilesChanged {
+		changed += file.Additions + file.Deletions
+	}
+	if changed > 0 && changed <= g.config.FullPatchLines {
+		var patches []string
+		for _, file := range commit.FilesChanged {
+			if file.Patch != "" {
+				patches = append(patches, fmt.Sprintf("%s:\n%s", file.Filename, strings.TrimRight(file.Patch, "\n")))
+			}
+		}
+		return strings.Join(patches, "\n")
+	}
+
+	// For token efficiency, only include diff summary for files with significant changes
+	var significantChanges []string
+	for _, file := range commit.FilesChanged {
+		if len(significantChanges) == g.config.DiffFiles {
+			break
+		}
+		if file.Additions+file.Deletions <= g.config.DiffThreshold || file.Patch == "" {
+			continue
+		}
+		if summary := llm.SummarizeFileDiff(file.Filename, file.Patch, g.config.DiffLines); summary != "" {
+			significantChanges = append(significantChanges, fmt.Sprintf("%s: %s", file.Filename, summary))
+		}
+	}
+	return strings.Join(significantChanges, "\n")
 }
 
 // prepareCommitsForLLM converts GitHub commits to LLM-friendly format
-func (g *Generator) prepareCommitsForLLM(commits []github.CommitData) []llm.CommitInfo {
+func (g *Generator) prepareCommitsForLLM(commits []provider.CommitData) []llm.CommitInfo {
 	commitInfos := make([]llm.CommitInfo, 0, len(commits))
 
 	for _, commit := range commits {
+		// Lockfiles, vendored, generated, and binary files only add noise
+		commit, ignored := g.withoutIgnoredFiles(commit)
+
 		// Extract file names
 		fileNames := make([]string, 0, len(commit.FilesChanged))
 		for _, file := range commit.FilesChanged {
 			fileNames = append(fileNames, file.Filename)
 		}
 
-		// Limit files shown to first 20 to avoid token overflow
-		if len(fileNames) > 20 {
-			fileNames = append(fileNames[:20], fmt.Sprintf("... and %d more files", len(fileNames)-20))
-		}
-
-		// Create a summary of the diffs
-		diffSummary := ""
-		if len(commit.FilesChanged) > 0 {
-			// For token efficiency, only include diff summary for files with significant changes
-			significantChanges := []string{}
-			for _, file := range commit.FilesChanged {
-				if file.Additions+file.Deletions > 10 { // Only show files with >10 line changes
-					if file.Patch != "" {
-						summary := llm.SummarizeDiff(file.Patch)
-						if summary != "" {
-							significantChanges = append(significantChanges, fmt.Sprintf("%s: %s", file.Filename, summary))
-						}
-					}
-				}
-			}
-			if len(significantChanges) > 0 {
-				// Limit to 3 most significant files
-				if len(significantChanges) > 3 {
-					significantChanges = significantChanges[:3]
-				}
-				diffSummary = strings.Join(significantChanges, "\n")
-			}
+		// Limit files shown to avoid token overflow
+		if limit := g.config.MaxFilesInPrompt; len(fileNames) > limit {
+			fileNames = append(fileNames[:limit], fmt.Sprintf("... and %d more files", len(fileNames)-limit))
 		}
+		if ignored > 0 {
+			fileNames = append(fileNames, fmt.Sprintf("(%s omitted: lockfiles, vendored, generated, or binary)", pluralize(ignored, "file")))
+		}
+
+		diffSummary := g.diffSummary(commit)
 
 		commitInfo := llm.CommitInfo{
 			SHA:          commit.SHA,
@@ -139,91 +446,337 @@ func (g *Generator) prepareCommitsForLLM(commits []github.CommitData) []llm.Comm
 	return commitInfos
 }
 
+// pendingReleases drops releases the target changelog already documents,
+// keeping those whose tag has moved since its section was written
+func (g *Generator) pendingReleases(releases []provider.TimelineRelease) []provider.TimelineRelease {
+	if len(g.documented) == 0 {
+		return releases
+	}
+
+	var pending []provider.TimelineRelease
+	for _, release := range releases {
+		if IsDocumented(g.documented, release.ToRef) {
+			recorded := documentedSHA(g.shas, release.ToRef)
+			if recorded == "" || release.ToSHA == "" || recorded == release.ToSHA {
+				logger.Info("skipping release already documented", "tag", release.ToRef)
+				continue
+			}
+			g.warn("tag %s moved from %s to %s; regenerating its section",
+				release.ToRef, shortRef(recorded), shortRef(release.ToSHA))
+			g.stale = append(g.stale, release.ToRef)
+		}
+		pending = append(pending, release)
+	}
+	return pending
+}
+
+// dropDeletedTags marks documented versions whose tag no longer exists as
+// stale so their sections are removed rather than left pointing nowhere.
+// Only sections that recorded a commit are considered, since hand-written
+// headings need not correspond to tags.
+func (g *Generator) dropDeletedTags(ctx context.Context) error {
+	if len(g.shas) == 0 {
+		return nil
+	}
+	tags, err := g.provider.ListTags(ctx)
+	if err != nil {
+		return fmt.Errorf("list tags: %w", err)
+	}
+	existing := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		existing[tag.Name] = true
+	}
+	var deleted []string
+	for version := range g.shas {
+		if !IsDocumented(existing, version) {
+			deleted = append(deleted, version)
+		}
+	}
+	sort.Strings(deleted)
+	for _, version := range deleted {
+		g.warn("tag %s no longer exists; dropping its section", version)
+	}
+	g.stale = append(g.stale, deleted...)
+	return nil
+}
+
+// normalizeReferences wraps file mentions in backticks and unwraps code
+// references that do not appear in the entry's commit
+func (g *Generator) normalizeReferences(response *llm.ChangelogResponse, commits []provider.CommitData) {
+	for category, entries := range response.Categories {
+		for i := range entries {
+			commit := findCommit(commits, entries[i].SHA)
+			if commit == nil {
+				continue
+			}
+			unverified := normalizeEntryReferences(&entries[i], *commit)
+			if len(unverified) > 0 {
+				g.warn("entry %q references %s not found in the diff",
+					entries[i].Title, strings.Join(unverified, ", "))
+			}
+		}
+		response.Categories[category] = entries
+	}
+}
+
+// prCorpus returns the title and body of the PR with the given number
+func prCorpus(prs []provider.PullRequestData, number int) string {
+	for _, pr := range prs {
+		if pr.Number == number {
+			return "\n" + pr.Title + "\n" + pr.Body
+		}
+	}
+	return ""
+}
+
+// linkEntryTickets attaches tickets referenced by each entry's commit message
+func (g *Generator) linkEntryTickets(ctx context.Context, response *llm.ChangelogResponse, commits []provider.CommitData) {
+	for category, entries := range response.Categories {
+		for i := range entries {
+			commit := findCommit(commits, entries[i].SHA)
+			if commit == nil {
+				continue
+			}
+			entries[i].Tickets = g.tickets.Link(ctx, commit.Message)
+		}
+		response.Categories[category] = entries
+	}
+}
+
+// findCommit returns the commit whose SHA matches sha (which may be abbreviated)
+func findCommit(commits []provider.CommitData, sha string) *provider.CommitData {
+	if sha == "" {
+		return nil
+	}
+	for i := range commits {
+		if strings.HasPrefix(commits[i].SHA, sha) {
+			return &commits[i]
+		}
+	}
+	return nil
+}
+
+// timelineReleases discovers the releases between from and to that still
+// need a section, grouped and filtered as configured
+func (g *Generator) timelineReleases(ctx context.Context, from, to time.Time) ([]provider.TimelineRelease, error) {
+	releases, err := g.provider.GetTimelineReleases(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("discover releases: %w", err)
+	}
+
+	logger.Info("found releases in timeline", "count", len(releases))
+
+	// Skip releases the target changelog already documents
+	if err := g.dropDeletedTags(ctx); err != nil {
+		return nil, err
+	}
+	releases = g.pendingReleases(releases)
+	releases = GroupReleases(releases, g.config.GroupBy)
+
+	if g.config.BotCommits == "exclude" {
+		for i := range releases {
+			releases[i] = excludeBotPullRequests(releases[i])
+		}
+	}
+	return releases, nil
+}
+
 // preparePRsForLLM converts GitHub PRs to LLM-friendly format
-func (g *Generator) preparePRsForLLM(prs []github.PullRequestData) []llm.PRInfo {
+func (g *Generator) preparePRsForLLM(prs []provider.PullRequestData) []llm.PRInfo {
 	infos := make([]llm.PRInfo, 0, len(prs))
 	for _, pr := range prs {
-		infos = append(infos, llm.PRInfo{
+		info := llm.PRInfo{
 			Number: pr.Number,
 			Title:  pr.Title,
 			Author: pr.Author,
 			Body:   pr.Body,
-		})
+		}
+		if pr.Reviews != nil {
+			info.Reviews = &llm.ReviewInfo{
+				Approvals:        pr.Reviews.Approvals,
+				ChangesRequested: pr.Reviews.ChangesRequested,
+				Comments:         pr.Reviews.Comments,
+			}
+		}
+		infos = append(infos, info)
 	}
 	return infos
 }
 
-// formatAsMarkdown formats the LLM response as markdown
-func (g *Generator) formatAsMarkdown(response *llm.ChangelogResponse, from, to string) string {
-	return FormatMarkdown(response, from, to, g.config)
+// formatAsMarkdown formats the LLM response as markdown, with the release
+// date and compare link directly under the title
+func (g *Generator) formatAsMarkdown(response *llm.ChangelogResponse, from, to string, date time.Time) string {
+	markdown := FormatMarkdown(response, from, to, g.config, g.commitURL())
+	title, body, _ := strings.Cut(markdown, "\n\n")
+	return title + "\n\n" + formatReleaseMeta(date, from, to, g.compareURL(from, to)) + body
+}
+
+// commitURL returns the provider's commit link builder, or nil when commits
+// did not come from a hosting provider
+func (g *Generator) commitURL() func(sha string) string {
+	if g.provider == nil {
+		return nil
+	}
+	return g.provider.CommitURL
+}
+
+// compareURL returns the provider's link comparing two refs, or "" when
+// commits did not come from a hosting provider
+func (g *Generator) compareURL(from, to string) string {
+	if g.provider == nil {
+		return ""
+	}
+	return g.provider.CompareURL(from, to)
 }
 
 // GenerateTimeline generates a changelog for multiple releases in a date range
-func (g *Generator) GenerateTimeline(from, to time.Time) (*TimelineChangelog, error) {
+func (g *Generator) GenerateTimeline(ctx context.Context, from, to time.Time) (*TimelineChangelog, error) {
+	g.resetRun()
+
 	// 1. Discover releases within timeline
-	timelineReleases, err := g.githubClient.GetTimelineReleases(from, to)
+	g.progress(StageFetching, fmt.Sprintf("discovering releases from %s to %s", from.Format("2006-01-02"), to.Format("2006-01-02")), 0, 0)
+	timelineReleases, err := g.timelineReleases(ctx, from, to)
 	if err != nil {
-		return nil, fmt.Errorf("discover releases: %w", err)
+		return nil, err
 	}
+	g.progress(StageFetched, fmt.Sprintf("found %d releases", len(timelineReleases)), 0, 0)
 
-	if g.config.Verbose {
-		fmt.Printf("Found %d releases in timeline\n\n", len(timelineReleases))
+	// 2. Generate PR summaries via LLM, several releases at once with --llm-concurrency
+	g.progress(StageGenerating, fmt.Sprintf("requesting changelogs for %d releases", len(timelineReleases)), 0, 0)
+	generated, err := g.generateReleaseSummaries(ctx, timelineReleases)
+	if err != nil {
+		return nil, err
 	}
 
-	// 2. Process each release (PR-based)
+	// 3. Process each release (PR-based)
 	var releaseChangelogs []ReleaseChangelog
 	for i, release := range timelineReleases {
-		if g.config.Verbose {
-			fmt.Printf("[%d/%d] Processing %s → %s (%d commits, %d PRs)...\n",
-				i+1, len(timelineReleases), release.FromRef, release.ToRef,
-				release.CommitCount, len(release.PullRequests))
-		}
+		logger.Info("processing release", "index", i+1, "total", len(timelineReleases),
+			"from", release.FromRef, "to", release.ToRef,
+			"commits", release.CommitCount, "pull_requests", len(release.PullRequests))
+		g.progress(StageRelease, fmt.Sprintf("%s → %s", release.FromRef, release.ToRef), i+1, len(timelineReleases))
 
-		// Build PR summaries via LLM
 		prSummaries := make(map[int]string)
-		if len(release.PullRequests) > 0 {
-			prInfos := g.preparePRsForLLM(release.PullRequests)
-
-			response, err := g.llmClient.GeneratePRChangelog(llm.PRChangelogRequest{
-				PRs:      prInfos,
-				RepoName: fmt.Sprintf("%s/%s", g.config.RepoOwner, g.config.RepoName),
-				FromRef:  release.FromRef,
-				ToRef:    release.ToRef,
-			})
-			if err != nil {
-				return nil, fmt.Errorf("generate PR changelog for %s: %w", release.ToRef, err)
+		var zoom ZoomSummaries
+		if response := generated[i].response; response != nil {
+			request := generated[i].request
+
+			corpus := referenceCorpus(release.Commits...)
+			for i, entry := range response.Entries {
+				summary, unverified := VerifyCodeReferences(entry.Summary, corpus+prCorpus(release.PullRequests, entry.Number))
+				if len(unverified) > 0 {
+					g.warn("PR #%d summary references %s not found in the diff",
+						entry.Number, strings.Join(unverified, ", "))
+				}
+				prSummaries[entry.Number] = summary
+				response.Entries[i].Summary = summary
+			}
+			g.recordExample(llm.BuildPRChangelogPrompt(request), response)
+			if response.Repaired {
+				g.softFail("LLM response for %s needed JSON cleanup before parsing", release.ToRef)
 			}
+			for _, pr := range release.PullRequests {
+				if prSummaries[pr.Number] == "" {
+					g.softFail("PR #%d in %s has no summary", pr.Number, release.ToRef)
+				}
+			}
+			zoom.OneLiner = response.OneLiner
+			zoom.Paragraph = response.Summary
+		}
 
-			for _, entry := range response.Entries {
-				prSummaries[entry.Number] = entry.Summary
+		// Link issue tracker tickets referenced in PR titles and bodies
+		var prTickets map[int][]tickets.Ticket
+		if g.tickets.Enabled() {
+			prTickets = make(map[int][]tickets.Ticket)
+			for _, pr := range release.PullRequests {
+				if linked := g.tickets.Link(ctx, pr.Title+"\n"+pr.Body); len(linked) > 0 {
+					prTickets[pr.Number] = linked
+				}
 			}
 		}
 
-		releaseChangelogs = append(releaseChangelogs, ReleaseChangelog{
+		var contributors *ContributorsSummary
+		if g.config.IncludeContributors {
+			contributors = ComputeContributors(release.Commits)
+			g.markFirstTimeContributors(ctx, contributors, release.Commits)
+		}
+
+		releaseChangelog := ReleaseChangelog{
 			FromRef:      release.FromRef,
 			ToRef:        release.ToRef,
+			ToSHA:        release.ToSHA,
+			Series:       release.Series,
+			Versions:     release.Versions,
+			Unreleased:   release.Unreleased,
 			FromDate:     release.FromDate,
 			ToDate:       release.ToDate,
+			Summary:      zoom.Paragraph,
 			Commits:      release.Commits,
 			PullRequests: release.PullRequests,
 			PRSummaries:  prSummaries,
-		})
+			PRTickets:    prTickets,
+			Contributors: contributors,
+			Model:        generated[i].model,
+		}
+		if g.config.PRImages {
+			releaseChangelog.PRImages = releaseImages(release.PullRequests)
+		}
+		if g.config.IncludeArtifacts {
+			releaseChangelog.Artifacts = release.Artifacts
+		}
+		if g.config.IncludeStats {
+			releaseChangelog.Stats = CompareRelease(release)
+		}
+
+		// Condense the rendered section if it exceeds the length budget
+		section := g.formatReleaseSection(&releaseChangelog)
+		compressed, err := g.enforceLengthBudget(ctx, section, release.ToRef)
+		if err != nil {
+			return nil, err
+		}
+		if compressed != section {
+			releaseChangelog.Markdown = compressed
+		}
+		zoom.Full = compressed
+		releaseChangelog.Zoom = zoom
+
+		releaseChangelogs = append(releaseChangelogs, releaseChangelog)
 	}
 
-	if g.config.Verbose {
-		fmt.Println()
+	// Score every release's pull requests on one shared scale
+	if g.config.CalibrateScores {
+		if err := g.calibrateScores(ctx, releaseChangelogs); err != nil {
+			return nil, err
+		}
 	}
 
-	// 3. Build timeline changelog
+	// 4. Build timeline changelog
 	timeline := &TimelineChangelog{
+		Schema:   SchemaVersion,
 		FromDate: from,
 		ToDate:   to,
 		RepoName: fmt.Sprintf("%s/%s", g.config.RepoOwner, g.config.RepoName),
 		Releases: releaseChangelogs,
 	}
+	if g.config.PeriodSummary {
+		period, err := g.summarizePeriod(ctx, timeline)
+		if err != nil {
+			return nil, err
+		}
+		timeline.Period = period
+	}
+	if g.config.IncludeMetrics {
+		timeline.Metrics = ComputeMetrics(timeline)
+	}
+	timeline.Metadata = g.metadata(Source{FromDate: from, ToDate: to})
 
-	// 4. Format as markdown
+	// 5. Format as markdown
 	timeline.Markdown = g.formatTimelineAsMarkdown(timeline)
 
+	if err := g.strictError(); err != nil {
+		return nil, err
+	}
+
+	g.progress(StageDone, fmt.Sprintf("timeline of %d releases ready", len(timeline.Releases)), 0, 0)
 	return timeline, nil
 }
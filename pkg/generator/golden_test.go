@@ -0,0 +1,68 @@
+package generator
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm/mock"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+// update rewrites the golden files: go test ./pkg/generator -run Golden -update
+var update = flag.Bool("update", false, "rewrite golden files with the current output")
+
+// goldenGenerator replays the recorded widgets repository with the mock LLM
+func goldenGenerator(t *testing.T) *Generator {
+	t.Helper()
+	fixture, err := provider.LoadFixture(filepath.Join("testdata", "golden", "widgets.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.Default()
+	cfg.RepoOwner, cfg.RepoName = "acme", "widgets"
+	cfg.IncludeContributors = true
+	cfg.FullChangelogLink = true
+	return New(fixture, mock.New(), WithConfig(cfg))
+}
+
+// checkGolden compares got with testdata/golden/name, or rewrites it with -update
+func checkGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", "golden", name)
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v (run with -update to create it)", err)
+	}
+	if got != string(want) {
+		t.Errorf("%s differs from the golden file (run with -update to accept):\n%s", name, got)
+	}
+}
+
+func TestGoldenRange(t *testing.T) {
+	changelog, err := goldenGenerator(t).Generate(context.Background(), "v1.2.0", "v1.3.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "range.md", changelog.Markdown)
+}
+
+func TestGoldenTimeline(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+	timeline, err := goldenGenerator(t).GenerateTimeline(context.Background(), from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "timeline.md", timeline.Markdown)
+}
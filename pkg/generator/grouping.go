@@ -0,0 +1,66 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/semver"
+)
+
+// GroupReleases folds consecutive timeline releases of the same "minor" or
+// "major" series into one release spanning them all, so v1.4.0 through
+// v1.4.7 become a single v1.4.x section. Releases whose tag is not a
+// semantic version, and the untagged head, stay on their own.
+func GroupReleases(releases []provider.TimelineRelease, by string) []provider.TimelineRelease {
+	if by == "" {
+		return releases
+	}
+
+	var grouped []provider.TimelineRelease
+	for _, release := range releases {
+		var series string
+		if !release.Unreleased {
+			series = releaseSeries(release.ToRef, by)
+		}
+		if n := len(grouped); n > 0 && series != "" && grouped[n-1].Series == series {
+			last := &grouped[n-1]
+			last.ToRef = release.ToRef
+			last.ToSHA = release.ToSHA
+			last.ToDate = release.ToDate
+			last.CommitCount += release.CommitCount
+			last.Commits = append(last.Commits, release.Commits...)
+			last.PullRequests = append(last.PullRequests, release.PullRequests...)
+			last.Artifacts = release.Artifacts
+			last.Versions = append(last.Versions, release.ToRef)
+			last.Notes = strings.TrimSpace(last.Notes + "\n\n" + release.Notes)
+			continue
+		}
+		if series != "" {
+			release.Series = series
+			release.Versions = []string{release.ToRef}
+		}
+		grouped = append(grouped, release)
+	}
+	if len(grouped) < len(releases) {
+		logger.Info("grouped releases", "by", by, "releases", len(releases), "sections", len(grouped))
+	}
+	return grouped
+}
+
+// releaseSeries labels the series a tag belongs to, keeping its prefix:
+// v1.4.7 is v1.4.x by minor and v1.x by major. It returns "" for tags that
+// are not semantic versions.
+func releaseSeries(tag, by string) string {
+	version, ok := semver.Parse(tag)
+	if !ok {
+		return ""
+	}
+	core, _, _ := strings.Cut(tag, "+")
+	core = strings.TrimSuffix(core, "-"+version.Prerelease)
+	prefix := strings.TrimRight(core, "0123456789.")
+	if by == "major" {
+		return fmt.Sprintf("%s%d.x", prefix, version.Major)
+	}
+	return fmt.Sprintf("%s%d.%d.x", prefix, version.Major, version.Minor)
+}
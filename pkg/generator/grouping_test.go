@@ -0,0 +1,73 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+func TestGroupReleasesByMinor(t *testing.T) {
+	releases := []provider.TimelineRelease{
+		{FromRef: "v1.3.2", ToRef: "v1.4.0", CommitCount: 3, PullRequests: []provider.PullRequestData{{Number: 1}}},
+		{FromRef: "v1.4.0", ToRef: "v1.4.1", CommitCount: 1},
+		{FromRef: "v1.4.1", ToRef: "v1.4.2", ToSHA: "abc", CommitCount: 2, PullRequests: []provider.PullRequestData{{Number: 2}}},
+		{FromRef: "v1.4.2", ToRef: "nightly", CommitCount: 1},
+		{FromRef: "nightly", ToRef: "v1.5.0", CommitCount: 4},
+	}
+
+	grouped := GroupReleases(releases, "minor")
+	if len(grouped) != 3 {
+		t.Fatalf("got %d sections, want 3: %+v", len(grouped), grouped)
+	}
+	minor := grouped[0]
+	if minor.Series != "v1.4.x" || minor.FromRef != "v1.3.2" || minor.ToRef != "v1.4.2" || minor.ToSHA != "abc" {
+		t.Errorf("series = %+v", minor)
+	}
+	if minor.CommitCount != 6 || len(minor.PullRequests) != 2 {
+		t.Errorf("commits = %d, PRs = %d", minor.CommitCount, len(minor.PullRequests))
+	}
+	if got := strings.Join(minor.Versions, ","); got != "v1.4.0,v1.4.1,v1.4.2" {
+		t.Errorf("versions = %s", got)
+	}
+	if grouped[1].Series != "" || grouped[1].ToRef != "nightly" {
+		t.Errorf("non-semver tag should stay on its own: %+v", grouped[1])
+	}
+	if grouped[2].Series != "v1.5.x" {
+		t.Errorf("series = %q, want v1.5.x", grouped[2].Series)
+	}
+
+	// The input is not modified
+	if releases[0].CommitCount != 3 || releases[0].Series != "" {
+		t.Errorf("input modified: %+v", releases[0])
+	}
+}
+
+func TestGroupReleasesByMajor(t *testing.T) {
+	releases := []provider.TimelineRelease{
+		{FromRef: "v1.9.0", ToRef: "v2.0.0-rc.1"},
+		{FromRef: "v2.0.0-rc.1", ToRef: "v2.0.0"},
+		{FromRef: "v2.0.0", ToRef: "v2.1.0"},
+	}
+	grouped := GroupReleases(releases, "major")
+	if len(grouped) != 1 || grouped[0].Series != "v2.x" || grouped[0].ToRef != "v2.1.0" {
+		t.Errorf("grouped = %+v", grouped)
+	}
+}
+
+func TestReleaseSeries(t *testing.T) {
+	tests := []struct {
+		tag, by, want string
+	}{
+		{"v1.4.7", "minor", "v1.4.x"},
+		{"1.4.7", "minor", "1.4.x"},
+		{"release-1.4.7+build.5", "minor", "release-1.4.x"},
+		{"app2-3.1.0-rc.1", "major", "app2-3.x"},
+		{"nightly", "minor", ""},
+	}
+	for _, tt := range tests {
+		if got := releaseSeries(tt.tag, tt.by); got != tt.want {
+			t.Errorf("releaseSeries(%q, %q) = %q, want %q", tt.tag, tt.by, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package generator
+
+import (
+	"math"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+// conventionalRe matches a conventional-commit subject: "type(scope)!: description"
+var conventionalRe = regexp.MustCompile(`^(\w+)(?:\([^)]*\))?(!)?:\s`)
+
+// conventionalBase is the starting score of each conventional-commit type
+var conventionalBase = map[string]float64{
+	"feat":     6,
+	"fix":      5,
+	"perf":     5,
+	"security": 7,
+	"revert":   4,
+	"refactor": 3,
+	"build":    2,
+	"ci":       2,
+	"chore":    2,
+	"docs":     2,
+	"style":    1,
+	"test":     2,
+}
+
+// defaultHeuristicBase scores commits without a conventional-commit type
+const defaultHeuristicBase = 4
+
+// HeuristicScore rates a commit's importance from measurable signals only,
+// so the same commit always gets the same score: its conventional-commit type
+// (breaking changes score 9), lines changed, files touched, and whether it
+// touches public interfaces (API, CLI, schemas) or only docs and tests. It
+// returns the score and a short explanation.
+func HeuristicScore(commit provider.CommitData) (float64, string) {
+	subject, body, _ := strings.Cut(commit.Message, "\n")
+	var reasons []string
+
+	score := float64(defaultHeuristicBase)
+	if m := conventionalRe.FindStringSubmatch(strings.TrimSpace(subject)); m != nil {
+		kind := strings.ToLower(m[1])
+		if base, ok := conventionalBase[kind]; ok {
+			score = base
+			reasons = append(reasons, kind)
+		}
+		if m[2] == "!" {
+			score = 9
+			reasons = append(reasons, "breaking")
+		}
+	}
+	if strings.Contains(body, "BREAKING CHANGE") && score < 9 {
+		score = 9
+		reasons = append(reasons, "breaking")
+	}
+
+	lines := commit.Stats.Additions + commit.Stats.Deletions
+	switch {
+	case lines >= 500:
+		score += 2
+	case lines >= 100:
+		score++
+	case lines >= 20:
+		score += 0.5
+	}
+	if lines > 0 {
+		reasons = append(reasons, pluralize(lines, "line"))
+	}
+
+	files := max(len(commit.FilesChanged), commit.Stats.Files) // Only the count with --fast-fetch
+	switch {
+	case files >= 20:
+		score++
+	case files >= 5:
+		score += 0.5
+	}
+	if files > 0 {
+		reasons = append(reasons, pluralize(files, "file"))
+	}
+
+	switch surface := changeSurface(commit.FilesChanged); surface {
+	case "public":
+		score++
+		reasons = append(reasons, "touches public interfaces")
+	case "docs", "tests", "docs and tests":
+		score--
+		reasons = append(reasons, surface+" only")
+	}
+
+	score = math.Round(min(max(score, 0), 10)*2) / 2
+	return score, "Heuristic: " + strings.Join(reasons, ", ")
+}
+
+// changeSurface classifies the files of a commit: "public" when any touches
+// an API, CLI, or schema path; "docs", "tests", or "docs and tests" when all
+// are documentation or tests; and "" otherwise
+func changeSurface(files []provider.FileChange) string {
+	if len(files) == 0 {
+		return ""
+	}
+	docs, tests := 0, 0
+	for _, file := range files {
+		name := strings.ToLower(file.Filename)
+		switch {
+		case isPublicPath(name):
+			return "public"
+		case strings.HasPrefix(name, "docs/") || strings.Contains(name, "/docs/") || path.Ext(name) == ".md":
+			docs++
+		case strings.HasSuffix(name, "_test.go") || strings.HasPrefix(name, "test/") || strings.HasPrefix(name, "tests/") ||
+			strings.Contains(name, "/test/") || strings.Contains(name, ".test.") || strings.Contains(name, ".spec."):
+			tests++
+		}
+	}
+	switch {
+	case docs == len(files):
+		return "docs"
+	case tests == len(files):
+		return "tests"
+	case docs+tests == len(files):
+		return "docs and tests"
+	}
+	return ""
+}
+
+// isPublicPath reports whether a lowercased path belongs to a public interface
+func isPublicPath(name string) bool {
+	for _, dir := range []string{"api/", "cmd/", "proto/", "openapi/", "schema/", "schemas/"} {
+		if strings.HasPrefix(name, dir) || strings.Contains(name, "/"+dir) {
+			return true
+		}
+	}
+	switch path.Ext(name) {
+	case ".proto", ".graphql":
+		return true
+	}
+	return strings.Contains(path.Base(name), "openapi") || strings.Contains(path.Base(name), "swagger")
+}
+
+// ApplyHeuristicScores replaces the model's importance scores with
+// HeuristicScore for every entry whose commit is known. It returns how many
+// entries were rescored.
+func ApplyHeuristicScores(categories map[string][]llm.ChangelogEntry, commits []provider.CommitData) int {
+	scored := 0
+	for category, entries := range categories {
+		for i := range entries {
+			commit := findCommit(commits, entries[i].SHA)
+			if commit == nil {
+				continue
+			}
+			entries[i].ImportanceScore, entries[i].ScoreReason = HeuristicScore(*commit)
+			entries[i].ScoreMissing = false
+			scored++
+		}
+		categories[category] = entries
+	}
+	return scored
+}
@@ -0,0 +1,88 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+func TestHeuristicScore(t *testing.T) {
+	files := func(names ...string) []provider.FileChange {
+		changes := make([]provider.FileChange, len(names))
+		for i, name := range names {
+			changes[i] = provider.FileChange{Filename: name}
+		}
+		return changes
+	}
+
+	tests := []struct {
+		name       string
+		commit     provider.CommitData
+		wantScore  float64
+		wantReason string
+	}{
+		{
+			name:       "feature touching the API",
+			commit:     provider.CommitData{Message: "feat(auth): add SSO", Stats: provider.CommitStats{Additions: 150, Deletions: 20}, FilesChanged: files("api/auth.go", "pkg/auth/sso.go")},
+			wantScore:  8,
+			wantReason: "Heuristic: feat, 170 lines, 2 files, touches public interfaces",
+		},
+		{
+			name:       "breaking marker",
+			commit:     provider.CommitData{Message: "refactor!: drop v1 endpoints", Stats: provider.CommitStats{Deletions: 600}, FilesChanged: files("pkg/a.go")},
+			wantScore:  10,
+			wantReason: "Heuristic: refactor, breaking, 600 lines, 1 file",
+		},
+		{
+			name:       "breaking footer",
+			commit:     provider.CommitData{Message: "fix: rename flag\n\nBREAKING CHANGE: --out is now --output", Stats: provider.CommitStats{Additions: 4}},
+			wantScore:  9,
+			wantReason: "Heuristic: fix, breaking, 4 lines",
+		},
+		{
+			name:       "docs only",
+			commit:     provider.CommitData{Message: "docs: fix typo", Stats: provider.CommitStats{Additions: 1, Deletions: 1}, FilesChanged: files("README.md")},
+			wantScore:  1,
+			wantReason: "Heuristic: docs, 2 lines, 1 file, docs only",
+		},
+		{
+			name:       "no conventional type",
+			commit:     provider.CommitData{Message: "Update handler", Stats: provider.CommitStats{Additions: 30}, FilesChanged: files("pkg/a.go", "pkg/b.go", "pkg/c.go", "pkg/a_test.go", "docs/x.md")},
+			wantScore:  5,
+			wantReason: "Heuristic: 30 lines, 5 files",
+		},
+		{
+			name:       "file count without file lists",
+			commit:     provider.CommitData{Message: "Update handler", Stats: provider.CommitStats{Additions: 30, Files: 5}},
+			wantScore:  5,
+			wantReason: "Heuristic: 30 lines, 5 files",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, reason := HeuristicScore(tt.commit)
+			if score != tt.wantScore || reason != tt.wantReason {
+				t.Errorf("HeuristicScore() = %.1f, %q; want %.1f, %q", score, reason, tt.wantScore, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestApplyHeuristicScores(t *testing.T) {
+	categories := map[string][]llm.ChangelogEntry{
+		"Features": {{SHA: "aaaa111", ImportanceScore: 2, ScoreMissing: true}, {SHA: "ffff999", ImportanceScore: 3}},
+	}
+	commits := []provider.CommitData{{SHA: "aaaa1111", Message: "feat: add login"}}
+
+	if scored := ApplyHeuristicScores(categories, commits); scored != 1 {
+		t.Errorf("Expected 1 entry rescored, got %d", scored)
+	}
+	entries := categories["Features"]
+	if entries[0].ImportanceScore != 6 || entries[0].ScoreMissing || entries[0].ScoreReason != "Heuristic: feat" {
+		t.Errorf("Unexpected rescored entry: %+v", entries[0])
+	}
+	if entries[1].ImportanceScore != 3 {
+		t.Error("Entries without a known commit should keep their score")
+	}
+}
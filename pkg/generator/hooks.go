@@ -0,0 +1,121 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+// Hooks are callbacks run at fixed points of a ref-range generation run.
+// Every field is optional. Returning an error vetoes the run; hooks that
+// return commits replace the commits passed on.
+type Hooks struct {
+	// PreFetch runs before the commits of from..to are fetched
+	PreFetch func(ctx context.Context, from, to string) error
+	// PostFetch filters or rewrites the fetched commits
+	PostFetch func(ctx context.Context, commits []provider.CommitData) ([]provider.CommitData, error)
+	// PreLLM filters or rewrites the commits just before they are sent to the model
+	PreLLM func(ctx context.Context, commits []llm.CommitInfo) ([]llm.CommitInfo, error)
+	// PostLLM mutates the model's entries after cleanup, before formatting
+	PostLLM func(ctx context.Context, response *llm.ChangelogResponse) error
+	// PreWrite inspects or edits the finished changelog before the caller writes it
+	PreWrite func(ctx context.Context, changelog *Changelog) error
+}
+
+// WithHooks registers hooks; hooks registered earlier run first
+func WithHooks(hooks Hooks) Option {
+	return func(g *Generator) {
+		g.hooks = append(g.hooks, hooks)
+	}
+}
+
+// AddHooks registers hooks after those already registered
+func (g *Generator) AddHooks(hooks Hooks) {
+	g.hooks = append(g.hooks, hooks)
+}
+
+// runPreFetch runs the pre-fetch hooks
+func (g *Generator) runPreFetch(ctx context.Context, from, to string) error {
+	for _, hooks := range g.hooks {
+		if hooks.PreFetch == nil {
+			continue
+		}
+		if err := hooks.PreFetch(ctx, from, to); err != nil {
+			return fmt.Errorf("pre-fetch hook: %w", err)
+		}
+	}
+	return nil
+}
+
+// runPostFetch runs the post-fetch hooks over the fetched commits
+func (g *Generator) runPostFetch(ctx context.Context, commits []provider.CommitData) ([]provider.CommitData, error) {
+	for _, hooks := range g.hooks {
+		if hooks.PostFetch == nil {
+			continue
+		}
+		var err error
+		if commits, err = hooks.PostFetch(ctx, commits); err != nil {
+			return nil, fmt.Errorf("post-fetch hook: %w", err)
+		}
+	}
+	return commits, nil
+}
+
+// runPreLLM runs the pre-LLM hooks and drops the details of commits they
+// removed, keeping both lists in step
+func (g *Generator) runPreLLM(ctx context.Context, commits []provider.CommitData, infos []llm.CommitInfo) ([]provider.CommitData, []llm.CommitInfo, error) {
+	ran := false
+	for _, hooks := range g.hooks {
+		if hooks.PreLLM == nil {
+			continue
+		}
+		var err error
+		if infos, err = hooks.PreLLM(ctx, infos); err != nil {
+			return nil, nil, fmt.Errorf("pre-LLM hook: %w", err)
+		}
+		ran = true
+	}
+	if !ran {
+		return commits, infos, nil
+	}
+
+	kept := make(map[string]bool, len(infos))
+	for _, info := range infos {
+		kept[info.SHA] = true
+	}
+	var remaining []provider.CommitData
+	for _, commit := range commits {
+		if kept[commit.SHA] {
+			remaining = append(remaining, commit)
+		}
+	}
+	return remaining, infos, nil
+}
+
+// runPostLLM runs the post-LLM hooks over the cleaned-up entries
+func (g *Generator) runPostLLM(ctx context.Context, response *llm.ChangelogResponse) error {
+	for _, hooks := range g.hooks {
+		if hooks.PostLLM == nil {
+			continue
+		}
+		if err := hooks.PostLLM(ctx, response); err != nil {
+			return fmt.Errorf("post-LLM hook: %w", err)
+		}
+	}
+	return nil
+}
+
+// runPreWrite runs the pre-write hooks over the finished changelog
+func (g *Generator) runPreWrite(ctx context.Context, changelog *Changelog) error {
+	for _, hooks := range g.hooks {
+		if hooks.PreWrite == nil {
+			continue
+		}
+		if err := hooks.PreWrite(ctx, changelog); err != nil {
+			return fmt.Errorf("pre-write hook: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+)
+
+func TestHooks(t *testing.T) {
+	ctx := context.Background()
+	commits := []llm.CommitInfo{
+		{SHA: "aaaaaaa", Message: "Add SSO login"},
+		{SHA: "bbbbbbb", Message: "wip"},
+	}
+	client := &fakeLLM{}
+	gen := New(nil, client, WithHooks(Hooks{
+		PreLLM: func(ctx context.Context, commits []llm.CommitInfo) ([]llm.CommitInfo, error) {
+			var kept []llm.CommitInfo
+			for _, commit := range commits {
+				if commit.Message != "wip" {
+					kept = append(kept, commit)
+				}
+			}
+			return kept, nil
+		},
+		PostLLM: func(ctx context.Context, response *llm.ChangelogResponse) error {
+			response.Categories["Features"][0].Title = "Single sign-on"
+			return nil
+		},
+	}))
+	gen.AddHooks(Hooks{
+		PreWrite: func(ctx context.Context, changelog *Changelog) error {
+			changelog.Markdown += "\nReviewed by release bot\n"
+			return nil
+		},
+	})
+
+	changelog, err := gen.GenerateFromCommits(ctx, commits, "v1.0.0", "v1.1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changelog.CommitCount != 1 || strings.Contains(changelog.Markdown, "wip") {
+		t.Errorf("Expected the pre-LLM hook to drop the wip commit, got %d commits:\n%s", changelog.CommitCount, changelog.Markdown)
+	}
+	if !strings.Contains(changelog.Markdown, "**Single sign-on**") || !strings.HasSuffix(changelog.Zoom.Full, "Reviewed by release bot\n") {
+		t.Errorf("Expected post-LLM and pre-write edits, got:\n%s", changelog.Zoom.Full)
+	}
+
+	veto := New(nil, client, WithHooks(Hooks{
+		PreWrite: func(ctx context.Context, changelog *Changelog) error { return errors.New("notes frozen") },
+	}))
+	if _, err := veto.GenerateFromCommits(ctx, commits, "v1.0.0", "v1.1.0"); err == nil || !strings.Contains(err.Error(), "pre-write hook: notes frozen") {
+		t.Errorf("Expected the pre-write hook to veto the run, got %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package generator
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	mdHeadingRe = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	mdCodeRe    = regexp.MustCompile("`([^`]+)`")
+	mdLinkRe    = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	mdBoldRe    = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	mdItalicRe  = regexp.MustCompile(`(^|[\s(])[_*]([^_*]+)[_*]([\s).,:;!?]|$)`)
+	mdRuleRe    = regexp.MustCompile(`^\|?[\s:|-]+\|?$`)
+)
+
+// FormatHTML renders changelog markdown as a standalone HTML page. It covers
+// the subset the formatter emits: headings, lists, tables, code blocks,
+// paragraphs, and inline code, links, bold, and italics.
+func FormatHTML(title, markdown string) string {
+	var sb strings.Builder
+	sb.WriteString("<!doctype html>\n<html lang=\"en\">\n<head>\n<meta charset=\"utf-8\">\n")
+	sb.WriteString(fmt.Sprintf("<title>%s</title>\n</head>\n<body>\n", html.EscapeString(title)))
+	sb.WriteString(MarkdownToHTML(markdown))
+	sb.WriteString("</body>\n</html>\n")
+	return sb.String()
+}
+
+// MarkdownToHTML converts markdown to an HTML fragment, block by block
+func MarkdownToHTML(markdown string) string {
+	var sb strings.Builder
+	lines := strings.Split(markdown, "\n")
+	inList := false
+	closeList := func() {
+		if inList {
+			sb.WriteString("</li>\n</ul>\n")
+			inList = false
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(trimmed, "```"):
+			closeList()
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code = append(code, lines[i])
+			}
+			sb.WriteString("<pre><code>" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+		case trimmed == "":
+			closeList()
+		case mdHeadingRe.MatchString(line):
+			closeList()
+			m := mdHeadingRe.FindStringSubmatch(line)
+			level := len(m[1])
+			sb.WriteString(fmt.Sprintf("<h%d>%s</h%d>\n", level, inlineHTML(m[2]), level))
+		case strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "* "):
+			if inList {
+				sb.WriteString("</li>\n")
+			} else {
+				sb.WriteString("<ul>\n")
+				inList = true
+			}
+			sb.WriteString("<li>" + inlineHTML(line[2:]))
+		case inList && strings.HasPrefix(line, " "):
+			// Indented continuation, e.g. an entry description
+			sb.WriteString("<br>" + inlineHTML(trimmed))
+		case strings.HasPrefix(trimmed, "|"):
+			closeList()
+			sb.WriteString("<table>\n")
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), "|"); i++ {
+				row := strings.TrimSpace(lines[i])
+				if mdRuleRe.MatchString(row) {
+					continue
+				}
+				sb.WriteString("<tr>")
+				for _, cell := range strings.Split(strings.Trim(row, "|"), "|") {
+					sb.WriteString("<td>" + inlineHTML(strings.TrimSpace(cell)) + "</td>")
+				}
+				sb.WriteString("</tr>\n")
+			}
+			i--
+			sb.WriteString("</table>\n")
+		default:
+			closeList()
+			sb.WriteString("<p>" + inlineHTML(trimmed) + "</p>\n")
+		}
+	}
+	closeList()
+	return sb.String()
+}
+
+// inlineHTML escapes text and converts inline markdown. Code spans are
+// swapped out first so their contents are left untouched.
+func inlineHTML(text string) string {
+	text = html.EscapeString(text)
+
+	var spans []string
+	text = mdCodeRe.ReplaceAllStringFunc(text, func(match string) string {
+		spans = append(spans, "<code>"+match[1:len(match)-1]+"</code>")
+		return fmt.Sprintf("\x00%d\x00", len(spans)-1)
+	})
+
+	text = mdLinkRe.ReplaceAllString(text, `<a href="$2">$1</a>`)
+	text = mdBoldRe.ReplaceAllString(text, "<strong>$1</strong>")
+	text = mdItalicRe.ReplaceAllString(text, "$1<em>$2</em>$3")
+
+	for i, span := range spans {
+		text = strings.Replace(text, fmt.Sprintf("\x00%d\x00", i), span, 1)
+	}
+	return text
+}
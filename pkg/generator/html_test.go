@@ -0,0 +1,31 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatHTML(t *testing.T) {
+	markdown := "# Changelog: v1.0.0 → v1.1.0\n\n" +
+		"## 🚀 Features\n\n" +
+		"- **Add <OAuth2>** ([`abc1234`](https://github.com/acme/api/commit/abc1234)) by @alice\n" +
+		"  Supports snake_case scopes and _PKCE_\n" +
+		"- **Fix login**\n\n" +
+		"| Metric | Value |\n|--------|-------|\n| Releases | 2 |\n\n" +
+		"```sh\ndocker pull acme/api:1.1.0 && echo <done>\n```\n"
+
+	page := FormatHTML("acme/api v1.1.0", markdown)
+	for _, want := range []string{
+		"<title>acme/api v1.1.0</title>",
+		"<h1>Changelog: v1.0.0 → v1.1.0</h1>",
+		"<h2>🚀 Features</h2>",
+		`<li><strong>Add &lt;OAuth2&gt;</strong> (<a href="https://github.com/acme/api/commit/abc1234"><code>abc1234</code></a>) by @alice<br>Supports snake_case scopes and <em>PKCE</em></li>`,
+		"<li><strong>Fix login</strong></li>\n</ul>",
+		"<tr><td>Metric</td><td>Value</td></tr>\n<tr><td>Releases</td><td>2</td></tr>",
+		"<pre><code>docker pull acme/api:1.1.0 &amp;&amp; echo &lt;done&gt;</code></pre>",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("Expected %q in:\n%s", want, page)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package generator
+
+import (
+	"path"
+	"strings"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+// DefaultIgnoredFiles are globs of lockfiles, vendored dependencies, and
+// generated code, whose diffs say little about what a commit changed
+var DefaultIgnoredFiles = []string{
+	// Lockfiles
+	"go.sum", "package-lock.json", "npm-shrinkwrap.json", "yarn.lock", "pnpm-lock.yaml", "bun.lockb",
+	"Cargo.lock", "Gemfile.lock", "composer.lock", "poetry.lock", "Pipfile.lock", "uv.lock", "mix.lock",
+	"pubspec.lock", "Podfile.lock", "packages.lock.json", "gradle.lockfile",
+	// Vendored dependencies
+	"**/vendor/**", "**/node_modules/**", "**/third_party/**",
+	// Generated code and build output
+	"*.pb.go", "*_pb.go", "*.pb.gw.go", "*_grpc.pb.go", "*_pb2.py", "*_pb2_grpc.py", "*_pb.js", "*_pb.d.ts",
+	"*_generated.go", "*.gen.go", "zz_generated.*.go", "*.generated.ts", "*.g.dart",
+	"*.min.js", "*.min.css", "*.map", "*.snap",
+	"**/dist/**",
+}
+
+// binaryExtensions are file types whose changes have no readable diff
+var binaryExtensions = map[string]bool{
+	".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".ico": true, ".webp": true, ".bmp": true,
+	".pdf": true, ".zip": true, ".gz": true, ".tgz": true, ".tar": true, ".7z": true, ".jar": true, ".war": true,
+	".exe": true, ".dll": true, ".so": true, ".dylib": true, ".a": true, ".o": true, ".wasm": true, ".bin": true,
+	".woff": true, ".woff2": true, ".ttf": true, ".otf": true, ".eot": true,
+	".mp3": true, ".mp4": true, ".mov": true, ".wav": true, ".ogg": true, ".webm": true,
+	".pyc": true, ".class": true, ".db": true, ".sqlite": true,
+}
+
+// IsBinaryFile reports whether a changed file is binary, by its extension or
+// by the "Binary files ... differ" line git writes in place of a patch
+func IsBinaryFile(file provider.FileChange) bool {
+	if binaryExtensions[strings.ToLower(path.Ext(file.Filename))] {
+		return true
+	}
+	return strings.HasPrefix(file.Patch, "Binary files ") || strings.Contains(file.Patch, "\nBinary files ")
+}
+
+// IsIgnoredFile reports whether a changed file is binary or matches one of
+// the default globs or extra, unless a "!glob" in extra keeps it
+func IsIgnoredFile(file provider.FileChange, extra []string) bool {
+	ignored := IsBinaryFile(file)
+	for _, pattern := range DefaultIgnoredFiles {
+		if ignored {
+			break
+		}
+		ignored = MatchGlob(pattern, file.Filename)
+	}
+	for _, pattern := range extra {
+		if keep, ok := strings.CutPrefix(pattern, "!"); ok {
+			if MatchGlob(keep, file.Filename) {
+				return false
+			}
+		} else if !ignored {
+			ignored = MatchGlob(pattern, file.Filename)
+		}
+	}
+	return ignored
+}
+
+// withoutIgnoredFiles returns a copy of commit without its binary, lockfile,
+// vendored, and generated files, its stats reduced by their lines, and how
+// many files were removed
+func (g *Generator) withoutIgnoredFiles(commit provider.CommitData) (provider.CommitData, int) {
+	var kept []provider.FileChange
+	for _, file := range commit.FilesChanged {
+		if !IsIgnoredFile(file, g.config.IgnoreFiles) {
+			kept = append(kept, file)
+		}
+	}
+	ignored := len(commit.FilesChanged) - len(kept)
+	if ignored == 0 {
+		return commit, 0
+	}
+
+	for _, file := range commit.FilesChanged {
+		if IsIgnoredFile(file, g.config.IgnoreFiles) {
+			commit.Stats.Additions = max(commit.Stats.Additions-file.Additions, 0)
+			commit.Stats.Deletions = max(commit.Stats.Deletions-file.Deletions, 0)
+		}
+	}
+	commit.Stats.Total = commit.Stats.Additions + commit.Stats.Deletions
+	commit.FilesChanged = kept
+	return commit, ignored
+}
+
+// scoringCommits returns commits without their ignored files, for the
+// importance heuristics
+func (g *Generator) scoringCommits(commits []provider.CommitData) []provider.CommitData {
+	filtered := make([]provider.CommitData, len(commits))
+	for i, commit := range commits {
+		filtered[i], _ = g.withoutIgnoredFiles(commit)
+	}
+	return filtered
+}
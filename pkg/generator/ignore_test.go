@@ -0,0 +1,58 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+func TestIsIgnoredFile(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+		extra []string
+		want  bool
+	}{
+		{name: "pkg/auth/login.go", want: false},
+		{name: "go.sum", want: true},
+		{name: "web/package-lock.json", want: true},
+		{name: "vendor/github.com/x/y.go", want: true},
+		{name: "ui/node_modules/react/index.js", want: true},
+		{name: "api/v1/user.pb.go", want: true},
+		{name: "web/dist/app.js", want: true},
+		{name: "docs/logo.PNG", want: true},
+		{name: "assets/font", patch: "Binary files a/assets/font and b/assets/font differ", want: true},
+		{name: "internal/mocks/store.go", extra: []string{"internal/mocks/**"}, want: true},
+		{name: "web/dist/app.js", extra: []string{"!web/dist/**"}, want: false},
+	}
+	for _, tt := range tests {
+		file := provider.FileChange{Filename: tt.name, Patch: tt.patch}
+		if got := IsIgnoredFile(file, tt.extra); got != tt.want {
+			t.Errorf("IsIgnoredFile(%q, %v) = %v, want %v", tt.name, tt.extra, got, tt.want)
+		}
+	}
+}
+
+func TestPromptOmitsIgnoredFiles(t *testing.T) {
+	commit := provider.CommitData{
+		SHA:   "abc1234",
+		Stats: provider.CommitStats{Additions: 1215, Deletions: 3, Total: 1218},
+		FilesChanged: []provider.FileChange{
+			{Filename: "go.sum", Additions: 1200, Patch: "@@ -1 +1,1200 @@\n+golang.org/x/net v0.1.0 h1:..."},
+			{Filename: "client.go", Additions: 15, Deletions: 3, Patch: "@@ -1,3 +1,15 @@\n+func Retry() {}"},
+		},
+	}
+
+	info := New(nil, &fakeLLM{}, WithConfig(config.Default())).prepareCommitsForLLM([]provider.CommitData{commit})[0]
+	if info.Stats != "+15/-3" {
+		t.Errorf("stats = %q, want +15/-3", info.Stats)
+	}
+	if strings.Join(info.FilesChanged, ",") != "client.go,(1 file omitted: lockfiles, vendored, generated, or binary)" {
+		t.Errorf("files = %q", info.FilesChanged)
+	}
+	if strings.Contains(info.DiffSummary, "go.sum") || !strings.Contains(info.DiffSummary, "client.go: ") {
+		t.Errorf("diff summary = %q", info.DiffSummary)
+	}
+}
@@ -0,0 +1,117 @@
+package generator
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+var (
+	// markdownImageRe matches ![alt](url "title")
+	markdownImageRe = regexp.MustCompile(`!\[[^\]]*\]\(\s*<?(https?://[^\s)>]+)>?(?:\s+"[^"]*")?\s*\)`)
+	// htmlImageRe matches <img ... src="url" ...>
+	htmlImageRe = regexp.MustCompile(`(?i)<img\s[^>]*?src\s*=\s*["']?(https?://[^"'\s>]+)`)
+	// bareImageRe matches an image URL alone on its line, as GitHub renders
+	// uploads pasted without markup
+	bareImageRe = regexp.MustCompile(`(?im)^\s*(https://github\.com/user-attachments/assets/[\w-]+|https?://\S+\.(?:png|jpe?g|gif|webp|svg)(?:\?\S*)?)\s*$`)
+	// htmlCommentRe matches HTML comments, which PR templates use for instructions
+	htmlCommentRe = regexp.MustCompile(`(?s)<!--.*?-->`)
+)
+
+// badgeHosts serve status badges, which are images but not visuals of a change
+var badgeHosts = []string{"shields.io", "badge.fury.io", "badgen.net", "codecov.io", "coveralls.io", "travis-ci", "circleci.com", "/badge"}
+
+// FirstImageURL returns the URL of the first image or GIF in a pull request
+// body: markdown images, <img> tags, and bare upload links, skipping status
+// badges and anything inside HTML comments. It returns "" when there is none.
+func FirstImageURL(body string) string {
+	body = htmlCommentRe.ReplaceAllString(body, "")
+
+	first, firstAt := "", -1
+	for _, re := range []*regexp.Regexp{markdownImageRe, htmlImageRe, bareImageRe} {
+		for _, m := range re.FindAllStringSubmatchIndex(body, -1) {
+			url := body[m[2]:m[3]]
+			if isBadge(url) {
+				continue
+			}
+			if firstAt < 0 || m[0] < firstAt {
+				first, firstAt = url, m[0]
+			}
+			break
+		}
+	}
+	return first
+}
+
+// isBadge reports whether an image URL points at a status badge
+func isBadge(url string) bool {
+	lower := strings.ToLower(url)
+	for _, host := range badgeHosts {
+		if strings.Contains(lower, host) {
+			return true
+		}
+	}
+	return false
+}
+
+// attachEntryImages sets each entry's image to the first image in the body
+// of its commit's pull request, fetching each pull request once
+func (g *Generator) attachEntryImages(ctx context.Context, response *llm.ChangelogResponse, commits []provider.CommitData) {
+	fetcher, ok := g.provider.(pullRequestFetcher)
+	if !ok {
+		g.warn("--pr-images needs a provider that looks up pull requests; entries get no images")
+		return
+	}
+
+	images := make(map[int]string)
+	attached := 0
+	for category, entries := range response.Categories {
+		for i := range entries {
+			commit := findCommit(commits, entries[i].SHA)
+			if commit == nil {
+				continue
+			}
+			number := CommitPRNumber(commit.Message)
+			if number == 0 {
+				continue
+			}
+			if _, fetched := images[number]; !fetched {
+				// Squash-merge suffixes can name issues rather than PRs; those just get no image
+				if pr, err := fetcher.GetPullRequest(ctx, number); err != nil {
+					logger.Debug("could not fetch pull request for its images", "number", number, "error", err)
+					images[number] = ""
+				} else {
+					images[number] = FirstImageURL(pr.Body)
+				}
+			}
+			if entries[i].Image = images[number]; entries[i].Image != "" {
+				attached++
+			}
+		}
+		response.Categories[category] = entries
+	}
+	logger.Info("attached pull request images", "pull_requests", len(images), "entries", attached)
+}
+
+// releaseImages maps each pull request of a release to the first image in its body
+func releaseImages(prs []provider.PullRequestData) map[int]string {
+	images := make(map[int]string)
+	for _, pr := range prs {
+		if url := FirstImageURL(pr.Body); url != "" {
+			images[pr.Number] = url
+		}
+	}
+	if len(images) == 0 {
+		return nil
+	}
+	return images
+}
+
+// formatImage renders an image line for an entry, with its title as alt text
+func formatImage(title, url string) string {
+	alt := strings.NewReplacer("[", "", "]", "").Replace(title)
+	return "![" + alt + "](" + url + ")"
+}
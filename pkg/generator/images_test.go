@@ -0,0 +1,49 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+func TestFirstImageURL(t *testing.T) {
+	tests := []struct {
+		name, body, want string
+	}{
+		{"markdown", "Before/after:\n![new dialog](https://example.com/after.png \"After\")", "https://example.com/after.png"},
+		{"html first", "<img width=\"600\" src=\"https://example.com/a.gif\">\n![b](https://example.com/b.png)", "https://example.com/a.gif"},
+		{"upload link", "Demo:\n\nhttps://github.com/user-attachments/assets/0f3c-42ab\n", "https://github.com/user-attachments/assets/0f3c-42ab"},
+		{"skips badges", "![ci](https://img.shields.io/badge/ci-passing-green)\n![shot](https://example.com/shot.jpg)", "https://example.com/shot.jpg"},
+		{"skips comments", "<!-- ![example](https://example.com/template.png) -->\nNo UI changes", ""},
+		{"link in prose", "See https://example.com/shot.png for details", ""},
+	}
+	for _, tt := range tests {
+		if got := FirstImageURL(tt.body); got != tt.want {
+			t.Errorf("%s: FirstImageURL() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestEntryImagesRendered(t *testing.T) {
+	var sb strings.Builder
+	writeEntry(&sb, llm.ChangelogEntry{SHA: "abc1234", Title: "New [beta] dialog", Image: "https://example.com/a.png"}, &config.Config{}, nil)
+	if !strings.Contains(sb.String(), "\n  ![New beta dialog](https://example.com/a.png)\n") {
+		t.Errorf("entry = %q", sb.String())
+	}
+
+	release := &ReleaseChangelog{PRImages: releaseImages([]provider.PullRequestData{
+		{Number: 7, Body: "![x](https://example.com/x.gif)"},
+		{Number: 8, Body: "No visuals"},
+	})}
+	if len(release.PRImages) != 1 {
+		t.Fatalf("PRImages = %v", release.PRImages)
+	}
+	var b strings.Builder
+	writeReleasePR(&b, release, provider.PullRequestData{Number: 7, Title: "Animate", Author: "bob", URL: "u"}, &config.Config{})
+	if !strings.HasSuffix(b.String(), "    ![Animate](https://example.com/x.gif)\n") {
+		t.Errorf("release PR = %q", b.String())
+	}
+}
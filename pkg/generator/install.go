@@ -0,0 +1,51 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/semver"
+)
+
+// FormatInstallSnippet renders install/upgrade commands for the image and Helm
+// chart published for tag, with headings at the given level. It returns ""
+// when no coordinates are configured or tag is not a version.
+func FormatInstallSnippet(cfg *config.Config, tag string, level int) string {
+	if cfg.InstallImage == "" && cfg.InstallChart == "" {
+		return ""
+	}
+	version, ok := semver.Parse(tag)
+	if !ok {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s 🚢 Install / Upgrade\n\n", strings.Repeat("#", level)))
+	sb.WriteString("```bash\n")
+	if cfg.InstallImage != "" {
+		sb.WriteString(fmt.Sprintf("docker pull %s\n", expandVersionPattern(cfg.InstallImage, tag, version)))
+	}
+	if cfg.InstallChart != "" {
+		chart := expandVersionPattern(cfg.InstallChart, tag, version)
+		release := cfg.InstallReleaseName
+		if release == "" {
+			release = path.Base(chart)
+		}
+		sb.WriteString(fmt.Sprintf("helm upgrade --install %s %s --version %s\n",
+			release, chart, expandVersionPattern(cfg.InstallChartVersion, tag, version)))
+	}
+	sb.WriteString("```\n\n")
+	return sb.String()
+}
+
+// expandVersionPattern substitutes {tag} with the tag name and {version} with
+// its bare semantic version (no prefix or leading v)
+func expandVersionPattern(pattern, tag string, version semver.Version) string {
+	bare := fmt.Sprintf("%d.%d.%d", version.Major, version.Minor, version.Patch)
+	if version.Prerelease != "" {
+		bare += "-" + version.Prerelease
+	}
+	return strings.NewReplacer("{tag}", tag, "{version}", bare).Replace(pattern)
+}
@@ -0,0 +1,34 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+)
+
+func TestFormatInstallSnippet(t *testing.T) {
+	cfg := &config.Config{
+		InstallImage:        "ghcr.io/acme/api:{tag}",
+		InstallChart:        "oci://ghcr.io/acme/charts/api",
+		InstallChartVersion: "{version}",
+	}
+
+	snippet := FormatInstallSnippet(cfg, "v1.4.0-rc.1", 2)
+	for _, want := range []string{
+		"## 🚢 Install / Upgrade",
+		"docker pull ghcr.io/acme/api:v1.4.0-rc.1\n",
+		"helm upgrade --install api oci://ghcr.io/acme/charts/api --version 1.4.0-rc.1\n",
+	} {
+		if !strings.Contains(snippet, want) {
+			t.Errorf("Expected snippet to contain %q\nGot:\n%s", want, snippet)
+		}
+	}
+
+	if FormatInstallSnippet(cfg, "main", 2) != "" {
+		t.Error("Expected no snippet for a non-version ref")
+	}
+	if FormatInstallSnippet(&config.Config{}, "v1.0.0", 2) != "" {
+		t.Error("Expected no snippet without configured coordinates")
+	}
+}
@@ -0,0 +1,154 @@
+package generator
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+// prNumberRe matches the pull request number in the first line of merge
+// commits ("Merge pull request #12 from ...") and squash merges ("Add login (#12)")
+var prNumberRe = regexp.MustCompile(`^Merge pull request #(\d+)|\(#(\d+)\)\s*$`)
+
+// CommitPRNumber returns the number of the pull request a commit merged, or
+// 0 when its message names none
+func CommitPRNumber(message string) int {
+	subject, _, _ := strings.Cut(message, "\n")
+	m := prNumberRe.FindStringSubmatch(strings.TrimSpace(subject))
+	if m == nil {
+		return 0
+	}
+	number, _ := strconv.Atoi(m[1] + m[2])
+	return number
+}
+
+// LabelCategory returns the category a set of labels maps to, matching label
+// names case-insensitively. DropCategory wins over everything; otherwise the
+// mapped category earliest in CategoryOrder wins, so "breaking" beats
+// "kind/feature". It returns "" when no label is mapped.
+func LabelCategory(labels []string, mapping map[string]string) string {
+	known := make(map[string]string)
+	for _, category := range CategoryOrder {
+		known[strings.ToLower(category)] = category
+	}
+	rank := make(map[string]int)
+	for i, category := range CategoryOrder {
+		rank[category] = i
+	}
+
+	best := ""
+	for _, label := range labels {
+		for name, target := range mapping {
+			if !strings.EqualFold(name, label) {
+				continue
+			}
+			category := canonicalCategory(target, known)
+			if category == DropCategory {
+				return DropCategory
+			}
+			if best == "" || rank[category] < rank[best] {
+				best = category
+			}
+		}
+	}
+	return best
+}
+
+// ApplyLabelCategories moves entries into the category their commit's labels
+// map to, or drops them for DropCategory. bySHA maps full commit SHAs to label
+// categories; entry SHAs may be abbreviated. It returns how many entries moved
+// or were dropped.
+func ApplyLabelCategories(categories map[string][]llm.ChangelogEntry, bySHA map[string]string) int {
+	if len(bySHA) == 0 {
+		return 0
+	}
+	changed := 0
+	moved := make(map[string][]llm.ChangelogEntry)
+	for category, entries := range categories {
+		var kept []llm.ChangelogEntry
+		for _, entry := range entries {
+			target := labelCategoryForSHA(bySHA, entry.SHA)
+			if target == "" || target == category {
+				kept = append(kept, entry)
+				continue
+			}
+			changed++
+			if target != DropCategory {
+				moved[target] = append(moved[target], entry)
+			}
+		}
+		categories[category] = kept
+	}
+	for category, entries := range moved {
+		categories[category] = append(categories[category], entries...)
+	}
+	for category, entries := range categories {
+		if len(entries) == 0 {
+			delete(categories, category)
+		}
+	}
+	return changed
+}
+
+// labelCategoryForSHA looks up the label category of a possibly abbreviated SHA
+func labelCategoryForSHA(bySHA map[string]string, sha string) string {
+	if sha == "" {
+		return ""
+	}
+	for full, category := range bySHA {
+		if strings.HasPrefix(full, sha) {
+			return category
+		}
+	}
+	return ""
+}
+
+// pullRequestFetcher is implemented by providers that look up pull requests by number
+type pullRequestFetcher interface {
+	GetPullRequest(ctx context.Context, number int) (*provider.PullRequestData, error)
+}
+
+// labelCommits fills in the labels of each commit's pull request when label
+// categories are configured, keeping labels the caller already supplied. In
+// hint mode the labeled category is passed to the model; otherwise, and for
+// dropped commits, the returned category per commit SHA is applied after
+// generation.
+func (g *Generator) labelCommits(ctx context.Context, commits []llm.CommitInfo) map[string]string {
+	if len(g.config.LabelCategories) == 0 {
+		return nil
+	}
+	fetcher, _ := g.provider.(pullRequestFetcher)
+
+	labels := make(map[int][]string)
+	bySHA := make(map[string]string)
+	for i := range commits {
+		commit := &commits[i]
+		if number := CommitPRNumber(commit.Message); len(commit.Labels) == 0 && number > 0 && fetcher != nil {
+			if _, fetched := labels[number]; !fetched {
+				// Squash-merge suffixes can name issues rather than PRs; those just get no labels
+				if pr, err := fetcher.GetPullRequest(ctx, number); err != nil {
+					g.warn("could not fetch labels of PR #%d: %v", number, err)
+					labels[number] = nil
+				} else {
+					labels[number] = pr.Labels
+				}
+			}
+			commit.Labels = labels[number]
+		}
+		category := LabelCategory(commit.Labels, g.config.LabelCategories)
+		switch {
+		case category == "":
+		case g.config.LabelCategoryMode == "hint" && category != DropCategory:
+			// The model weighs the labeled category against the change itself
+			commit.LabelCategory = category
+		default:
+			bySHA[commit.SHA] = category
+		}
+	}
+	logger.Info("looked up commit labels", "pull_requests", len(labels), "categorized", len(bySHA))
+	return bySHA
+}
@@ -0,0 +1,73 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+)
+
+func TestCommitPRNumber(t *testing.T) {
+	tests := map[string]int{
+		"Merge pull request #42 from acme/login\n\nAdd login": 42,
+		"Add login (#17)":                 17,
+		"Add login (#17)\n\n* wip (#3)":   17,
+		"Fix #12 in parser":               0,
+		"Refactor (#abc)":                 0,
+		"Bump deps (see #9) and clean up": 0,
+	}
+	for message, want := range tests {
+		if got := CommitPRNumber(message); got != want {
+			t.Errorf("CommitPRNumber(%q) = %d, want %d", message, got, want)
+		}
+	}
+}
+
+func TestLabelCategory(t *testing.T) {
+	mapping := map[string]string{
+		"kind/feature":   "features",
+		"kind/bug":       "Bug Fixes",
+		"breaking":       "Breaking Changes",
+		"skip-changelog": "drop",
+	}
+
+	tests := []struct {
+		labels []string
+		want   string
+	}{
+		{[]string{"Kind/Feature"}, "Features"},
+		{[]string{"kind/feature", "breaking"}, "Breaking Changes"},
+		{[]string{"breaking", "skip-changelog"}, DropCategory},
+		{[]string{"area/auth"}, ""},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		if got := LabelCategory(tt.labels, mapping); got != tt.want {
+			t.Errorf("LabelCategory(%v) = %q, want %q", tt.labels, got, tt.want)
+		}
+	}
+}
+
+func TestApplyLabelCategories(t *testing.T) {
+	categories := map[string][]llm.ChangelogEntry{
+		"Features":  {{Title: "Login", SHA: "aaaa1111"}, {Title: "Export", SHA: "bbbb2222"}},
+		"Bug Fixes": {{Title: "Typo", SHA: "cccc3333"}},
+	}
+	bySHA := map[string]string{
+		"aaaa1111ffffffff": "Features", // Already in place
+		"bbbb2222ffffffff": "Bug Fixes",
+		"cccc3333ffffffff": DropCategory,
+	}
+
+	if changed := ApplyLabelCategories(categories, bySHA); changed != 2 {
+		t.Errorf("Expected 2 entries changed, got %d", changed)
+	}
+	if len(categories["Features"]) != 1 || categories["Features"][0].Title != "Login" {
+		t.Errorf("Unexpected Features: %+v", categories["Features"])
+	}
+	if len(categories["Bug Fixes"]) != 1 || categories["Bug Fixes"][0].Title != "Export" {
+		t.Errorf("Expected the dropped fix replaced by the moved entry, got %+v", categories["Bug Fixes"])
+	}
+	if len(categories) != 2 {
+		t.Errorf("Unexpected categories: %v", categories)
+	}
+}
@@ -0,0 +1,51 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// MeasureLength counts the words or non-blank lines in a markdown section
+func MeasureLength(markdown, unit string) int {
+	if unit == "lines" {
+		count := 0
+		for _, line := range strings.Split(markdown, "\n") {
+			if strings.TrimSpace(line) != "" {
+				count++
+			}
+		}
+		return count
+	}
+	return len(strings.Fields(markdown))
+}
+
+// enforceLengthBudget runs a compression pass over a release section that
+// exceeds the configured max length. Sections within budget are returned as-is.
+func (g *Generator) enforceLengthBudget(ctx context.Context, section, label string) (string, error) {
+	limit, unit, err := g.config.LengthBudget()
+	if err != nil {
+		return "", err
+	}
+	if limit == 0 {
+		return section, nil
+	}
+
+	length := MeasureLength(section, unit)
+	if length <= limit {
+		return section, nil
+	}
+
+	logger.Info("compressing section", "section", label, "length", length, "unit", unit, "budget", limit)
+
+	compressed, err := g.llmClient.CompressSection(ctx, section, limit, unit)
+	if err != nil {
+		return "", fmt.Errorf("compress section %s: %w", label, err)
+	}
+
+	if after := MeasureLength(compressed, unit); after > limit {
+		logger.Warn("section still over budget after compression", "section", label, "length", after, "unit", unit, "budget", limit)
+	}
+
+	return compressed, nil
+}
@@ -0,0 +1,73 @@
+package generator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm/mock"
+)
+
+func TestMeasureLength(t *testing.T) {
+	section := "## [Release v1.2.0]\n\n### Features\n\n- **Add export** to CSV\n\n  \n- **Fix retries**\n"
+	if got := MeasureLength(section, "words"); got != 13 {
+		t.Errorf("MeasureLength(words) = %d, want 13", got)
+	}
+	if got := MeasureLength(section, "lines"); got != 4 {
+		t.Errorf("MeasureLength(lines) = %d, want 4, blank lines not counted", got)
+	}
+	if got := MeasureLength("", "words"); got != 0 {
+		t.Errorf("MeasureLength(empty) = %d", got)
+	}
+}
+
+// compressingLLM is the mock LLM with a compression pass that replaces the
+// section and counts its calls
+type compressingLLM struct {
+	*mock.Client
+	compressions int
+}
+
+func (c *compressingLLM) CompressSection(ctx context.Context, markdown string, limit int, unit string) (string, error) {
+	c.compressions++
+	return "## Compressed\n\n- Short.\n", nil
+}
+
+func TestLengthBudgetCompressesOnlyOverBudget(t *testing.T) {
+	commits := []llm.CommitInfo{
+		{SHA: "abc1234", Message: "feat: add export to CSV and JSON", Author: "alice"},
+		{SHA: "def5678", Message: "fix: retry failed uploads", Author: "bob"},
+	}
+	cases := []struct {
+		maxLength  string
+		compressed bool
+	}{
+		{"", false},
+		{"10000w", false},
+		{"5w", true},
+		{"2lines", true},
+	}
+	for _, c := range cases {
+		cfg := config.Default()
+		cfg.MaxLength = c.maxLength
+		client := &compressingLLM{Client: mock.New()}
+		gen := New(nil, client, WithConfig(cfg))
+
+		changelog, err := gen.GenerateFromCommits(context.Background(), commits, "v1.0.0", "v1.1.0")
+		if err != nil {
+			t.Fatalf("max-length %q: %v", c.maxLength, err)
+		}
+		want := 0
+		if c.compressed {
+			want = 1
+		}
+		if client.compressions != want {
+			t.Errorf("max-length %q: %d compression passes, want %d", c.maxLength, client.compressions, want)
+		}
+		if got := strings.HasPrefix(changelog.Markdown, "## Compressed"); got != c.compressed {
+			t.Errorf("max-length %q: markdown =\n%s", c.maxLength, changelog.Markdown)
+		}
+	}
+}
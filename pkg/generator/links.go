@@ -0,0 +1,53 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+// linkPullRequests sets the pull request URL of every entry whose commit
+// merged a pull request, when the provider builds pull request URLs
+func (g *Generator) linkPullRequests(response *llm.ChangelogResponse) {
+	linker, ok := g.provider.(provider.PullRequestLinker)
+	if !ok {
+		return
+	}
+	for category, entries := range response.Categories {
+		for i := range entries {
+			if entries[i].PR > 0 {
+				entries[i].PRURL = linker.PullRequestURL(entries[i].PR)
+			}
+		}
+		response.Categories[category] = entries
+	}
+}
+
+// formatEntryLinks renders the parenthesized links after an entry's title:
+// its short SHA, the number of the pull request that merged it, or both, as
+// linkStyle asks. Entries without a pull request always show their SHA.
+// Missing URLs leave the SHA or number unlinked.
+func formatEntryLinks(entry llm.ChangelogEntry, linkStyle string, commitURL func(sha string) string) string {
+	// Get short SHA (first 7 chars or full if shorter)
+	shortSHA := entry.SHA
+	if len(shortSHA) > 7 {
+		shortSHA = shortSHA[:7]
+	}
+	sha := fmt.Sprintf("`%s`", shortSHA)
+	if commitURL != nil {
+		sha = fmt.Sprintf("[`%s`](%s)", shortSHA, commitURL(entry.SHA))
+	}
+	if entry.PR == 0 || linkStyle == "commit" || linkStyle == "" {
+		return fmt.Sprintf(" (%s)", sha)
+	}
+
+	pr := fmt.Sprintf("#%d", entry.PR)
+	if entry.PRURL != "" {
+		pr = fmt.Sprintf("[#%d](%s)", entry.PR, entry.PRURL)
+	}
+	if linkStyle == "both" {
+		return fmt.Sprintf(" (%s, %s)", pr, sha)
+	}
+	return fmt.Sprintf(" (%s)", pr)
+}
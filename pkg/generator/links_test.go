@@ -0,0 +1,68 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/github"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+func TestFormatEntryLinks(t *testing.T) {
+	commitURL := func(sha string) string { return "https://example.com/commit/" + sha }
+	merged := llm.ChangelogEntry{SHA: "abc1234def", PR: 1234, PRURL: "https://example.com/pull/1234"}
+	direct := llm.ChangelogEntry{SHA: "fed4321cba"}
+
+	tests := []struct {
+		name      string
+		entry     llm.ChangelogEntry
+		style     string
+		commitURL func(sha string) string
+		want      string
+	}{
+		{"commit", merged, "commit", commitURL, " ([`abc1234`](https://example.com/commit/abc1234def))"},
+		{"pr", merged, "pr", commitURL, " ([#1234](https://example.com/pull/1234))"},
+		{"both", merged, "both", commitURL, " ([#1234](https://example.com/pull/1234), [`abc1234`](https://example.com/commit/abc1234def))"},
+		{"pr without a pull request", direct, "pr", commitURL, " ([`fed4321`](https://example.com/commit/fed4321cba))"},
+		{"pr without URLs", llm.ChangelogEntry{SHA: "abc1234def", PR: 1234}, "pr", nil, " (#1234)"},
+		{"both without URLs", llm.ChangelogEntry{SHA: "abc1234def", PR: 1234}, "both", nil, " (#1234, `abc1234`)"},
+	}
+	for _, tt := range tests {
+		if got := formatEntryLinks(tt.entry, tt.style, tt.commitURL); got != tt.want {
+			t.Errorf("%s: formatEntryLinks() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestLinkStylePR(t *testing.T) {
+	commits := []provider.CommitData{
+		{SHA: "aaaa1111", Message: "Add SSO login (#1234)\n\nSquash-merged"},
+		{SHA: "bbbb2222", Message: "Merge pull request #77 from acme/fix-crash\n\nFix crash"},
+		{SHA: "cccc3333", Message: "Tidy imports"},
+	}
+	response := &llm.ChangelogResponse{Categories: map[string][]llm.ChangelogEntry{
+		"Features":  {{SHA: "aaaa111", Title: "SSO login"}},
+		"Bug Fixes": {{SHA: "bbbb222", Title: "Crash fix"}},
+		"Internal":  {{SHA: "cccc333", Title: "Tidy imports"}},
+	}}
+
+	cfg := config.Default()
+	cfg.LinkStyle = "pr"
+	gh := github.NewClient("", "acme", "api")
+	g := New(gh, nil, WithConfig(cfg))
+	attributeEntries(response, commits)
+	g.linkPullRequests(response)
+
+	markdown := FormatMarkdown(response, "v1.0.0", "v1.1.0", cfg, gh.CommitURL)
+	for _, want := range []string{
+		"- **SSO login** ([#1234](https://github.com/acme/api/pull/1234))",
+		"- **Crash fix** ([#77](https://github.com/acme/api/pull/77))",
+		"- **Tidy imports** ([`cccc333`](https://github.com/acme/api/commit/cccc333))",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Expected %q in:\n%s", want, markdown)
+		}
+	}
+}
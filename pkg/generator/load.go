@@ -0,0 +1,32 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// LoadChangelog decodes a changelog written with --format=json, returning a
+// *Changelog, *TimelineChangelog, or *OrgTimelineChangelog depending on its shape
+func LoadChangelog(data []byte) (any, error) {
+	var shape map[string]json.RawMessage
+	if err := json.Unmarshal(data, &shape); err != nil {
+		return nil, fmt.Errorf("decode changelog: %w", err)
+	}
+
+	var changelog any
+	switch {
+	case shape["repos"] != nil:
+		changelog = &OrgTimelineChangelog{}
+	case shape["releases"] != nil:
+		changelog = &TimelineChangelog{}
+	case shape["categories"] != nil:
+		changelog = &Changelog{}
+	default:
+		return nil, fmt.Errorf("decode changelog: not a changelog JSON document")
+	}
+
+	if err := json.Unmarshal(data, changelog); err != nil {
+		return nil, fmt.Errorf("decode changelog: %w", err)
+	}
+	return changelog, nil
+}
@@ -0,0 +1,33 @@
+package generator
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestLoadChangelog(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"range", `{"categories": {"Features": []}, "to_ref": "v1.1.0"}`, "*generator.Changelog"},
+		{"timeline", `{"repo_name": "api", "releases": []}`, "*generator.TimelineChangelog"},
+		{"org", `{"org": "acme", "repos": []}`, "*generator.OrgTimelineChangelog"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changelog, err := LoadChangelog([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("LoadChangelog() error = %v", err)
+			}
+			if got := fmt.Sprintf("%T", changelog); got != tt.want {
+				t.Errorf("LoadChangelog() type = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := LoadChangelog([]byte(`{"name": "other"}`)); err == nil {
+		t.Error("Expected an error for a non-changelog document")
+	}
+}
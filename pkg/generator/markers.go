@@ -0,0 +1,48 @@
+package generator
+
+import "strings"
+
+// Markers delimiting the generated block inside a hand-edited release body.
+// Everything outside them is left untouched by --update-release-notes.
+const (
+	GeneratedBlockStart = "<!-- changelog-generator:start -->"
+	GeneratedBlockEnd   = "<!-- changelog-generator:end -->"
+)
+
+// ReplaceGeneratedBlock returns body with the text between the markers
+// replaced by generated. A body without a complete marker pair gets the
+// block appended after its existing text, so the next update finds it.
+func ReplaceGeneratedBlock(body, generated string) string {
+	block := GeneratedBlockStart + "\n" + strings.TrimSpace(generated) + "\n" + GeneratedBlockEnd
+
+	start := strings.Index(body, GeneratedBlockStart)
+	if start >= 0 {
+		if end := strings.Index(body[start:], GeneratedBlockEnd); end >= 0 {
+			end += start + len(GeneratedBlockEnd)
+			return body[:start] + block + body[end:]
+		}
+	}
+
+	body = strings.TrimRight(body, "\r\n\t ")
+	if body == "" {
+		return block + "\n"
+	}
+	return body + "\n\n" + block + "\n"
+}
+
+// HandWrittenNotes returns a release body without its generated block, the
+// part maintainers wrote themselves
+func HandWrittenNotes(body string) string {
+	for {
+		start := strings.Index(body, GeneratedBlockStart)
+		if start < 0 {
+			break
+		}
+		end := strings.Index(body[start:], GeneratedBlockEnd)
+		if end < 0 {
+			break
+		}
+		body = body[:start] + body[start+end+len(GeneratedBlockEnd):]
+	}
+	return strings.TrimSpace(body)
+}
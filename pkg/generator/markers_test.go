@@ -0,0 +1,47 @@
+package generator
+
+import "testing"
+
+func TestReplaceGeneratedBlock(t *testing.T) {
+	cases := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "empty body",
+			body: "",
+			want: GeneratedBlockStart + "\nnew\n" + GeneratedBlockEnd + "\n",
+		},
+		{
+			name: "no markers appends",
+			body: "Hand-written intro\n\n",
+			want: "Hand-written intro\n\n" + GeneratedBlockStart + "\nnew\n" + GeneratedBlockEnd + "\n",
+		},
+		{
+			name: "replaces between markers only",
+			body: "## Upgrade notes\nRun migrations.\n\n" + GeneratedBlockStart + "\nold\nlines\n" + GeneratedBlockEnd + "\n\n## Thanks\nTo everyone.",
+			want: "## Upgrade notes\nRun migrations.\n\n" + GeneratedBlockStart + "\nnew\n" + GeneratedBlockEnd + "\n\n## Thanks\nTo everyone.",
+		},
+		{
+			name: "unterminated start marker appends",
+			body: "intro\n" + GeneratedBlockStart + "\nold",
+			want: "intro\n" + GeneratedBlockStart + "\nold\n\n" + GeneratedBlockStart + "\nnew\n" + GeneratedBlockEnd + "\n",
+		},
+	}
+	for _, c := range cases {
+		if got := ReplaceGeneratedBlock(c.body, "\nnew\n"); got != c.want {
+			t.Errorf("%s:\ngot  %q\nwant %q", c.name, got, c.want)
+		}
+	}
+}
+
+func TestHandWrittenNotes(t *testing.T) {
+	body := "## Upgrade notes\nRun migrations.\n\n" + GeneratedBlockStart + "\ngenerated\n" + GeneratedBlockEnd + "\n\n## Thanks\nTo everyone.\n"
+	if got, want := HandWrittenNotes(body), "## Upgrade notes\nRun migrations.\n\n\n\n## Thanks\nTo everyone."; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got := HandWrittenNotes(GeneratedBlockStart + "\nonly generated\n" + GeneratedBlockEnd); got != "" {
+		t.Errorf("got %q, want empty", got)
+	}
+}
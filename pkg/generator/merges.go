@@ -0,0 +1,136 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+// mergePRRe matches GitHub's merge commit subject: "Merge pull request #12 from owner/branch"
+var mergePRRe = regexp.MustCompile(`^Merge pull request #(\d+) from \S+`)
+
+// CollapseMerges replaces each merge commit and the branch commits it brought
+// in with one commit: the merge's SHA and date, the pull request title (or the
+// merge subject), the branch commits' subjects as the body, and their files
+// and line counts combined. The author is the branch's first committer and the
+// other branch authors become co-authors. Commits must be oldest first and
+// carry parent SHAs; merges whose branch brought in nothing of the range stay
+// as they are.
+func CollapseMerges(commits []provider.CommitData) []provider.CommitData {
+	index := make(map[string]int, len(commits))
+	for i, commit := range commits {
+		index[commit.SHA] = i
+	}
+
+	absorbed := make(map[string]bool)
+	collapsed := make(map[string]provider.CommitData)
+	// Newest first, so an outer merge absorbs merges nested in its branch
+	for i := len(commits) - 1; i >= 0; i-- {
+		merge := commits[i]
+		if len(merge.Parents) < 2 || absorbed[merge.SHA] {
+			continue
+		}
+		mainline := reachable(commits, index, merge.Parents[:1], nil)
+		branch := reachable(commits, index, merge.Parents[1:], mainline)
+		var constituents []provider.CommitData
+		for j, commit := range commits {
+			if branch[commit.SHA] && !absorbed[commit.SHA] && j < i {
+				constituents = append(constituents, commit)
+				absorbed[commit.SHA] = true
+			}
+		}
+		if len(constituents) > 0 {
+			collapsed[merge.SHA] = collapseMerge(merge, constituents)
+		}
+	}
+
+	result := make([]provider.CommitData, 0, len(commits)-len(absorbed))
+	for _, commit := range commits {
+		switch {
+		case absorbed[commit.SHA]:
+		case collapsed[commit.SHA].SHA != "":
+			result = append(result, collapsed[commit.SHA])
+		default:
+			result = append(result, commit)
+		}
+	}
+	return result
+}
+
+// reachable returns the SHAs of the range reachable from start through
+// parent links, not walking into stop
+func reachable(commits []provider.CommitData, index map[string]int, start []string, stop map[string]bool) map[string]bool {
+	seen := make(map[string]bool)
+	queue := append([]string(nil), start...)
+	for len(queue) > 0 {
+		sha := queue[0]
+		queue = queue[1:]
+		i, inRange := index[sha]
+		if !inRange || seen[sha] || stop[sha] {
+			continue
+		}
+		seen[sha] = true
+		queue = append(queue, commits[i].Parents...)
+	}
+	return seen
+}
+
+// collapseMerge builds the single logical commit for a merge and its branch commits
+func collapseMerge(merge provider.CommitData, constituents []provider.CommitData) provider.CommitData {
+	subject, body, _ := strings.Cut(merge.Message, "\n")
+	title := strings.TrimSpace(subject)
+	if m := mergePRRe.FindStringSubmatch(title); m != nil {
+		// GitHub puts the pull request title on the first body line
+		if prTitle := strings.TrimSpace(strings.SplitN(strings.TrimSpace(body), "\n", 2)[0]); prTitle != "" {
+			title = prTitle
+		}
+		title = fmt.Sprintf("%s (#%s)", title, m[1])
+	}
+
+	var sb strings.Builder
+	sb.WriteString(title + "\n\nCommits:\n")
+	for _, commit := range constituents {
+		sb.WriteString("- " + commitSubject(commit.Message) + "\n")
+	}
+
+	collapsed := provider.CommitData{
+		SHA:          merge.SHA,
+		Message:      sb.String(),
+		Author:       constituents[0].Author,
+		Date:         merge.Date,
+		Verification: merge.Verification,
+		Parents:      merge.Parents,
+	}
+	authors := map[string]bool{collapsed.Author: true}
+	files := make(map[string]int)
+	for _, commit := range constituents {
+		for _, author := range append([]string{commit.Author}, commit.CoAuthors...) {
+			if author != "" && !authors[author] {
+				authors[author] = true
+				collapsed.CoAuthors = append(collapsed.CoAuthors, author)
+			}
+		}
+		for _, file := range commit.FilesChanged {
+			j, seen := files[file.Filename]
+			if !seen {
+				files[file.Filename] = len(collapsed.FilesChanged)
+				collapsed.FilesChanged = append(collapsed.FilesChanged, file)
+				continue
+			}
+			combined := &collapsed.FilesChanged[j]
+			combined.Additions += file.Additions
+			combined.Deletions += file.Deletions
+			if file.Patch != "" {
+				combined.Patch = strings.TrimRight(combined.Patch, "\n") + "\n" + file.Patch
+			}
+		}
+		collapsed.Stats.Additions += commit.Stats.Additions
+		collapsed.Stats.Deletions += commit.Stats.Deletions
+		collapsed.Stats.Total += commit.Stats.Total
+		collapsed.Stats.Files = max(collapsed.Stats.Files, commit.Stats.Files) // At least the largest constituent's count
+	}
+	collapsed.Stats.Files = max(collapsed.Stats.Files, len(collapsed.FilesChanged))
+	return collapsed
+}
@@ -0,0 +1,53 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+func TestCollapseMerges(t *testing.T) {
+	// main: base ── fix ─────────── M1 ── M2
+	//          └── wip ── tests ──┘     /
+	//          └── docs ─────────────────┘
+	commits := []provider.CommitData{
+		{SHA: "base", Message: "Prepare release", Parents: []string{"v1"}},
+		{SHA: "wip", Message: "WIP sso", Author: "alice", Parents: []string{"base"}, Stats: provider.CommitStats{Additions: 10, Total: 10},
+			FilesChanged: []provider.FileChange{{Filename: "auth/sso.go", Status: "added", Additions: 10}}},
+		{SHA: "fix", Message: "Fix typo", Author: "carol", Parents: []string{"base"}},
+		{SHA: "tests", Message: "Fix tests\n\nCo-authored-by: Dan <dan@example.com>", Author: "bob", CoAuthors: []string{"Dan"}, Parents: []string{"wip"},
+			Stats:        provider.CommitStats{Additions: 3, Deletions: 1, Total: 4},
+			FilesChanged: []provider.FileChange{{Filename: "auth/sso.go", Status: "modified", Additions: 3, Deletions: 1}}},
+		{SHA: "M1", Message: "Merge pull request #42 from acme/sso\n\nAdd SSO login", Author: "maintainer", Parents: []string{"fix", "tests"}},
+		{SHA: "docs", Message: "Document SSO", Author: "erin", Parents: []string{"base"}},
+		{SHA: "M2", Message: "Merge branch 'docs'", Author: "maintainer", Parents: []string{"M1", "docs"}},
+		{SHA: "M3", Message: "Merge branch 'main' into release", Parents: []string{"M2", "v1"}}, // Brings in nothing of the range
+	}
+
+	collapsed := CollapseMerges(commits)
+	var shas []string
+	for _, commit := range collapsed {
+		shas = append(shas, commit.SHA)
+	}
+	if strings.Join(shas, ",") != "base,fix,M1,M2,M3" {
+		t.Fatalf("Unexpected commits after collapsing: %v", shas)
+	}
+
+	sso := collapsed[2]
+	if sso.Message != "Add SSO login (#42)\n\nCommits:\n- WIP sso\n- Fix tests\n" {
+		t.Errorf("Unexpected message %q", sso.Message)
+	}
+	if sso.Author != "alice" || strings.Join(sso.CoAuthors, ",") != "bob,Dan" {
+		t.Errorf("Unexpected authors %q, %v", sso.Author, sso.CoAuthors)
+	}
+	if len(sso.FilesChanged) != 1 || sso.FilesChanged[0].Additions != 13 || sso.FilesChanged[0].Status != "added" || sso.Stats.Total != 14 {
+		t.Errorf("Unexpected combined changes: %+v, %+v", sso.FilesChanged, sso.Stats)
+	}
+	if CommitPRNumber(sso.Message) != 42 {
+		t.Error("Expected the collapsed commit to keep its PR number")
+	}
+	if !strings.HasPrefix(collapsed[3].Message, "Merge branch 'docs'\n\nCommits:\n- Document SSO\n") {
+		t.Errorf("Unexpected branch merge message %q", collapsed[3].Message)
+	}
+}
@@ -0,0 +1,101 @@
+package generator
+
+import (
+	"time"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+)
+
+// toolName identifies this tool in generation metadata
+const toolName = "changelog-generator"
+
+// Metadata records how a changelog was generated, so archived changelogs can
+// be audited and regenerated from the same inputs
+type Metadata struct {
+	Tool          string    `json:"tool"`
+	ToolVersion   string    `json:"tool_version,omitempty"` // Empty when used as a library without WithToolVersion
+	Model         string    `json:"model"`
+	Temperature   float64   `json:"temperature"`
+	PromptVersion string    `json:"prompt_version"` // llm.PromptVersion
+	StartedAt     time.Time `json:"started_at"`
+	FinishedAt    time.Time `json:"finished_at"`
+	Usage         llm.Usage `json:"usage"` // LLM calls and tokens of this run
+	Source        Source    `json:"source"`
+	Reproducible  bool      `json:"reproducible,omitempty"`
+	Seed          int       `json:"seed,omitempty"`
+	PromptHash    string    `json:"prompt_hash,omitempty"`    // llm.HashPrompts of the prompts of this run, in order
+	ModelSnapshot string    `json:"model_snapshot,omitempty"` // Model version that answered, e.g. gpt-4o-2024-08-06
+	Fingerprint   string    `json:"system_fingerprint,omitempty"`
+}
+
+// tracer is implemented by LLM clients that record the prompts they send and
+// the model snapshot that answered
+type tracer interface {
+	Trace() llm.Trace
+}
+
+// Source is what a changelog was generated from
+type Source struct {
+	Provider string    `json:"provider,omitempty"` // Empty for commits supplied by the caller
+	Repo     string    `json:"repo"`
+	Branch   string    `json:"branch,omitempty"`
+	FromRef  string    `json:"from_ref,omitempty"`
+	ToRef    string    `json:"to_ref,omitempty"`
+	FromDate time.Time `json:"from_date,omitzero"` // Timelines
+	ToDate   time.Time `json:"to_date,omitzero"`
+}
+
+// NewMetadata describes a run with cfg's model settings that started at
+// started, finished now, and used usage
+func NewMetadata(cfg *config.Config, toolVersion string, started time.Time, usage llm.Usage, source Source) *Metadata {
+	return &Metadata{
+		Tool:          toolName,
+		ToolVersion:   toolVersion,
+		Model:         cfg.OpenAIModel,
+		Temperature:   cfg.Temperature,
+		PromptVersion: llm.PromptVersion,
+		StartedAt:     started.UTC().Truncate(time.Second),
+		FinishedAt:    time.Now().UTC().Truncate(time.Second),
+		Usage:         usage,
+		Source:        source,
+		Reproducible:  cfg.Reproducible,
+		Seed:          cfg.Seed,
+	}
+}
+
+// SetToolVersion records the version of the calling program in generation
+// metadata, like WithToolVersion
+func (g *Generator) SetToolVersion(version string) {
+	g.version = version
+}
+
+// metadata describes the current run, reading from source
+func (g *Generator) metadata(source Source) *Metadata {
+	if g.provider != nil {
+		source.Provider = g.config.Provider
+		source.Branch = g.config.Branch
+	}
+	source.Repo = g.config.RepoOwner + "/" + g.config.RepoName
+	metadata := NewMetadata(g.config, g.version, g.started, g.runUsage(), source)
+	if t, ok := g.llmClient.(tracer); ok {
+		trace := t.Trace()
+		if hashes := trace.PromptHashes[min(g.baseTrace, len(trace.PromptHashes)):]; len(hashes) > 0 {
+			metadata.PromptHash = llm.HashPrompts(hashes)
+		}
+		metadata.ModelSnapshot = trace.Model
+		metadata.Fingerprint = trace.Fingerprint
+	}
+	return metadata
+}
+
+// runUsage returns the LLM usage since the current run started, including
+// the batch responses it used
+func (g *Generator) runUsage() llm.Usage {
+	usage := g.llmClient.Usage()
+	return llm.Usage{
+		Calls:        usage.Calls - g.baseUsage.Calls + g.batchUsage.Calls,
+		InputTokens:  usage.InputTokens - g.baseUsage.InputTokens + g.batchUsage.InputTokens,
+		OutputTokens: usage.OutputTokens - g.baseUsage.OutputTokens + g.batchUsage.OutputTokens,
+	}
+}
@@ -0,0 +1,101 @@
+package generator
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+)
+
+func TestChangelogMetadata(t *testing.T) {
+	client := &fakeLLM{}
+	gen := New(nil, client, WithToolVersion("1.2.3"))
+	commits := []llm.CommitInfo{{SHA: "abc1234", Message: "Add SSO login", Author: "alice"}}
+
+	// Usage is per run, not per client
+	if _, err := gen.GenerateFromCommits(context.Background(), commits, "v1.0.0", "v1.1.0"); err != nil {
+		t.Fatal(err)
+	}
+	changelog, err := gen.GenerateFromCommits(context.Background(), commits, "v1.0.0", "v1.1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	meta := changelog.Metadata
+	if meta == nil || meta.Tool != "changelog-generator" || meta.ToolVersion != "1.2.3" || meta.PromptVersion != llm.PromptVersion {
+		t.Fatalf("metadata = %+v", meta)
+	}
+	if meta.Model != "gpt-4o" || meta.Usage.Calls != 1 || meta.FinishedAt.Before(meta.StartedAt) {
+		t.Errorf("metadata = %+v", meta)
+	}
+	if meta.Source.FromRef != "v1.0.0" || meta.Source.ToRef != "v1.1.0" || meta.Source.Provider != "" {
+		t.Errorf("source = %+v", meta.Source)
+	}
+
+	data, err := json.Marshal(changelog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateJSON(data); err != nil {
+		t.Error(err)
+	}
+}
+
+// tracingLLM records a prompt hash per changelog call, like OpenAIClient
+type tracingLLM struct {
+	fakeLLM
+	trace llm.Trace
+}
+
+func (f *tracingLLM) GenerateChangelog(ctx context.Context, req llm.ChangelogRequest) (*llm.ChangelogResponse, error) {
+	f.trace.PromptHashes = append(f.trace.PromptHashes, strings.Repeat(req.ToRef[len(req.ToRef)-1:], 64))
+	f.trace.Model, f.trace.Fingerprint = "gpt-4o-2024-08-06", "fp_1"
+	return f.fakeLLM.GenerateChangelog(ctx, req)
+}
+
+func (f *tracingLLM) Trace() llm.Trace {
+	return f.trace
+}
+
+func TestReproducibleMetadata(t *testing.T) {
+	client := &tracingLLM{}
+	cfg := config.Default()
+	cfg.Reproducible, cfg.Temperature, cfg.Seed = true, 0, config.DefaultSeed
+	gen := New(nil, client, WithConfig(cfg))
+	commits := []llm.CommitInfo{{SHA: "abc1234", Message: "Add SSO login", Author: "alice"}}
+
+	first, err := gen.GenerateFromCommits(context.Background(), commits, "v1.0.0", "v1.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := gen.GenerateFromCommits(context.Background(), commits, "v1.0.0", "v1.0.2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	meta := second.Metadata
+	if !meta.Reproducible || meta.Seed != config.DefaultSeed || meta.Temperature != 0 {
+		t.Errorf("metadata = %+v", meta)
+	}
+	if meta.ModelSnapshot != "gpt-4o-2024-08-06" || meta.Fingerprint != "fp_1" {
+		t.Errorf("snapshot = %q, fingerprint = %q", meta.ModelSnapshot, meta.Fingerprint)
+	}
+	// The hash covers only this run's prompts
+	if want := llm.HashPrompts([]string{strings.Repeat("2", 64)}); meta.PromptHash != want {
+		t.Errorf("prompt hash = %q, want %q", meta.PromptHash, want)
+	}
+	if first.Metadata.PromptHash == meta.PromptHash {
+		t.Error("different prompts hashed the same")
+	}
+
+	data, err := json.Marshal(second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateJSON(data); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,104 @@
+package generator
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// EngineeringMetrics holds DORA-style delivery metrics for a timeline
+type EngineeringMetrics struct {
+	Releases          int     `json:"releases"`
+	ReleasesPerWeek   float64 `json:"releases_per_week"`   // Deployment frequency, approximated by releases
+	PullRequests      int     `json:"pull_requests"`       // PRs with known open and merge times
+	MedianMergeHours  float64 `json:"median_merge_hours"`  // PR opened → merged
+	MedianLeadHours   float64 `json:"median_lead_hours"`   // PR opened → released
+	MedianReleaseDays float64 `json:"median_release_days"` // Time between consecutive releases
+}
+
+// ComputeMetrics derives delivery metrics from a timeline's releases and the
+// open/merge times of their pull requests
+func ComputeMetrics(timeline *TimelineChangelog) *EngineeringMetrics {
+	metrics := &EngineeringMetrics{Releases: len(timeline.Releases)}
+
+	if weeks := timeline.ToDate.Sub(timeline.FromDate).Hours() / (24 * 7); weeks > 0 {
+		metrics.ReleasesPerWeek = float64(metrics.Releases) / weeks
+	}
+
+	var mergeHours, leadHours, releaseDays []float64
+	for i, release := range timeline.Releases {
+		if i > 0 {
+			previous := timeline.Releases[i-1].ToDate
+			releaseDays = append(releaseDays, release.ToDate.Sub(previous).Hours()/24)
+		}
+		for _, pr := range release.PullRequests {
+			if pr.CreatedAt.IsZero() || pr.MergedAt.IsZero() {
+				continue
+			}
+			mergeHours = append(mergeHours, pr.MergedAt.Sub(pr.CreatedAt).Hours())
+			if release.ToDate.After(pr.CreatedAt) {
+				leadHours = append(leadHours, release.ToDate.Sub(pr.CreatedAt).Hours())
+			}
+		}
+	}
+
+	metrics.PullRequests = len(mergeHours)
+	metrics.MedianMergeHours = median(mergeHours)
+	metrics.MedianLeadHours = median(leadHours)
+	metrics.MedianReleaseDays = median(releaseDays)
+	return metrics
+}
+
+// median returns the median of values (0 when empty)
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// FormatMetrics renders the engineering-metrics appendix as a markdown table
+func FormatMetrics(metrics *EngineeringMetrics) string {
+	if metrics == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## 📈 Engineering Metrics\n\n")
+	sb.WriteString("| Metric | Value |\n")
+	sb.WriteString("|--------|-------|\n")
+	sb.WriteString(fmt.Sprintf("| Releases | %d (%.1f per week) |\n", metrics.Releases, metrics.ReleasesPerWeek))
+	if metrics.Releases > 1 {
+		sb.WriteString(fmt.Sprintf("| Median time between releases | %s |\n", formatHours(metrics.MedianReleaseDays*24)))
+	}
+	if metrics.PullRequests > 0 {
+		sb.WriteString(fmt.Sprintf("| Median time to merge | %s (%s) |\n",
+			formatHours(metrics.MedianMergeHours), pluralize(metrics.PullRequests, "PR")))
+		sb.WriteString(fmt.Sprintf("| Median lead time (PR opened → released) | %s |\n", formatHours(metrics.MedianLeadHours)))
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// formatHours renders a duration in hours as "3d 4h", "5h", or "<1h"
+func formatHours(hours float64) string {
+	d := time.Duration(hours * float64(time.Hour)).Round(time.Hour)
+	days, rest := int(d/(24*time.Hour)), int((d%(24*time.Hour))/time.Hour)
+	switch {
+	case days > 0 && rest > 0:
+		return fmt.Sprintf("%dd %dh", days, rest)
+	case days > 0:
+		return fmt.Sprintf("%dd", days)
+	case rest > 0:
+		return fmt.Sprintf("%dh", rest)
+	default:
+		return "<1h"
+	}
+}
@@ -0,0 +1,60 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+func TestComputeMetrics(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	timeline := &TimelineChangelog{
+		FromDate: day(1),
+		ToDate:   day(15),
+		Releases: []ReleaseChangelog{
+			{ToRef: "v1.0.0", ToDate: day(5), PullRequests: []provider.PullRequestData{
+				{Number: 1, CreatedAt: day(1), MergedAt: day(2)},
+				{Number: 2}, // Unknown times are skipped
+			}},
+			{ToRef: "v1.1.0", ToDate: day(11), PullRequests: []provider.PullRequestData{
+				{Number: 3, CreatedAt: day(6), MergedAt: day(9)},
+				{Number: 4, CreatedAt: day(8), MergedAt: day(10)},
+			}},
+		},
+	}
+
+	metrics := ComputeMetrics(timeline)
+	if metrics.Releases != 2 || metrics.ReleasesPerWeek != 1 {
+		t.Errorf("Expected 2 releases at 1 per week, got %d at %.2f", metrics.Releases, metrics.ReleasesPerWeek)
+	}
+	if metrics.PullRequests != 3 {
+		t.Errorf("Expected 3 measured PRs, got %d", metrics.PullRequests)
+	}
+	if metrics.MedianMergeHours != 48 {
+		t.Errorf("Expected median merge time of 48h, got %.1f", metrics.MedianMergeHours)
+	}
+	if metrics.MedianLeadHours != 96 {
+		t.Errorf("Expected median lead time of 96h, got %.1f", metrics.MedianLeadHours)
+	}
+	if metrics.MedianReleaseDays != 6 {
+		t.Errorf("Expected 6 days between releases, got %.1f", metrics.MedianReleaseDays)
+	}
+
+	markdown := FormatMetrics(metrics)
+	for _, want := range []string{"## 📈 Engineering Metrics", "| Releases | 2 (1.0 per week) |", "| Median time to merge | 2d (3 PRs) |", "| 4d |"} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Expected metrics to contain %q\nGot:\n%s", want, markdown)
+		}
+	}
+}
+
+func TestFormatHours(t *testing.T) {
+	tests := map[float64]string{0.2: "<1h", 5: "5h", 24: "1d", 28.4: "1d 4h"}
+	for hours, want := range tests {
+		if got := formatHours(hours); got != want {
+			t.Errorf("formatHours(%v) = %q, want %q", hours, got, want)
+		}
+	}
+}
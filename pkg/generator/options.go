@@ -0,0 +1,69 @@
+package generator
+
+import (
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/tickets"
+)
+
+// Option configures a Generator created with New
+type Option func(*Generator)
+
+// New creates a changelog generator for use as a library. source is any
+// hosting provider (nil when commits are passed to GenerateFromCommits) and
+// client any LLM backend. Without WithConfig the defaults of config.Default
+// apply; nothing is read from config files, flags, or the environment, and
+// nothing is printed.
+func New(source provider.Provider, client llm.Client, opts ...Option) *Generator {
+	g := &Generator{
+		provider:  source,
+		llmClient: client,
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	if g.config == nil {
+		g.config = config.Default()
+	}
+	return g
+}
+
+// WithConfig sets the generation settings; start from config.Default to keep
+// the defaults of fields left unset
+func WithConfig(cfg *config.Config) Option {
+	return func(g *Generator) {
+		g.config = cfg
+	}
+}
+
+// WithTicketLinker links issue tracker references in generated entries
+func WithTicketLinker(linker *tickets.Linker) Option {
+	return func(g *Generator) {
+		g.tickets = linker
+	}
+}
+
+// WithDocumentedVersions skips timeline releases ending at these versions,
+// like SetDocumentedVersions
+func WithDocumentedVersions(versions map[string]bool) Option {
+	return func(g *Generator) {
+		g.documented = versions
+	}
+}
+
+// WithCommitSHAs fetches exactly these commits instead of enumerating a range,
+// like SetCommitSHAs
+func WithCommitSHAs(shas []string) Option {
+	return func(g *Generator) {
+		g.commitSHAs = shas
+	}
+}
+
+// WithToolVersion records the version of the calling program in generation
+// metadata, like SetToolVersion
+func WithToolVersion(version string) Option {
+	return func(g *Generator) {
+		g.version = version
+	}
+}
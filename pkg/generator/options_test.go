@@ -0,0 +1,91 @@
+package generator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+// fakeLLM answers every changelog request with one entry per commit
+type fakeLLM struct {
+	llm.Client // Other calls are not expected in these tests
+	calls      int
+}
+
+func (f *fakeLLM) GenerateChangelog(ctx context.Context, req llm.ChangelogRequest) (*llm.ChangelogResponse, error) {
+	f.calls++
+	response := &llm.ChangelogResponse{Summary: "A small release.", Categories: map[string][]llm.ChangelogEntry{}}
+	for _, commit := range req.Commits {
+		response.Categories["Features"] = append(response.Categories["Features"], llm.ChangelogEntry{
+			SHA: commit.SHA, Title: commit.Message, ImportanceScore: 6, Author: commit.Author,
+		})
+	}
+	return response, nil
+}
+
+func (f *fakeLLM) Usage() llm.Usage {
+	return llm.Usage{Calls: f.calls}
+}
+
+func TestNewAsLibrary(t *testing.T) {
+	client := &fakeLLM{}
+	gen := New(nil, client)
+	commits := []llm.CommitInfo{{SHA: "abc1234", Message: "Add SSO login", Author: "alice"}}
+
+	changelog, err := gen.GenerateFromCommits(context.Background(), commits, "v1.0.0", "v1.1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(changelog.Markdown, "**Add SSO login**") || gen.Usage().Calls != 1 {
+		t.Errorf("Unexpected library run: %d calls, markdown:\n%s", gen.Usage().Calls, changelog.Markdown)
+	}
+	// Defaults match the CLI's, e.g. authors are credited
+	if !strings.Contains(changelog.Markdown, "@alice") {
+		t.Errorf("Expected default config to credit authors, got:\n%s", changelog.Markdown)
+	}
+
+	cfg := config.Default()
+	cfg.IncludeAuthors = false
+	changelog, err = New(nil, client, WithConfig(cfg)).GenerateFromCommits(context.Background(), commits, "v1.0.0", "v1.1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(changelog.Markdown, "@alice") {
+		t.Errorf("Expected WithConfig to turn off authors, got:\n%s", changelog.Markdown)
+	}
+}
+
+func TestDiffSummaryStrategy(t *testing.T) {
+	small := provider.CommitData{FilesChanged: []provider.FileChange{
+		{Filename: "a.go", Additions: 1, Deletions: 1, Patch: "@@ -1 +1 @@\n-old\n+new\n"},
+	}}
+	large := provider.CommitData{FilesChanged: []provider.FileChange{
+		{Filename: "a.go", Additions: 5, Patch: "@@ -0,0 +1,5 @@\n+1\n+2\n+3\n+4\n+5"},
+		{Filename: "b.go", Additions: 30, Patch: "@@ -0,0 +1,30 @@\n+1\n+2\n+3"},
+		{Filename: "c.go", Additions: 30, Patch: "@@ -0,0 +1,30 @@\n+1"},
+	}}
+
+	cfg := config.Default()
+	gen := New(nil, &fakeLLM{}, WithConfig(cfg))
+	if got := gen.diffSummary(small); got != "" {
+		t.Errorf("small commit without --full-patch-lines = %q", got)
+	}
+	if got := gen.diffSummary(large); !strings.HasPrefix(got, "b.go: +3/-0 lines.") || !strings.Contains(got, "\nc.go: ") {
+		t.Errorf("default summary = %q", got)
+	}
+
+	cfg.FullPatchLines = 2
+	cfg.DiffThreshold = 0
+	cfg.DiffFiles = 1
+	cfg.DiffLines = 2
+	if got := gen.diffSummary(small); got != "a.go:\n@@ -1 +1 @@\n-old\n+new" {
+		t.Errorf("full patch = %q", got)
+	}
+	if got := gen.diffSummary(large); got != "a.go: +5/-0 lines. Sample:\n@@ -0,0 +1,5 @@\n+1\n... (4 more lines truncated)" {
+		t.Errorf("configured summary = %q", got)
+	}
+}
@@ -0,0 +1,84 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// OrgTimelineChangelog consolidates the timelines of every repository in an
+// organization that shipped releases in the date range
+type OrgTimelineChangelog struct {
+	Schema       string               `json:"schema_version"` // SchemaVersion
+	Metadata     *Metadata            `json:"metadata,omitempty"`
+	Org          string               `json:"org"`
+	FromDate     time.Time            `json:"from_date"`
+	ToDate       time.Time            `json:"to_date"`
+	ReposScanned int                  `json:"repos_scanned"`
+	Repos        []*TimelineChangelog `json:"repos"`
+	Markdown     string               `json:"-"`
+}
+
+// FormatOrgTimeline renders a consolidated "what shipped" document grouped by
+// repository. Repository sections reuse each release's full markdown, nested
+// one heading level deeper.
+func FormatOrgTimeline(org *OrgTimelineChangelog) string {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("# What Shipped: %s\n\n", org.Org))
+	b.WriteString(fmt.Sprintf("**Timeline:** %s to %s\n\n",
+		org.FromDate.Format("January 2, 2006"),
+		org.ToDate.Format("January 2, 2006")))
+	b.WriteString(fmt.Sprintf("**Repositories:** %d of %d scanned shipped releases\n\n",
+		len(org.Repos), org.ReposScanned))
+
+	if len(org.Repos) == 0 {
+		b.WriteString("_No releases in this period._\n")
+		return b.String()
+	}
+
+	// Overview: one line per repository, described by its newest release
+	for _, repo := range org.Repos {
+		b.WriteString(fmt.Sprintf("- **%s** (%s)", repo.RepoName, pluralize(len(repo.Releases), "release")))
+		if newest := repo.Releases[len(repo.Releases)-1]; newest.Zoom.OneLiner != "" {
+			b.WriteString(": " + newest.Zoom.OneLiner)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+
+	for _, repo := range org.Repos {
+		b.WriteString("---\n\n")
+		b.WriteString(fmt.Sprintf("## %s\n\n", repo.RepoName))
+		for _, release := range repo.Releases {
+			b.WriteString(strings.TrimRight(demoteHeadings(release.Zoom.Full), "\n") + "\n\n")
+		}
+	}
+
+	return b.String()
+}
+
+// demoteHeadings nests every markdown heading one level deeper, leaving
+// fenced code blocks untouched
+func demoteHeadings(markdown string) string {
+	lines := strings.Split(markdown, "\n")
+	inFence := false
+	for i, line := range lines {
+		if strings.HasPrefix(line, "```") {
+			inFence = !inFence
+			continue
+		}
+		if !inFence && strings.HasPrefix(line, "#") {
+			lines[i] = "#" + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// pluralize formats a count with a singular or plural noun
+func pluralize(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
@@ -0,0 +1,41 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDemoteHeadings(t *testing.T) {
+	input := "## [Release v1.0.0]\n\n- change\n```sh\n# comment\n```\n### Contributors"
+	want := "### [Release v1.0.0]\n\n- change\n```sh\n# comment\n```\n#### Contributors"
+	if got := demoteHeadings(input); got != want {
+		t.Errorf("demoteHeadings() = %q, want %q", got, want)
+	}
+}
+
+func TestFormatOrgTimeline(t *testing.T) {
+	org := &OrgTimelineChangelog{
+		Org:          "acme",
+		ReposScanned: 3,
+		Repos: []*TimelineChangelog{{
+			RepoName: "acme/api",
+			Releases: []ReleaseChangelog{
+				{ToRef: "v1.0.0", Zoom: ZoomSummaries{Full: "## [Release v1.0.0]\n"}},
+				{ToRef: "v1.1.0", Zoom: ZoomSummaries{OneLiner: "Adds webhooks.", Full: "## [Release v1.1.0]\n"}},
+			},
+		}},
+	}
+
+	markdown := FormatOrgTimeline(org)
+	for _, want := range []string{
+		"# What Shipped: acme",
+		"1 of 3 scanned",
+		"- **acme/api** (2 releases): Adds webhooks.",
+		"## acme/api",
+		"### [Release v1.1.0]",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Expected %q in:\n%s", want, markdown)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+// releaseSummaries is the PR changelog generated for one timeline release
+type releaseSummaries struct {
+	request  llm.PRChangelogRequest
+	response *llm.PRChangelogResponse // Nil for releases without pull requests
+	model    string                   // Model routed to, empty for the default
+}
+
+// generateReleaseSummaries asks the LLM for the PR changelog of every release
+// with pull requests and no Batch API response, up to LLMConcurrency releases
+// at once. Releases are started in order and results keep their order; the
+// first failure cancels the requests still running.
+func (g *Generator) generateReleaseSummaries(ctx context.Context, releases []provider.TimelineRelease) ([]releaseSummaries, error) {
+	results := make([]releaseSummaries, len(releases))
+	var pending []int
+	for i, release := range releases {
+		if len(release.PullRequests) == 0 {
+			continue
+		}
+		results[i].request = g.prChangelogRequest(ctx, release)
+		if g.batch != nil {
+			if response, ok := g.batch[release.ToRef]; ok {
+				results[i].response = response
+				continue
+			}
+			g.warn("the batch has no response for %s; generating it live", release.ToRef)
+		}
+		results[i].model = g.routeModel(len(release.PullRequests), g.changedLines(release.Commits))
+		pending = append(pending, i)
+	}
+
+	workers := min(max(g.config.LLMConcurrency, 1), len(pending))
+	if workers > 1 {
+		logger.Info("generating release changelogs concurrently", "releases", len(pending), "concurrency", workers)
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if ctx.Err() != nil {
+					continue
+				}
+				logger.Debug("generating release changelog", "to", releases[i].ToRef, "pull_requests", len(releases[i].PullRequests), "model", results[i].model)
+				response, err := g.llmClient.GeneratePRChangelog(llm.WithModel(ctx, results[i].model), results[i].request)
+				if err != nil {
+					cancel(fmt.Errorf("generate PR changelog for %s: %w", releases[i].ToRef, err))
+					continue
+				}
+				results[i].response = response
+			}
+		}()
+	}
+
+dispatch:
+	for _, i := range pending {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := context.Cause(ctx); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// prChangelogRequest builds the PR changelog request of a timeline release
+func (g *Generator) prChangelogRequest(ctx context.Context, release provider.TimelineRelease) llm.PRChangelogRequest {
+	request := llm.PRChangelogRequest{
+		PRs:      g.preparePRsForLLM(release.PullRequests),
+		RepoName: fmt.Sprintf("%s/%s", g.config.RepoOwner, g.config.RepoName),
+		FromRef:  release.FromRef,
+		ToRef:    release.ToRef,
+		Stack:    g.repoStack(ctx),
+	}
+	if g.config.ExistingNotes {
+		request.ExistingNotes = HandWrittenNotes(release.Notes)
+	}
+	return request
+}
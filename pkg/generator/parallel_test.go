@@ -0,0 +1,129 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+// concurrentLLM records how many PR changelog requests run at once
+type concurrentLLM struct {
+	llm.Client
+	mu      sync.Mutex
+	running int
+	peak    int
+	fail    string // ToRef whose request fails
+}
+
+func (c *concurrentLLM) GeneratePRChangelog(ctx context.Context, req llm.PRChangelogRequest) (*llm.PRChangelogResponse, error) {
+	c.mu.Lock()
+	c.running++
+	c.peak = max(c.peak, c.running)
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		c.running--
+		c.mu.Unlock()
+	}()
+
+	if req.ToRef == c.fail {
+		return nil, errors.New("rate limited")
+	}
+	select {
+	case <-time.After(20 * time.Millisecond):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return &llm.PRChangelogResponse{Summary: "Release " + req.ToRef}, nil
+}
+
+func timelineReleases(refs ...string) []provider.TimelineRelease {
+	var releases []provider.TimelineRelease
+	for i, ref := range refs {
+		release := provider.TimelineRelease{ToRef: ref}
+		if ref != "v0" { // A release without pull requests needs no LLM call
+			release.PullRequests = []provider.PullRequestData{{Number: i + 1, Title: "Change " + ref}}
+		}
+		releases = append(releases, release)
+	}
+	return releases
+}
+
+func TestGenerateReleaseSummariesConcurrently(t *testing.T) {
+	client := &concurrentLLM{}
+	cfg := config.Default()
+	cfg.LLMConcurrency = 3
+	gen := New(nil, client, WithConfig(cfg))
+
+	releases := timelineReleases("v1", "v0", "v2", "v3", "v4", "v5", "v6")
+	results, err := gen.generateReleaseSummaries(context.Background(), releases)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.peak != 3 {
+		t.Errorf("peak concurrency = %d, want 3", client.peak)
+	}
+	for i, release := range releases {
+		response := results[i].response
+		if release.ToRef == "v0" {
+			if response != nil {
+				t.Errorf("release without pull requests got %+v", response)
+			}
+			continue
+		}
+		if response == nil || response.Summary != "Release "+release.ToRef {
+			t.Errorf("results[%d] = %+v, want the summary of %s", i, response, release.ToRef)
+		}
+	}
+}
+
+func TestGenerateReleaseSummariesSerialByDefault(t *testing.T) {
+	client := &concurrentLLM{}
+	gen := New(nil, client)
+
+	if _, err := gen.generateReleaseSummaries(context.Background(), timelineReleases("v1", "v2", "v3")); err != nil {
+		t.Fatal(err)
+	}
+	if client.peak != 1 {
+		t.Errorf("peak concurrency = %d, want 1", client.peak)
+	}
+}
+
+func TestGenerateReleaseSummariesFailure(t *testing.T) {
+	client := &concurrentLLM{fail: "v2"}
+	cfg := config.Default()
+	cfg.LLMConcurrency = 2
+	gen := New(nil, client, WithConfig(cfg))
+
+	_, err := gen.generateReleaseSummaries(context.Background(), timelineReleases("v1", "v2", "v3", "v4"))
+	if err == nil || err.Error() != "generate PR changelog for v2: rate limited" {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestGenerateReleaseSummariesUsesBatchResults(t *testing.T) {
+	client := &concurrentLLM{}
+	gen := New(nil, client)
+	gen.batch = map[string]*llm.PRChangelogResponse{"v1": {Summary: "From the batch"}}
+
+	results, err := gen.generateReleaseSummaries(context.Background(), timelineReleases("v1", "v2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].response.Summary != "From the batch" || results[1].response.Summary != "Release v2" {
+		t.Errorf("summaries = %q, %q", results[0].response.Summary, results[1].response.Summary)
+	}
+	if client.peak != 1 {
+		t.Errorf("live requests peaked at %d, want only v2 generated live", client.peak)
+	}
+	if warnings := gen.Warnings(); len(warnings) != 1 || !strings.Contains(warnings[0], "v2") {
+		t.Errorf("warnings = %v", warnings)
+	}
+}
@@ -0,0 +1,83 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+// PeriodSummary is the executive digest that opens a timeline
+type PeriodSummary struct {
+	Summary string   `json:"summary"`
+	Themes  []string `json:"themes,omitempty"`
+}
+
+// summarizePeriod asks the model for the themes running across every release
+// of the timeline in one extra LLM call. It returns nil for an empty timeline.
+func (g *Generator) summarizePeriod(ctx context.Context, timeline *TimelineChangelog) (*PeriodSummary, error) {
+	if len(timeline.Releases) == 0 {
+		return nil, nil
+	}
+
+	request := llm.PeriodSummaryRequest{
+		RepoName: timeline.RepoName,
+		FromDate: timeline.FromDate,
+		ToDate:   timeline.ToDate,
+	}
+	for _, release := range timeline.Releases {
+		name := release.ToRef
+		switch {
+		case release.Unreleased:
+			name = "Unreleased"
+		case release.Series != "":
+			name = release.Series
+		}
+		request.Releases = append(request.Releases, periodRelease(name, release.ToDate, release.Summary, release.PullRequests))
+	}
+
+	logger.Info("summarizing period across releases", "releases", len(request.Releases))
+	response, err := g.llmClient.SummarizePeriod(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("summarize period: %w", err)
+	}
+	if response.Repaired {
+		g.softFail("LLM response for the period summary needed JSON cleanup before parsing")
+	}
+	if strings.TrimSpace(response.Summary) == "" {
+		g.softFail("LLM returned an empty period summary")
+		return nil, nil
+	}
+	return &PeriodSummary{Summary: strings.TrimSpace(response.Summary), Themes: response.Themes}, nil
+}
+
+// periodRelease describes one release to the period summary prompt
+func periodRelease(name string, date time.Time, summary string, prs []provider.PullRequestData) llm.PeriodRelease {
+	release := llm.PeriodRelease{Name: name, Date: date, Summary: summary}
+	for _, pr := range prs {
+		release.PRTitles = append(release.PRTitles, pr.Title)
+	}
+	return release
+}
+
+// FormatPeriodSummary renders the digest as a "Period Summary" section, or ""
+// when there is none
+func FormatPeriodSummary(period *PeriodSummary) string {
+	if period == nil {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("## Period Summary\n\n")
+	b.WriteString(period.Summary + "\n\n")
+	for _, theme := range period.Themes {
+		b.WriteString(fmt.Sprintf("- %s\n", theme))
+	}
+	if len(period.Themes) > 0 {
+		b.WriteString("\n")
+	}
+	b.WriteString("---\n\n")
+	return b.String()
+}
@@ -0,0 +1,59 @@
+package generator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+// periodLLM returns a fixed digest and records the request
+type periodLLM struct {
+	fakeLLM
+	request llm.PeriodSummaryRequest
+}
+
+func (p *periodLLM) SummarizePeriod(ctx context.Context, req llm.PeriodSummaryRequest) (*llm.PeriodSummaryResponse, error) {
+	p.request = req
+	return &llm.PeriodSummaryResponse{Summary: " Q1 focused on performance. ", Themes: []string{"Faster startup (v1.1.0, v1.2.0)"}}, nil
+}
+
+func TestSummarizePeriod(t *testing.T) {
+	client := &periodLLM{}
+	gen := New(nil, client)
+	timeline := &TimelineChangelog{
+		RepoName: "acme/api",
+		Releases: []ReleaseChangelog{
+			{ToRef: "v1.1.0", Summary: "Caches config.", PullRequests: []provider.PullRequestData{{Number: 1, Title: "Cache config"}}},
+			{ToRef: "v1.2.2", Series: "v1.2.x"},
+			{ToRef: "abc1234", Unreleased: true},
+		},
+	}
+
+	period, err := gen.summarizePeriod(context.Background(), timeline)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if period.Summary != "Q1 focused on performance." || len(period.Themes) != 1 {
+		t.Errorf("period = %+v", period)
+	}
+	releases := client.request.Releases
+	if len(releases) != 3 || releases[0].PRTitles[0] != "Cache config" || releases[1].Name != "v1.2.x" || releases[2].Name != "Unreleased" {
+		t.Errorf("request releases = %+v", releases)
+	}
+
+	timeline.Period = period
+	markdown := gen.formatTimelineAsMarkdown(timeline)
+	summaryAt := strings.Index(markdown, "## Period Summary\n\nQ1 focused on performance.\n\n- Faster startup (v1.1.0, v1.2.0)\n")
+	if summaryAt < 0 || summaryAt > strings.Index(markdown, "## [Release v1.1.0]") {
+		t.Errorf("period summary should precede the releases:\n%s", markdown)
+	}
+}
+
+func TestFormatPeriodSummaryEmpty(t *testing.T) {
+	if got := FormatPeriodSummary(nil); got != "" {
+		t.Errorf("FormatPeriodSummary(nil) = %q", got)
+	}
+}
@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFormatDiff(t *testing.T) {
+	before := "# Changelog\n\n## v1.0.0\n\n- Curated note\n- Second\n"
+	after := "# Changelog\n\n## v1.1.0\n\n- New feature\n\n## v1.0.0\n\n- Curated note\n- Second\n"
+
+	diff := FormatDiff("CHANGELOG.md", "CHANGELOG.md (new)", before, after, false)
+	expected := "--- CHANGELOG.md\n" +
+		"+++ CHANGELOG.md (new)\n" +
+		"@@ -1,5 +1,9 @@\n" +
+		" # Changelog\n" +
+		" \n" +
+		"+## v1.1.0\n" +
+		"+\n" +
+		"+- New feature\n" +
+		"+\n" +
+		" ## v1.0.0\n" +
+		" \n" +
+		" - Curated note\n"
+	if diff != expected {
+		t.Errorf("Unexpected diff:\n%s\nwant:\n%s", diff, expected)
+	}
+}
+
+func TestFormatDiffRemovalAndHunks(t *testing.T) {
+	var oldLines []string
+	for i := 1; i <= 20; i++ {
+		oldLines = append(oldLines, "line")
+	}
+	oldLines[1] = "first"
+	oldLines[17] = "last"
+	newLines := append([]string(nil), oldLines...)
+	newLines[1] = "FIRST"
+	newLines = append(newLines[:17], newLines[18:]...)
+
+	diff := FormatDiff("a", "b", strings.Join(oldLines, "\n"), strings.Join(newLines, "\n"), false)
+	if strings.Count(diff, "@@ -") != 2 {
+		t.Fatalf("Expected two hunks for distant changes, got:\n%s", diff)
+	}
+	for _, want := range []string{"@@ -1,5 +1,5 @@", "-first\n+FIRST", "@@ -15,6 +15,5 @@", "-last"} {
+		if !strings.Contains(diff, want) {
+			t.Errorf("Expected %q in diff:\n%s", want, diff)
+		}
+	}
+}
+
+func TestFormatDiffIdenticalAndColor(t *testing.T) {
+	if diff := FormatDiff("a", "b", "same\n", "same\n", true); diff != "" {
+		t.Errorf("Expected no diff for identical text, got %q", diff)
+	}
+
+	diff := FormatDiff("a", "b", "", "added\n", true)
+	if !strings.Contains(diff, ansiGreen+"+added"+ansiReset) {
+		t.Errorf("Expected a green added line, got %q", diff)
+	}
+	if !strings.Contains(diff, "@@ -0,0 +1,1 @@") {
+		t.Errorf("Expected a hunk for the new file, got %q", diff)
+	}
+}
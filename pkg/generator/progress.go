@@ -0,0 +1,38 @@
+package generator
+
+// Progress stages reported to the callback of WithProgress
+const (
+	StageFetching   = "fetching"   // Fetching commits or discovering releases
+	StageFetched    = "fetched"    // Commits or releases found
+	StageGenerating = "generating" // Waiting for the LLM
+	StageRelease    = "release"    // Assembling one release of a timeline
+	StageDone       = "done"       // The result is ready
+)
+
+// ProgressEvent reports a step of a generation run
+type ProgressEvent struct {
+	Stage   string // One of the Stage constants
+	Message string
+	Current int // Position within the stage when it is counted, e.g. release 2 of Total
+	Total   int
+}
+
+// WithProgress calls report for each step of a run, like SetProgress
+func WithProgress(report func(ProgressEvent)) Option {
+	return func(g *Generator) {
+		g.onProgress = report
+	}
+}
+
+// SetProgress calls report for each step of Generate and GenerateTimeline,
+// on the goroutine running them
+func (g *Generator) SetProgress(report func(ProgressEvent)) {
+	g.onProgress = report
+}
+
+// progress reports a step when a progress callback is set
+func (g *Generator) progress(stage, message string, current, total int) {
+	if g.onProgress != nil {
+		g.onProgress(ProgressEvent{Stage: stage, Message: message, Current: current, Total: total})
+	}
+}
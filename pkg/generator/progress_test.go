@@ -0,0 +1,35 @@
+package generator
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestProgressReportsEachStage(t *testing.T) {
+	var stages []string
+	gen := goldenGenerator(t)
+	gen.SetProgress(func(event ProgressEvent) {
+		stages = append(stages, event.Stage)
+	})
+
+	if _, err := gen.Generate(context.Background(), "v1.2.0", "v1.3.0"); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{StageFetching, StageFetched, StageGenerating, StageDone}
+	if !reflect.DeepEqual(stages, want) {
+		t.Errorf("Generate reported %v, want %v", stages, want)
+	}
+
+	stages = nil
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)
+	timeline, err := gen.GenerateTimeline(context.Background(), from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stages) != 4+len(timeline.Releases) || stages[3] != StageRelease || stages[len(stages)-1] != StageDone {
+		t.Errorf("GenerateTimeline reported %v for %d releases", stages, len(timeline.Releases))
+	}
+}
@@ -0,0 +1,113 @@
+package generator
+
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+// codeSpanRe matches inline code spans: `identifier`
+var codeSpanRe = regexp.MustCompile("`([^`\n]+)`")
+
+// referenceCorpus returns the text a code reference must appear in to be
+// considered real: commit messages, changed file paths, and patches
+func referenceCorpus(commits ...provider.CommitData) string {
+	var sb strings.Builder
+	for _, commit := range commits {
+		sb.WriteString(commit.Message)
+		sb.WriteString("\n")
+		for _, file := range commit.FilesChanged {
+			sb.WriteString(file.Filename)
+			sb.WriteString("\n")
+			sb.WriteString(file.Patch)
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
+
+// VerifyCodeReferences checks every inline code span in text against corpus.
+// Spans that do not appear in the corpus are likely hallucinated API names;
+// their backticks are removed so they no longer read as real identifiers.
+// It returns the cleaned text and the unverified references.
+func VerifyCodeReferences(text, corpus string) (string, []string) {
+	var unverified []string
+	cleaned := codeSpanRe.ReplaceAllStringFunc(text, func(span string) string {
+		ref := strings.Trim(span, "`")
+		if referenceAppears(ref, corpus) {
+			return span
+		}
+		unverified = append(unverified, ref)
+		return ref
+	})
+	return cleaned, unverified
+}
+
+// referenceAppears reports whether a code reference (or its bare name, for
+// calls like Foo() or flags like --flag=value) occurs in corpus
+func referenceAppears(ref, corpus string) bool {
+	if strings.Contains(corpus, ref) {
+		return true
+	}
+	bare := strings.TrimSuffix(ref, "()")
+	if i := strings.IndexAny(bare, "=("); i > 0 {
+		bare = bare[:i]
+	}
+	return bare != "" && strings.Contains(corpus, bare)
+}
+
+// WrapFileReferences wraps bare mentions of changed file paths (or their base
+// names) in backticks. Text already inside code spans is left untouched.
+func WrapFileReferences(text string, files []string) string {
+	names := make(map[string]bool)
+	for _, file := range files {
+		names[file] = true
+		if base := path.Base(file); strings.Contains(base, ".") {
+			names[base] = true
+		}
+	}
+	if len(names) == 0 {
+		return text
+	}
+
+	// Longest first so full paths win over their base names
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, regexp.QuoteMeta(name))
+	}
+	sort.Slice(sorted, func(i, j int) bool { return len(sorted[i]) > len(sorted[j]) })
+	fileRe := regexp.MustCompile(`(^|[\s(])(` + strings.Join(sorted, "|") + `)([\s).,;:!?]|$)`)
+
+	// Only rewrite the segments outside existing code spans
+	var sb strings.Builder
+	last := 0
+	for _, loc := range codeSpanRe.FindAllStringIndex(text, -1) {
+		sb.WriteString(fileRe.ReplaceAllString(text[last:loc[0]], "$1`$2`$3"))
+		sb.WriteString(text[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	sb.WriteString(fileRe.ReplaceAllString(text[last:], "$1`$2`$3"))
+	return sb.String()
+}
+
+// normalizeEntryReferences wraps file mentions and strips unverified code
+// spans in an entry's title and description. It returns the unverified references.
+func normalizeEntryReferences(entry *llm.ChangelogEntry, commit provider.CommitData) []string {
+	files := make([]string, 0, len(commit.FilesChanged))
+	for _, file := range commit.FilesChanged {
+		files = append(files, file.Filename)
+	}
+	corpus := referenceCorpus(commit)
+
+	var unverified, refs []string
+	entry.Title, refs = VerifyCodeReferences(WrapFileReferences(entry.Title, files), corpus)
+	unverified = append(unverified, refs...)
+	entry.Description, refs = VerifyCodeReferences(WrapFileReferences(entry.Description, files), corpus)
+	unverified = append(unverified, refs...)
+
+	return unverified
+}
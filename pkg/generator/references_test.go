@@ -0,0 +1,41 @@
+package generator
+
+import (
+	"testing"
+)
+
+func TestVerifyCodeReferences(t *testing.T) {
+	corpus := "Add --max-length flag\npkg/config/config.go\n+func LengthBudget() (int, string, error) {"
+
+	text := "Adds `--max-length=300w`, `LengthBudget()` and `CompressEverything()`"
+	cleaned, unverified := VerifyCodeReferences(text, corpus)
+
+	expected := "Adds `--max-length=300w`, `LengthBudget()` and CompressEverything()"
+	if cleaned != expected {
+		t.Errorf("Expected %q, got %q", expected, cleaned)
+	}
+	if len(unverified) != 1 || unverified[0] != "CompressEverything()" {
+		t.Errorf("Expected CompressEverything() to be unverified, got %v", unverified)
+	}
+}
+
+func TestWrapFileReferences(t *testing.T) {
+	files := []string{"pkg/config/config.go", "README.md"}
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Updated pkg/config/config.go defaults", "Updated `pkg/config/config.go` defaults"},
+		{"Clarified README.md.", "Clarified `README.md`."},
+		{"Changed config.go (loader)", "Changed `config.go` (loader)"},
+		{"Already `README.md` wrapped", "Already `README.md` wrapped"},
+		{"No files here", "No files here"},
+	}
+
+	for _, tt := range tests {
+		if got := WrapFileReferences(tt.input, files); got != tt.expected {
+			t.Errorf("WrapFileReferences(%q) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+)
+
+// calibrateScores scores the pull requests of every release in one LLM call,
+// so importance is comparable across releases rather than judged per release.
+// Sections that were not compressed are re-rendered with the new scores.
+func (g *Generator) calibrateScores(ctx context.Context, releases []ReleaseChangelog) error {
+	request := llm.RescoreRequest{RepoName: fmt.Sprintf("%s/%s", g.config.RepoOwner, g.config.RepoName)}
+	for _, release := range releases {
+		for _, pr := range release.PullRequests {
+			request.Items = append(request.Items, llm.RescoreItem{
+				ID:      rescoreID(release.ToRef, pr.Number),
+				Release: release.ToRef,
+				Title:   pr.Title,
+				Summary: release.PRSummaries[pr.Number],
+			})
+		}
+	}
+	if len(request.Items) == 0 {
+		return nil
+	}
+
+	logger.Info("calibrating importance scores across releases", "releases", len(releases), "pull_requests", len(request.Items))
+	response, err := g.llmClient.RescoreEntries(ctx, request)
+	if err != nil {
+		return fmt.Errorf("calibrate scores: %w", err)
+	}
+	if response.Repaired {
+		g.softFail("LLM response for score calibration needed JSON cleanup before parsing")
+	}
+	applyCalibratedScores(releases, response.Scores)
+
+	for i := range releases {
+		release := &releases[i]
+		for _, pr := range release.PullRequests {
+			if _, ok := release.PRScores[pr.Number]; !ok {
+				g.softFail("PR #%d in %s has no calibrated score", pr.Number, release.ToRef)
+			}
+		}
+		if release.Markdown == "" {
+			release.Zoom.Full = g.formatReleaseSection(release)
+		}
+	}
+	return nil
+}
+
+// applyCalibratedScores records each score on the release and pull request
+// its ID names, ignoring IDs the model made up
+func applyCalibratedScores(releases []ReleaseChangelog, scores []llm.RescoreEntry) {
+	byID := make(map[string]llm.RescoreEntry, len(scores))
+	for _, score := range scores {
+		byID[score.ID] = score
+	}
+	for i := range releases {
+		release := &releases[i]
+		for _, pr := range release.PullRequests {
+			score, ok := byID[rescoreID(release.ToRef, pr.Number)]
+			if !ok {
+				continue
+			}
+			if release.PRScores == nil {
+				release.PRScores = make(map[int]llm.RescoreEntry)
+			}
+			release.PRScores[pr.Number] = score
+		}
+	}
+}
+
+// rescoreID identifies a pull request of a release in a calibration pass
+func rescoreID(release string, number int) string {
+	return fmt.Sprintf("%s#%d", release, number)
+}
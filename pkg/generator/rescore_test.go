@@ -0,0 +1,36 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+func TestApplyCalibratedScores(t *testing.T) {
+	releases := []ReleaseChangelog{
+		{ToRef: "v1.1.0", PullRequests: []provider.PullRequestData{{Number: 12, Title: "Add login", Author: "alice", URL: "https://github.com/acme/api/pull/12"}, {Number: 13, Title: "Fix typo"}}},
+		{ToRef: "v1.2.0", PullRequests: []provider.PullRequestData{{Number: 20, Title: "Add SSO"}}},
+	}
+	applyCalibratedScores(releases, []llm.RescoreEntry{
+		{ID: "v1.1.0#12", ImportanceScore: 6, ScoreReason: "New sign-in"},
+		{ID: "v1.1.0#13", ImportanceScore: 1},
+		{ID: "v1.2.0#20", ImportanceScore: 9},
+		{ID: "v9.9.9#1", ImportanceScore: 10}, // Invented by the model
+	})
+	if releases[0].PRScores[12].ImportanceScore != 6 || releases[1].PRScores[20].ImportanceScore != 9 || len(releases[1].PRScores) != 1 {
+		t.Errorf("Unexpected calibrated scores: %+v, %+v", releases[0].PRScores, releases[1].PRScores)
+	}
+
+	var b strings.Builder
+	cfg := &config.Config{ShowScores: true, ShowScoreReasons: true, MinScore: 2}
+	for _, pr := range releases[0].PullRequests {
+		writeReleasePR(&b, &releases[0], pr, cfg)
+	}
+	want := "- Add login by @alice in https://github.com/acme/api/pull/12 🟡 **[6.0]**\n    _Score 6.0: New sign-in_\n"
+	if b.String() != want {
+		t.Errorf("writeReleasePR() = %q, want %q", b.String(), want)
+	}
+}
@@ -0,0 +1,158 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+var (
+	// revertSubjectRe matches the subject git writes for reverts: Revert "subject"
+	revertSubjectRe = regexp.MustCompile(`^Revert "(.+)"$`)
+	// revertBodyRe matches the body line git writes for reverts
+	revertBodyRe = regexp.MustCompile(`This reverts commit ([0-9a-f]{7,40})`)
+	// cherryPickRe matches the trailer `git cherry-pick -x` adds
+	cherryPickRe = regexp.MustCompile(`\(cherry picked from commit ([0-9a-f]{7,40})\)`)
+)
+
+// CommitRelations records which commits of a range cancel or repeat others
+type CommitRelations struct {
+	Reverts     map[string]string // Revert SHA → reverted SHA, both within the range
+	CherryPicks map[string]string // Duplicate SHA → SHA of the original within the range
+}
+
+// FindCommitRelations pairs each revert with the commit it reverts, by the
+// "This reverts commit" line or else by subject, and each cherry-picked
+// duplicate with its original, by the "cherry picked from" trailer or else by
+// an identical message and diff. Commits are expected oldest first. Reverts of
+// commits outside the range are left alone: they undo an earlier release.
+func FindCommitRelations(commits []provider.CommitData) CommitRelations {
+	relations := CommitRelations{Reverts: make(map[string]string), CherryPicks: make(map[string]string)}
+	reverted := make(map[string]bool)
+
+	// Newest first, so a revert of a revert cancels the inner revert and the
+	// original change stands
+	for i := len(commits) - 1; i >= 0; i-- {
+		commit := commits[i]
+		if reverted[commit.SHA] {
+			continue
+		}
+		if target := revertTarget(commits[:i], commit.Message); target != nil && !reverted[target.SHA] {
+			relations.Reverts[commit.SHA] = target.SHA
+			reverted[target.SHA] = true
+		}
+	}
+
+	for i, commit := range commits {
+		if _, ok := relations.Reverts[commit.SHA]; ok {
+			continue
+		}
+		if m := cherryPickRe.FindStringSubmatch(commit.Message); m != nil {
+			if original := findCommit(commits, m[1]); original != nil && original.SHA != commit.SHA {
+				relations.CherryPicks[commit.SHA] = original.SHA
+			}
+			continue
+		}
+		for _, earlier := range commits[:i] {
+			if _, dup := relations.CherryPicks[earlier.SHA]; !dup && sameChange(earlier, commit) {
+				relations.CherryPicks[commit.SHA] = earlier.SHA
+				break
+			}
+		}
+	}
+	return relations
+}
+
+// revertTarget returns the commit among earlier that message reverts, or nil
+func revertTarget(earlier []provider.CommitData, message string) *provider.CommitData {
+	if m := revertBodyRe.FindStringSubmatch(message); m != nil {
+		return findCommit(earlier, m[1])
+	}
+	subject, _, _ := strings.Cut(message, "\n")
+	m := revertSubjectRe.FindStringSubmatch(strings.TrimSpace(subject))
+	if m == nil {
+		return nil
+	}
+	for i := len(earlier) - 1; i >= 0; i-- {
+		if commitSubject(earlier[i].Message) == m[1] {
+			return &earlier[i]
+		}
+	}
+	return nil
+}
+
+// sameChange reports whether two commits carry the same message and diff, as
+// a cherry-pick without -x does
+func sameChange(a, b provider.CommitData) bool {
+	if strings.TrimSpace(a.Message) != strings.TrimSpace(b.Message) || a.Stats != b.Stats ||
+		a.Stats.Total == 0 || len(a.FilesChanged) != len(b.FilesChanged) {
+		return false
+	}
+	for i := range a.FilesChanged {
+		if a.FilesChanged[i].Filename != b.FilesChanged[i].Filename || a.FilesChanged[i].Patch != b.FilesChanged[i].Patch {
+			return false
+		}
+	}
+	return true
+}
+
+// commitSubject returns the first line of a commit message
+func commitSubject(message string) string {
+	subject, _, _ := strings.Cut(message, "\n")
+	return strings.TrimSpace(subject)
+}
+
+// Cancelled reports whether a commit is a revert, a reverted commit, or a
+// cherry-picked duplicate within the range
+func (r CommitRelations) Cancelled(sha string) bool {
+	if _, ok := r.Reverts[sha]; ok {
+		return true
+	}
+	if _, ok := r.CherryPicks[sha]; ok {
+		return true
+	}
+	for _, target := range r.Reverts {
+		if target == sha {
+			return true
+		}
+	}
+	return false
+}
+
+// annotateRelations marks entries whose commits revert, were reverted by, or
+// repeat another commit of the range
+func annotateRelations(response *llm.ChangelogResponse, commits []provider.CommitData, relations CommitRelations) {
+	revertedBy := make(map[string]string, len(relations.Reverts))
+	for revert, target := range relations.Reverts {
+		revertedBy[target] = revert
+	}
+	for category, entries := range response.Categories {
+		for i := range entries {
+			commit := findCommit(commits, entries[i].SHA)
+			if commit == nil {
+				continue
+			}
+			entries[i].Reverts = relations.Reverts[commit.SHA]
+			entries[i].RevertedBy = revertedBy[commit.SHA]
+			entries[i].PickedFrom = relations.CherryPicks[commit.SHA]
+		}
+		response.Categories[category] = entries
+	}
+}
+
+// formatRelations renders an entry's revert and cherry-pick annotations
+func formatRelations(entry llm.ChangelogEntry) string {
+	var sb strings.Builder
+	if entry.RevertedBy != "" {
+		sb.WriteString(" · ↩️ reverted in `" + shortRef(entry.RevertedBy) + "`")
+	}
+	if entry.Reverts != "" {
+		sb.WriteString(" · ↩️ reverts `" + shortRef(entry.Reverts) + "`")
+	}
+	if entry.PickedFrom != "" {
+		sb.WriteString(" · 🍒 cherry-pick of `" + shortRef(entry.PickedFrom) + "`")
+	}
+	return sb.String()
+}
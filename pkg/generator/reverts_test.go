@@ -0,0 +1,63 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+func TestFindCommitRelations(t *testing.T) {
+	sha := func(c byte) string { return strings.Repeat(string(c), 40) }
+	stats := provider.CommitStats{Additions: 5, Total: 5}
+	commits := []provider.CommitData{
+		{SHA: sha('a'), Message: "Add dark mode"},
+		{SHA: sha('b'), Message: "Add export", Stats: stats},
+		{SHA: sha('c'), Message: "Revert \"Add dark mode\"\n\nThis reverts commit " + sha('a') + "."},
+		{SHA: sha('d'), Message: "Fix login\n\n(cherry picked from commit " + sha('e')[:12] + ")"},
+		{SHA: sha('e'), Message: "Fix login"},
+		{SHA: sha('f'), Message: "Add export", Stats: stats},         // Cherry-picked without -x
+		{SHA: sha('1'), Message: "Revert \"Bump cache size\""},       // Reverts a commit from an earlier release
+		{SHA: sha('2'), Message: "Add search"},                       // Added, reverted, and re-applied
+		{SHA: sha('3'), Message: "Revert \"Add search\""},            // Cancelled by the revert of the revert
+		{SHA: sha('4'), Message: "Revert \"Revert \"Add search\"\""}, // Re-applies search
+	}
+
+	relations := FindCommitRelations(commits)
+	if relations.Reverts[sha('c')] != sha('a') || relations.Reverts[sha('4')] != sha('3') || len(relations.Reverts) != 2 {
+		t.Errorf("Unexpected reverts: %v", relations.Reverts)
+	}
+	if relations.CherryPicks[sha('d')] != sha('e') || relations.CherryPicks[sha('f')] != sha('b') || len(relations.CherryPicks) != 2 {
+		t.Errorf("Unexpected cherry-picks: %v", relations.CherryPicks)
+	}
+
+	var kept []string
+	for _, commit := range commits {
+		if !relations.Cancelled(commit.SHA) {
+			kept = append(kept, commitSubject(commit.Message))
+		}
+	}
+	want := `Add export|Fix login|Revert "Bump cache size"|Add search`
+	if strings.Join(kept, "|") != want {
+		t.Errorf("Kept %q, want %q", strings.Join(kept, "|"), want)
+	}
+}
+
+func TestAnnotateRelations(t *testing.T) {
+	a, c := strings.Repeat("a", 40), strings.Repeat("c", 40)
+	commits := []provider.CommitData{{SHA: a, Message: "Add dark mode"}, {SHA: c, Message: "Revert \"Add dark mode\""}}
+	response := &llm.ChangelogResponse{Categories: map[string][]llm.ChangelogEntry{
+		"Features": {{SHA: "aaaaaaa", Title: "Dark mode"}},
+		"Internal": {{SHA: "ccccccc", Title: "Remove dark mode"}},
+	}}
+
+	annotateRelations(response, commits, FindCommitRelations(commits))
+	markdown := FormatMarkdown(response, "v1.0.0", "v1.1.0", &config.Config{}, nil)
+	for _, want := range []string{"**Dark mode** (`aaaaaaa`) · ↩️ reverted in `ccccccc`", "**Remove dark mode** (`ccccccc`) · ↩️ reverts `aaaaaaa`"} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Expected %q in\n%s", want, markdown)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+// ReviewReport is the gap report of human-written release notes checked
+// against the commits of the release
+type ReviewReport struct {
+	Repo         string                 `json:"repo"`
+	FromRef      string                 `json:"from_ref"`
+	ToRef        string                 `json:"to_ref"`
+	Commits      int                    `json:"commits"`
+	Summary      string                 `json:"summary"`
+	Omissions    []llm.ReviewOmission   `json:"omissions"`
+	Inaccuracies []llm.ReviewInaccuracy `json:"inaccuracies"`
+}
+
+// Gaps returns how many omissions and inaccuracies the review found
+func (r *ReviewReport) Gaps() int {
+	return len(r.Omissions) + len(r.Inaccuracies)
+}
+
+// Review fetches the commits of from..to and has the model check the release
+// notes against them for omissions and inaccuracies
+func (g *Generator) Review(ctx context.Context, notes, from, to string) (*ReviewReport, error) {
+	g.resetRun()
+
+	if strings.TrimSpace(notes) == "" {
+		return nil, fmt.Errorf("release notes to review are empty")
+	}
+	commits, err := g.fetchCommits(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("fetch commits: %w", err)
+	}
+	if len(commits) == 0 {
+		return nil, fmt.Errorf("no commits found in range %s..%s", from, to)
+	}
+	commits = g.collapseMerges(commits)
+
+	repo := fmt.Sprintf("%s/%s", g.config.RepoOwner, g.config.RepoName)
+	logger.Info("reviewing release notes", "from", from, "to", to, "commits", len(commits))
+	response, err := g.llmClient.ReviewNotes(ctx, llm.ReviewRequest{
+		RepoName: repo,
+		FromRef:  from,
+		ToRef:    to,
+		Notes:    notes,
+		Commits:  g.prepareCommitsForLLM(commits),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("review release notes: %w", err)
+	}
+	if response.Repaired {
+		g.softFail("LLM response for the review needed JSON cleanup before parsing")
+	}
+
+	omissions, unknown := checkOmissions(response.Omissions, commits, notes)
+	for _, sha := range unknown {
+		g.softFail("omission %q does not match a commit in %s..%s", sha, from, to)
+	}
+	for i := range response.Inaccuracies {
+		for j, sha := range response.Inaccuracies[i].SHAs {
+			if commit := findCommit(commits, sha); commit != nil {
+				response.Inaccuracies[i].SHAs[j] = commit.SHA
+			}
+		}
+	}
+	report := &ReviewReport{
+		Repo:         repo,
+		FromRef:      from,
+		ToRef:        to,
+		Commits:      len(commits),
+		Summary:      response.Summary,
+		Omissions:    omissions,
+		Inaccuracies: response.Inaccuracies,
+	}
+	if err := g.strictError(); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// checkOmissions keeps the omissions that name a commit of the release, most
+// important first, with full SHAs. Commits the notes reference by SHA or pull request number
+// are mentioned by definition and are dropped. It also returns the SHAs that
+// match no commit.
+func checkOmissions(omissions []llm.ReviewOmission, commits []provider.CommitData, notes string) (kept []llm.ReviewOmission, unknown []string) {
+	for _, omission := range omissions {
+		commit := findCommit(commits, omission.SHA)
+		if commit == nil {
+			unknown = append(unknown, omission.SHA)
+			continue
+		}
+		if referencedInNotes(notes, *commit) {
+			continue
+		}
+		omission.SHA = commit.SHA
+		kept = append(kept, omission)
+	}
+	sort.SliceStable(kept, func(i, j int) bool {
+		return kept[i].ImportanceScore > kept[j].ImportanceScore
+	})
+	return kept, unknown
+}
+
+// referencedInNotes reports whether notes cite a commit by its abbreviated
+// SHA or by the number of the pull request it merged
+func referencedInNotes(notes string, commit provider.CommitData) bool {
+	if len(commit.SHA) >= 7 && strings.Contains(notes, commit.SHA[:7]) {
+		return true
+	}
+	number := CommitPRNumber(commit.Message)
+	if number == 0 {
+		return false
+	}
+	return regexp.MustCompile(fmt.Sprintf(`(#|/pull/)%d\b`, number)).MatchString(notes)
+}
+
+// FormatReviewReport renders a review as a markdown gap report. commitURL
+// links SHAs; with a nil commitURL they are not linked.
+func FormatReviewReport(report *ReviewReport, commitURL func(sha string) string) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# Release notes review: %s → %s\n\n", report.FromRef, report.ToRef))
+	if report.Summary != "" {
+		sb.WriteString(report.Summary + "\n\n")
+	}
+	if report.Gaps() == 0 {
+		sb.WriteString(fmt.Sprintf("No gaps found across %s.\n", pluralize(report.Commits, "commit")))
+		return sb.String()
+	}
+	inaccuracies := fmt.Sprintf("%d inaccuracies", len(report.Inaccuracies))
+	if len(report.Inaccuracies) == 1 {
+		inaccuracies = "1 inaccuracy"
+	}
+	sb.WriteString(fmt.Sprintf("**%s, %s** across %s.\n\n",
+		pluralize(len(report.Omissions), "omission"), inaccuracies, pluralize(report.Commits, "commit")))
+
+	if len(report.Omissions) > 0 {
+		sb.WriteString("## Omissions\n\n")
+		for _, omission := range report.Omissions {
+			sb.WriteString(fmt.Sprintf("- %s **[%.1f]** **%s** (%s)", getScoreIndicator(omission.ImportanceScore), omission.ImportanceScore, omission.Title, reviewSHA(omission.SHA, commitURL)))
+			if omission.Reason != "" {
+				sb.WriteString(" — " + omission.Reason)
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(report.Inaccuracies) > 0 {
+		sb.WriteString("## Inaccuracies\n\n")
+		for _, inaccuracy := range report.Inaccuracies {
+			sb.WriteString(fmt.Sprintf("- “%s”: %s", inaccuracy.Claim, inaccuracy.Problem))
+			var refs []string
+			for _, sha := range inaccuracy.SHAs {
+				refs = append(refs, reviewSHA(sha, commitURL))
+			}
+			if len(refs) > 0 {
+				sb.WriteString(" (" + strings.Join(refs, ", ") + ")")
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// reviewSHA renders an abbreviated, optionally linked commit SHA
+func reviewSHA(sha string, commitURL func(sha string) string) string {
+	if commitURL == nil {
+		return fmt.Sprintf("`%s`", shortRef(sha))
+	}
+	return fmt.Sprintf("[`%s`](%s)", shortRef(sha), commitURL(sha))
+}
@@ -0,0 +1,66 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+func TestCheckOmissions(t *testing.T) {
+	commits := []provider.CommitData{
+		{SHA: "aaaaaaa111111111111111111111111111111111", Message: "Drop the legacy /v1 endpoints"},
+		{SHA: "bbbbbbb222222222222222222222222222222222", Message: "Add SSO login (#42)"},
+		{SHA: "ccccccc333333333333333333333333333333333", Message: "Fix upload timeouts"},
+	}
+	notes := "- SSO login (#42)\n- Upload fix (ccccccc)\n"
+	omissions := []llm.ReviewOmission{
+		{SHA: "bbbbbbb", Title: "SSO", ImportanceScore: 8},      // Cited by PR number
+		{SHA: "ccccccc", Title: "Timeouts", ImportanceScore: 5}, // Cited by SHA
+		{SHA: "aaaaaaa", Title: "Removed /v1", ImportanceScore: 9},
+		{SHA: "ddddddd", Title: "Made up", ImportanceScore: 7},
+	}
+
+	kept, unknown := checkOmissions(omissions, commits, notes)
+	if len(kept) != 1 || kept[0].Title != "Removed /v1" || kept[0].SHA != commits[0].SHA {
+		t.Errorf("Expected only the uncited /v1 removal with its full SHA, got %+v", kept)
+	}
+	if len(unknown) != 1 || unknown[0] != "ddddddd" {
+		t.Errorf("Expected the made-up SHA to be reported, got %v", unknown)
+	}
+
+	if referencedInNotes("Fixed in #420", commits[1]) {
+		t.Error("#420 should not count as a reference to #42")
+	}
+}
+
+func TestFormatReviewReport(t *testing.T) {
+	report := &ReviewReport{
+		FromRef: "v1.0.0",
+		ToRef:   "v1.1.0",
+		Commits: 3,
+		Summary: "The notes miss a breaking change.",
+		Omissions: []llm.ReviewOmission{
+			{SHA: "aaaaaaa111111111111111111111111111111111", Title: "Removed /v1", ImportanceScore: 9, Reason: "Breaks clients"},
+		},
+		Inaccuracies: []llm.ReviewInaccuracy{
+			{Claim: "SSO supports SAML", Problem: "Only OIDC was added", SHAs: []string{"bbbbbbb222222222222222222222222222222222"}},
+		},
+	}
+	markdown := FormatReviewReport(report, func(sha string) string { return "https://example.com/" + sha[:7] })
+	for _, want := range []string{
+		"# Release notes review: v1.0.0 → v1.1.0\n\nThe notes miss a breaking change.\n\n**1 omission, 1 inaccuracy** across 3 commits.\n",
+		"- 🔴 **[9.0]** **Removed /v1** ([`aaaaaaa`](https://example.com/aaaaaaa)) — Breaks clients\n",
+		"- “SSO supports SAML”: Only OIDC was added ([`bbbbbbb`](https://example.com/bbbbbbb))\n",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Expected %q in report\nGot:\n%s", want, markdown)
+		}
+	}
+
+	clean := FormatReviewReport(&ReviewReport{FromRef: "v1.0.0", ToRef: "v1.1.0", Commits: 1}, nil)
+	if !strings.Contains(clean, "No gaps found across 1 commit.") {
+		t.Errorf("Expected a clean report, got:\n%s", clean)
+	}
+}
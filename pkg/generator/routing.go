@@ -0,0 +1,35 @@
+package generator
+
+import (
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+// routeModel picks the model for a release of items commits or pull requests
+// changing lines lines under --model-routing: the small model for small
+// releases, the large model for large or sprawling ones, and the default model
+// otherwise. It returns "" when routing is off or the default model applies.
+func (g *Generator) routeModel(items, lines int) string {
+	if !g.config.ModelRouting {
+		return ""
+	}
+	model := ""
+	switch {
+	case items >= g.config.LargeReleaseMin || (g.config.LargeReleaseLines > 0 && lines >= g.config.LargeReleaseLines):
+		model = g.config.LargeModel
+	case items <= g.config.SmallReleaseMax:
+		model = g.config.SmallModel
+	}
+	if model == g.config.OpenAIModel {
+		return ""
+	}
+	return model
+}
+
+// changedLines sums the lines changed by commits, leaving out ignored files
+func (g *Generator) changedLines(commits []provider.CommitData) int {
+	lines := 0
+	for _, commit := range g.scoringCommits(commits) {
+		lines += commit.Stats.Total
+	}
+	return lines
+}
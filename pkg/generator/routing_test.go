@@ -0,0 +1,96 @@
+package generator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+)
+
+func routingConfig() *config.Config {
+	cfg := config.Default()
+	cfg.ModelRouting = true
+	cfg.LargeModel = "gpt-4.1"
+	return cfg
+}
+
+func TestRouteModel(t *testing.T) {
+	tests := []struct {
+		name         string
+		items, lines int
+		want         string
+	}{
+		{"small", 3, 200, "gpt-4o-mini"},
+		{"small threshold", config.DefaultSmallReleaseMax, 0, "gpt-4o-mini"},
+		{"medium", 20, 1000, ""},
+		{"large by items", config.DefaultLargeReleaseMin, 100, "gpt-4.1"},
+		{"large by lines", 2, config.DefaultLargeReleaseLines, "gpt-4.1"},
+	}
+	gen := New(nil, &fakeLLM{}, WithConfig(routingConfig()))
+	for _, tt := range tests {
+		if got := gen.routeModel(tt.items, tt.lines); got != tt.want {
+			t.Errorf("%s: routeModel(%d, %d) = %q, want %q", tt.name, tt.items, tt.lines, got, tt.want)
+		}
+	}
+
+	if got := New(nil, &fakeLLM{}).routeModel(1, 0); got != "" {
+		t.Errorf("routing off: routeModel = %q, want the default model", got)
+	}
+	cfg := routingConfig()
+	cfg.LargeModel = ""
+	if got := New(nil, &fakeLLM{}, WithConfig(cfg)).routeModel(100, 0); got != "" {
+		t.Errorf("no large model: routeModel = %q, want the default model", got)
+	}
+}
+
+// modelLLM records the model each changelog request was routed to
+type modelLLM struct {
+	fakeLLM
+	models []string
+}
+
+func (m *modelLLM) GenerateChangelog(ctx context.Context, req llm.ChangelogRequest) (*llm.ChangelogResponse, error) {
+	m.models = append(m.models, llm.ModelFromContext(ctx, "default"))
+	return m.fakeLLM.GenerateChangelog(ctx, req)
+}
+
+func TestGenerateRoutesSmallRange(t *testing.T) {
+	client := &modelLLM{}
+	gen := New(nil, client, WithConfig(routingConfig()))
+	commits := []llm.CommitInfo{{SHA: "abc1234", Message: "Fix typo", Author: "alice"}}
+
+	changelog, err := gen.GenerateFromCommits(context.Background(), commits, "v1.0.0", "v1.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(client.models) != 1 || client.models[0] != "gpt-4o-mini" {
+		t.Errorf("models = %v, want [gpt-4o-mini]", client.models)
+	}
+	if changelog.Metadata.Model != "gpt-4o-mini" {
+		t.Errorf("metadata model = %q", changelog.Metadata.Model)
+	}
+}
+
+func TestDryRunPricesRoutedCalls(t *testing.T) {
+	commits := []llm.CommitInfo{{SHA: "abc1234", Message: "Fix typo", Author: "alice"}}
+	routed, err := New(nil, &fakeLLM{}, WithConfig(routingConfig())).DryRunCommits(context.Background(), commits, "v1.0.0", "v1.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain, err := New(nil, &fakeLLM{}).DryRunCommits(context.Background(), commits, "v1.0.0", "v1.0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if routed.Calls[0].Model != "gpt-4o-mini" || !routed.PricingKnown {
+		t.Fatalf("routed report = %+v", routed)
+	}
+	if routed.EstimatedCost >= plain.EstimatedCost {
+		t.Errorf("routed cost %f is not below the default model's %f", routed.EstimatedCost, plain.EstimatedCost)
+	}
+	if !strings.Contains(FormatDryRunReport(routed), "on gpt-4o-mini") {
+		t.Error("report does not name the routed model")
+	}
+}
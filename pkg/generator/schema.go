@@ -0,0 +1,223 @@
+package generator
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// SchemaVersion is the version of the JSON output contract, written as
+// schema_version. It changes only when a field is removed or retyped.
+const SchemaVersion = "1"
+
+// Schema is the JSON Schema (draft 2020-12) of --format=json output
+//
+//go:embed schema/changelog.v1.json
+var Schema []byte
+
+// maxSchemaErrors caps how many violations a validation error lists
+const maxSchemaErrors = 10
+
+// ValidateJSON checks a JSON changelog against Schema, returning an error that
+// lists the violations, each prefixed with its JSON path
+func ValidateJSON(data []byte) error {
+	var root map[string]any
+	if err := json.Unmarshal(Schema, &root); err != nil {
+		return fmt.Errorf("parse schema: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		return fmt.Errorf("parse JSON changelog: %w", err)
+	}
+
+	v := &schemaValidator{root: root}
+	violations := v.validate(root, doc, "$")
+	if len(violations) == 0 {
+		return nil
+	}
+	if len(violations) > maxSchemaErrors {
+		violations = append(violations[:maxSchemaErrors], fmt.Sprintf("... and %d more", len(violations)-maxSchemaErrors))
+	}
+	return fmt.Errorf("output does not match schema version %s:\n  %s", SchemaVersion, strings.Join(violations, "\n  "))
+}
+
+// schemaValidator implements the subset of JSON Schema that Schema uses:
+// $ref into $defs, type, const, properties, required, additionalProperties,
+// propertyNames, pattern, items, minimum, maximum, and oneOf
+type schemaValidator struct {
+	root map[string]any
+}
+
+// validate returns the violations of value against schema, at path
+func (v *schemaValidator) validate(schema map[string]any, value any, path string) []string {
+	var violations []string
+	if ref, ok := schema["$ref"].(string); ok {
+		target, err := v.resolve(ref)
+		if err != nil {
+			return []string{fmt.Sprintf("%s: %v", path, err)}
+		}
+		violations = append(violations, v.validate(target, value, path)...)
+	}
+
+	if types, ok := schema["type"]; ok && !matchesType(types, value) {
+		return append(violations, fmt.Sprintf("%s: expected %s, got %s", path, typeNames(types), jsonType(value)))
+	}
+	if want, ok := schema["const"]; ok && fmt.Sprint(want) != fmt.Sprint(value) {
+		violations = append(violations, fmt.Sprintf("%s: expected %v, got %v", path, want, value))
+	}
+
+	switch value := value.(type) {
+	case map[string]any:
+		violations = append(violations, v.validateObject(schema, value, path)...)
+	case []any:
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range value {
+				violations = append(violations, v.validate(items, item, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	case json.Number:
+		n, _ := value.Float64()
+		if minimum, ok := schema["minimum"].(float64); ok && n < minimum {
+			violations = append(violations, fmt.Sprintf("%s: %v is below the minimum %v", path, value, minimum))
+		}
+		if maximum, ok := schema["maximum"].(float64); ok && n > maximum {
+			violations = append(violations, fmt.Sprintf("%s: %v is above the maximum %v", path, value, maximum))
+		}
+	case string:
+		if pattern, ok := schema["pattern"].(string); ok {
+			if re, err := regexp.Compile(pattern); err == nil && !re.MatchString(value) {
+				violations = append(violations, fmt.Sprintf("%s: %q does not match %s", path, value, pattern))
+			}
+		}
+	}
+
+	if branches, ok := schema["oneOf"].([]any); ok {
+		violations = append(violations, v.validateOneOf(branches, value, path)...)
+	}
+	return violations
+}
+
+// validateObject checks required, properties, additionalProperties, and propertyNames
+func (v *schemaValidator) validateObject(schema, object map[string]any, path string) []string {
+	var violations []string
+	if required, ok := schema["required"].([]any); ok {
+		for _, name := range required {
+			if _, ok := object[name.(string)]; !ok {
+				violations = append(violations, fmt.Sprintf("%s: missing required field %q", path, name))
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+	additional, _ := schema["additionalProperties"].(map[string]any)
+	names, _ := schema["propertyNames"].(map[string]any)
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		child := path + "." + key
+		if names != nil {
+			violations = append(violations, v.validate(names, key, child)...)
+		}
+		if property, ok := properties[key].(map[string]any); ok {
+			violations = append(violations, v.validate(property, object[key], child)...)
+		} else if additional != nil {
+			violations = append(violations, v.validate(additional, object[key], child)...)
+		}
+	}
+	return violations
+}
+
+// validateOneOf requires value to match exactly one branch. When none match,
+// the violations of the closest branch are reported.
+func (v *schemaValidator) validateOneOf(branches []any, value any, path string) []string {
+	var closest []string
+	matched := 0
+	for _, branch := range branches {
+		violations := v.validate(branch.(map[string]any), value, path)
+		if len(violations) == 0 {
+			matched++
+		} else if closest == nil || len(violations) < len(closest) {
+			closest = violations
+		}
+	}
+	switch matched {
+	case 0:
+		return closest
+	case 1:
+		return nil
+	}
+	return []string{fmt.Sprintf("%s: matches %d alternatives, expected exactly one", path, matched)}
+}
+
+// resolve returns the schema a "#/$defs/name" reference points at
+func (v *schemaValidator) resolve(ref string) (map[string]any, error) {
+	name, ok := strings.CutPrefix(ref, "#/$defs/")
+	if !ok {
+		return nil, fmt.Errorf("unsupported schema reference %q", ref)
+	}
+	defs, _ := v.root["$defs"].(map[string]any)
+	target, ok := defs[name].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unknown schema reference %q", ref)
+	}
+	return target, nil
+}
+
+// matchesType reports whether value has one of the schema's types
+func matchesType(types, value any) bool {
+	names, ok := types.([]any)
+	if !ok {
+		names = []any{types}
+	}
+	actual := jsonType(value)
+	for _, name := range names {
+		if name == actual || (name == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// typeNames renders a schema's type keyword, e.g. "array or null"
+func typeNames(types any) string {
+	names, ok := types.([]any)
+	if !ok {
+		return fmt.Sprint(types)
+	}
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprint(name)
+	}
+	return strings.Join(parts, " or ")
+}
+
+// jsonType names the JSON type of a decoded value
+func jsonType(value any) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if _, err := value.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
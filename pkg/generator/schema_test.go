@@ -0,0 +1,72 @@
+package generator
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+func TestValidateJSONAcceptsOutput(t *testing.T) {
+	changelog := &Changelog{
+		Schema:     SchemaVersion,
+		Summary:    "A small release.",
+		Categories: map[string][]llm.ChangelogEntry{"Features": {{SHA: "abc1234", Title: "Add cache", ImportanceScore: 6.5}}},
+		Date:       time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+		FromRef:    "v1.0.0",
+		ToRef:      "v1.1.0",
+	}
+	timeline := &TimelineChangelog{
+		Schema: SchemaVersion,
+		Releases: []ReleaseChangelog{{
+			ToRef:        "v1.1.0",
+			PullRequests: []provider.PullRequestData{{Number: 4, Title: "Add cache", URL: "u"}},
+			PRSummaries:  map[int]string{4: "Adds a cache."},
+			PRScores:     map[int]llm.RescoreEntry{4: {ID: "v1.1.0#4", ImportanceScore: 7}},
+		}},
+		Period: &PeriodSummary{Summary: "Caching."},
+	}
+	org := &OrgTimelineChangelog{Schema: SchemaVersion, Org: "acme", Repos: []*TimelineChangelog{timeline}}
+
+	for _, doc := range []any{changelog, timeline, org} {
+		data, err := json.Marshal(doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ValidateJSON(data); err != nil {
+			t.Errorf("%T: %v", doc, err)
+		}
+	}
+}
+
+func TestValidateJSONReportsViolations(t *testing.T) {
+	data := []byte(`{
+  "schema_version": "1",
+  "summary": "s",
+  "categories": {"Features": [{"sha": "abc", "title": "t", "description": "", "author": "a", "importance_score": 12}]},
+  "zoom": {"one_liner": "", "paragraph": "", "full": ""},
+  "commit_count": "3",
+  "date": "2026-01-02T00:00:00Z",
+  "from_ref": "v1", "to_ref": "v2"
+}`)
+	err := ValidateJSON(data)
+	if err == nil {
+		t.Fatal("expected violations")
+	}
+	for _, want := range []string{
+		`$: missing required field "repo_name"`,
+		"$.categories.Features[0].importance_score: 12 is above the maximum 10",
+		"$.commit_count: expected integer, got string",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("missing %q in:\n%v", want, err)
+		}
+	}
+
+	if err := ValidateJSON([]byte(`{"schema_version": "2", "releases": []}`)); err == nil || !strings.Contains(err.Error(), "expected 1, got 2") {
+		t.Errorf("unexpected schema version error: %v", err)
+	}
+}
@@ -0,0 +1,161 @@
+package generator
+
+import (
+	"context"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+var (
+	// advisoryIDRe matches CVE and GitHub advisory identifiers
+	advisoryIDRe = regexp.MustCompile(`(?i)\b(CVE-\d{4}-\d{4,}|GHSA(?:-[23456789cfghjmpqrvwx]{4}){3})\b`)
+	// securityPathRe matches path segments of security-sensitive code
+	securityPathRe = regexp.MustCompile(`(?i)(^|[/_.-])(auth|authn|authz|oauth|saml|sso|jwt|crypto|cipher|tls|ssl|cert|certs|security|permission|permissions|rbac|acl|session|sessions|password|passwords|secret|secrets|csrf|sanitize|sanitizer)([/_.-]|$)`)
+	// securityMessageRe matches commit message wording typical of security fixes
+	securityMessageRe = regexp.MustCompile(`(?i)\b(security|vulnerab\w*|exploit\w*|xss|csrf|ssrf|rce|injection|privilege escalation|path traversal|denial of service)\b`)
+)
+
+// dependencyManifests are the files whose changes bump dependencies
+var dependencyManifests = map[string]bool{
+	"go.mod": true, "go.sum": true,
+	"package.json": true, "package-lock.json": true, "yarn.lock": true, "pnpm-lock.yaml": true,
+	"requirements.txt": true, "poetry.lock": true, "Pipfile.lock": true, "pyproject.toml": true,
+	"Gemfile": true, "Gemfile.lock": true,
+	"Cargo.toml": true, "Cargo.lock": true,
+	"pom.xml": true, "build.gradle": true, "build.gradle.kts": true,
+	"composer.json": true, "composer.lock": true,
+}
+
+// SecuritySignals explains why a commit may be security-relevant: advisory
+// identifiers in its message, security wording, or changes to auth, crypto,
+// or dependency files. It returns "" for commits with no signal.
+func SecuritySignals(commit llm.CommitInfo) string {
+	var signals []string
+
+	if ids := advisoryIDs(commit.Message); len(ids) > 0 {
+		signals = append(signals, "references "+strings.Join(ids, ", "))
+	} else if securityMessageRe.MatchString(commit.Message) {
+		signals = append(signals, "message mentions security")
+	}
+
+	var sensitive, dependencies bool
+	for _, file := range commit.FilesChanged {
+		if dependencyManifests[path.Base(file)] {
+			dependencies = true
+		} else if securityPathRe.MatchString(file) {
+			sensitive = true
+		}
+	}
+	if sensitive {
+		signals = append(signals, "touches auth/crypto code")
+	}
+	if dependencies {
+		signals = append(signals, "changes dependencies")
+	}
+
+	return strings.Join(signals, "; ")
+}
+
+// advisoryIDs returns the distinct CVE/GHSA identifiers in text, upper-cased
+func advisoryIDs(text string) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, id := range advisoryIDRe.FindAllString(text, -1) {
+		id = strings.ToUpper(id)
+		if strings.HasPrefix(id, "GHSA") {
+			id = "GHSA" + strings.ToLower(id[4:])
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// RelevantAdvisories returns the published advisories a release plausibly
+// addresses: those referenced by a commit message, or whose patched versions
+// name the release
+func RelevantAdvisories(advisories []provider.SecurityAdvisory, commits []llm.CommitInfo, release string) []provider.SecurityAdvisory {
+	referenced := make(map[string]bool)
+	for _, commit := range commits {
+		for _, id := range advisoryIDs(commit.Message) {
+			referenced[id] = true
+		}
+	}
+	version := strings.TrimPrefix(release, "v")
+
+	var relevant []provider.SecurityAdvisory
+	for _, advisory := range advisories {
+		patched := false
+		for _, field := range strings.FieldsFunc(advisory.PatchedVersions, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '=' || r == '>' || r == '<'
+		}) {
+			if strings.TrimPrefix(field, "v") == version {
+				patched = true
+			}
+		}
+		if patched || referenced[advisory.ID] || (advisory.CVE != "" && referenced[advisory.CVE]) {
+			relevant = append(relevant, advisory)
+		}
+	}
+	return relevant
+}
+
+// advisoryLister is implemented by providers that expose repository security advisories
+type advisoryLister interface {
+	ListSecurityAdvisories(ctx context.Context) ([]provider.SecurityAdvisory, error)
+}
+
+// securityEnabled reports whether the security pass runs; cross-referencing
+// advisories implies it
+func (g *Generator) securityEnabled() bool {
+	return g.config.SecuritySection || g.config.SecurityAdvisories
+}
+
+// flagSecurityCommits annotates commits with their security signals when the
+// security pass is enabled
+func (g *Generator) flagSecurityCommits(commits []llm.CommitInfo) {
+	if !g.securityEnabled() {
+		return
+	}
+	flagged := 0
+	for i := range commits {
+		commits[i].SecuritySignals = SecuritySignals(commits[i])
+		if commits[i].SecuritySignals != "" {
+			flagged++
+		}
+	}
+	logger.Info("flagged security-relevant commits", "count", flagged)
+}
+
+// securityAdvisories fetches the repository's advisories relevant to the
+// release when --security-advisories is set. Failures only warn.
+func (g *Generator) securityAdvisories(ctx context.Context, commits []llm.CommitInfo, release string) []llm.AdvisoryInfo {
+	lister, ok := g.provider.(advisoryLister)
+	if !g.config.SecurityAdvisories || !ok {
+		return nil
+	}
+	advisories, err := lister.ListSecurityAdvisories(ctx)
+	if err != nil {
+		g.warn("could not list security advisories: %v", err)
+		return nil
+	}
+
+	var infos []llm.AdvisoryInfo
+	for _, advisory := range RelevantAdvisories(advisories, commits, release) {
+		infos = append(infos, llm.AdvisoryInfo{
+			ID:       advisory.ID,
+			CVE:      advisory.CVE,
+			Severity: advisory.Severity,
+			Summary:  advisory.Summary,
+			URL:      advisory.URL,
+		})
+	}
+	logger.Info("cross-referenced security advisories", "published", len(advisories), "relevant", len(infos))
+	return infos
+}
@@ -0,0 +1,40 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+func TestSecuritySignals(t *testing.T) {
+	tests := []struct {
+		commit llm.CommitInfo
+		want   string
+	}{
+		{llm.CommitInfo{Message: "Fix CVE-2024-12345 and cve-2024-12345 in parser"}, "references CVE-2024-12345"},
+		{llm.CommitInfo{Message: "Escape output to prevent XSS"}, "message mentions security"},
+		{llm.CommitInfo{Message: "Refresh tokens", FilesChanged: []string{"pkg/auth/refresh.go"}}, "touches auth/crypto code"},
+		{llm.CommitInfo{Message: "Bump x/net", FilesChanged: []string{"go.mod", "go.sum"}}, "changes dependencies"},
+		{llm.CommitInfo{Message: "Add author field", FilesChanged: []string{"pkg/authors/list.go"}}, ""},
+	}
+	for _, tt := range tests {
+		if got := SecuritySignals(tt.commit); got != tt.want {
+			t.Errorf("SecuritySignals(%q) = %q, want %q", tt.commit.Message, got, tt.want)
+		}
+	}
+}
+
+func TestRelevantAdvisories(t *testing.T) {
+	advisories := []provider.SecurityAdvisory{
+		{ID: "GHSA-abcd-efgh-ijkm", PatchedVersions: "1.4.2"},
+		{ID: "GHSA-2222-3333-4444", CVE: "CVE-2024-0001", PatchedVersions: ">= 2.0.0"},
+		{ID: "GHSA-5555-6666-7777", PatchedVersions: "1.3.0"},
+	}
+	commits := []llm.CommitInfo{{Message: "Validate redirect URLs (CVE-2024-0001)"}}
+
+	relevant := RelevantAdvisories(advisories, commits, "v1.4.2")
+	if len(relevant) != 2 || relevant[0].ID != "GHSA-abcd-efgh-ijkm" || relevant[1].ID != "GHSA-2222-3333-4444" {
+		t.Errorf("RelevantAdvisories() = %+v", relevant)
+	}
+}
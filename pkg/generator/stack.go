@@ -0,0 +1,30 @@
+package generator
+
+import (
+	"context"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/stack"
+)
+
+// repoStack returns the repository's detected languages and frameworks for
+// prompts, detecting them on first use. Detection is best-effort: providers
+// without the needed APIs, and failures, leave the prompt without a stack.
+func (g *Generator) repoStack(ctx context.Context) string {
+	if g.stack != nil {
+		return *g.stack
+	}
+	g.stack = new(string)
+
+	source, ok := g.provider.(stack.Source)
+	if !g.config.DetectStack || !ok {
+		return ""
+	}
+	profile, err := stack.Detect(ctx, source)
+	if err != nil {
+		g.warn("could not detect the repository's tech stack: %v", err)
+		return ""
+	}
+	*g.stack = profile.String()
+	logger.Info("detected tech stack", "stack", *g.stack)
+	return *g.stack
+}
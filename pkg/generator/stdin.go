@@ -0,0 +1,66 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+// ReadCommits decodes a JSON array of commits in the llm.CommitInfo schema.
+// This lets repositories outside GitHub (Perforce, SVN exports) feed the pipeline.
+func ReadCommits(r io.Reader) ([]llm.CommitInfo, error) {
+	var commits []llm.CommitInfo
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&commits); err != nil {
+		return nil, fmt.Errorf("decode commits: %w", err)
+	}
+
+	for i, commit := range commits {
+		if strings.TrimSpace(commit.SHA) == "" {
+			return nil, fmt.Errorf("commit %d: missing sha", i)
+		}
+		if strings.TrimSpace(commit.Message) == "" {
+			return nil, fmt.Errorf("commit %s: missing message", commit.SHA)
+		}
+	}
+	return commits, nil
+}
+
+// commitDataFromInfos rebuilds the commit details the post-processing steps
+// use (SHA lookup, reference checks, contributors) from caller-supplied commits.
+// Diff summary lines are attached to the file they describe.
+func commitDataFromInfos(infos []llm.CommitInfo) []provider.CommitData {
+	commits := make([]provider.CommitData, 0, len(infos))
+	for _, info := range infos {
+		summaries := make(map[string]string)
+		for _, line := range strings.Split(info.DiffSummary, "\n") {
+			if file, summary, ok := strings.Cut(line, ": "); ok {
+				summaries[file] = summary
+			}
+		}
+
+		commit := provider.CommitData{
+			SHA:       info.SHA,
+			Message:   info.Message,
+			Author:    info.Author,
+			Date:      info.Date,
+			CoAuthors: provider.CoAuthors(info.Message),
+		}
+		for _, file := range info.FilesChanged {
+			commit.FilesChanged = append(commit.FilesChanged, provider.FileChange{
+				Filename: file,
+				Patch:    summaries[file],
+			})
+		}
+		fmt.Sscanf(info.Stats, "+%d/-%d", &commit.Stats.Additions, &commit.Stats.Deletions)
+		commit.Stats.Total = commit.Stats.Additions + commit.Stats.Deletions
+
+		commits = append(commits, commit)
+	}
+	return commits
+}
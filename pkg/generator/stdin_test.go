@@ -0,0 +1,46 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReadCommits(t *testing.T) {
+	input := `[{
+		"sha": "abc123",
+		"message": "Add retry support",
+		"author": "alice",
+		"date": "2024-03-01T10:00:00Z",
+		"files_changed": ["pkg/retry.go"],
+		"diff_summary": "pkg/retry.go: +func Retry",
+		"stats": "+40/-2"
+	}]`
+
+	commits, err := ReadCommits(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadCommits() error = %v", err)
+	}
+	if len(commits) != 1 || commits[0].SHA != "abc123" || commits[0].Date.IsZero() {
+		t.Fatalf("Unexpected commits: %+v", commits)
+	}
+
+	data := commitDataFromInfos(commits)
+	if data[0].Stats.Additions != 40 || data[0].Stats.Deletions != 2 {
+		t.Errorf("Expected stats +40/-2, got %+v", data[0].Stats)
+	}
+	if data[0].FilesChanged[0].Patch != "+func Retry" {
+		t.Errorf("Expected diff summary attached to file, got %q", data[0].FilesChanged[0].Patch)
+	}
+}
+
+func TestReadCommitsRejectsInvalid(t *testing.T) {
+	for _, input := range []string{
+		`{"sha": "abc"}`,
+		`[{"message": "no sha"}]`,
+		`[{"sha": "abc", "message": "m", "unknown": 1}]`,
+	} {
+		if _, err := ReadCommits(strings.NewReader(input)); err == nil {
+			t.Errorf("Expected error for %s", input)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+// Warnings returns the warnings recorded during the last generation run
+func (g *Generator) Warnings() []string {
+	return g.warnings
+}
+
+// warn records an informational warning and logs it
+func (g *Generator) warn(format string, args ...any) {
+	message := fmt.Sprintf(format, args...)
+	g.warnings = append(g.warnings, message)
+	logger.Warn(message)
+}
+
+// softFail records a condition that is tolerated normally but fails the run
+// in strict mode
+func (g *Generator) softFail(format string, args ...any) {
+	g.warn(format, args...)
+	g.violations = append(g.violations, g.warnings[len(g.warnings)-1])
+}
+
+// strictError returns an error listing every soft failure when strict mode is on
+func (g *Generator) strictError() error {
+	if !g.config.Strict || len(g.violations) == 0 {
+		return nil
+	}
+	return fmt.Errorf("strict mode: %d issue(s) in generated output:\n  - %s",
+		len(g.violations), strings.Join(g.violations, "\n  - "))
+}
+
+// checkEntries records soft failures for entries with a missing or unknown
+// SHA, or without an importance score
+func (g *Generator) checkEntries(response *llm.ChangelogResponse, commits []provider.CommitData) {
+	for _, category := range categoriesByPriority(response.Categories) {
+		for _, entry := range response.Categories[category] {
+			switch {
+			case entry.SHA == "":
+				g.softFail("entry %q in %s has no commit SHA", entry.Title, category)
+			case findCommit(commits, entry.SHA) == nil:
+				g.softFail("entry %q in %s references unknown commit %s", entry.Title, category, entry.SHA)
+			}
+			if entry.ScoreMissing {
+				g.softFail("entry %q in %s has no importance score", entry.Title, category)
+			}
+		}
+	}
+}
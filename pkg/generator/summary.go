@@ -0,0 +1,190 @@
+package generator
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+)
+
+// ReleaseStats counts what went into one processed release or range
+type ReleaseStats struct {
+	Name         string
+	Commits      int
+	PullRequests int
+}
+
+// RunSummary describes a generation run for CI reviewers: inputs, what was
+// processed, filters, cost, and warnings
+type RunSummary struct {
+	Repository        string
+	Inputs            string // Range or date span
+	Model             string
+	Duration          time.Duration
+	OutputPath        string
+	Releases          []ReleaseStats
+	EntriesByCategory map[string]int
+	FilteredByScore   int // Entries hidden by --min-score
+	Filters           []string
+	Usage             llm.Usage
+	Cost              float64
+	PricingKnown      bool
+	Warnings          []string
+}
+
+// NewRunSummary starts a summary with the inputs and filters from cfg
+func NewRunSummary(cfg *config.Config, inputs string) *RunSummary {
+	summary := &RunSummary{
+		Repository:        fmt.Sprintf("%s/%s", cfg.RepoOwner, cfg.RepoName),
+		Inputs:            inputs,
+		Model:             cfg.OpenAIModel,
+		OutputPath:        cfg.OutputPath,
+		EntriesByCategory: make(map[string]int),
+	}
+
+	if cfg.Branch != "" {
+		summary.Filters = append(summary.Filters, fmt.Sprintf("Branch: `%s`", cfg.Branch))
+	}
+	if cfg.MinScore > 0 {
+		summary.Filters = append(summary.Filters, fmt.Sprintf("Minimum importance score: %.1f", cfg.MinScore))
+	}
+	if cfg.Top > 0 {
+		summary.Filters = append(summary.Filters, fmt.Sprintf("Top entries: %d", cfg.Top))
+	}
+	if cfg.TopPerCategory > 0 {
+		summary.Filters = append(summary.Filters, fmt.Sprintf("Top entries per category: %d", cfg.TopPerCategory))
+	}
+	if cfg.MaxLength != "" {
+		summary.Filters = append(summary.Filters, fmt.Sprintf("Length budget: %s per release", cfg.MaxLength))
+	}
+	if len(cfg.CategoryAliases) > 0 {
+		summary.Filters = append(summary.Filters, fmt.Sprintf("Category aliases: %d configured", len(cfg.CategoryAliases)))
+	}
+	summary.Filters = append(summary.Filters, fmt.Sprintf("Unknown categories: %s", cfg.UnknownCategory))
+	if cfg.Prepend {
+		summary.Filters = append(summary.Filters, "Prepend: versions already in the output file are skipped")
+	}
+	if cfg.Strict {
+		summary.Filters = append(summary.Filters, "Strict mode")
+	}
+	return summary
+}
+
+// AddChangelog records a range changelog
+func (s *RunSummary) AddChangelog(changelog *Changelog, minScore float64) {
+	s.Releases = append(s.Releases, ReleaseStats{Name: changelog.ToRef, Commits: changelog.CommitCount})
+	for category, entries := range changelog.Categories {
+		for _, entry := range entries {
+			if minScore > 0 && entry.ImportanceScore < minScore {
+				s.FilteredByScore++
+				continue
+			}
+			s.EntriesByCategory[category]++
+		}
+	}
+}
+
+// AddTimeline records every release of a timeline changelog
+func (s *RunSummary) AddTimeline(timeline *TimelineChangelog) {
+	for _, release := range timeline.Releases {
+		s.Releases = append(s.Releases, ReleaseStats{
+			Name:         release.ToRef,
+			Commits:      len(release.Commits),
+			PullRequests: len(release.PullRequests),
+		})
+	}
+}
+
+// Finish records the run's duration, LLM usage, and warnings
+func (s *RunSummary) Finish(started time.Time, usage llm.Usage, warnings []string) {
+	s.Duration = time.Since(started).Round(time.Second)
+	s.Usage = usage
+	s.Cost, s.PricingKnown = llm.EstimateCost(s.Model, usage.InputTokens, usage.OutputTokens)
+	s.Warnings = warnings
+}
+
+// FormatRunSummary renders the summary as markdown suitable for a CI job
+// summary or a release PR description
+func FormatRunSummary(s *RunSummary) string {
+	var b strings.Builder
+
+	b.WriteString("# Changelog Run Summary\n\n")
+	b.WriteString("## Inputs\n\n")
+	b.WriteString(fmt.Sprintf("- **Repository:** %s\n", s.Repository))
+	b.WriteString(fmt.Sprintf("- **Inputs:** %s\n", s.Inputs))
+	b.WriteString(fmt.Sprintf("- **Model:** %s\n", s.Model))
+	if s.OutputPath != "" {
+		b.WriteString(fmt.Sprintf("- **Output:** `%s`\n", s.OutputPath))
+	}
+	b.WriteString(fmt.Sprintf("- **Duration:** %s\n\n", s.Duration))
+
+	b.WriteString("## Releases Processed\n\n")
+	if len(s.Releases) == 0 {
+		b.WriteString("_None._\n\n")
+	} else {
+		b.WriteString("| Release | Commits | PRs |\n|---|---:|---:|\n")
+		for _, release := range s.Releases {
+			b.WriteString(fmt.Sprintf("| %s | %d | %d |\n", release.Name, release.Commits, release.PullRequests))
+		}
+		b.WriteString("\n")
+	}
+
+	if len(s.EntriesByCategory) > 0 || s.FilteredByScore > 0 {
+		b.WriteString("## Entries per Category\n\n")
+		for _, category := range sortedCategoryNames(s.EntriesByCategory) {
+			b.WriteString(fmt.Sprintf("- %s: %d\n", category, s.EntriesByCategory[category]))
+		}
+		if s.FilteredByScore > 0 {
+			b.WriteString(fmt.Sprintf("- _Hidden by minimum score: %d_\n", s.FilteredByScore))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## Filters\n\n")
+	for _, filter := range s.Filters {
+		b.WriteString(fmt.Sprintf("- %s\n", filter))
+	}
+	b.WriteString("\n")
+
+	b.WriteString("## Cost\n\n")
+	b.WriteString(fmt.Sprintf("- LLM calls: %d\n", s.Usage.Calls))
+	b.WriteString(fmt.Sprintf("- Tokens: %d input, %d output\n", s.Usage.InputTokens, s.Usage.OutputTokens))
+	if s.PricingKnown {
+		b.WriteString(fmt.Sprintf("- Cost: $%.4f\n\n", s.Cost))
+	} else {
+		b.WriteString(fmt.Sprintf("- Cost: unknown (no pricing for %s)\n\n", s.Model))
+	}
+
+	b.WriteString("## Warnings\n\n")
+	if len(s.Warnings) == 0 {
+		b.WriteString("_None._\n")
+	}
+	for _, warning := range s.Warnings {
+		b.WriteString(fmt.Sprintf("- %s\n", warning))
+	}
+
+	return b.String()
+}
+
+// sortedCategoryNames orders category counts per CategoryOrder, with unknown
+// categories alphabetically at the end
+func sortedCategoryNames(counts map[string]int) []string {
+	var names []string
+	for _, category := range CategoryOrder {
+		if _, ok := counts[category]; ok {
+			names = append(names, category)
+		}
+	}
+	var unknown []string
+	for category := range counts {
+		if !slices.Contains(CategoryOrder, category) {
+			unknown = append(unknown, category)
+		}
+	}
+	sort.Strings(unknown)
+	return append(names, unknown...)
+}
@@ -0,0 +1,40 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+)
+
+func TestFormatRunSummary(t *testing.T) {
+	cfg := &config.Config{RepoOwner: "acme", RepoName: "api", OpenAIModel: "gpt-4o", MinScore: 5, UnknownCategory: "Internal"}
+	summary := NewRunSummary(cfg, "v1.0.0..v1.1.0")
+	summary.AddChangelog(&Changelog{
+		ToRef:       "v1.1.0",
+		CommitCount: 3,
+		Categories: map[string][]llm.ChangelogEntry{
+			"Features":  {{Title: "A", ImportanceScore: 8}, {Title: "B", ImportanceScore: 2}},
+			"Bug Fixes": {{Title: "C", ImportanceScore: 6}},
+		},
+	}, cfg.MinScore)
+	summary.Usage = llm.Usage{Calls: 1, InputTokens: 1000000, OutputTokens: 0}
+	summary.Cost, summary.PricingKnown = llm.EstimateCost(summary.Model, 1000000, 0)
+	summary.Warnings = []string{"moved 1 entries from unknown category \"Misc\" to \"Internal\""}
+
+	markdown := FormatRunSummary(summary)
+	for _, want := range []string{
+		"**Repository:** acme/api",
+		"| v1.1.0 | 3 | 0 |",
+		"- Features: 1\n- Bug Fixes: 1",
+		"Hidden by minimum score: 1",
+		"Minimum importance score: 5.0",
+		"Cost: $2.5000",
+		"unknown category",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Expected %q in:\n%s", want, markdown)
+		}
+	}
+}
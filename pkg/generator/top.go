@@ -0,0 +1,69 @@
+package generator
+
+import (
+	"sort"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+)
+
+// entryRef locates an entry within the categories map
+type entryRef struct {
+	category string
+	index    int
+	score    float64
+}
+
+// SelectTopEntries keeps the top highest-scoring entries across all
+// categories, and at most perCategory in each category (0 = no limit). Ties go
+// to the higher-priority category, then the earlier entry; kept entries keep
+// their order. It returns how many entries were dropped.
+func SelectTopEntries(categories map[string][]llm.ChangelogEntry, top, perCategory int) int {
+	if top <= 0 && perCategory <= 0 {
+		return 0
+	}
+
+	var candidates []entryRef
+	for _, category := range categoriesByPriority(categories) {
+		refs := make([]entryRef, len(categories[category]))
+		for i, entry := range categories[category] {
+			refs[i] = entryRef{category: category, index: i, score: entry.ImportanceScore}
+		}
+		candidates = append(candidates, bestEntries(refs, perCategory)...)
+	}
+	// Candidates are in priority order, so the stable sort keeps ties in it
+	kept := make(map[entryRef]bool)
+	for _, ref := range bestEntries(candidates, top) {
+		kept[ref] = true
+	}
+
+	dropped := 0
+	for category, entries := range categories {
+		var remaining []llm.ChangelogEntry
+		for i, entry := range entries {
+			if kept[entryRef{category: category, index: i, score: entry.ImportanceScore}] {
+				remaining = append(remaining, entry)
+			} else {
+				dropped++
+			}
+		}
+		if len(remaining) == 0 {
+			delete(categories, category)
+		} else {
+			categories[category] = remaining
+		}
+	}
+	return dropped
+}
+
+// bestEntries returns the limit highest-scoring refs (all with limit <= 0),
+// preferring earlier refs on ties
+func bestEntries(refs []entryRef, limit int) []entryRef {
+	if limit <= 0 || len(refs) <= limit {
+		return refs
+	}
+	sorted := append([]entryRef(nil), refs...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].score > sorted[j].score
+	})
+	return sorted[:limit]
+}
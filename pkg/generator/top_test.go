@@ -0,0 +1,49 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+)
+
+func TestSelectTopEntries(t *testing.T) {
+	newCategories := func() map[string][]llm.ChangelogEntry {
+		return map[string][]llm.ChangelogEntry{
+			"Features":  {{Title: "A", ImportanceScore: 8}, {Title: "B", ImportanceScore: 4}, {Title: "C", ImportanceScore: 7}},
+			"Bug Fixes": {{Title: "D", ImportanceScore: 7}, {Title: "E", ImportanceScore: 2}},
+			"Internal":  {{Title: "F", ImportanceScore: 1}},
+		}
+	}
+	titles := func(entries []llm.ChangelogEntry) string {
+		var s string
+		for _, entry := range entries {
+			s += entry.Title
+		}
+		return s
+	}
+
+	categories := newCategories()
+	if dropped := SelectTopEntries(categories, 3, 0); dropped != 3 {
+		t.Errorf("Expected 3 dropped, got %d", dropped)
+	}
+	// The 7s tie: Features ranks above Bug Fixes, so C wins over D
+	if titles(categories["Features"]) != "AC" || len(categories) != 2 || titles(categories["Bug Fixes"]) != "D" {
+		t.Errorf("Unexpected top 3: %v", categories)
+	}
+
+	categories = newCategories()
+	SelectTopEntries(categories, 0, 1)
+	if titles(categories["Features"]) != "A" || titles(categories["Bug Fixes"]) != "D" || titles(categories["Internal"]) != "F" {
+		t.Errorf("Unexpected top per category: %v", categories)
+	}
+
+	categories = newCategories()
+	SelectTopEntries(categories, 2, 1)
+	if titles(categories["Features"]) != "A" || titles(categories["Bug Fixes"]) != "D" || len(categories) != 2 {
+		t.Errorf("Unexpected combined selection: %v", categories)
+	}
+
+	if SelectTopEntries(newCategories(), 0, 0) != 0 {
+		t.Error("Expected no selection without limits")
+	}
+}
@@ -0,0 +1,202 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+)
+
+// maxTranslateBatch caps the characters of text sent in one translation call;
+// a longer single text is sent on its own
+const maxTranslateBatch = 6000
+
+// translatableKeys are the JSON output fields holding prose. Every string
+// below them is translated; identifiers, refs, URLs, authors, and category
+// names are left as they are.
+var translatableKeys = map[string]bool{
+	"summary":      true,
+	"one_liner":    true,
+	"paragraph":    true,
+	"full":         true,
+	"highlights":   true,
+	"themes":       true,
+	"title":        true,
+	"description":  true,
+	"score_reason": true,
+	"pr_summaries": true,
+}
+
+// TranslateMarkdown translates a generated markdown changelog into language
+// heading by heading, keeping its layout. No commits are fetched.
+func (g *Generator) TranslateMarkdown(ctx context.Context, markdown, language string) (string, error) {
+	g.resetRun()
+
+	sections := splitSections(markdown)
+	texts := make([]string, len(sections))
+	for i, section := range sections {
+		texts[i] = strings.TrimRight(section, "\n")
+	}
+	translated, err := g.translateTexts(ctx, texts, language)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	for i, section := range sections {
+		b.WriteString(translated[i])
+		b.WriteString(section[len(texts[i]):]) // Trailing blank lines
+	}
+	if err := g.strictError(); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// TranslateJSON translates the prose fields of a generated JSON changelog
+// (range or timeline) into language, leaving every other field untouched
+func (g *Generator) TranslateJSON(ctx context.Context, data []byte, language string) ([]byte, error) {
+	g.resetRun()
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("parse JSON changelog: %w", err)
+	}
+
+	var texts []string
+	var setters []func(string)
+	collectTranslatable(doc, false, func(text string, set func(string)) {
+		texts = append(texts, text)
+		setters = append(setters, set)
+	})
+	translated, err := g.translateTexts(ctx, texts, language)
+	if err != nil {
+		return nil, err
+	}
+	for i, set := range setters {
+		set(translated[i])
+	}
+	if err := g.strictError(); err != nil {
+		return nil, err
+	}
+
+	out, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode JSON changelog: %w", err)
+	}
+	return append(out, '\n'), nil
+}
+
+// collectTranslatable calls found for every non-blank string in v that sits
+// below a translatable key, with a function that replaces it
+func collectTranslatable(v any, translatable bool, found func(text string, set func(string))) {
+	switch v := v.(type) {
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys) // Stable prompts across runs
+		for _, key := range keys {
+			child := v[key]
+			if s, ok := child.(string); ok {
+				if (translatable || translatableKeys[key]) && strings.TrimSpace(s) != "" {
+					found(s, func(text string) { v[key] = text })
+				}
+				continue
+			}
+			collectTranslatable(child, translatable || translatableKeys[key], found)
+		}
+	case []any:
+		for i, child := range v {
+			if s, ok := child.(string); ok {
+				if translatable && strings.TrimSpace(s) != "" {
+					found(s, func(text string) { v[i] = text })
+				}
+				continue
+			}
+			collectTranslatable(child, translatable, found)
+		}
+	}
+}
+
+// translateTexts translates texts in batches of up to maxTranslateBatch
+// characters, returning them in order. Blank texts are kept as they are.
+func (g *Generator) translateTexts(ctx context.Context, texts []string, language string) ([]string, error) {
+	translated := make([]string, len(texts))
+	copy(translated, texts)
+
+	var batch []int
+	size := 0
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		req := llm.TranslateRequest{Language: language}
+		for _, i := range batch {
+			req.Texts = append(req.Texts, texts[i])
+		}
+		logger.Info("translating", "language", language, "texts", len(batch), "chars", size)
+		response, err := g.llmClient.Translate(ctx, req)
+		if err != nil {
+			return fmt.Errorf("translate to %s: %w", language, err)
+		}
+		if len(response.Translations) != len(batch) {
+			return fmt.Errorf("translate to %s: got %d translations for %d texts", language, len(response.Translations), len(batch))
+		}
+		if response.Repaired {
+			g.softFail("LLM translation response needed JSON cleanup before parsing")
+		}
+		for j, i := range batch {
+			translated[i] = response.Translations[j]
+		}
+		batch, size = nil, 0
+		return nil
+	}
+
+	for i, text := range texts {
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		if size > 0 && size+len(text) > maxTranslateBatch {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+		batch = append(batch, i)
+		size += len(text)
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return translated, nil
+}
+
+// splitSections splits markdown before each heading outside code fences, so
+// the sections concatenate back to the original
+func splitSections(markdown string) []string {
+	var sections []string
+	var current strings.Builder
+	inFence := false
+	for _, line := range strings.SplitAfter(markdown, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+		}
+		if !inFence && strings.HasPrefix(trimmed, "#") && current.Len() > 0 {
+			sections = append(sections, current.String())
+			current.Reset()
+		}
+		current.WriteString(line)
+	}
+	if current.Len() > 0 {
+		sections = append(sections, current.String())
+	}
+	return sections
+}
@@ -0,0 +1,90 @@
+package generator
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+)
+
+// shoutingTranslator "translates" by upper-casing every text
+type shoutingTranslator struct {
+	fakeLLM
+	requests []llm.TranslateRequest
+}
+
+func (s *shoutingTranslator) Translate(ctx context.Context, req llm.TranslateRequest) (*llm.TranslateResponse, error) {
+	s.requests = append(s.requests, req)
+	response := &llm.TranslateResponse{}
+	for _, text := range req.Texts {
+		response.Translations = append(response.Translations, strings.ToUpper(text))
+	}
+	return response, nil
+}
+
+func TestTranslateMarkdownKeepsLayout(t *testing.T) {
+	markdown := "# Changelog\n\n## v1.1.0\n\n- Faster startup\n\n```\n# not a heading\n```\n\n## v1.0.0\n\n- First release\n"
+	client := &shoutingTranslator{}
+
+	got, err := New(nil, client).TranslateMarkdown(context.Background(), markdown, "German")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != strings.ToUpper(markdown) {
+		t.Errorf("got %q", got)
+	}
+	if len(client.requests) != 1 || len(client.requests[0].Texts) != 3 || client.requests[0].Language != "German" {
+		t.Errorf("requests = %+v", client.requests)
+	}
+	for _, text := range client.requests[0].Texts {
+		if strings.HasSuffix(text, "\n") {
+			t.Errorf("trailing newlines should not be sent: %q", text)
+		}
+	}
+}
+
+func TestTranslateJSONOnlyProse(t *testing.T) {
+	data := []byte(`{
+  "summary": "A small release.",
+  "highlights": ["Faster startup"],
+  "categories": {"Features": [{"sha": "abc123", "title": "Add cache", "author": "alice", "importance_score": 6.5}]},
+  "pr_summaries": {"42": "Adds a cache."},
+  "to_ref": "v1.1.0"
+}`)
+	client := &shoutingTranslator{}
+
+	out, err := New(nil, client).TranslateJSON(context.Background(), data, "fr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Summary    string                          `json:"summary"`
+		Highlights []string                        `json:"highlights"`
+		Categories map[string][]llm.ChangelogEntry `json:"categories"`
+		PRs        map[string]string               `json:"pr_summaries"`
+		ToRef      string                          `json:"to_ref"`
+	}
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatal(err)
+	}
+	entry := doc.Categories["Features"][0]
+	if doc.Summary != "A SMALL RELEASE." || doc.Highlights[0] != "FASTER STARTUP" || entry.Title != "ADD CACHE" || doc.PRs["42"] != "ADDS A CACHE." {
+		t.Errorf("prose not translated: %s", out)
+	}
+	if doc.ToRef != "v1.1.0" || entry.SHA != "abc123" || entry.Author != "alice" || entry.ImportanceScore != 6.5 {
+		t.Errorf("non-prose fields changed: %s", out)
+	}
+	if !strings.Contains(string(out), `"importance_score": 6.5`) {
+		t.Errorf("numbers should be kept as written: %s", out)
+	}
+}
+
+func TestSplitSectionsRoundTrips(t *testing.T) {
+	markdown := "intro\n## a\ntext\n\n### b\n```\n## in code\n```\n"
+	sections := splitSections(markdown)
+	if strings.Join(sections, "") != markdown || len(sections) != 3 {
+		t.Errorf("sections = %q", sections)
+	}
+}
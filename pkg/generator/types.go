@@ -3,40 +3,74 @@ package generator
 import (
 	"time"
 
-	"github.com/rakshaksatsangi/changelog-generator/pkg/github"
 	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/tickets"
 )
 
 // Changelog represents the complete generated changelog
 type Changelog struct {
-	Summary    string
-	Highlights []string
-	Categories map[string][]llm.ChangelogEntry
-	Markdown   string
-	FromRef    string
-	ToRef      string
-	RepoName   string
+	Schema       string                          `json:"schema_version"` // SchemaVersion
+	Metadata     *Metadata                       `json:"metadata,omitempty"`
+	Summary      string                          `json:"summary"`
+	Highlights   []string                        `json:"highlights"`
+	Categories   map[string][]llm.ChangelogEntry `json:"categories"`
+	Zoom         ZoomSummaries                   `json:"zoom"`
+	Markdown     string                          `json:"-"`                      // Same as Zoom.Full
+	Contributors *ContributorsSummary            `json:"contributors,omitempty"` // Set when contributor stats are enabled
+	Artifacts    []provider.ReleaseAsset         `json:"artifacts,omitempty"`    // Set when the artifact index is enabled
+	CommitCount  int                             `json:"commit_count"`
+	Commits      []provider.CommitData           `json:"-"`    // Commits in the range
+	Date         time.Time                       `json:"date"` // Newest commit date in the range
+	FromRef      string                          `json:"from_ref"`
+	ToRef        string                          `json:"to_ref"`
+	RepoName     string                          `json:"repo_name"`
 }
 
 // TimelineChangelog represents a changelog covering multiple releases
 type TimelineChangelog struct {
-	FromDate time.Time
-	ToDate   time.Time
-	RepoName string
-	Releases []ReleaseChangelog
-	Markdown string
+	Schema   string              `json:"schema_version"` // SchemaVersion
+	Metadata *Metadata           `json:"metadata,omitempty"`
+	FromDate time.Time           `json:"from_date"`
+	ToDate   time.Time           `json:"to_date"`
+	RepoName string              `json:"repo_name"`
+	Releases []ReleaseChangelog  `json:"releases"`
+	Period   *PeriodSummary      `json:"period_summary,omitempty"` // Set when the period summary is enabled
+	Metrics  *EngineeringMetrics `json:"metrics,omitempty"`        // Set when the metrics appendix is enabled
+	Markdown string              `json:"-"`
 }
 
 // ReleaseChangelog represents a single release within a timeline
 type ReleaseChangelog struct {
-	FromRef      string
-	ToRef        string
-	FromDate     time.Time
-	ToDate       time.Time
-	Summary      string
-	Highlights   []string
-	Categories   map[string][]llm.ChangelogEntry
-	Commits      []github.CommitData      // Individual commits in this release
-	PullRequests []github.PullRequestData  // PRs in this release
-	PRSummaries  map[int]string            // PR number → LLM summary
+	FromRef      string                          `json:"from_ref"`
+	ToRef        string                          `json:"to_ref"`
+	ToSHA        string                          `json:"to_sha,omitempty"`
+	Series       string                          `json:"series,omitempty"`     // Set when releases are grouped, e.g. "v1.4.x"
+	Versions     []string                        `json:"versions,omitempty"`   // Releases folded into the series
+	Unreleased   bool                            `json:"unreleased,omitempty"` // Ends at the untagged branch head
+	FromDate     time.Time                       `json:"from_date"`
+	ToDate       time.Time                       `json:"to_date"`
+	Summary      string                          `json:"summary,omitempty"`
+	Highlights   []string                        `json:"highlights,omitempty"`
+	Categories   map[string][]llm.ChangelogEntry `json:"categories,omitempty"`
+	Zoom         ZoomSummaries                   `json:"zoom"`
+	Model        string                          `json:"model,omitempty"`        // Set when --model-routing sent the release to another model
+	Commits      []provider.CommitData           `json:"-"`                      // Individual commits in this release
+	PullRequests []provider.PullRequestData      `json:"pull_requests"`          // PRs in this release
+	PRSummaries  map[int]string                  `json:"pr_summaries"`           // PR number → LLM summary
+	PRTickets    map[int][]tickets.Ticket        `json:"pr_tickets,omitempty"`   // PR number → linked issue tracker tickets
+	PRImages     map[int]string                  `json:"pr_images,omitempty"`    // PR number → first image in its body
+	PRScores     map[int]llm.RescoreEntry        `json:"pr_scores,omitempty"`    // PR number → importance calibrated across the timeline
+	Contributors *ContributorsSummary            `json:"contributors,omitempty"` // Set when contributor stats are enabled
+	Artifacts    []provider.ReleaseAsset         `json:"artifacts,omitempty"`    // Set when the artifact index is enabled
+	Stats        *ReleaseComparison              `json:"stats,omitempty"`        // Set when the stats header is enabled
+	Markdown     string                          `json:"-"`                      // Rendered section override (set after a length-budget compression pass)
+}
+
+// ZoomSummaries describes a release at three levels of detail so consumers
+// (chat notifications, emails, docs) can pick a length without extra LLM calls
+type ZoomSummaries struct {
+	OneLiner  string `json:"one_liner"` // Single sentence
+	Paragraph string `json:"paragraph"` // 2-3 sentences
+	Full      string `json:"full"`      // Complete markdown section
 }
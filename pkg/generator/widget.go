@@ -0,0 +1,133 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+// WidgetItem is one release in the shape in-app "What's New" widgets import:
+// a title, a date, tags, and an HTML body
+type WidgetItem struct {
+	ID      string    `json:"id"` // Repository and version, e.g. acme-api-v1.2.0
+	Title   string    `json:"title"`
+	Date    time.Time `json:"date"`
+	Tags    []string  `json:"tags"`              // Categories with entries, in category order
+	Summary string    `json:"summary,omitempty"` // One-line summary
+	Body    string    `json:"body"`              // The release's notes as HTML, without their heading
+}
+
+// releasePage is one release of a changelog, for the outputs that publish
+// each release on its own
+type releasePage struct {
+	repo       string
+	version    string
+	date       time.Time
+	tags       []string // Categories with entries, in category order
+	summary    string   // One-line summary
+	markdown   string   // The release's section, heading included
+	unreleased bool     // The untagged branch head
+}
+
+// releasePages splits a Changelog, TimelineChangelog, or OrgTimelineChangelog
+// into its releases
+func releasePages(changelog any) ([]releasePage, error) {
+	switch c := changelog.(type) {
+	case *Changelog:
+		return []releasePage{{c.RepoName, c.ToRef, c.Date, categoriesByPriority(c.Categories), c.Zoom.OneLiner, c.Markdown, false}}, nil
+	case *TimelineChangelog:
+		return timelinePages(c), nil
+	case *OrgTimelineChangelog:
+		var pages []releasePage
+		for _, repo := range c.Repos {
+			pages = append(pages, timelinePages(repo)...)
+		}
+		return pages, nil
+	default:
+		return nil, fmt.Errorf("unsupported changelog type %T", changelog)
+	}
+}
+
+// timelinePages returns the page of every release of a timeline
+func timelinePages(timeline *TimelineChangelog) []releasePage {
+	var pages []releasePage
+	for _, release := range timeline.Releases {
+		version := release.ToRef
+		if release.Series != "" {
+			version = release.Series
+		} else if release.Unreleased {
+			version = "Unreleased"
+		}
+		pages = append(pages, releasePage{timeline.RepoName, version, release.ToDate, categoriesByPriority(release.Categories),
+			release.Zoom.OneLiner, firstNonEmpty(release.Markdown, release.Zoom.Full), release.Unreleased})
+	}
+	return pages
+}
+
+// title names a release page after its repository and version
+func (p releasePage) title() string {
+	return fmt.Sprintf("%s %s", path.Base(p.repo), p.version)
+}
+
+// body returns the page's markdown without the heading, which pages show as
+// their title, and the HTML comments that mark release sections for reruns
+func (p releasePage) body() string {
+	var kept []string
+	heading := false
+	for _, line := range strings.Split(p.markdown, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if !heading && strings.HasPrefix(trimmed, "#") {
+			heading = true
+			continue
+		}
+		if strings.HasPrefix(trimmed, "<!--") && strings.HasSuffix(trimmed, "-->") {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.TrimSpace(strings.Join(kept, "\n")) + "\n"
+}
+
+// WidgetItems converts a Changelog, or every release of a TimelineChangelog
+// or OrgTimelineChangelog, to widget items
+func WidgetItems(changelog any) ([]WidgetItem, error) {
+	pages, err := releasePages(changelog)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]WidgetItem, 0, len(pages))
+	for _, page := range pages {
+		tags := page.tags
+		if tags == nil {
+			tags = []string{}
+		}
+		items = append(items, WidgetItem{
+			ID:      strings.ReplaceAll(page.repo, "/", "-") + "-" + page.version,
+			Title:   page.title(),
+			Date:    page.date,
+			Tags:    tags,
+			Summary: page.summary,
+			Body:    MarkdownToHTML(page.body()),
+		})
+	}
+	return items, nil
+}
+
+// FormatWidgetJSON renders widget items as an indented JSON array. HTML in
+// bodies is not escaped, so the output stays readable.
+func FormatWidgetJSON(items []WidgetItem) (string, error) {
+	if items == nil {
+		items = []WidgetItem{}
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(items); err != nil {
+		return "", fmt.Errorf("encode widget JSON: %w", err)
+	}
+	return buf.String(), nil
+}
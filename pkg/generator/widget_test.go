@@ -0,0 +1,69 @@
+package generator
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+)
+
+func TestWidgetItems(t *testing.T) {
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	timeline := &TimelineChangelog{
+		RepoName: "acme/api",
+		Releases: []ReleaseChangelog{{
+			ToRef:  "v1.2.0",
+			ToDate: date,
+			Categories: map[string][]llm.ChangelogEntry{
+				"Bug Fixes": {{Title: "Fix retries"}},
+				"Features":  {{Title: "Add export"}},
+			},
+			Zoom: ZoomSummaries{
+				OneLiner: "Exports and steadier retries.",
+				Full:     "## [Release v1.2.0]\n" + releaseSHAComment("abc1234") + "\n\n- **Add export**\n",
+			},
+		}},
+	}
+
+	items, err := WidgetItems(timeline)
+	if err != nil || len(items) != 1 {
+		t.Fatalf("WidgetItems() = %v, %v", items, err)
+	}
+	item := items[0]
+	if item.ID != "acme-api-v1.2.0" || item.Title != "api v1.2.0" || !item.Date.Equal(date) || item.Summary != "Exports and steadier retries." {
+		t.Errorf("Unexpected item: %+v", item)
+	}
+	if strings.Join(item.Tags, ",") != "Features,Bug Fixes" {
+		t.Errorf("Tags = %v, want categories in category order", item.Tags)
+	}
+	if strings.Contains(item.Body, "Release v1.2.0") || strings.Contains(item.Body, "release-sha") || !strings.Contains(item.Body, "<strong>Add export</strong>") {
+		t.Errorf("Body = %q, want the notes as HTML without the heading and marker", item.Body)
+	}
+
+	output, err := FormatWidgetJSON(items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded []map[string]any
+	if err := json.Unmarshal([]byte(output), &decoded); err != nil {
+		t.Fatalf("FormatWidgetJSON() is not a JSON array: %v", err)
+	}
+	for _, key := range []string{"id", "title", "date", "tags", "summary", "body"} {
+		if _, ok := decoded[0][key]; !ok {
+			t.Errorf("Widget JSON has no %q field: %s", key, output)
+		}
+	}
+}
+
+func TestWidgetItemsEmptyTags(t *testing.T) {
+	items, err := WidgetItems(&Changelog{RepoName: "acme/api", ToRef: "v1.0.1", Markdown: "# Changelog: v1.0.0 → v1.0.1\n\nNothing notable.\n"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	output, _ := FormatWidgetJSON(items)
+	if !strings.Contains(output, `"tags": []`) || !strings.Contains(output, "<p>Nothing notable.</p>") {
+		t.Errorf("Unexpected widget JSON: %s", output)
+	}
+}
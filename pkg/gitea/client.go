@@ -0,0 +1,456 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+// pageSize is the number of items requested per page
+const pageSize = 50
+
+// prRefRe matches pull request references in Gitea merge and squash commit
+// messages: "Merge pull request 'Title' (#12) from ..." or "Title (#12)"
+var prRefRe = regexp.MustCompile(`\(#(\d+)\)`)
+
+// Client is a Gitea / Forgejo implementation of the provider interface
+type Client struct {
+	httpClient *http.Client
+	baseURL    string // Web root, e.g. https://codeberg.org
+	owner      string
+	repo       string
+	token      string
+
+	tagFilter provider.TagFilter // Which tags and releases timelines are built from
+	edges     bool               // Extend timelines to the tag before the window and the untagged head
+}
+
+// Client implements the hosting provider interface
+var (
+	_ provider.Provider          = (*Client)(nil)
+	_ provider.PullRequestLinker = (*Client)(nil)
+)
+
+// NewClient creates a client for the Gitea instance at baseURL (e.g.,
+// https://gitea.example.com or https://codeberg.org)
+func NewClient(baseURL, token, owner, repo string) *Client {
+	return &Client{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		owner:      owner,
+		repo:       repo,
+		token:      token,
+	}
+}
+
+// SetTagFilter restricts timeline discovery to the tags and releases that
+// pass filter
+func (c *Client) SetTagFilter(filter provider.TagFilter) {
+	c.tagFilter = filter
+}
+
+// SetIncludeBoundaries makes timelines start at the newest tag before the
+// window and end at the newest untagged commit in it, so commits at the
+// edges are not skipped
+func (c *Client) SetIncludeBoundaries(include bool) {
+	c.edges = include
+}
+
+// ValidateAccess checks if the client has access to the repository
+func (c *Client) ValidateAccess(ctx context.Context) error {
+	var repo struct {
+		ID int64 `json:"id"`
+	}
+	if err := c.getJSON(ctx, c.repoPath(""), &repo); err != nil {
+		return fmt.Errorf("validate repository access: %w", err)
+	}
+	return nil
+}
+
+// apiCommit is a commit as returned by the compare and commit endpoints
+type apiCommit struct {
+	SHA    string `json:"sha"`
+	Commit struct {
+		Message string `json:"message"`
+		Author  struct {
+			Name string    `json:"name"`
+			Date time.Time `json:"date"`
+		} `json:"author"`
+		Verification *provider.CommitVerification `json:"verification"`
+	} `json:"commit"`
+	Author *struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	Parents []struct {
+		SHA string `json:"sha"`
+	} `json:"parents"`
+	Files []struct {
+		Filename string `json:"filename"`
+		Status   string `json:"status"`
+	} `json:"files"`
+}
+
+// GetCommitRange fetches the commits between two refs, oldest first
+func (c *Client) GetCommitRange(ctx context.Context, from, to string) ([]provider.CommitData, error) {
+	var comparison struct {
+		Commits []apiCommit `json:"commits"`
+	}
+	if err := c.getJSON(ctx, c.repoPath("/compare/"+url.PathEscape(from)+"..."+url.PathEscape(to)), &comparison); err != nil {
+		return nil, fmt.Errorf("compare commits: %w", err)
+	}
+
+	commits := make([]provider.CommitData, 0, len(comparison.Commits))
+	for _, listed := range comparison.Commits {
+		commit, err := c.GetCommitDetails(ctx, listed.SHA)
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, *commit)
+	}
+	return commits, nil
+}
+
+// GetCommitDetails fetches a commit with its changed files and patches
+func (c *Client) GetCommitDetails(ctx context.Context, sha string) (*provider.CommitData, error) {
+	var commit apiCommit
+	if err := c.getJSON(ctx, c.repoPath("/git/commits/"+sha)+"?stat=true&files=true", &commit); err != nil {
+		return nil, fmt.Errorf("get commit %s: %w", sha, err)
+	}
+
+	diff, err := c.get(ctx, c.repoPath("/git/commits/"+sha+".diff"))
+	if err != nil {
+		return nil, fmt.Errorf("get diff for %s: %w", sha, err)
+	}
+	patches := provider.SplitDiff(string(diff))
+
+	author := commit.Commit.Author.Name
+	if commit.Author != nil && commit.Author.Login != "" {
+		author = commit.Author.Login
+	}
+
+	data := &provider.CommitData{
+		SHA:          commit.SHA,
+		Message:      commit.Commit.Message,
+		Author:       author,
+		Date:         commit.Commit.Author.Date,
+		CoAuthors:    provider.CoAuthors(commit.Commit.Message),
+		Verification: commit.Commit.Verification,
+	}
+	for _, parent := range commit.Parents {
+		data.Parents = append(data.Parents, parent.SHA)
+	}
+	for _, file := range commit.Files {
+		patch := patches[file.Filename]
+		additions, deletions := countChangedLines(patch)
+		data.FilesChanged = append(data.FilesChanged, provider.FileChange{
+			Filename:  file.Filename,
+			Status:    file.Status,
+			Additions: additions,
+			Deletions: deletions,
+			Patch:     patch,
+		})
+		data.Stats.Additions += additions
+		data.Stats.Deletions += deletions
+	}
+	data.Stats.Total = data.Stats.Additions + data.Stats.Deletions
+
+	return data, nil
+}
+
+// countChangedLines counts added and removed lines in a file patch
+func countChangedLines(patch string) (additions, deletions int) {
+	for _, line := range strings.Split(patch, "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+		case strings.HasPrefix(line, "+"):
+			additions++
+		case strings.HasPrefix(line, "-"):
+			deletions++
+		}
+	}
+	return additions, deletions
+}
+
+// ListReleases fetches all releases
+func (c *Client) ListReleases(ctx context.Context) ([]provider.ReleaseInfo, error) {
+	var releases []provider.ReleaseInfo
+	for page := 1; ; page++ {
+		var batch []struct {
+			TagName     string    `json:"tag_name"`
+			Name        string    `json:"name"`
+			Body        string    `json:"body"`
+			Draft       bool      `json:"draft"`
+			Prerelease  bool      `json:"prerelease"`
+			CreatedAt   time.Time `json:"created_at"`
+			PublishedAt time.Time `json:"published_at"`
+			Author      struct {
+				Login string `json:"login"`
+			} `json:"author"`
+			Assets []struct {
+				Name        string `json:"name"`
+				Size        int64  `json:"size"`
+				DownloadURL string `json:"browser_download_url"`
+			} `json:"assets"`
+		}
+		if err := c.getJSON(ctx, c.pagePath("/releases", page), &batch); err != nil {
+			return nil, fmt.Errorf("list releases: %w", err)
+		}
+		for _, release := range batch {
+			var assets []provider.ReleaseAsset
+			for _, asset := range release.Assets {
+				assets = append(assets, provider.ReleaseAsset{Name: asset.Name, URL: asset.DownloadURL, Size: asset.Size})
+			}
+			releases = append(releases, provider.ReleaseInfo{
+				TagName:     release.TagName,
+				Name:        release.Name,
+				PublishedAt: release.PublishedAt,
+				CreatedAt:   release.CreatedAt,
+				Body:        release.Body,
+				Author:      release.Author.Login,
+				Draft:       release.Draft,
+				Prerelease:  release.Prerelease,
+				Assets:      assets,
+			})
+		}
+		if len(batch) < pageSize {
+			return releases, nil
+		}
+	}
+}
+
+// ListTags fetches all tags with the commits they point to
+func (c *Client) ListTags(ctx context.Context) ([]provider.TagInfo, error) {
+	var tags []provider.TagInfo
+	for page := 1; ; page++ {
+		var batch []struct {
+			Name    string `json:"name"`
+			ID      string `json:"id"`
+			Message string `json:"message"`
+			Commit  struct {
+				SHA     string    `json:"sha"`
+				Created time.Time `json:"created"`
+			} `json:"commit"`
+		}
+		if err := c.getJSON(ctx, c.pagePath("/tags", page), &batch); err != nil {
+			return nil, fmt.Errorf("list tags: %w", err)
+		}
+		for _, tag := range batch {
+			tags = append(tags, provider.TagInfo{
+				Name:       tag.Name,
+				SHA:        tag.ID,
+				CommitSHA:  tag.Commit.SHA,
+				CommitDate: tag.Commit.Created,
+				Message:    tag.Message,
+			})
+		}
+		if len(batch) < pageSize {
+			return tags, nil
+		}
+	}
+}
+
+// GetPRsBetween fetches the pull requests merged between two refs
+func (c *Client) GetPRsBetween(ctx context.Context, from, to string) ([]provider.PullRequestData, error) {
+	commits, err := c.GetCommitRange(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return c.extractPRs(ctx, commits)
+}
+
+// extractPRs scans commit messages for pull request references and fetches their details
+func (c *Client) extractPRs(ctx context.Context, commits []provider.CommitData) ([]provider.PullRequestData, error) {
+	seen := make(map[int]bool)
+	var prs []provider.PullRequestData
+
+	for _, commit := range commits {
+		matches := prRefRe.FindStringSubmatch(commit.Message)
+		if len(matches) < 2 {
+			continue
+		}
+		number, err := strconv.Atoi(matches[1])
+		if err != nil || seen[number] {
+			continue
+		}
+		seen[number] = true
+
+		pr, err := c.getPullRequest(ctx, number)
+		if err != nil {
+			return nil, err
+		}
+		prs = append(prs, *pr)
+	}
+
+	return prs, nil
+}
+
+// getPullRequest fetches a single pull request by number
+func (c *Client) getPullRequest(ctx context.Context, number int) (*provider.PullRequestData, error) {
+	var pr struct {
+		Number    int        `json:"number"`
+		Title     string     `json:"title"`
+		Body      string     `json:"body"`
+		HTMLURL   string     `json:"html_url"`
+		CreatedAt time.Time  `json:"created_at"`
+		MergedAt  *time.Time `json:"merged_at"`
+		User      struct {
+			Login string `json:"login"`
+		} `json:"user"`
+		Labels []struct {
+			Name string `json:"name"`
+		} `json:"labels"`
+	}
+	if err := c.getJSON(ctx, c.repoPath(fmt.Sprintf("/pulls/%d", number)), &pr); err != nil {
+		return nil, fmt.Errorf("get pull request #%d: %w", number, err)
+	}
+
+	data := &provider.PullRequestData{
+		Number:    pr.Number,
+		Title:     pr.Title,
+		Author:    pr.User.Login,
+		URL:       pr.HTMLURL,
+		Body:      pr.Body,
+		CreatedAt: pr.CreatedAt,
+	}
+	if pr.MergedAt != nil {
+		data.MergedAt = *pr.MergedAt
+	}
+	for _, label := range pr.Labels {
+		data.Labels = append(data.Labels, label.Name)
+	}
+	return data, nil
+}
+
+// GetTimelineReleases builds timeline releases for consecutive tags and
+// releases in a date range
+func (c *Client) GetTimelineReleases(ctx context.Context, from, to time.Time) ([]provider.TimelineRelease, error) {
+	tags, err := c.ListTags(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetch tags: %w", err)
+	}
+	releases, err := c.ListReleases(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetch releases: %w", err)
+	}
+
+	refs := c.tagFilter.Apply(provider.ReleaseRefsInRange(tags, releases, from, to))
+	if c.edges {
+		head, err := c.headCommit(ctx, to)
+		if err != nil {
+			return nil, err
+		}
+		refs = provider.WithBoundaries(refs, tags, c.tagFilter, from, head)
+	}
+	if len(refs) == 0 {
+		return nil, fmt.Errorf("%w between %s and %s",
+			provider.ErrNoReleases, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	}
+
+	return provider.BuildTimeline(refs, func(from, to string) ([]provider.CommitData, []provider.PullRequestData, error) {
+		commits, err := c.GetCommitRange(ctx, from, to)
+		if err != nil {
+			return nil, nil, err
+		}
+		prs, err := c.extractPRs(ctx, commits)
+		if err != nil {
+			return nil, nil, fmt.Errorf("extract PRs: %w", err)
+		}
+		return commits, prs, nil
+	})
+}
+
+// headCommit returns the newest commit on the default branch dated on or
+// before until, or a zero ref when there is none
+func (c *Client) headCommit(ctx context.Context, until time.Time) (provider.ReleaseRef, error) {
+	var commits []struct {
+		SHA     string    `json:"sha"`
+		Created time.Time `json:"created"`
+	}
+	apiURL := c.repoPath("/commits") + "?limit=1&stat=false&verification=false&files=false&until=" + url.QueryEscape(until.Format(time.RFC3339))
+	if err := c.getJSON(ctx, apiURL, &commits); err != nil {
+		return provider.ReleaseRef{}, fmt.Errorf("find head commit: %w", err)
+	}
+	if len(commits) == 0 {
+		return provider.ReleaseRef{}, nil
+	}
+	return provider.HeadRef(commits[0].SHA, commits[0].Created), nil
+}
+
+// HasCommitsBefore is not supported: the Gitea commits API cannot filter by
+// author and date
+func (c *Client) HasCommitsBefore(ctx context.Context, author string, before time.Time) (bool, error) {
+	return false, errors.ErrUnsupported
+}
+
+// CommitURL returns the web URL of a commit on the Gitea instance
+func (c *Client) CommitURL(sha string) string {
+	return fmt.Sprintf("%s/%s/%s/commit/%s", c.baseURL, c.owner, c.repo, sha)
+}
+
+// PullRequestURL returns the web URL of a pull request on the Gitea instance
+func (c *Client) PullRequestURL(number int) string {
+	return fmt.Sprintf("%s/%s/%s/pulls/%d", c.baseURL, c.owner, c.repo, number)
+}
+
+// CompareURL returns the web URL comparing two refs on the Gitea instance
+func (c *Client) CompareURL(from, to string) string {
+	return fmt.Sprintf("%s/%s/%s/compare/%s...%s", c.baseURL, c.owner, c.repo, from, to)
+}
+
+// repoPath returns the API URL of a path under the repository
+func (c *Client) repoPath(path string) string {
+	return fmt.Sprintf("%s/api/v1/repos/%s/%s%s", c.baseURL, url.PathEscape(c.owner), url.PathEscape(c.repo), path)
+}
+
+// pagePath returns the API URL of one page of a repository list endpoint
+func (c *Client) pagePath(path string, page int) string {
+	return fmt.Sprintf("%s?page=%d&limit=%d", c.repoPath(path), page, pageSize)
+}
+
+// getJSON fetches an API URL and decodes the JSON response into v
+func (c *Client) getJSON(ctx context.Context, apiURL string, v any) error {
+	body, err := c.get(ctx, apiURL)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+// get fetches an API URL with authentication and returns the response body
+func (c *Client) get(ctx context.Context, apiURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "token "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request %s: %w", apiURL, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("gitea API returned %s for %s", resp.Status, apiURL)
+	}
+	return body, nil
+}
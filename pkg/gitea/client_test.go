@@ -0,0 +1,49 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListTagsPagination(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/repos/acme/api/tags" || r.Header.Get("Authorization") != "token secret" {
+			http.NotFound(w, r)
+			return
+		}
+		var tags []map[string]any
+		if r.URL.Query().Get("page") == "1" {
+			for i := 0; i < pageSize; i++ {
+				tags = append(tags, map[string]any{"name": "t", "commit": map[string]any{"sha": "abc", "created": "2024-01-02T00:00:00Z"}})
+			}
+		} else {
+			tags = append(tags, map[string]any{"name": "v1.0.0", "commit": map[string]any{"sha": "def", "created": "2024-01-03T00:00:00Z"}})
+		}
+		json.NewEncoder(w).Encode(tags)
+	}))
+	defer server.Close()
+
+	tags, err := NewClient(server.URL+"/", "secret", "acme", "api").ListTags(context.Background())
+	if err != nil {
+		t.Fatalf("ListTags() error = %v", err)
+	}
+	if len(tags) != pageSize+1 || tags[pageSize].Name != "v1.0.0" || tags[pageSize].CommitDate.Day() != 3 {
+		t.Errorf("Unexpected tags: %d, last %+v", len(tags), tags[len(tags)-1])
+	}
+}
+
+func TestCountChangedLines(t *testing.T) {
+	additions, deletions := countChangedLines("--- a/x\n+++ b/x\n@@ -1 +1,2 @@\n-old\n+new\n+more\n")
+	if additions != 2 || deletions != 1 {
+		t.Errorf("countChangedLines() = %d, %d", additions, deletions)
+	}
+}
+
+func TestCommitURL(t *testing.T) {
+	if got := NewClient("https://codeberg.org/", "", "acme", "api").CommitURL("abc"); got != "https://codeberg.org/acme/api/commit/abc" {
+		t.Errorf("CommitURL() = %q", got)
+	}
+}
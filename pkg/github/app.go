@@ -0,0 +1,153 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v66/github"
+	"golang.org/x/oauth2"
+)
+
+// AppAuth identifies a GitHub App installation to authenticate as
+type AppAuth struct {
+	AppID          int64
+	PrivateKey     []byte // PEM-encoded RSA key downloaded from the app's settings
+	InstallationID int64  // 0 = look up the installation on the repository or organization
+}
+
+// NewAppClient creates a client that authenticates as a GitHub App
+// installation. Installation tokens are created on first use and renewed
+// shortly before they expire. Without a repo the installation is looked up on
+// the owner organization.
+func NewAppClient(auth AppAuth, owner, repo string) (*Client, error) {
+	key, err := parsePrivateKey(auth.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	apps := github.NewClient(&http.Client{Transport: &appTransport{appID: auth.AppID, key: key, base: http.DefaultTransport}})
+	source := &installationTokenSource{
+		apps:           apps,
+		installationID: auth.InstallationID,
+		owner:          owner,
+		repo:           repo,
+	}
+	tc := oauth2.NewClient(context.Background(), oauth2.ReuseTokenSource(nil, source))
+	return newClient(tc, owner, repo), nil
+}
+
+// parsePrivateKey decodes a PKCS#1 or PKCS#8 PEM-encoded RSA private key
+func parsePrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("parse GitHub App private key: no PEM block found")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse GitHub App private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("parse GitHub App private key: not an RSA key")
+	}
+	return key, nil
+}
+
+// appJWT returns the RS256-signed JSON Web Token that authenticates as the
+// app itself. It is backdated a minute against clock drift and valid for
+// nine minutes, under GitHub's ten-minute limit.
+func appJWT(appID int64, key *rsa.PrivateKey, now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]any{
+		"iat": now.Add(-time.Minute).Unix(),
+		"exp": now.Add(9 * time.Minute).Unix(),
+		"iss": strconv.FormatInt(appID, 10),
+	})
+	if err != nil {
+		return "", err
+	}
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(nil, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("sign GitHub App token: %w", err)
+	}
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// appTransport authenticates each request as the app with a fresh JWT
+type appTransport struct {
+	appID int64
+	key   *rsa.PrivateKey
+	base  http.RoundTripper
+}
+
+func (t *appTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := appJWT(t.appID, t.key, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return t.base.RoundTrip(req)
+}
+
+// installationTokenSource creates installation access tokens, finding the
+// installation on first use when its ID is not configured
+type installationTokenSource struct {
+	apps           *github.Client // Authenticated as the app
+	mu             sync.Mutex
+	installationID int64
+	owner          string
+	repo           string
+}
+
+func (s *installationTokenSource) Token() (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ctx := context.Background()
+	if s.installationID == 0 {
+		var installation *github.Installation
+		var err error
+		if s.repo != "" {
+			installation, _, err = s.apps.Apps.FindRepositoryInstallation(ctx, s.owner, s.repo)
+		} else {
+			installation, _, err = s.apps.Apps.FindOrganizationInstallation(ctx, s.owner)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("find GitHub App installation for %s: %w", s.target(), err)
+		}
+		s.installationID = installation.GetID()
+		logger.Info("found GitHub App installation", "target", s.target(), "installation", s.installationID)
+	}
+
+	token, _, err := s.apps.Apps.CreateInstallationToken(ctx, s.installationID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create GitHub App installation token: %w", err)
+	}
+	return &oauth2.Token{AccessToken: token.GetToken(), Expiry: token.GetExpiresAt().Time}, nil
+}
+
+func (s *installationTokenSource) target() string {
+	if s.repo == "" {
+		return s.owner
+	}
+	return s.owner + "/" + s.repo
+}
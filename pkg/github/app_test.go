@@ -0,0 +1,115 @@
+package github
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v66/github"
+)
+
+func TestAppJWT(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1700000000, 0)
+	token, err := appJWT(42, key, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("token has %d parts, want 3", len(parts))
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature); err != nil {
+		t.Fatalf("signature does not verify: %v", err)
+	}
+
+	payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	var claims struct {
+		IAT int64  `json:"iat"`
+		EXP int64  `json:"exp"`
+		ISS string `json:"iss"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		t.Fatal(err)
+	}
+	if claims.ISS != "42" || claims.IAT != now.Unix()-60 || claims.EXP != now.Unix()+540 {
+		t.Errorf("claims = %+v", claims)
+	}
+}
+
+func TestParsePrivateKeyFormats(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, block := range map[string]*pem.Block{
+		"pkcs1": {Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)},
+		"pkcs8": {Type: "PRIVATE KEY", Bytes: pkcs8},
+	} {
+		if _, err := parsePrivateKey(pem.EncodeToMemory(block)); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+	if _, err := parsePrivateKey([]byte("not a key")); err == nil {
+		t.Error("expected an error for a non-PEM key")
+	}
+}
+
+func TestInstallationTokenSourceFindsInstallation(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/repos/acme/api/installation":
+			json.NewEncoder(w).Encode(map[string]any{"id": 7})
+		case "/app/installations/7/access_tokens":
+			json.NewEncoder(w).Encode(map[string]any{"token": "ghs_installation", "expires_at": "2030-01-01T00:00:00Z"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	apps := github.NewClient(server.Client())
+	apps.BaseURL, _ = url.Parse(server.URL + "/")
+	source := &installationTokenSource{apps: apps, owner: "acme", repo: "api"}
+
+	token, err := source.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.AccessToken != "ghs_installation" || token.Expiry.Year() != 2030 {
+		t.Errorf("token = %+v", token)
+	}
+	if _, err := source.Token(); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"GET /repos/acme/api/installation", "POST /app/installations/7/access_tokens", "POST /app/installations/7/access_tokens"}
+	if strings.Join(paths, ",") != strings.Join(want, ",") {
+		t.Errorf("requests = %v, want %v", paths, want)
+	}
+}
@@ -3,63 +3,151 @@ package github
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"regexp"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/logging"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/semver"
 	"golang.org/x/oauth2"
 )
 
 var mergeCommitRe = regexp.MustCompile(`Merge pull request #(\d+)`)
 
+var logger = logging.Module("github")
+
+// Client implements the hosting provider interface
+var (
+	_ provider.Provider          = (*Client)(nil)
+	_ provider.PullRequestLinker = (*Client)(nil)
+)
+
+// ErrNoReleases is returned when a timeline contains no tags or releases
+var ErrNoReleases = provider.ErrNoReleases
+
 // Client wraps the GitHub API client
 type Client struct {
-	client *github.Client
-	owner  string
-	repo   string
-	ctx    context.Context
+	client     *github.Client
+	httpClient *http.Client // Authenticated client for GraphQL requests
+	graphqlURL string
+	owner      string
+	repo       string
+	branch     string // Restricts HEAD and tag discovery to this branch (empty = default branch)
+	fastFetch  bool   // Fetch commits in GraphQL batches without file changes
+	reviews    bool   // Fetch review stats for pull requests
+	maxCommits int    // Refuse ranges with more commits than this (0 = unlimited)
+
+	tagFilter provider.TagFilter // Which tags and releases timelines are built from
+	edges     bool               // Extend timelines to the tag before the window and the untagged head
 }
 
 // NewClient creates a new GitHub client
 func NewClient(token, owner, repo string) *Client {
-	ctx := context.Background()
 	ts := oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: token},
 	)
-	tc := oauth2.NewClient(ctx, ts)
-	client := github.NewClient(tc)
+	tc := oauth2.NewClient(context.Background(), ts)
+	return newClient(tc, owner, repo)
+}
 
+// newClient creates a client whose requests go through the authenticated tc
+func newClient(tc *http.Client, owner, repo string) *Client {
 	return &Client{
-		client: client,
-		owner:  owner,
-		repo:   repo,
-		ctx:    ctx,
+		client:     github.NewClient(tc),
+		httpClient: tc,
+		graphqlURL: defaultGraphQLURL,
+		owner:      owner,
+		repo:       repo,
 	}
 }
 
-// GetCommitRange fetches all commits between two refs
-func (c *Client) GetCommitRange(from, to string) ([]CommitData, error) {
-	// Use GitHub's compare API to get commits between refs
+// ForRepo returns a client for another repository of the same owner,
+// sharing the authenticated HTTP client
+func (c *Client) ForRepo(repo string) *Client {
+	clone := *c
+	clone.repo = repo
+	return &clone
+}
+
+// SetBranch restricts latest-tag resolution and timeline tag discovery to
+// tags reachable from branch
+func (c *Client) SetBranch(branch string) {
+	c.branch = branch
+}
+
+// SetTagFilter restricts timeline discovery to the tags and releases that
+// pass filter
+func (c *Client) SetTagFilter(filter provider.TagFilter) {
+	c.tagFilter = filter
+}
+
+// SetIncludeBoundaries makes timelines start at the newest tag before the
+// window and end at the newest untagged commit in it, so commits at the
+// edges are not skipped
+func (c *Client) SetIncludeBoundaries(include bool) {
+	c.edges = include
+}
+
+// SetFastFetch makes GetCommitRange fetch commit messages, authors, and stats
+// in GraphQL batches instead of one REST request per commit. GitHub's GraphQL
+// API does not expose a commit's changed files, so commits carry no file
+// changes or patches in this mode.
+func (c *Client) SetFastFetch(fast bool) {
+	c.fastFetch = fast
+}
+
+// SetReviewStats makes GetPullRequest also fetch approval, change request,
+// and comment counts
+func (c *Client) SetReviewStats(enabled bool) {
+	c.reviews = enabled
+}
+
+// SetMaxCommits makes GetCommitRange fail for ranges with more than max
+// commits instead of fetching them all (0 = unlimited)
+func (c *Client) SetMaxCommits(max int) {
+	c.maxCommits = max
+}
+
+// IsReachable reports whether ref is an ancestor of (or equal to) branch
+func (c *Client) IsReachable(ctx context.Context, ref, branch string) (bool, error) {
 	comparison, _, err := c.client.Repositories.CompareCommits(
-		c.ctx,
+		ctx,
 		c.owner,
 		c.repo,
-		from,
-		to,
-		&github.ListOptions{PerPage: 250},
+		ref,
+		branch,
+		&github.ListOptions{PerPage: 1},
 	)
 	if err != nil {
-		return nil, fmt.Errorf("compare commits: %w", err)
+		return false, fmt.Errorf("compare %s with %s: %w", ref, branch, err)
+	}
+	status := comparison.GetStatus()
+	return status == "ahead" || status == "identical", nil
+}
+
+// GetCommitRange fetches all commits between two refs
+func (c *Client) GetCommitRange(ctx context.Context, from, to string) ([]CommitData, error) {
+	shas, err := c.compareSHAs(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.fastFetch {
+		logger.Debug("fetching commits via GraphQL", "commits", len(shas))
+		return c.getCommitsBatched(ctx, shas)
 	}
 
 	var commits []CommitData
-	for _, commit := range comparison.Commits {
+	for _, sha := range shas {
 		// Get full commit details including diffs
-		fullCommit, err := c.GetCommitDetails(commit.GetSHA())
+		fullCommit, err := c.GetCommitDetails(ctx, sha)
 		if err != nil {
-			return nil, fmt.Errorf("get commit details for %s: %w", commit.GetSHA(), err)
+			return nil, fmt.Errorf("get commit details for %s: %w", sha, err)
 		}
 		commits = append(commits, *fullCommit)
 	}
@@ -67,10 +155,45 @@ func (c *Client) GetCommitRange(from, to string) ([]CommitData, error) {
 	return commits, nil
 }
 
+// compareSHAs lists the SHAs of every commit between two refs, oldest first,
+// following the compare API's pagination
+func (c *Client) compareSHAs(ctx context.Context, from, to string) ([]string, error) {
+	var shas []string
+	total := 0
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		comparison, resp, err := c.client.Repositories.CompareCommits(ctx, c.owner, c.repo, from, to, opts)
+		if err != nil {
+			return nil, fmt.Errorf("compare commits: %w", err)
+		}
+
+		total = comparison.GetTotalCommits()
+		if c.maxCommits > 0 && total > c.maxCommits {
+			return nil, fmt.Errorf("range %s..%s has %d commits, more than --max-commits=%d (narrow the range or raise the limit)",
+				from, to, total, c.maxCommits)
+		}
+
+		for _, commit := range comparison.Commits {
+			shas = append(shas, commit.GetSHA())
+		}
+		if resp.NextPage == 0 || len(comparison.Commits) == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	if len(shas) < total {
+		logger.Warn("compare returned fewer commits than the range holds; the changelog will be incomplete",
+			"from", from, "to", to, "returned", len(shas), "total", total)
+	}
+	logger.Debug("compared refs", "from", from, "to", to, "commits", len(shas))
+	return shas, nil
+}
+
 // GetCommitDetails fetches full details for a single commit
-func (c *Client) GetCommitDetails(sha string) (*CommitData, error) {
+func (c *Client) GetCommitDetails(ctx context.Context, sha string) (*CommitData, error) {
 	commit, _, err := c.client.Repositories.GetCommit(
-		c.ctx,
+		ctx,
 		c.owner,
 		c.repo,
 		sha,
@@ -82,9 +205,10 @@ func (c *Client) GetCommitDetails(sha string) (*CommitData, error) {
 
 	// Extract commit data
 	commitData := &CommitData{
-		SHA:     commit.GetSHA(),
-		Message: commit.GetCommit().GetMessage(),
-		Date:    commit.GetCommit().GetAuthor().GetDate().Time,
+		SHA:       commit.GetSHA(),
+		Message:   commit.GetCommit().GetMessage(),
+		Date:      commit.GetCommit().GetAuthor().GetDate().Time,
+		CoAuthors: provider.CoAuthors(commit.GetCommit().GetMessage()),
 		Stats: CommitStats{
 			Additions: commit.GetStats().GetAdditions(),
 			Deletions: commit.GetStats().GetDeletions(),
@@ -98,6 +222,15 @@ func (c *Client) GetCommitDetails(sha string) (*CommitData, error) {
 	} else if commit.GetCommit().GetAuthor() != nil {
 		commitData.Author = commit.GetCommit().GetAuthor().GetName()
 	}
+	for _, parent := range commit.Parents {
+		commitData.Parents = append(commitData.Parents, parent.GetSHA())
+	}
+	if verification := commit.GetCommit().GetVerification(); verification != nil {
+		commitData.Verification = &provider.CommitVerification{
+			Verified: verification.GetVerified(),
+			Reason:   verification.GetReason(),
+		}
+	}
 
 	// Extract file changes
 	for _, file := range commit.Files {
@@ -114,23 +247,350 @@ func (c *Client) GetCommitDetails(sha string) (*CommitData, error) {
 	return commitData, nil
 }
 
-// ValidateAccess checks if the client has access to the repository
-func (c *Client) ValidateAccess() error {
-	_, _, err := c.client.Repositories.Get(c.ctx, c.owner, c.repo)
+// HasCommitsBefore reports whether author has any commit in the repository
+// dated before the given time (used to detect first-time contributors)
+func (c *Client) HasCommitsBefore(ctx context.Context, author string, before time.Time) (bool, error) {
+	commits, _, err := c.client.Repositories.ListCommits(
+		ctx,
+		c.owner,
+		c.repo,
+		&github.CommitsListOptions{
+			Author:      author,
+			Until:       before,
+			ListOptions: github.ListOptions{PerPage: 1},
+		},
+	)
+	if err != nil {
+		return false, fmt.Errorf("list commits by %s: %w", author, err)
+	}
+	return len(commits) > 0, nil
+}
+
+// ListOrgRepos returns the names of the owner organization's non-archived
+// repositories, sorted alphabetically
+func (c *Client) ListOrgRepos(ctx context.Context) ([]string, error) {
+	var names []string
+	opts := &github.RepositoryListByOrgOptions{
+		Type:        "all",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	for {
+		repos, resp, err := c.client.Repositories.ListByOrg(ctx, c.owner, opts)
+		if err != nil {
+			return nil, fmt.Errorf("list repositories for %s: %w", c.owner, checkSSO(err))
+		}
+		for _, repo := range repos {
+			if repo.GetArchived() {
+				continue
+			}
+			names = append(names, repo.GetName())
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	sort.Strings(names)
+	return names, nil
+}
+
+// DefaultBranch returns the repository's default branch name
+func (c *Client) DefaultBranch(ctx context.Context) (string, error) {
+	repo, _, err := c.client.Repositories.Get(ctx, c.owner, c.repo)
+	if err != nil {
+		return "", fmt.Errorf("get repository: %w", err)
+	}
+	return repo.GetDefaultBranch(), nil
+}
+
+// ResolveSHA returns the commit SHA a branch, tag, or SHA currently points at
+func (c *Client) ResolveSHA(ctx context.Context, ref string) (string, error) {
+	sha, _, err := c.client.Repositories.GetCommitSHA1(ctx, c.owner, c.repo, ref, "")
+	if err != nil {
+		return "", fmt.Errorf("resolve %s: %w", ref, err)
+	}
+	return sha, nil
+}
+
+// Languages returns the bytes of code per language reported by GitHub
+func (c *Client) Languages(ctx context.Context) (map[string]int, error) {
+	languages, _, err := c.client.Repositories.ListLanguages(ctx, c.owner, c.repo)
+	if err != nil {
+		return nil, fmt.Errorf("list languages: %w", err)
+	}
+	return languages, nil
+}
+
+// FileContent returns a file from the default branch, or nil when it does not exist
+func (c *Client) FileContent(ctx context.Context, path string) ([]byte, error) {
+	file, _, resp, err := c.client.Repositories.GetContents(ctx, c.owner, c.repo, path, nil)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get %s: %w", path, err)
+	}
+	if file == nil {
+		return nil, nil // A directory
+	}
+	content, err := file.GetContent()
+	if err != nil {
+		return nil, fmt.Errorf("decode %s: %w", path, err)
+	}
+	return []byte(content), nil
+}
+
+// ListSecurityAdvisories fetches the repository's published security advisories
+func (c *Client) ListSecurityAdvisories(ctx context.Context) ([]provider.SecurityAdvisory, error) {
+	var advisories []provider.SecurityAdvisory
+	opts := &github.ListRepositorySecurityAdvisoriesOptions{State: "published"}
+	for {
+		page, resp, err := c.client.SecurityAdvisories.ListRepositorySecurityAdvisories(ctx, c.owner, c.repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("list security advisories: %w", err)
+		}
+		for _, advisory := range page {
+			var patched []string
+			for _, vulnerability := range advisory.Vulnerabilities {
+				if versions := vulnerability.GetPatchedVersions(); versions != "" {
+					patched = append(patched, versions)
+				}
+			}
+			advisories = append(advisories, provider.SecurityAdvisory{
+				ID:              advisory.GetGHSAID(),
+				CVE:             advisory.GetCVEID(),
+				Severity:        advisory.GetSeverity(),
+				Summary:         advisory.GetSummary(),
+				URL:             advisory.GetHTMLURL(),
+				PatchedVersions: strings.Join(patched, ", "),
+			})
+		}
+		if resp.After == "" {
+			break
+		}
+		opts.After = resp.After
+	}
+	return advisories, nil
+}
+
+// ListTagNames fetches the names of all tags without resolving their commits
+func (c *Client) ListTagNames(ctx context.Context) ([]string, error) {
+	var names []string
+	opts := &github.ListOptions{PerPage: 100}
+
+	for {
+		tags, resp, err := c.client.Repositories.ListTags(ctx, c.owner, c.repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("list tags: %w", err)
+		}
+		for _, tag := range tags {
+			names = append(names, tag.GetName())
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return names, nil
+}
+
+// LatestTag returns the highest semver tag in the repository. When no tag
+// parses as a version, it falls back to the tag with the newest commit date.
+// With a branch set, only tags reachable from that branch are considered.
+func (c *Client) LatestTag(ctx context.Context, includePrereleases bool) (string, error) {
+	names, err := c.ListTagNames(ctx)
 	if err != nil {
-		return fmt.Errorf("validate repository access: %w", err)
+		return "", err
+	}
+	if len(names) == 0 {
+		return "", fmt.Errorf("repository %s/%s has no tags", c.owner, c.repo)
+	}
+
+	var candidates []string
+	if versions := semver.Sort(names, includePrereleases); len(versions) > 0 {
+		for _, v := range versions {
+			candidates = append(candidates, v.Original)
+		}
+	} else {
+		tags, err := c.ListTags(ctx)
+		if err != nil {
+			return "", err
+		}
+		sort.Slice(tags, func(i, j int) bool {
+			return tags[i].CommitDate.After(tags[j].CommitDate)
+		})
+		for _, tag := range tags {
+			candidates = append(candidates, tag.Name)
+		}
+	}
+
+	if c.branch == "" {
+		return candidates[0], nil
+	}
+	for _, candidate := range candidates {
+		reachable, err := c.IsReachable(ctx, candidate, c.branch)
+		if err != nil {
+			return "", err
+		}
+		if reachable {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("no tags reachable from branch %s", c.branch)
+}
+
+// ResolveReleaseAlias resolves "latest" and "previous" to the tag names of the
+// most recent and second most recent published releases, only counting those
+// reachable from the branch set with SetBranch. Any other ref is returned
+// unchanged. Prereleases are only considered when includePrereleases is set.
+func (c *Client) ResolveReleaseAlias(ctx context.Context, ref string, includePrereleases bool) (string, error) {
+	var index int
+	switch ref {
+	case "latest":
+		index = 0
+	case "previous":
+		index = 1
+	default:
+		return ref, nil
+	}
+
+	releases, err := c.ListReleases(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var published []ReleaseInfo
+	for _, release := range releases {
+		if release.Draft || (release.Prerelease && !includePrereleases) {
+			continue
+		}
+		published = append(published, release)
+	}
+	sort.Slice(published, func(i, j int) bool {
+		return published[i].PublishedAt.After(published[j].PublishedAt)
+	})
+
+	if c.branch == "" {
+		if index >= len(published) {
+			return "", fmt.Errorf("cannot resolve %q: repository has %d published releases", ref, len(published))
+		}
+		return published[index].TagName, nil
+	}
+
+	found := 0
+	for _, release := range published {
+		reachable, err := c.IsReachable(ctx, release.TagName, c.branch)
+		if err != nil {
+			return "", err
+		}
+		if !reachable {
+			continue
+		}
+		if found == index {
+			return release.TagName, nil
+		}
+		found++
+	}
+	return "", fmt.Errorf("cannot resolve %q: branch %s has %d published releases", ref, c.branch, found)
+}
+
+// ReleaseNotes returns the body of the GitHub release for tag, or "" when
+// the tag has no release
+func (c *Client) ReleaseNotes(ctx context.Context, tag string) (string, error) {
+	release, resp, err := c.client.Repositories.GetReleaseByTag(ctx, c.owner, c.repo, tag)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("get release %s: %w", tag, err)
+	}
+	return release.GetBody(), nil
+}
+
+// UpsertReleaseNotes sets the body of the GitHub release for tag, creating
+// the release when the tag has none
+func (c *Client) UpsertReleaseNotes(ctx context.Context, tag, body string) error {
+	release, resp, err := c.client.Repositories.GetReleaseByTag(ctx, c.owner, c.repo, tag)
+	if err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+		return fmt.Errorf("get release %s: %w", tag, err)
+	}
+
+	if release == nil {
+		_, _, err = c.client.Repositories.CreateRelease(ctx, c.owner, c.repo, &github.RepositoryRelease{
+			TagName: github.String(tag),
+			Name:    github.String(tag),
+			Body:    github.String(body),
+		})
+		if err != nil {
+			return fmt.Errorf("create release %s: %w", tag, err)
+		}
+		return nil
+	}
+
+	_, _, err = c.client.Repositories.EditRelease(ctx, c.owner, c.repo, release.GetID(), &github.RepositoryRelease{
+		Body: github.String(body),
+	})
+	if err != nil {
+		return fmt.Errorf("update release %s: %w", tag, err)
 	}
 	return nil
 }
 
-// ListAllTags fetches all tags from the repository with pagination
-func (c *Client) ListAllTags() ([]TagInfo, error) {
+// EditableRelease returns the ID and body of the release for tag. Drafts
+// have no tag lookup, so they are searched among the listed releases. It
+// fails when the tag has neither a release nor a draft.
+func (c *Client) EditableRelease(ctx context.Context, tag string) (int64, string, error) {
+	release, resp, err := c.client.Repositories.GetReleaseByTag(ctx, c.owner, c.repo, tag)
+	if err == nil {
+		return release.GetID(), release.GetBody(), nil
+	}
+	if resp == nil || resp.StatusCode != http.StatusNotFound {
+		return 0, "", fmt.Errorf("get release %s: %w", tag, err)
+	}
+
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		releases, resp, err := c.client.Repositories.ListReleases(ctx, c.owner, c.repo, opts)
+		if err != nil {
+			return 0, "", fmt.Errorf("list releases: %w", err)
+		}
+		for _, release := range releases {
+			if release.GetDraft() && release.GetTagName() == tag {
+				return release.GetID(), release.GetBody(), nil
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return 0, "", fmt.Errorf("no GitHub release or draft for tag %s", tag)
+}
+
+// SetReleaseBody replaces the body of the release with the given ID
+func (c *Client) SetReleaseBody(ctx context.Context, id int64, body string) error {
+	_, _, err := c.client.Repositories.EditRelease(ctx, c.owner, c.repo, id, &github.RepositoryRelease{
+		Body: github.String(body),
+	})
+	if err != nil {
+		return fmt.Errorf("update release %d: %w", id, err)
+	}
+	return nil
+}
+
+// ListTags fetches all tags from the repository with pagination
+func (c *Client) ListTags(ctx context.Context) ([]TagInfo, error) {
 	var allTags []TagInfo
 	opts := &github.ListOptions{PerPage: 100}
 
 	for {
 		tags, resp, err := c.client.Repositories.ListTags(
-			c.ctx,
+			ctx,
 			c.owner,
 			c.repo,
 			opts,
@@ -142,7 +602,7 @@ func (c *Client) ListAllTags() ([]TagInfo, error) {
 		for _, tag := range tags {
 			// Get commit details to extract date
 			commit, _, err := c.client.Repositories.GetCommit(
-				c.ctx,
+				ctx,
 				c.owner,
 				c.repo,
 				tag.GetCommit().GetSHA(),
@@ -171,14 +631,14 @@ func (c *Client) ListAllTags() ([]TagInfo, error) {
 	return allTags, nil
 }
 
-// ListAllReleases fetches all GitHub releases with pagination
-func (c *Client) ListAllReleases() ([]ReleaseInfo, error) {
+// ListReleases fetches all GitHub releases with pagination
+func (c *Client) ListReleases(ctx context.Context) ([]ReleaseInfo, error) {
 	var allReleases []ReleaseInfo
 	opts := &github.ListOptions{PerPage: 100}
 
 	for {
 		releases, resp, err := c.client.Repositories.ListReleases(
-			c.ctx,
+			ctx,
 			c.owner,
 			c.repo,
 			opts,
@@ -198,6 +658,13 @@ func (c *Client) ListAllReleases() ([]ReleaseInfo, error) {
 				Draft:       release.GetDraft(),
 				Prerelease:  release.GetPrerelease(),
 			}
+			for _, asset := range release.Assets {
+				releaseInfo.Assets = append(releaseInfo.Assets, provider.ReleaseAsset{
+					Name: asset.GetName(),
+					URL:  asset.GetBrowserDownloadURL(),
+					Size: int64(asset.GetSize()),
+				})
+			}
 			allReleases = append(allReleases, releaseInfo)
 		}
 
@@ -210,73 +677,51 @@ func (c *Client) ListAllReleases() ([]ReleaseInfo, error) {
 	return allReleases, nil
 }
 
-// GetReleaseRefsInTimeline discovers all tags and releases within a date range
+// GetReleaseRefsInTimeline discovers the tags and releases within a date
+// range that pass the tag filter
 // Returns deduplicated, sorted list of release references
-func (c *Client) GetReleaseRefsInTimeline(from, to time.Time) ([]ReleaseRef, error) {
-	// Fetch tags
-	tags, err := c.ListAllTags()
+func (c *Client) GetReleaseRefsInTimeline(ctx context.Context, from, to time.Time) ([]ReleaseRef, error) {
+	tags, err := c.ListTags(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("fetch tags: %w", err)
 	}
 
-	// Fetch releases
-	releases, err := c.ListAllReleases()
+	releases, err := c.ListReleases(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("fetch releases: %w", err)
 	}
 
-	// Convert to unified ReleaseRef format
-	refMap := make(map[string]ReleaseRef) // Map to deduplicate by name
-
-	// Add tags
-	for _, tag := range tags {
-		if (tag.CommitDate.Equal(from) || tag.CommitDate.After(from)) &&
-			(tag.CommitDate.Equal(to) || tag.CommitDate.Before(to)) {
-			refMap[tag.Name] = ReleaseRef{
-				Name:         tag.Name,
-				Date:         tag.CommitDate,
-				Type:         "tag",
-				IsPrerelease: false,
-			}
-		}
-	}
-
-	// Add releases (may override tags with same name, prioritizing release data)
-	for _, release := range releases {
-		if release.Draft {
-			continue // Skip draft releases
-		}
-
-		pubDate := release.PublishedAt
-		if (pubDate.Equal(from) || pubDate.After(from)) &&
-			(pubDate.Equal(to) || pubDate.Before(to)) {
-			// If tag exists with same name, update with release info
-			refMap[release.TagName] = ReleaseRef{
-				Name:         release.TagName,
-				Date:         pubDate,
-				Type:         "release",
-				IsPrerelease: release.Prerelease,
-			}
-		}
+	refs := c.tagFilter.Apply(provider.ReleaseRefsInRange(tags, releases, from, to))
+	if !c.edges {
+		return refs, nil
 	}
-
-	// Convert map to slice
-	var refs []ReleaseRef
-	for _, ref := range refMap {
-		refs = append(refs, ref)
+	head, err := c.headCommit(ctx, to)
+	if err != nil {
+		return nil, err
 	}
+	return provider.WithBoundaries(refs, tags, c.tagFilter, from, head), nil
+}
 
-	// Sort by date ascending
-	sort.Slice(refs, func(i, j int) bool {
-		return refs[i].Date.Before(refs[j].Date)
+// headCommit returns the newest commit on the selected (or default) branch
+// dated on or before until, or a zero ref when there is none
+func (c *Client) headCommit(ctx context.Context, until time.Time) (ReleaseRef, error) {
+	commits, _, err := c.client.Repositories.ListCommits(ctx, c.owner, c.repo, &github.CommitsListOptions{
+		SHA:         c.branch,
+		Until:       until,
+		ListOptions: github.ListOptions{PerPage: 1},
 	})
-
-	return refs, nil
+	if err != nil {
+		return ReleaseRef{}, fmt.Errorf("find head commit: %w", err)
+	}
+	if len(commits) == 0 {
+		return ReleaseRef{}, nil
+	}
+	return provider.HeadRef(commits[0].GetSHA(), commits[0].GetCommit().GetCommitter().GetDate().Time), nil
 }
 
 // GetPullRequest fetches details for a single pull request by number
-func (c *Client) GetPullRequest(number int) (*PullRequestData, error) {
-	pr, _, err := c.client.PullRequests.Get(c.ctx, c.owner, c.repo, number)
+func (c *Client) GetPullRequest(ctx context.Context, number int) (*PullRequestData, error) {
+	pr, _, err := c.client.PullRequests.Get(ctx, c.owner, c.repo, number)
 	if err != nil {
 		return nil, fmt.Errorf("get pull request #%d: %w", number, err)
 	}
@@ -286,18 +731,55 @@ func (c *Client) GetPullRequest(number int) (*PullRequestData, error) {
 		labels = append(labels, label.GetName())
 	}
 
-	return &PullRequestData{
-		Number: pr.GetNumber(),
-		Title:  pr.GetTitle(),
-		Author: pr.GetUser().GetLogin(),
-		URL:    pr.GetHTMLURL(),
-		Body:   pr.GetBody(),
-		Labels: labels,
-	}, nil
+	data := &PullRequestData{
+		Number:    pr.GetNumber(),
+		Title:     pr.GetTitle(),
+		Author:    pr.GetUser().GetLogin(),
+		URL:       pr.GetHTMLURL(),
+		Body:      pr.GetBody(),
+		Labels:    labels,
+		CreatedAt: pr.GetCreatedAt().Time,
+		MergedAt:  pr.GetMergedAt().Time,
+	}
+
+	if c.reviews {
+		stats, err := c.getReviewStats(ctx, number)
+		if err != nil {
+			return nil, err
+		}
+		stats.Comments = pr.GetComments() + pr.GetReviewComments()
+		data.Reviews = stats
+	}
+
+	return data, nil
+}
+
+// getReviewStats counts the approvals and change requests on a pull request
+func (c *Client) getReviewStats(ctx context.Context, number int) (*provider.ReviewStats, error) {
+	stats := &provider.ReviewStats{}
+	opts := &github.ListOptions{PerPage: 100}
+	for {
+		reviews, resp, err := c.client.PullRequests.ListReviews(ctx, c.owner, c.repo, number, opts)
+		if err != nil {
+			return nil, fmt.Errorf("list reviews for #%d: %w", number, err)
+		}
+		for _, review := range reviews {
+			switch review.GetState() {
+			case "APPROVED":
+				stats.Approvals++
+			case "CHANGES_REQUESTED":
+				stats.ChangesRequested++
+			}
+		}
+		if resp.NextPage == 0 {
+			return stats, nil
+		}
+		opts.Page = resp.NextPage
+	}
 }
 
 // ExtractPRsFromCommits scans merge commit messages for PR numbers and fetches their details
-func (c *Client) ExtractPRsFromCommits(commits []CommitData) ([]PullRequestData, error) {
+func (c *Client) ExtractPRsFromCommits(ctx context.Context, commits []CommitData) ([]PullRequestData, error) {
 	seen := make(map[int]bool)
 	var prs []PullRequestData
 
@@ -315,7 +797,7 @@ func (c *Client) ExtractPRsFromCommits(commits []CommitData) ([]PullRequestData,
 		}
 		seen[prNumber] = true
 
-		pr, err := c.GetPullRequest(prNumber)
+		pr, err := c.GetPullRequest(ctx, prNumber)
 		if err != nil {
 			return nil, err
 		}
@@ -325,47 +807,68 @@ func (c *Client) ExtractPRsFromCommits(commits []CommitData) ([]PullRequestData,
 	return prs, nil
 }
 
+// GetPRsBetween fetches the pull requests merged between two refs
+func (c *Client) GetPRsBetween(ctx context.Context, from, to string) ([]PullRequestData, error) {
+	commits, err := c.GetCommitRange(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return c.ExtractPRsFromCommits(ctx, commits)
+}
+
+// CommitURL returns the github.com URL of a commit
+func (c *Client) CommitURL(sha string) string {
+	return fmt.Sprintf("https://github.com/%s/%s/commit/%s", c.owner, c.repo, sha)
+}
+
+// PullRequestURL returns the github.com URL of a pull request
+func (c *Client) PullRequestURL(number int) string {
+	return fmt.Sprintf("https://github.com/%s/%s/pull/%d", c.owner, c.repo, number)
+}
+
+// CompareURL returns the github.com URL comparing two refs
+func (c *Client) CompareURL(from, to string) string {
+	return fmt.Sprintf("https://github.com/%s/%s/compare/%s...%s", c.owner, c.repo, from, to)
+}
+
 // GetTimelineReleases builds TimelineRelease objects for consecutive ref pairs
-func (c *Client) GetTimelineReleases(from, to time.Time) ([]TimelineRelease, error) {
+func (c *Client) GetTimelineReleases(ctx context.Context, from, to time.Time) ([]TimelineRelease, error) {
 	// Get all release refs in timeline
-	refs, err := c.GetReleaseRefsInTimeline(from, to)
+	refs, err := c.GetReleaseRefsInTimeline(ctx, from, to)
 	if err != nil {
 		return nil, err
 	}
 
+	// Only keep tags on the selected branch's history
+	if c.branch != "" {
+		var onBranch []ReleaseRef
+		for _, ref := range refs {
+			reachable, err := c.IsReachable(ctx, ref.Name, c.branch)
+			if err != nil {
+				return nil, err
+			}
+			if reachable {
+				onBranch = append(onBranch, ref)
+			}
+		}
+		refs = onBranch
+	}
+
 	if len(refs) == 0 {
-		return nil, fmt.Errorf("no tags or releases found between %s and %s",
-			from.Format("2006-01-02"), to.Format("2006-01-02"))
+		return nil, fmt.Errorf("%w between %s and %s",
+			ErrNoReleases, from.Format("2006-01-02"), to.Format("2006-01-02"))
 	}
 
 	// Build timeline releases from consecutive pairs
-	var timelineReleases []TimelineRelease
-	for i := 0; i < len(refs)-1; i++ {
-		fromRef := refs[i]
-		toRef := refs[i+1]
-
-		// Fetch commits between these refs
-		commits, err := c.GetCommitRange(fromRef.Name, toRef.Name)
+	return provider.BuildTimeline(refs, func(from, to string) ([]CommitData, []PullRequestData, error) {
+		commits, err := c.GetCommitRange(ctx, from, to)
 		if err != nil {
-			return nil, fmt.Errorf("get commits %s..%s: %w", fromRef.Name, toRef.Name, err)
+			return nil, nil, err
 		}
-
-		// Extract PRs from merge commits
-		prs, err := c.ExtractPRsFromCommits(commits)
+		prs, err := c.ExtractPRsFromCommits(ctx, commits)
 		if err != nil {
-			return nil, fmt.Errorf("extract PRs %s..%s: %w", fromRef.Name, toRef.Name, err)
+			return nil, nil, fmt.Errorf("extract PRs: %w", err)
 		}
-
-		timelineReleases = append(timelineReleases, TimelineRelease{
-			FromRef:      fromRef.Name,
-			ToRef:        toRef.Name,
-			FromDate:     fromRef.Date,
-			ToDate:       toRef.Date,
-			CommitCount:  len(commits),
-			Commits:      commits,
-			PullRequests: prs,
-		})
-	}
-
-	return timelineReleases, nil
+		return commits, prs, nil
+	})
 }
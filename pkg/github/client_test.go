@@ -0,0 +1,275 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v66/github"
+)
+
+// newTestClient returns a client whose REST calls go to server
+func newTestClient(t *testing.T, server *httptest.Server) *Client {
+	t.Helper()
+	api := github.NewClient(server.Client())
+	baseURL, err := url.Parse(server.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	api.BaseURL = baseURL
+	return &Client{client: api, owner: "acme", repo: "api"}
+}
+
+func TestCompareSHAsPaginates(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		commits := []map[string]string{{"sha": "a"}, {"sha": "b"}}
+		if page == "2" {
+			commits = []map[string]string{{"sha": "c"}}
+		} else {
+			w.Header().Set("Link", fmt.Sprintf(`<%s%s?page=2>; rel="next"`, server.URL, r.URL.Path))
+		}
+		json.NewEncoder(w).Encode(map[string]any{"total_commits": 3, "commits": commits})
+	}))
+	defer server.Close()
+
+	client := newTestClient(t, server)
+	shas, err := client.compareSHAs(context.Background(), "v1", "v2")
+	if err != nil {
+		t.Fatalf("compareSHAs() error = %v", err)
+	}
+	if strings.Join(shas, ",") != "a,b,c" {
+		t.Errorf("compareSHAs() = %v, want [a b c]", shas)
+	}
+
+	client.SetMaxCommits(2)
+	if _, err := client.compareSHAs(context.Background(), "v1", "v2"); err == nil || !strings.Contains(err.Error(), "--max-commits=2") {
+		t.Errorf("Expected max commits error, got %v", err)
+	}
+}
+
+func TestEditableReleaseFindsDraft(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/acme/api/releases/tags/v2.0.0":
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(map[string]any{"message": "Not Found"})
+		case "/repos/acme/api/releases":
+			json.NewEncoder(w).Encode([]map[string]any{
+				{"id": 1, "tag_name": "v1.0.0", "body": "old"},
+				{"id": 2, "tag_name": "v2.0.0", "draft": true, "body": "draft notes"},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	id, body, err := newTestClient(t, server).EditableRelease(context.Background(), "v2.0.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != 2 || body != "draft notes" {
+		t.Errorf("got release %d with body %q", id, body)
+	}
+	if _, _, err := newTestClient(t, server).EditableRelease(context.Background(), "v3.0.0"); err == nil {
+		t.Error("expected an error for a tag without a release")
+	}
+}
+
+// fakeRepo is a repository served by fakeRepoServer. Tags compare with the
+// branch main by their status ("ahead" when missing, i.e. on main); other
+// branches do not exist.
+type fakeRepo struct {
+	tags     []string          // Tag names, each tagging the commit sha-<tag>
+	dates    map[string]string // Commit date of each tag, RFC 3339
+	releases []map[string]any
+	status   map[string]string
+}
+
+func fakeRepoServer(t *testing.T, repo fakeRepo) *Client {
+	t.Helper()
+	isTag := make(map[string]bool)
+	for _, tag := range repo.tags {
+		isTag[tag] = true
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/repos/acme/api/")
+		switch {
+		case path == "tags":
+			var tags []map[string]any
+			for _, tag := range repo.tags {
+				tags = append(tags, map[string]any{"name": tag, "commit": map[string]string{"sha": "sha-" + tag}})
+			}
+			json.NewEncoder(w).Encode(tags)
+		case strings.HasPrefix(path, "commits/sha-"):
+			date := repo.dates[strings.TrimPrefix(path, "commits/sha-")]
+			json.NewEncoder(w).Encode(map[string]any{"commit": map[string]any{"committer": map[string]string{"date": date}}})
+		case path == "releases":
+			json.NewEncoder(w).Encode(repo.releases)
+		case strings.HasPrefix(path, "compare/"):
+			base, head, _ := strings.Cut(strings.TrimPrefix(path, "compare/"), "...")
+			status := "ahead"
+			switch {
+			case head == "main" && repo.status[base] != "":
+				status = repo.status[base]
+			case head != "main" && !isTag[head]:
+				w.WriteHeader(http.StatusNotFound)
+				json.NewEncoder(w).Encode(map[string]string{"message": "Not Found"})
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]any{"status": status, "total_commits": 0, "commits": []any{}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return newTestClient(t, server)
+}
+
+// release is a published release of tag for fakeRepo
+func release(tag, published string, prerelease, draft bool) map[string]any {
+	return map[string]any{"tag_name": tag, "published_at": published, "prerelease": prerelease, "draft": draft}
+}
+
+func TestResolveReleaseAlias(t *testing.T) {
+	repo := fakeRepo{
+		releases: []map[string]any{
+			release("v1.0.0", "2024-01-01T00:00:00Z", false, false),
+			release("v1.1.0-rc.1", "2024-02-01T00:00:00Z", true, false),
+			release("v1.1.0", "2024-03-01T00:00:00Z", false, false),
+			release("v1.2.0", "2024-04-01T00:00:00Z", false, false),
+			release("v2.0.0", "2024-05-01T00:00:00Z", false, false),
+			release("v3.0.0", "2024-06-01T00:00:00Z", false, true),
+		},
+		// v1.2.0 and v2.0.0 were released from other branches
+		status: map[string]string{"v1.2.0": "behind", "v2.0.0": "diverged"},
+	}
+	cases := []struct {
+		branch      string
+		prereleases bool
+		latest      string
+		previous    string
+	}{
+		{"", false, "v2.0.0", "v1.2.0"},
+		{"", true, "v2.0.0", "v1.2.0"},
+		{"main", false, "v1.1.0", "v1.0.0"},
+		{"main", true, "v1.1.0", "v1.1.0-rc.1"},
+	}
+	ctx := context.Background()
+	for _, c := range cases {
+		client := fakeRepoServer(t, repo)
+		client.SetBranch(c.branch)
+		for alias, want := range map[string]string{"latest": c.latest, "previous": c.previous} {
+			if got, err := client.ResolveReleaseAlias(ctx, alias, c.prereleases); err != nil || got != want {
+				t.Errorf("branch %q, prereleases %v: %s = %q, %v; want %q", c.branch, c.prereleases, alias, got, err, want)
+			}
+		}
+	}
+
+	client := fakeRepoServer(t, repo)
+	if got, err := client.ResolveReleaseAlias(ctx, "v0.9.0", false); err != nil || got != "v0.9.0" {
+		t.Errorf("ResolveReleaseAlias(v0.9.0) = %q, %v; want the ref unchanged", got, err)
+	}
+}
+
+func TestResolveReleaseAliasTooFewReleases(t *testing.T) {
+	repo := fakeRepo{releases: []map[string]any{
+		release("v1.0.0", "2024-01-01T00:00:00Z", false, false),
+		release("v1.1.0-rc.1", "2024-02-01T00:00:00Z", true, false),
+	}}
+	ctx := context.Background()
+	client := fakeRepoServer(t, repo)
+	if got, err := client.ResolveReleaseAlias(ctx, "latest", false); err != nil || got != "v1.0.0" {
+		t.Errorf("latest = %q, %v", got, err)
+	}
+	if _, err := client.ResolveReleaseAlias(ctx, "previous", false); err == nil || !strings.Contains(err.Error(), "1 published releases") {
+		t.Errorf("previous error = %v, want too few releases", err)
+	}
+
+	client.SetBranch("main")
+	if _, err := client.ResolveReleaseAlias(ctx, "previous", false); err == nil || !strings.Contains(err.Error(), "branch main has 1") {
+		t.Errorf("previous on main error = %v, want too few releases", err)
+	}
+	client.SetBranch("nope")
+	if _, err := client.ResolveReleaseAlias(ctx, "latest", false); err == nil {
+		t.Error("expected an error for an unknown branch")
+	}
+}
+
+// branchRepo has tags on main, one ahead of it, and one on a diverged branch
+func branchRepo() fakeRepo {
+	return fakeRepo{
+		tags: []string{"v2.0.0", "v1.2.0", "v1.1.0", "v1.0.0"},
+		dates: map[string]string{
+			"v1.0.0": "2024-01-10T00:00:00Z",
+			"v1.1.0": "2024-02-10T00:00:00Z",
+			"v1.2.0": "2024-03-10T00:00:00Z",
+			"v2.0.0": "2024-04-10T00:00:00Z",
+		},
+		status: map[string]string{"v1.2.0": "behind", "v2.0.0": "diverged"},
+	}
+}
+
+func TestIsReachable(t *testing.T) {
+	client := fakeRepoServer(t, branchRepo())
+	ctx := context.Background()
+	for tag, want := range map[string]bool{"v1.1.0": true, "v1.2.0": false, "v2.0.0": false} {
+		if got, err := client.IsReachable(ctx, tag, "main"); err != nil || got != want {
+			t.Errorf("IsReachable(%s, main) = %v, %v; want %v", tag, got, err, want)
+		}
+	}
+	if _, err := client.IsReachable(ctx, "v1.1.0", "nope"); err == nil {
+		t.Error("expected an error for an unknown branch")
+	}
+}
+
+func TestLatestTagOnBranch(t *testing.T) {
+	ctx := context.Background()
+	client := fakeRepoServer(t, branchRepo())
+	if got, err := client.LatestTag(ctx, false); err != nil || got != "v2.0.0" {
+		t.Errorf("LatestTag() = %q, %v; want v2.0.0 without a branch", got, err)
+	}
+
+	client.SetBranch("main")
+	if got, err := client.LatestTag(ctx, false); err != nil || got != "v1.1.0" {
+		t.Errorf("LatestTag() on main = %q, %v; want v1.1.0, skipping tags ahead of or diverged from main", got, err)
+	}
+
+	client.SetBranch("nope")
+	if got, err := client.LatestTag(ctx, false); err == nil {
+		t.Errorf("LatestTag() on an unknown branch = %q, want an error", got)
+	}
+}
+
+func TestTimelineReleasesOnBranch(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	client := fakeRepoServer(t, branchRepo())
+	client.SetBranch("main")
+	releases, err := client.GetTimelineReleases(ctx, from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, release := range releases {
+		names = append(names, release.FromRef+".."+release.ToRef)
+	}
+	if strings.Join(names, ",") != "v1.0.0..v1.1.0" {
+		t.Errorf("GetTimelineReleases() on main = %v, want only the tags on main", names)
+	}
+
+	client.SetBranch("nope")
+	if releases, err := client.GetTimelineReleases(ctx, from, to); err == nil {
+		t.Errorf("GetTimelineReleases() on an unknown branch = %v, want an error", releases)
+	}
+}
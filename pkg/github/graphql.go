@@ -0,0 +1,178 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+// defaultGraphQLURL is the GitHub GraphQL API endpoint
+const defaultGraphQLURL = "https://api.github.com/graphql"
+
+// graphQLBatchSize is the number of commits requested per GraphQL query
+const graphQLBatchSize = 100
+
+// graphQLCommit is the subset of a GraphQL Commit object fetched per commit
+type graphQLCommit struct {
+	OID          string `json:"oid"`
+	Message      string `json:"message"`
+	Additions    int    `json:"additions"`
+	Deletions    int    `json:"deletions"`
+	ChangedFiles *int   `json:"changedFilesIfAvailable"` // Null when GitHub cannot count them cheaply
+	Author       struct {
+		Name string    `json:"name"`
+		Date time.Time `json:"date"`
+		User *struct {
+			Login string `json:"login"`
+		} `json:"user"`
+	} `json:"author"`
+	Signature *struct {
+		IsValid bool   `json:"isValid"`
+		State   string `json:"state"`
+	} `json:"signature"` // Null for unsigned commits
+	Parents struct {
+		Nodes []struct {
+			OID string `json:"oid"`
+		} `json:"nodes"`
+	} `json:"parents"`
+}
+
+// getCommitsBatched fetches commits by SHA, up to graphQLBatchSize per
+// request, preserving the order of shas
+func (c *Client) getCommitsBatched(ctx context.Context, shas []string) ([]CommitData, error) {
+	commits := make([]CommitData, 0, len(shas))
+	for start := 0; start < len(shas); start += graphQLBatchSize {
+		end := min(start+graphQLBatchSize, len(shas))
+		batch, err := c.queryCommits(ctx, shas[start:end])
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, batch...)
+	}
+	return commits, nil
+}
+
+// queryCommits fetches one batch of commits with a single GraphQL query
+func (c *Client) queryCommits(ctx context.Context, shas []string) ([]CommitData, error) {
+	payload, err := json.Marshal(map[string]any{
+		"query":     buildCommitsQuery(len(shas)),
+		"variables": commitsQueryVariables(c.owner, c.repo, shas),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encode GraphQL query: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.graphqlURL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("create GraphQL request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("query commits: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("query commits: unexpected status %s", resp.Status)
+	}
+
+	var result struct {
+		Data struct {
+			Repository map[string]*graphQLCommit `json:"repository"`
+		} `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode GraphQL response: %w", err)
+	}
+	if len(result.Errors) > 0 {
+		return nil, fmt.Errorf("query commits: %s", result.Errors[0].Message)
+	}
+
+	commits := make([]CommitData, 0, len(shas))
+	for i, sha := range shas {
+		node := result.Data.Repository[fmt.Sprintf("c%d", i)]
+		if node == nil {
+			return nil, fmt.Errorf("query commits: commit %s not found", sha)
+		}
+		commits = append(commits, node.commitData())
+	}
+	return commits, nil
+}
+
+// buildCommitsQuery returns a query fetching n commits, aliased c0..c(n-1)
+// and selected by the $sha0..$sha(n-1) variables
+func buildCommitsQuery(n int) string {
+	var sb strings.Builder
+	sb.WriteString("query($owner: String!, $name: String!")
+	for i := range n {
+		fmt.Fprintf(&sb, ", $sha%d: GitObjectID!", i)
+	}
+	sb.WriteString(") {\n  repository(owner: $owner, name: $name) {\n")
+	for i := range n {
+		fmt.Fprintf(&sb, "    c%d: object(oid: $sha%d) { ...commitFields }\n", i, i)
+	}
+	sb.WriteString("  }\n}\n")
+	sb.WriteString("fragment commitFields on Commit {\n")
+	sb.WriteString("  oid message additions deletions changedFilesIfAvailable\n")
+	sb.WriteString("  author { name date user { login } }\n")
+	sb.WriteString("  signature { isValid state }\n")
+	sb.WriteString("  parents(first: 5) { nodes { oid } }\n")
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// commitsQueryVariables returns the variables for buildCommitsQuery
+func commitsQueryVariables(owner, repo string, shas []string) map[string]any {
+	variables := map[string]any{"owner": owner, "name": repo}
+	for i, sha := range shas {
+		variables[fmt.Sprintf("sha%d", i)] = sha
+	}
+	return variables
+}
+
+// commitData converts a GraphQL commit to the provider commit type
+func (n *graphQLCommit) commitData() CommitData {
+	author := n.Author.Name
+	if n.Author.User != nil && n.Author.User.Login != "" {
+		author = n.Author.User.Login
+	}
+	verification := &provider.CommitVerification{Reason: "unsigned"}
+	if n.Signature != nil {
+		verification.Verified = n.Signature.IsValid
+		verification.Reason = strings.ToLower(n.Signature.State)
+	}
+	var parents []string
+	for _, parent := range n.Parents.Nodes {
+		parents = append(parents, parent.OID)
+	}
+	var files int
+	if n.ChangedFiles != nil {
+		files = *n.ChangedFiles
+	}
+	return CommitData{
+		SHA:          n.OID,
+		Message:      n.Message,
+		Author:       author,
+		Date:         n.Author.Date,
+		CoAuthors:    provider.CoAuthors(n.Message),
+		Verification: verification,
+		Parents:      parents,
+		Stats: CommitStats{
+			Additions: n.Additions,
+			Deletions: n.Deletions,
+			Total:     n.Additions + n.Deletions,
+			Files:     files,
+		},
+	}
+}
@@ -0,0 +1,86 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGetCommitsBatched(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var body struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		if body.Variables["owner"] != "acme" || body.Variables["name"] != "api" {
+			t.Errorf("Unexpected variables %v", body.Variables)
+		}
+
+		repository := map[string]any{}
+		for i := 0; body.Variables[fmt.Sprintf("sha%d", i)] != nil; i++ {
+			sha := body.Variables[fmt.Sprintf("sha%d", i)].(string)
+			node := map[string]any{
+				"oid": sha, "message": "Commit " + sha, "additions": 3, "deletions": 1, "changedFilesIfAvailable": 2,
+				"author": map[string]any{"name": "Alice", "date": "2024-03-01T00:00:00Z", "user": nil},
+			}
+			if sha == "sha0" {
+				node["author"] = map[string]any{"name": "Alice", "date": "2024-03-01T00:00:00Z", "user": map[string]any{"login": "alice"}}
+				node["signature"] = map[string]any{"isValid": true, "state": "VALID"}
+			}
+			repository[fmt.Sprintf("c%d", i)] = node
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"repository": repository}})
+	}))
+	defer server.Close()
+
+	client := &Client{httpClient: server.Client(), graphqlURL: server.URL, owner: "acme", repo: "api"}
+
+	shas := make([]string, graphQLBatchSize+5)
+	for i := range shas {
+		shas[i] = fmt.Sprintf("sha%d", i)
+	}
+	commits, err := client.getCommitsBatched(context.Background(), shas)
+	if err != nil {
+		t.Fatalf("getCommitsBatched() error = %v", err)
+	}
+
+	if requests != 2 {
+		t.Errorf("Expected 2 GraphQL requests, got %d", requests)
+	}
+	if len(commits) != len(shas) || commits[len(shas)-1].SHA != shas[len(shas)-1] {
+		t.Fatalf("Expected %d commits in order, got %d", len(shas), len(commits))
+	}
+	if commits[0].Author != "alice" || commits[1].Author != "Alice" {
+		t.Errorf("Expected login with name fallback, got %q and %q", commits[0].Author, commits[1].Author)
+	}
+	if commits[0].Stats.Total != 4 {
+		t.Errorf("Expected total of 4 changed lines, got %d", commits[0].Stats.Total)
+	}
+	if commits[0].Stats.Files != 2 || len(commits[0].FilesChanged) != 0 {
+		t.Errorf("Expected a count of 2 changed files without file lists, got %d and %d", commits[0].Stats.Files, len(commits[0].FilesChanged))
+	}
+	if v := commits[0].Verification; v == nil || !v.Verified || v.Reason != "valid" {
+		t.Errorf("Expected a verified signature, got %+v", v)
+	}
+	if v := commits[1].Verification; v == nil || v.Verified || v.Reason != "unsigned" {
+		t.Errorf("Expected an unsigned commit, got %+v", v)
+	}
+}
+
+func TestBuildCommitsQuery(t *testing.T) {
+	query := buildCommitsQuery(2)
+	for _, want := range []string{"$sha1: GitObjectID!", "c1: object(oid: $sha1)", "fragment commitFields on Commit", "signature { isValid state }", "parents(first: 5) { nodes { oid } }"} {
+		if !strings.Contains(query, want) {
+			t.Errorf("Expected query to contain %q\nGot:\n%s", want, query)
+		}
+	}
+}
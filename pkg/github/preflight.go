@@ -0,0 +1,155 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v66/github"
+)
+
+// acceptedPermissionsHeader lists, on fine-grained token and GitHub App
+// responses, the permissions that would have allowed the request, e.g.
+// "contents=read; pull_requests=read"
+const acceptedPermissionsHeader = "X-Accepted-GitHub-Permissions"
+
+// permissionNames are the labels GitHub's token settings page uses
+var permissionNames = map[string]string{
+	"contents":      "Contents",
+	"metadata":      "Metadata",
+	"pull_requests": "Pull requests",
+}
+
+// PermissionError reports that the token cannot perform a request the run
+// depends on, with what to grant
+type PermissionError struct {
+	Check string // What was being checked, e.g. "read pull requests"
+	Fix   string // How to grant the missing access
+	Err   error
+}
+
+func (e *PermissionError) Error() string {
+	return fmt.Sprintf("GitHub token cannot %s.\n%s\n(GitHub error: %v)", e.Check, e.Fix, e.Err)
+}
+
+func (e *PermissionError) Unwrap() error {
+	return e.Err
+}
+
+// ValidateAccess checks that the token can do what a run needs: see the
+// repository (metadata), read its commits (contents), and read its pull
+// requests. Each is probed with a one-item request, so a missing permission
+// fails up front with what to grant instead of with a 403 or 404 mid-run.
+func (c *Client) ValidateAccess(ctx context.Context) error {
+	if _, _, err := c.client.Repositories.Get(ctx, c.owner, c.repo); err != nil {
+		return c.accessError("read repository metadata", "metadata", err)
+	}
+
+	one := github.ListOptions{PerPage: 1}
+	if _, _, err := c.client.Repositories.ListCommits(ctx, c.owner, c.repo, &github.CommitsListOptions{ListOptions: one}); err != nil && !isEmptyRepository(err) {
+		return c.accessError("read repository contents (commits)", "contents", err)
+	}
+	if _, _, err := c.client.PullRequests.List(ctx, c.owner, c.repo, &github.PullRequestListOptions{State: "all", ListOptions: one}); err != nil {
+		return c.accessError("read pull requests", "pull_requests", err)
+	}
+	return nil
+}
+
+// accessError turns a failed probe into an actionable error: SSO enforcement,
+// an invalid token, or the permission to grant. Other failures, such as rate
+// limits or outages, are returned as is.
+func (c *Client) accessError(check, permission string, err error) error {
+	if ssoErr := checkSSO(err); ssoErr != err {
+		return ssoErr
+	}
+	var errResp *github.ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response == nil {
+		return fmt.Errorf("validate repository access: %w", err)
+	}
+
+	target := c.owner + "/" + c.repo
+	switch errResp.Response.StatusCode {
+	case http.StatusUnauthorized:
+		return &PermissionError{
+			Check: "authenticate",
+			Fix:   "The token is invalid, expired, or revoked. Create a new one and set GITHUB_TOKEN (or run auth login).",
+			Err:   err,
+		}
+	case http.StatusForbidden:
+		if isRateLimit(err) {
+			return fmt.Errorf("validate repository access: %w", err)
+		}
+		return &PermissionError{Check: check, Fix: permissionFix(target, permission, errResp.Response.Header.Get(acceptedPermissionsHeader)), Err: err}
+	case http.StatusNotFound:
+		// GitHub answers 404 rather than 403 for private repositories the
+		// token cannot see
+		if permission == "metadata" {
+			return &PermissionError{
+				Check: "see " + target,
+				Fix: fmt.Sprintf("Check the owner and repository name. If %s is private: a fine-grained token needs it under \"Repository access\" "+
+					"(and the organization must allow fine-grained tokens); a classic token needs the repo scope; a GitHub App must be installed on it.", target),
+				Err: err,
+			}
+		}
+		return &PermissionError{Check: check, Fix: permissionFix(target, permission, ""), Err: err}
+	}
+	return fmt.Errorf("validate repository access: %w", err)
+}
+
+// permissionFix explains how to grant permission, preferring the
+// permissions GitHub reported as accepted
+func permissionFix(target, permission, accepted string) string {
+	grants := parseAcceptedPermissions(accepted)
+	if len(grants) == 0 {
+		grants = []string{permissionLabel(permission, "read")}
+	}
+	return fmt.Sprintf("Fine-grained tokens and GitHub Apps need %s on %s; classic tokens need the repo scope for private repositories.",
+		strings.Join(grants, " or "), target)
+}
+
+// parseAcceptedPermissions turns "contents=read; pull_requests=read" into
+// settings-page labels such as "Contents: Read-only"
+func parseAcceptedPermissions(header string) []string {
+	var grants []string
+	for _, part := range strings.Split(header, ";") {
+		name, level, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		grants = append(grants, permissionLabel(name, level))
+	}
+	sort.Strings(grants)
+	return grants
+}
+
+func permissionLabel(name, level string) string {
+	label, ok := permissionNames[name]
+	if !ok {
+		label = strings.ReplaceAll(name, "_", " ")
+	}
+	switch level {
+	case "read":
+		return `"` + label + `: Read-only"`
+	case "write":
+		return `"` + label + `: Read and write"`
+	}
+	return `"` + label + ": " + level + `"`
+}
+
+// isRateLimit reports whether a 403 is GitHub's rate limiting rather than a
+// permission problem
+func isRateLimit(err error) bool {
+	var rateErr *github.RateLimitError
+	var abuseErr *github.AbuseRateLimitError
+	return errors.As(err, &rateErr) || errors.As(err, &abuseErr)
+}
+
+// isEmptyRepository reports whether listing commits failed with 409 Conflict
+// because the repository has no commits yet
+func isEmptyRepository(err error) bool {
+	var errResp *github.ErrorResponse
+	return errors.As(err, &errResp) && errResp.Response != nil && errResp.Response.StatusCode == http.StatusConflict
+}
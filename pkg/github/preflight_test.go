@@ -0,0 +1,63 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestValidateAccessReportsMissingPermission(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/acme/api":
+			json.NewEncoder(w).Encode(map[string]any{"full_name": "acme/api"})
+		case "/repos/acme/api/commits":
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(map[string]any{"message": "Git Repository is empty."})
+		case "/repos/acme/api/pulls":
+			w.Header().Set(acceptedPermissionsHeader, "pull_requests=read")
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(map[string]any{"message": "Resource not accessible by personal access token"})
+		}
+	}))
+	defer server.Close()
+
+	err := newTestClient(t, server).ValidateAccess(context.Background())
+	var permErr *PermissionError
+	if !errors.As(err, &permErr) {
+		t.Fatalf("err = %v, want a PermissionError", err)
+	}
+	if permErr.Check != "read pull requests" || !strings.Contains(permErr.Fix, `"Pull requests: Read-only" on acme/api`) {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestValidateAccessStatusMessages(t *testing.T) {
+	cases := map[int]string{
+		http.StatusNotFound:     "cannot see acme/api",
+		http.StatusUnauthorized: "invalid, expired, or revoked",
+	}
+	for status, want := range cases {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(map[string]any{"message": http.StatusText(status)})
+		}))
+		err := newTestClient(t, server).ValidateAccess(context.Background())
+		server.Close()
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("status %d: err = %v, want it to mention %q", status, err, want)
+		}
+	}
+}
+
+func TestParseAcceptedPermissions(t *testing.T) {
+	got := parseAcceptedPermissions("pull_requests=read; contents=write")
+	want := []string{`"Contents: Read and write"`, `"Pull requests: Read-only"`}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,66 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v66/github"
+)
+
+// ssoHeader is set on 403 responses when an organization enforces SAML SSO
+// and the token has not been authorized for it
+const ssoHeader = "X-GitHub-SSO"
+
+// SSOError reports that a request was blocked by SAML SSO enforcement
+type SSOError struct {
+	AuthorizationURL string // Where to authorize the token (may be empty)
+	Err              error
+}
+
+func (e *SSOError) Error() string {
+	var b strings.Builder
+	b.WriteString("GitHub denied access: the organization enforces SAML single sign-on and this token is not authorized for it.\n")
+	if e.AuthorizationURL != "" {
+		b.WriteString("Authorize the token by opening this URL, then re-run the command:\n")
+		b.WriteString("  " + e.AuthorizationURL + "\n")
+	} else {
+		b.WriteString("Authorize the token under GitHub Settings → Developer settings → Personal access tokens → Configure SSO, then re-run the command.\n")
+	}
+	b.WriteString(fmt.Sprintf("(GitHub error: %v)", e.Err))
+	return b.String()
+}
+
+func (e *SSOError) Unwrap() error {
+	return e.Err
+}
+
+// checkSSO converts a 403 caused by SSO enforcement into an SSOError with
+// the authorization URL; other errors are returned unchanged
+func checkSSO(err error) error {
+	var errResp *github.ErrorResponse
+	if !errors.As(err, &errResp) || errResp.Response == nil || errResp.Response.StatusCode != http.StatusForbidden {
+		return err
+	}
+	url, required := parseSSOHeader(errResp.Response.Header.Get(ssoHeader))
+	if !required {
+		return err
+	}
+	return &SSOError{AuthorizationURL: url, Err: err}
+}
+
+// parseSSOHeader parses an X-GitHub-SSO value such as
+// "required; url=https://github.com/orgs/acme/sso?authorization_request=..."
+func parseSSOHeader(value string) (url string, required bool) {
+	for _, part := range strings.Split(value, ";") {
+		part = strings.TrimSpace(part)
+		switch {
+		case part == "required":
+			required = true
+		case strings.HasPrefix(part, "url="):
+			url = strings.TrimPrefix(part, "url=")
+		}
+	}
+	return url, required
+}
@@ -0,0 +1,35 @@
+package github
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v66/github"
+)
+
+func TestCheckSSO(t *testing.T) {
+	header := http.Header{}
+	header.Set(ssoHeader, "required; url=https://github.com/orgs/acme/sso?authorization_request=abc")
+	err := &github.ErrorResponse{
+		Response: &http.Response{StatusCode: http.StatusForbidden, Header: header, Request: &http.Request{}},
+		Message:  "Resource protected by organization SAML enforcement.",
+	}
+
+	var ssoErr *SSOError
+	if !errors.As(checkSSO(err), &ssoErr) {
+		t.Fatal("Expected SSOError for SSO-enforced 403")
+	}
+	if ssoErr.AuthorizationURL != "https://github.com/orgs/acme/sso?authorization_request=abc" {
+		t.Errorf("Unexpected authorization URL %q", ssoErr.AuthorizationURL)
+	}
+	if !strings.Contains(ssoErr.Error(), ssoErr.AuthorizationURL) {
+		t.Error("Expected the message to include the authorization URL")
+	}
+
+	plain := &github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusForbidden, Header: http.Header{}, Request: &http.Request{}}}
+	if checkSSO(plain) != error(plain) {
+		t.Error("Expected non-SSO 403 to pass through unchanged")
+	}
+}
@@ -0,0 +1,237 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Cassette is a recorded sequence of GitHub API requests and their responses,
+// replayed by ReplayTransport so client code can be tested without a token
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is one recorded request and its response
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest identifies a request by method, URL, and body. Headers are
+// not recorded, so credentials never reach a cassette.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"` // GraphQL queries and other request bodies
+}
+
+// RecordedResponse is the part of a response the client reads
+type RecordedResponse struct {
+	Status int                 `json:"status"`
+	Header map[string][]string `json:"header,omitempty"`
+	Body   json.RawMessage     `json:"body"` // JSON bodies as is, others as a JSON string
+}
+
+// recordedHeaders are the response headers kept in cassettes: what the
+// client and go-github read for pagination, rate limits, and permissions
+var recordedHeaders = []string{
+	"Content-Type",
+	"Link",
+	"Retry-After",
+	"X-RateLimit-Limit",
+	"X-RateLimit-Remaining",
+	"X-RateLimit-Reset",
+	"X-RateLimit-Used",
+	"X-RateLimit-Resource",
+	acceptedPermissionsHeader,
+	"X-GitHub-SSO",
+}
+
+// LoadCassette reads a cassette written by RecordingTransport.Save
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read cassette: %w", err)
+	}
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, fmt.Errorf("parse cassette %s: %w", path, err)
+	}
+	return &cassette, nil
+}
+
+// RecordingTransport passes requests to Base and records every response
+type RecordingTransport struct {
+	Base http.RoundTripper // nil = http.DefaultTransport
+
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// RoundTrip sends req and records the exchange
+func (t *RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	recorded := RecordedResponse{Status: resp.StatusCode, Body: encodeBody(respBody), Header: make(map[string][]string)}
+	for _, name := range recordedHeaders {
+		if values := resp.Header.Values(name); len(values) > 0 {
+			recorded.Header[name] = values
+		}
+	}
+	t.mu.Lock()
+	t.cassette.Interactions = append(t.cassette.Interactions, Interaction{
+		Request:  RecordedRequest{Method: req.Method, URL: req.URL.String(), Body: body},
+		Response: recorded,
+	})
+	t.mu.Unlock()
+	return resp, nil
+}
+
+// Save writes the recorded interactions to path
+func (t *RecordingTransport) Save(path string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	data, err := json.MarshalIndent(t.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode cassette: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write cassette: %w", err)
+	}
+	return nil
+}
+
+// ReplayTransport answers requests from a cassette without a network. Each
+// request gets the first unused interaction with the same method, path,
+// query, and body, so repeated requests replay in recorded order. A request
+// the cassette does not hold fails.
+type ReplayTransport struct {
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+// NewReplayTransport replays cassette
+func NewReplayTransport(cassette *Cassette) *ReplayTransport {
+	return &ReplayTransport{cassette: cassette, used: make([]bool, len(cassette.Interactions))}
+}
+
+// RoundTrip answers req with its recorded response
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, interaction := range t.cassette.Interactions {
+		if t.used[i] || !matches(interaction.Request, req, body) {
+			continue
+		}
+		t.used[i] = true
+		recorded := interaction.Response
+		body := decodeBody(recorded.Body)
+		header := make(http.Header)
+		for name, values := range recorded.Header {
+			for _, value := range values {
+				header.Add(name, value)
+			}
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", recorded.Status, http.StatusText(recorded.Status)),
+			StatusCode:    recorded.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("cassette has no response for %s %s", req.Method, req.URL)
+}
+
+// Unused returns the recorded requests that were never replayed, e.g. to
+// check that a test exercised the whole cassette
+func (t *ReplayTransport) Unused() []RecordedRequest {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var unused []RecordedRequest
+	for i, interaction := range t.cassette.Interactions {
+		if !t.used[i] {
+			unused = append(unused, interaction.Request)
+		}
+	}
+	return unused
+}
+
+// matches reports whether a recorded request is req, comparing query
+// parameters regardless of their order and ignoring the host
+func matches(recorded RecordedRequest, req *http.Request, body string) bool {
+	if recorded.Method != req.Method || recorded.Body != body {
+		return false
+	}
+	u, err := url.Parse(recorded.URL)
+	if err != nil {
+		return false
+	}
+	return u.Path == req.URL.Path && u.Query().Encode() == req.URL.Query().Encode()
+}
+
+// readBody reads a request or response body and replaces it with a copy
+func readBody(body *io.ReadCloser) (string, error) {
+	if *body == nil || *body == http.NoBody {
+		return "", nil
+	}
+	data, err := io.ReadAll(*body)
+	(*body).Close()
+	if err != nil {
+		return "", fmt.Errorf("read body: %w", err)
+	}
+	*body = io.NopCloser(bytes.NewReader(data))
+	return string(data), nil
+}
+
+// encodeBody stores a JSON response body as is, so cassettes stay readable,
+// and any other body as a JSON string
+func encodeBody(body string) json.RawMessage {
+	trimmed := strings.TrimSpace(body)
+	if trimmed != "" && trimmed[0] != '"' && json.Valid([]byte(trimmed)) {
+		return json.RawMessage(trimmed)
+	}
+	encoded, _ := json.Marshal(body)
+	return encoded
+}
+
+// decodeBody reverses encodeBody
+func decodeBody(body json.RawMessage) string {
+	var text string
+	if json.Unmarshal(body, &text) == nil {
+		return text
+	}
+	return string(body)
+}
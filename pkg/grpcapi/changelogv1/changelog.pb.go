@@ -0,0 +1,991 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: changelog/v1/changelog.proto
+
+// Changelog generation for internal services: the gRPC counterpart of the
+// generate command. Regenerate the Go code with `go generate ./pkg/grpcapi`.
+
+package changelogv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GenerateChangelogRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Repository owner and name; empty fields default to the server's repository
+	Owner string `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	Repo  string `protobuf:"bytes,2,opt,name=repo,proto3" json:"repo,omitempty"`
+	// Range start and end: tags, branches, or commit SHAs
+	From          string `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To            string `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateChangelogRequest) Reset() {
+	*x = GenerateChangelogRequest{}
+	mi := &file_changelog_v1_changelog_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateChangelogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateChangelogRequest) ProtoMessage() {}
+
+func (x *GenerateChangelogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_changelog_v1_changelog_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateChangelogRequest.ProtoReflect.Descriptor instead.
+func (*GenerateChangelogRequest) Descriptor() ([]byte, []int) {
+	return file_changelog_v1_changelog_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GenerateChangelogRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *GenerateChangelogRequest) GetRepo() string {
+	if x != nil {
+		return x.Repo
+	}
+	return ""
+}
+
+func (x *GenerateChangelogRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *GenerateChangelogRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type GenerateChangelogResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*GenerateChangelogResponse_Progress
+	//	*GenerateChangelogResponse_Changelog
+	Event         isGenerateChangelogResponse_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateChangelogResponse) Reset() {
+	*x = GenerateChangelogResponse{}
+	mi := &file_changelog_v1_changelog_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateChangelogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateChangelogResponse) ProtoMessage() {}
+
+func (x *GenerateChangelogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_changelog_v1_changelog_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateChangelogResponse.ProtoReflect.Descriptor instead.
+func (*GenerateChangelogResponse) Descriptor() ([]byte, []int) {
+	return file_changelog_v1_changelog_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GenerateChangelogResponse) GetEvent() isGenerateChangelogResponse_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *GenerateChangelogResponse) GetProgress() *ProgressEvent {
+	if x != nil {
+		if x, ok := x.Event.(*GenerateChangelogResponse_Progress); ok {
+			return x.Progress
+		}
+	}
+	return nil
+}
+
+func (x *GenerateChangelogResponse) GetChangelog() *Changelog {
+	if x != nil {
+		if x, ok := x.Event.(*GenerateChangelogResponse_Changelog); ok {
+			return x.Changelog
+		}
+	}
+	return nil
+}
+
+type isGenerateChangelogResponse_Event interface {
+	isGenerateChangelogResponse_Event()
+}
+
+type GenerateChangelogResponse_Progress struct {
+	Progress *ProgressEvent `protobuf:"bytes,1,opt,name=progress,proto3,oneof"`
+}
+
+type GenerateChangelogResponse_Changelog struct {
+	// Sent once, as the last message
+	Changelog *Changelog `protobuf:"bytes,2,opt,name=changelog,proto3,oneof"`
+}
+
+func (*GenerateChangelogResponse_Progress) isGenerateChangelogResponse_Event() {}
+
+func (*GenerateChangelogResponse_Changelog) isGenerateChangelogResponse_Event() {}
+
+type GenerateTimelineRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Repository owner and name; empty fields default to the server's repository
+	Owner         string                 `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	Repo          string                 `protobuf:"bytes,2,opt,name=repo,proto3" json:"repo,omitempty"`
+	FromDate      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=from_date,json=fromDate,proto3" json:"from_date,omitempty"`
+	ToDate        *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=to_date,json=toDate,proto3" json:"to_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateTimelineRequest) Reset() {
+	*x = GenerateTimelineRequest{}
+	mi := &file_changelog_v1_changelog_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateTimelineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateTimelineRequest) ProtoMessage() {}
+
+func (x *GenerateTimelineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_changelog_v1_changelog_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateTimelineRequest.ProtoReflect.Descriptor instead.
+func (*GenerateTimelineRequest) Descriptor() ([]byte, []int) {
+	return file_changelog_v1_changelog_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GenerateTimelineRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *GenerateTimelineRequest) GetRepo() string {
+	if x != nil {
+		return x.Repo
+	}
+	return ""
+}
+
+func (x *GenerateTimelineRequest) GetFromDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FromDate
+	}
+	return nil
+}
+
+func (x *GenerateTimelineRequest) GetToDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ToDate
+	}
+	return nil
+}
+
+type GenerateTimelineResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*GenerateTimelineResponse_Progress
+	//	*GenerateTimelineResponse_Timeline
+	Event         isGenerateTimelineResponse_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateTimelineResponse) Reset() {
+	*x = GenerateTimelineResponse{}
+	mi := &file_changelog_v1_changelog_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateTimelineResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateTimelineResponse) ProtoMessage() {}
+
+func (x *GenerateTimelineResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_changelog_v1_changelog_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateTimelineResponse.ProtoReflect.Descriptor instead.
+func (*GenerateTimelineResponse) Descriptor() ([]byte, []int) {
+	return file_changelog_v1_changelog_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GenerateTimelineResponse) GetEvent() isGenerateTimelineResponse_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *GenerateTimelineResponse) GetProgress() *ProgressEvent {
+	if x != nil {
+		if x, ok := x.Event.(*GenerateTimelineResponse_Progress); ok {
+			return x.Progress
+		}
+	}
+	return nil
+}
+
+func (x *GenerateTimelineResponse) GetTimeline() *Timeline {
+	if x != nil {
+		if x, ok := x.Event.(*GenerateTimelineResponse_Timeline); ok {
+			return x.Timeline
+		}
+	}
+	return nil
+}
+
+type isGenerateTimelineResponse_Event interface {
+	isGenerateTimelineResponse_Event()
+}
+
+type GenerateTimelineResponse_Progress struct {
+	Progress *ProgressEvent `protobuf:"bytes,1,opt,name=progress,proto3,oneof"`
+}
+
+type GenerateTimelineResponse_Timeline struct {
+	// Sent once, as the last message
+	Timeline *Timeline `protobuf:"bytes,2,opt,name=timeline,proto3,oneof"`
+}
+
+func (*GenerateTimelineResponse_Progress) isGenerateTimelineResponse_Event() {}
+
+func (*GenerateTimelineResponse_Timeline) isGenerateTimelineResponse_Event() {}
+
+// ProgressEvent reports a step of a run
+type ProgressEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "fetching", "fetched", "generating", "release", or "done"
+	Stage   string `protobuf:"bytes,1,opt,name=stage,proto3" json:"stage,omitempty"`
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// Position within the stage when it is counted, e.g. release 2 of 5
+	Current       int32 `protobuf:"varint,3,opt,name=current,proto3" json:"current,omitempty"`
+	Total         int32 `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProgressEvent) Reset() {
+	*x = ProgressEvent{}
+	mi := &file_changelog_v1_changelog_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProgressEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProgressEvent) ProtoMessage() {}
+
+func (x *ProgressEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_changelog_v1_changelog_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProgressEvent.ProtoReflect.Descriptor instead.
+func (*ProgressEvent) Descriptor() ([]byte, []int) {
+	return file_changelog_v1_changelog_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ProgressEvent) GetStage() string {
+	if x != nil {
+		return x.Stage
+	}
+	return ""
+}
+
+func (x *ProgressEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ProgressEvent) GetCurrent() int32 {
+	if x != nil {
+		return x.Current
+	}
+	return 0
+}
+
+func (x *ProgressEvent) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type Changelog struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	RepoName    string                 `protobuf:"bytes,1,opt,name=repo_name,json=repoName,proto3" json:"repo_name,omitempty"`
+	FromRef     string                 `protobuf:"bytes,2,opt,name=from_ref,json=fromRef,proto3" json:"from_ref,omitempty"`
+	ToRef       string                 `protobuf:"bytes,3,opt,name=to_ref,json=toRef,proto3" json:"to_ref,omitempty"`
+	Date        *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=date,proto3" json:"date,omitempty"`
+	Summary     string                 `protobuf:"bytes,5,opt,name=summary,proto3" json:"summary,omitempty"`
+	Highlights  []string               `protobuf:"bytes,6,rep,name=highlights,proto3" json:"highlights,omitempty"`
+	Categories  []*Category            `protobuf:"bytes,7,rep,name=categories,proto3" json:"categories,omitempty"`
+	CommitCount int32                  `protobuf:"varint,8,opt,name=commit_count,json=commitCount,proto3" json:"commit_count,omitempty"`
+	Markdown    string                 `protobuf:"bytes,9,opt,name=markdown,proto3" json:"markdown,omitempty"`
+	// The changelog as written by --format=json, with every field
+	Json          string `protobuf:"bytes,10,opt,name=json,proto3" json:"json,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Changelog) Reset() {
+	*x = Changelog{}
+	mi := &file_changelog_v1_changelog_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Changelog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Changelog) ProtoMessage() {}
+
+func (x *Changelog) ProtoReflect() protoreflect.Message {
+	mi := &file_changelog_v1_changelog_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Changelog.ProtoReflect.Descriptor instead.
+func (*Changelog) Descriptor() ([]byte, []int) {
+	return file_changelog_v1_changelog_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Changelog) GetRepoName() string {
+	if x != nil {
+		return x.RepoName
+	}
+	return ""
+}
+
+func (x *Changelog) GetFromRef() string {
+	if x != nil {
+		return x.FromRef
+	}
+	return ""
+}
+
+func (x *Changelog) GetToRef() string {
+	if x != nil {
+		return x.ToRef
+	}
+	return ""
+}
+
+func (x *Changelog) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *Changelog) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *Changelog) GetHighlights() []string {
+	if x != nil {
+		return x.Highlights
+	}
+	return nil
+}
+
+func (x *Changelog) GetCategories() []*Category {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *Changelog) GetCommitCount() int32 {
+	if x != nil {
+		return x.CommitCount
+	}
+	return 0
+}
+
+func (x *Changelog) GetMarkdown() string {
+	if x != nil {
+		return x.Markdown
+	}
+	return ""
+}
+
+func (x *Changelog) GetJson() string {
+	if x != nil {
+		return x.Json
+	}
+	return ""
+}
+
+type Category struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Entries       []*Entry               `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Category) Reset() {
+	*x = Category{}
+	mi := &file_changelog_v1_changelog_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Category) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Category) ProtoMessage() {}
+
+func (x *Category) ProtoReflect() protoreflect.Message {
+	mi := &file_changelog_v1_changelog_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Category.ProtoReflect.Descriptor instead.
+func (*Category) Descriptor() ([]byte, []int) {
+	return file_changelog_v1_changelog_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Category) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Category) GetEntries() []*Entry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type Entry struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Sha             string                 `protobuf:"bytes,1,opt,name=sha,proto3" json:"sha,omitempty"`
+	Title           string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description     string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Author          string                 `protobuf:"bytes,4,opt,name=author,proto3" json:"author,omitempty"`
+	ImportanceScore float64                `protobuf:"fixed64,5,opt,name=importance_score,json=importanceScore,proto3" json:"importance_score,omitempty"`
+	// Pull request the commit merged (0 = none)
+	Pr            int32 `protobuf:"varint,6,opt,name=pr,proto3" json:"pr,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Entry) Reset() {
+	*x = Entry{}
+	mi := &file_changelog_v1_changelog_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Entry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Entry) ProtoMessage() {}
+
+func (x *Entry) ProtoReflect() protoreflect.Message {
+	mi := &file_changelog_v1_changelog_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Entry.ProtoReflect.Descriptor instead.
+func (*Entry) Descriptor() ([]byte, []int) {
+	return file_changelog_v1_changelog_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Entry) GetSha() string {
+	if x != nil {
+		return x.Sha
+	}
+	return ""
+}
+
+func (x *Entry) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Entry) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Entry) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *Entry) GetImportanceScore() float64 {
+	if x != nil {
+		return x.ImportanceScore
+	}
+	return 0
+}
+
+func (x *Entry) GetPr() int32 {
+	if x != nil {
+		return x.Pr
+	}
+	return 0
+}
+
+type Timeline struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	RepoName string                 `protobuf:"bytes,1,opt,name=repo_name,json=repoName,proto3" json:"repo_name,omitempty"`
+	FromDate *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from_date,json=fromDate,proto3" json:"from_date,omitempty"`
+	ToDate   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to_date,json=toDate,proto3" json:"to_date,omitempty"`
+	Releases []*Release             `protobuf:"bytes,4,rep,name=releases,proto3" json:"releases,omitempty"`
+	Markdown string                 `protobuf:"bytes,5,opt,name=markdown,proto3" json:"markdown,omitempty"`
+	// The timeline as written by --format=json, with every field
+	Json          string `protobuf:"bytes,6,opt,name=json,proto3" json:"json,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Timeline) Reset() {
+	*x = Timeline{}
+	mi := &file_changelog_v1_changelog_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Timeline) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Timeline) ProtoMessage() {}
+
+func (x *Timeline) ProtoReflect() protoreflect.Message {
+	mi := &file_changelog_v1_changelog_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Timeline.ProtoReflect.Descriptor instead.
+func (*Timeline) Descriptor() ([]byte, []int) {
+	return file_changelog_v1_changelog_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Timeline) GetRepoName() string {
+	if x != nil {
+		return x.RepoName
+	}
+	return ""
+}
+
+func (x *Timeline) GetFromDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FromDate
+	}
+	return nil
+}
+
+func (x *Timeline) GetToDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ToDate
+	}
+	return nil
+}
+
+func (x *Timeline) GetReleases() []*Release {
+	if x != nil {
+		return x.Releases
+	}
+	return nil
+}
+
+func (x *Timeline) GetMarkdown() string {
+	if x != nil {
+		return x.Markdown
+	}
+	return ""
+}
+
+func (x *Timeline) GetJson() string {
+	if x != nil {
+		return x.Json
+	}
+	return ""
+}
+
+type Release struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromRef       string                 `protobuf:"bytes,1,opt,name=from_ref,json=fromRef,proto3" json:"from_ref,omitempty"`
+	ToRef         string                 `protobuf:"bytes,2,opt,name=to_ref,json=toRef,proto3" json:"to_ref,omitempty"`
+	Date          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`
+	Summary       string                 `protobuf:"bytes,4,opt,name=summary,proto3" json:"summary,omitempty"`
+	Highlights    []string               `protobuf:"bytes,5,rep,name=highlights,proto3" json:"highlights,omitempty"`
+	PullRequests  int32                  `protobuf:"varint,6,opt,name=pull_requests,json=pullRequests,proto3" json:"pull_requests,omitempty"`
+	Markdown      string                 `protobuf:"bytes,7,opt,name=markdown,proto3" json:"markdown,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Release) Reset() {
+	*x = Release{}
+	mi := &file_changelog_v1_changelog_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Release) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Release) ProtoMessage() {}
+
+func (x *Release) ProtoReflect() protoreflect.Message {
+	mi := &file_changelog_v1_changelog_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Release.ProtoReflect.Descriptor instead.
+func (*Release) Descriptor() ([]byte, []int) {
+	return file_changelog_v1_changelog_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Release) GetFromRef() string {
+	if x != nil {
+		return x.FromRef
+	}
+	return ""
+}
+
+func (x *Release) GetToRef() string {
+	if x != nil {
+		return x.ToRef
+	}
+	return ""
+}
+
+func (x *Release) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *Release) GetSummary() string {
+	if x != nil {
+		return x.Summary
+	}
+	return ""
+}
+
+func (x *Release) GetHighlights() []string {
+	if x != nil {
+		return x.Highlights
+	}
+	return nil
+}
+
+func (x *Release) GetPullRequests() int32 {
+	if x != nil {
+		return x.PullRequests
+	}
+	return 0
+}
+
+func (x *Release) GetMarkdown() string {
+	if x != nil {
+		return x.Markdown
+	}
+	return ""
+}
+
+var File_changelog_v1_changelog_proto protoreflect.FileDescriptor
+
+const file_changelog_v1_changelog_proto_rawDesc = "" +
+	"\n" +
+	"\x1cchangelog/v1/changelog.proto\x12\fchangelog.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"h\n" +
+	"\x18GenerateChangelogRequest\x12\x14\n" +
+	"\x05owner\x18\x01 \x01(\tR\x05owner\x12\x12\n" +
+	"\x04repo\x18\x02 \x01(\tR\x04repo\x12\x12\n" +
+	"\x04from\x18\x03 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x04 \x01(\tR\x02to\"\x98\x01\n" +
+	"\x19GenerateChangelogResponse\x129\n" +
+	"\bprogress\x18\x01 \x01(\v2\x1b.changelog.v1.ProgressEventH\x00R\bprogress\x127\n" +
+	"\tchangelog\x18\x02 \x01(\v2\x17.changelog.v1.ChangelogH\x00R\tchangelogB\a\n" +
+	"\x05event\"\xb1\x01\n" +
+	"\x17GenerateTimelineRequest\x12\x14\n" +
+	"\x05owner\x18\x01 \x01(\tR\x05owner\x12\x12\n" +
+	"\x04repo\x18\x02 \x01(\tR\x04repo\x127\n" +
+	"\tfrom_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bfromDate\x123\n" +
+	"\ato_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x06toDate\"\x94\x01\n" +
+	"\x18GenerateTimelineResponse\x129\n" +
+	"\bprogress\x18\x01 \x01(\v2\x1b.changelog.v1.ProgressEventH\x00R\bprogress\x124\n" +
+	"\btimeline\x18\x02 \x01(\v2\x16.changelog.v1.TimelineH\x00R\btimelineB\a\n" +
+	"\x05event\"o\n" +
+	"\rProgressEvent\x12\x14\n" +
+	"\x05stage\x18\x01 \x01(\tR\x05stage\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x18\n" +
+	"\acurrent\x18\x03 \x01(\x05R\acurrent\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x05R\x05total\"\xcf\x02\n" +
+	"\tChangelog\x12\x1b\n" +
+	"\trepo_name\x18\x01 \x01(\tR\brepoName\x12\x19\n" +
+	"\bfrom_ref\x18\x02 \x01(\tR\afromRef\x12\x15\n" +
+	"\x06to_ref\x18\x03 \x01(\tR\x05toRef\x12.\n" +
+	"\x04date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x18\n" +
+	"\asummary\x18\x05 \x01(\tR\asummary\x12\x1e\n" +
+	"\n" +
+	"highlights\x18\x06 \x03(\tR\n" +
+	"highlights\x126\n" +
+	"\n" +
+	"categories\x18\a \x03(\v2\x16.changelog.v1.CategoryR\n" +
+	"categories\x12!\n" +
+	"\fcommit_count\x18\b \x01(\x05R\vcommitCount\x12\x1a\n" +
+	"\bmarkdown\x18\t \x01(\tR\bmarkdown\x12\x12\n" +
+	"\x04json\x18\n" +
+	" \x01(\tR\x04json\"M\n" +
+	"\bCategory\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12-\n" +
+	"\aentries\x18\x02 \x03(\v2\x13.changelog.v1.EntryR\aentries\"\xa4\x01\n" +
+	"\x05Entry\x12\x10\n" +
+	"\x03sha\x18\x01 \x01(\tR\x03sha\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06author\x18\x04 \x01(\tR\x06author\x12)\n" +
+	"\x10importance_score\x18\x05 \x01(\x01R\x0fimportanceScore\x12\x0e\n" +
+	"\x02pr\x18\x06 \x01(\x05R\x02pr\"\xf8\x01\n" +
+	"\bTimeline\x12\x1b\n" +
+	"\trepo_name\x18\x01 \x01(\tR\brepoName\x127\n" +
+	"\tfrom_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bfromDate\x123\n" +
+	"\ato_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x06toDate\x121\n" +
+	"\breleases\x18\x04 \x03(\v2\x15.changelog.v1.ReleaseR\breleases\x12\x1a\n" +
+	"\bmarkdown\x18\x05 \x01(\tR\bmarkdown\x12\x12\n" +
+	"\x04json\x18\x06 \x01(\tR\x04json\"\xe6\x01\n" +
+	"\aRelease\x12\x19\n" +
+	"\bfrom_ref\x18\x01 \x01(\tR\afromRef\x12\x15\n" +
+	"\x06to_ref\x18\x02 \x01(\tR\x05toRef\x12.\n" +
+	"\x04date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x18\n" +
+	"\asummary\x18\x04 \x01(\tR\asummary\x12\x1e\n" +
+	"\n" +
+	"highlights\x18\x05 \x03(\tR\n" +
+	"highlights\x12#\n" +
+	"\rpull_requests\x18\x06 \x01(\x05R\fpullRequests\x12\x1a\n" +
+	"\bmarkdown\x18\a \x01(\tR\bmarkdown2\xdf\x01\n" +
+	"\x10ChangelogService\x12f\n" +
+	"\x11GenerateChangelog\x12&.changelog.v1.GenerateChangelogRequest\x1a'.changelog.v1.GenerateChangelogResponse0\x01\x12c\n" +
+	"\x10GenerateTimeline\x12%.changelog.v1.GenerateTimelineRequest\x1a&.changelog.v1.GenerateTimelineResponse0\x01BTZRgithub.com/rakshaksatsangi/changelog-generator/pkg/grpcapi/changelogv1;changelogv1b\x06proto3"
+
+var (
+	file_changelog_v1_changelog_proto_rawDescOnce sync.Once
+	file_changelog_v1_changelog_proto_rawDescData []byte
+)
+
+func file_changelog_v1_changelog_proto_rawDescGZIP() []byte {
+	file_changelog_v1_changelog_proto_rawDescOnce.Do(func() {
+		file_changelog_v1_changelog_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_changelog_v1_changelog_proto_rawDesc), len(file_changelog_v1_changelog_proto_rawDesc)))
+	})
+	return file_changelog_v1_changelog_proto_rawDescData
+}
+
+var file_changelog_v1_changelog_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_changelog_v1_changelog_proto_goTypes = []any{
+	(*GenerateChangelogRequest)(nil),  // 0: changelog.v1.GenerateChangelogRequest
+	(*GenerateChangelogResponse)(nil), // 1: changelog.v1.GenerateChangelogResponse
+	(*GenerateTimelineRequest)(nil),   // 2: changelog.v1.GenerateTimelineRequest
+	(*GenerateTimelineResponse)(nil),  // 3: changelog.v1.GenerateTimelineResponse
+	(*ProgressEvent)(nil),             // 4: changelog.v1.ProgressEvent
+	(*Changelog)(nil),                 // 5: changelog.v1.Changelog
+	(*Category)(nil),                  // 6: changelog.v1.Category
+	(*Entry)(nil),                     // 7: changelog.v1.Entry
+	(*Timeline)(nil),                  // 8: changelog.v1.Timeline
+	(*Release)(nil),                   // 9: changelog.v1.Release
+	(*timestamppb.Timestamp)(nil),     // 10: google.protobuf.Timestamp
+}
+var file_changelog_v1_changelog_proto_depIdxs = []int32{
+	4,  // 0: changelog.v1.GenerateChangelogResponse.progress:type_name -> changelog.v1.ProgressEvent
+	5,  // 1: changelog.v1.GenerateChangelogResponse.changelog:type_name -> changelog.v1.Changelog
+	10, // 2: changelog.v1.GenerateTimelineRequest.from_date:type_name -> google.protobuf.Timestamp
+	10, // 3: changelog.v1.GenerateTimelineRequest.to_date:type_name -> google.protobuf.Timestamp
+	4,  // 4: changelog.v1.GenerateTimelineResponse.progress:type_name -> changelog.v1.ProgressEvent
+	8,  // 5: changelog.v1.GenerateTimelineResponse.timeline:type_name -> changelog.v1.Timeline
+	10, // 6: changelog.v1.Changelog.date:type_name -> google.protobuf.Timestamp
+	6,  // 7: changelog.v1.Changelog.categories:type_name -> changelog.v1.Category
+	7,  // 8: changelog.v1.Category.entries:type_name -> changelog.v1.Entry
+	10, // 9: changelog.v1.Timeline.from_date:type_name -> google.protobuf.Timestamp
+	10, // 10: changelog.v1.Timeline.to_date:type_name -> google.protobuf.Timestamp
+	9,  // 11: changelog.v1.Timeline.releases:type_name -> changelog.v1.Release
+	10, // 12: changelog.v1.Release.date:type_name -> google.protobuf.Timestamp
+	0,  // 13: changelog.v1.ChangelogService.GenerateChangelog:input_type -> changelog.v1.GenerateChangelogRequest
+	2,  // 14: changelog.v1.ChangelogService.GenerateTimeline:input_type -> changelog.v1.GenerateTimelineRequest
+	1,  // 15: changelog.v1.ChangelogService.GenerateChangelog:output_type -> changelog.v1.GenerateChangelogResponse
+	3,  // 16: changelog.v1.ChangelogService.GenerateTimeline:output_type -> changelog.v1.GenerateTimelineResponse
+	15, // [15:17] is the sub-list for method output_type
+	13, // [13:15] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_changelog_v1_changelog_proto_init() }
+func file_changelog_v1_changelog_proto_init() {
+	if File_changelog_v1_changelog_proto != nil {
+		return
+	}
+	file_changelog_v1_changelog_proto_msgTypes[1].OneofWrappers = []any{
+		(*GenerateChangelogResponse_Progress)(nil),
+		(*GenerateChangelogResponse_Changelog)(nil),
+	}
+	file_changelog_v1_changelog_proto_msgTypes[3].OneofWrappers = []any{
+		(*GenerateTimelineResponse_Progress)(nil),
+		(*GenerateTimelineResponse_Timeline)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_changelog_v1_changelog_proto_rawDesc), len(file_changelog_v1_changelog_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_changelog_v1_changelog_proto_goTypes,
+		DependencyIndexes: file_changelog_v1_changelog_proto_depIdxs,
+		MessageInfos:      file_changelog_v1_changelog_proto_msgTypes,
+	}.Build()
+	File_changelog_v1_changelog_proto = out.File
+	file_changelog_v1_changelog_proto_goTypes = nil
+	file_changelog_v1_changelog_proto_depIdxs = nil
+}
@@ -0,0 +1,180 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: changelog/v1/changelog.proto
+
+// Changelog generation for internal services: the gRPC counterpart of the
+// generate command. Regenerate the Go code with `go generate ./pkg/grpcapi`.
+
+package changelogv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ChangelogService_GenerateChangelog_FullMethodName = "/changelog.v1.ChangelogService/GenerateChangelog"
+	ChangelogService_GenerateTimeline_FullMethodName  = "/changelog.v1.ChangelogService/GenerateTimeline"
+)
+
+// ChangelogServiceClient is the client API for ChangelogService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ChangelogService generates changelogs with the server's configuration
+// (model, provider, prompts, hooks). Both calls stream progress events while
+// the run is underway and end with the result.
+type ChangelogServiceClient interface {
+	// GenerateChangelog describes the commits of one ref range
+	GenerateChangelog(ctx context.Context, in *GenerateChangelogRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GenerateChangelogResponse], error)
+	// GenerateTimeline describes every release published in a date range
+	GenerateTimeline(ctx context.Context, in *GenerateTimelineRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GenerateTimelineResponse], error)
+}
+
+type changelogServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewChangelogServiceClient(cc grpc.ClientConnInterface) ChangelogServiceClient {
+	return &changelogServiceClient{cc}
+}
+
+func (c *changelogServiceClient) GenerateChangelog(ctx context.Context, in *GenerateChangelogRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GenerateChangelogResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ChangelogService_ServiceDesc.Streams[0], ChangelogService_GenerateChangelog_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GenerateChangelogRequest, GenerateChangelogResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChangelogService_GenerateChangelogClient = grpc.ServerStreamingClient[GenerateChangelogResponse]
+
+func (c *changelogServiceClient) GenerateTimeline(ctx context.Context, in *GenerateTimelineRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GenerateTimelineResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ChangelogService_ServiceDesc.Streams[1], ChangelogService_GenerateTimeline_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GenerateTimelineRequest, GenerateTimelineResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChangelogService_GenerateTimelineClient = grpc.ServerStreamingClient[GenerateTimelineResponse]
+
+// ChangelogServiceServer is the server API for ChangelogService service.
+// All implementations must embed UnimplementedChangelogServiceServer
+// for forward compatibility.
+//
+// ChangelogService generates changelogs with the server's configuration
+// (model, provider, prompts, hooks). Both calls stream progress events while
+// the run is underway and end with the result.
+type ChangelogServiceServer interface {
+	// GenerateChangelog describes the commits of one ref range
+	GenerateChangelog(*GenerateChangelogRequest, grpc.ServerStreamingServer[GenerateChangelogResponse]) error
+	// GenerateTimeline describes every release published in a date range
+	GenerateTimeline(*GenerateTimelineRequest, grpc.ServerStreamingServer[GenerateTimelineResponse]) error
+	mustEmbedUnimplementedChangelogServiceServer()
+}
+
+// UnimplementedChangelogServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedChangelogServiceServer struct{}
+
+func (UnimplementedChangelogServiceServer) GenerateChangelog(*GenerateChangelogRequest, grpc.ServerStreamingServer[GenerateChangelogResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method GenerateChangelog not implemented")
+}
+func (UnimplementedChangelogServiceServer) GenerateTimeline(*GenerateTimelineRequest, grpc.ServerStreamingServer[GenerateTimelineResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method GenerateTimeline not implemented")
+}
+func (UnimplementedChangelogServiceServer) mustEmbedUnimplementedChangelogServiceServer() {}
+func (UnimplementedChangelogServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeChangelogServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ChangelogServiceServer will
+// result in compilation errors.
+type UnsafeChangelogServiceServer interface {
+	mustEmbedUnimplementedChangelogServiceServer()
+}
+
+func RegisterChangelogServiceServer(s grpc.ServiceRegistrar, srv ChangelogServiceServer) {
+	// If the following call pancis, it indicates UnimplementedChangelogServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ChangelogService_ServiceDesc, srv)
+}
+
+func _ChangelogService_GenerateChangelog_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GenerateChangelogRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ChangelogServiceServer).GenerateChangelog(m, &grpc.GenericServerStream[GenerateChangelogRequest, GenerateChangelogResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChangelogService_GenerateChangelogServer = grpc.ServerStreamingServer[GenerateChangelogResponse]
+
+func _ChangelogService_GenerateTimeline_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GenerateTimelineRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ChangelogServiceServer).GenerateTimeline(m, &grpc.GenericServerStream[GenerateTimelineRequest, GenerateTimelineResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ChangelogService_GenerateTimelineServer = grpc.ServerStreamingServer[GenerateTimelineResponse]
+
+// ChangelogService_ServiceDesc is the grpc.ServiceDesc for ChangelogService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ChangelogService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "changelog.v1.ChangelogService",
+	HandlerType: (*ChangelogServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GenerateChangelog",
+			Handler:       _ChangelogService_GenerateChangelog_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GenerateTimeline",
+			Handler:       _ChangelogService_GenerateTimeline_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "changelog/v1/changelog.proto",
+}
@@ -0,0 +1,236 @@
+// Package grpcapi serves changelog generation to internal services over gRPC:
+// the ChangelogService of proto/changelog/v1/changelog.proto, whose generated
+// types live in the changelogv1 package
+package grpcapi
+
+//go:generate protoc -I ../../proto --go_out=../.. --go_opt=module=github.com/rakshaksatsangi/changelog-generator --go-grpc_out=../.. --go-grpc_opt=module=github.com/rakshaksatsangi/changelog-generator changelog/v1/changelog.proto
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/generator"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/grpcapi/changelogv1"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var logger = logging.Module("grpcapi")
+
+// GeneratorFactory builds the generator for one request on owner/repo; empty
+// values mean the server's configured repository
+type GeneratorFactory func(ctx context.Context, owner, repo string) (*generator.Generator, error)
+
+// Server implements changelogv1.ChangelogServiceServer with a fresh generator
+// per request, so concurrent requests share no run state
+type Server struct {
+	changelogv1.UnimplementedChangelogServiceServer
+	newGenerator GeneratorFactory
+}
+
+// NewServer creates a service building its generators with newGenerator
+func NewServer(newGenerator GeneratorFactory) *Server {
+	return &Server{newGenerator: newGenerator}
+}
+
+// Register adds the service to a gRPC server
+func (s *Server) Register(registrar grpc.ServiceRegistrar) {
+	changelogv1.RegisterChangelogServiceServer(registrar, s)
+}
+
+func (s *Server) GenerateChangelog(req *changelogv1.GenerateChangelogRequest, stream grpc.ServerStreamingServer[changelogv1.GenerateChangelogResponse]) error {
+	if req.GetFrom() == "" || req.GetTo() == "" {
+		return status.Error(codes.InvalidArgument, "from and to are required")
+	}
+	ctx := stream.Context()
+	gen, err := s.newGenerator(ctx, req.GetOwner(), req.GetRepo())
+	if err != nil {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	send := progressSender(func(event *changelogv1.ProgressEvent) error {
+		return stream.Send(&changelogv1.GenerateChangelogResponse{
+			Event: &changelogv1.GenerateChangelogResponse_Progress{Progress: event},
+		})
+	})
+	gen.SetProgress(send)
+	logger.Info("generating changelog", "repo", req.GetOwner()+"/"+req.GetRepo(), "from", req.GetFrom(), "to", req.GetTo())
+	changelog, err := gen.Generate(ctx, req.GetFrom(), req.GetTo())
+	if err != nil {
+		return runError(ctx, fmt.Errorf("generate changelog: %w", err))
+	}
+
+	message, err := changelogMessage(changelog)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	return stream.Send(&changelogv1.GenerateChangelogResponse{
+		Event: &changelogv1.GenerateChangelogResponse_Changelog{Changelog: message},
+	})
+}
+
+func (s *Server) GenerateTimeline(req *changelogv1.GenerateTimelineRequest, stream grpc.ServerStreamingServer[changelogv1.GenerateTimelineResponse]) error {
+	if req.GetFromDate() == nil || req.GetToDate() == nil {
+		return status.Error(codes.InvalidArgument, "from_date and to_date are required")
+	}
+	from, to := req.GetFromDate().AsTime(), req.GetToDate().AsTime()
+	if from.After(to) {
+		return status.Error(codes.InvalidArgument, "from_date must be before to_date")
+	}
+	ctx := stream.Context()
+	gen, err := s.newGenerator(ctx, req.GetOwner(), req.GetRepo())
+	if err != nil {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	send := progressSender(func(event *changelogv1.ProgressEvent) error {
+		return stream.Send(&changelogv1.GenerateTimelineResponse{
+			Event: &changelogv1.GenerateTimelineResponse_Progress{Progress: event},
+		})
+	})
+	gen.SetProgress(send)
+	logger.Info("generating timeline", "repo", req.GetOwner()+"/"+req.GetRepo(),
+		"from_date", from.Format(time.DateOnly), "to_date", to.Format(time.DateOnly))
+	timeline, err := gen.GenerateTimeline(ctx, from, to)
+	if err != nil {
+		return runError(ctx, fmt.Errorf("generate timeline changelog: %w", err))
+	}
+
+	message, err := timelineMessage(timeline)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	return stream.Send(&changelogv1.GenerateTimelineResponse{
+		Event: &changelogv1.GenerateTimelineResponse_Timeline{Timeline: message},
+	})
+}
+
+// progressSender adapts send into a generator progress callback. Sends are
+// serialized, and after one fails (the client went away, which also cancels
+// the run) the rest are dropped.
+func progressSender(send func(*changelogv1.ProgressEvent) error) func(generator.ProgressEvent) {
+	var mu sync.Mutex
+	failed := false
+	return func(event generator.ProgressEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		if failed {
+			return
+		}
+		err := send(&changelogv1.ProgressEvent{
+			Stage:   event.Stage,
+			Message: event.Message,
+			Current: int32(event.Current),
+			Total:   int32(event.Total),
+		})
+		if err != nil {
+			logger.Debug("dropping progress events", "error", err)
+			failed = true
+		}
+	}
+}
+
+// runError converts a failed run to a status; cancellations and deadlines
+// keep their codes
+func runError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return status.FromContextError(ctx.Err()).Err()
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// changelogMessage converts a changelog, with the JSON that --format=json writes
+func changelogMessage(changelog *generator.Changelog) (*changelogv1.Changelog, error) {
+	data, err := json.MarshalIndent(changelog, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode changelog: %w", err)
+	}
+	return &changelogv1.Changelog{
+		RepoName:    changelog.RepoName,
+		FromRef:     changelog.FromRef,
+		ToRef:       changelog.ToRef,
+		Date:        timestamp(changelog.Date),
+		Summary:     changelog.Summary,
+		Highlights:  changelog.Highlights,
+		Categories:  categoryMessages(changelog.Categories),
+		CommitCount: int32(changelog.CommitCount),
+		Markdown:    changelog.Markdown,
+		Json:        string(data),
+	}, nil
+}
+
+// timelineMessage converts a timeline, with the JSON that --format=json writes
+func timelineMessage(timeline *generator.TimelineChangelog) (*changelogv1.Timeline, error) {
+	data, err := json.MarshalIndent(timeline, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode timeline: %w", err)
+	}
+	message := &changelogv1.Timeline{
+		RepoName: timeline.RepoName,
+		FromDate: timestamp(timeline.FromDate),
+		ToDate:   timestamp(timeline.ToDate),
+		Markdown: timeline.Markdown,
+		Json:     string(data),
+	}
+	for _, release := range timeline.Releases {
+		message.Releases = append(message.Releases, &changelogv1.Release{
+			FromRef:      release.FromRef,
+			ToRef:        release.ToRef,
+			Date:         timestamp(release.ToDate),
+			Summary:      release.Summary,
+			Highlights:   release.Highlights,
+			PullRequests: int32(len(release.PullRequests)),
+			Markdown:     release.Zoom.Full,
+		})
+	}
+	return message, nil
+}
+
+// categoryMessages lists categories in CategoryOrder, then any others by name
+func categoryMessages(categories map[string][]llm.ChangelogEntry) []*changelogv1.Category {
+	var names []string
+	for name := range categories {
+		if !slices.Contains(generator.CategoryOrder, name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	names = append(slices.Clone(generator.CategoryOrder), names...)
+
+	var messages []*changelogv1.Category
+	for _, name := range names {
+		entries := categories[name]
+		if len(entries) == 0 {
+			continue
+		}
+		category := &changelogv1.Category{Name: name}
+		for _, entry := range entries {
+			category.Entries = append(category.Entries, &changelogv1.Entry{
+				Sha:             entry.SHA,
+				Title:           entry.Title,
+				Description:     entry.Description,
+				Author:          entry.Author,
+				ImportanceScore: entry.ImportanceScore,
+				Pr:              int32(entry.PR),
+			})
+		}
+		messages = append(messages, category)
+	}
+	return messages
+}
+
+// timestamp converts t, leaving the zero time unset
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
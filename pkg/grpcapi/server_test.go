@@ -0,0 +1,205 @@
+package grpcapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/generator"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/grpcapi/changelogv1"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm/mock"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// testFixture has two commits in v1.0.0..v1.1.0 and one release in the first
+// quarter of 2025
+func testFixture() *provider.Fixture {
+	date := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	commits := []provider.CommitData{
+		{SHA: "aaaa1111", Message: "feat: add export", Author: "alice", Date: date},
+		{SHA: "bbbb2222", Message: "fix: retry uploads", Author: "bob", Date: date},
+	}
+	return &provider.Fixture{
+		Repo:    "acme/widgets",
+		Commits: map[string][]provider.CommitData{"v1.0.0..v1.1.0": commits},
+		Timelines: map[string][]provider.TimelineRelease{
+			"2025-01-01T00:00:00Z..2025-03-31T00:00:00Z": {{
+				FromRef: "v1.0.0", ToRef: "v1.1.0", FromDate: date.AddDate(0, 0, -7), ToDate: date,
+				CommitCount: len(commits), Commits: commits,
+				PullRequests: []provider.PullRequestData{{Number: 1, Title: "feat: add export", Author: "alice"}},
+			}},
+		},
+		ListsReleases: true,
+		ListsTags:     true,
+	}
+}
+
+// dialServer serves a Server building generators with newGenerator over an
+// in-memory connection and returns a client for it
+func dialServer(t *testing.T, newGenerator GeneratorFactory) changelogv1.ChangelogServiceClient {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	NewServer(newGenerator).Register(server)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return changelogv1.NewChangelogServiceClient(conn)
+}
+
+// mockGenerators builds generators on testFixture with the mock LLM and
+// records the repository of each request
+func mockGenerators(repos *[]string) GeneratorFactory {
+	return func(ctx context.Context, owner, repo string) (*generator.Generator, error) {
+		*repos = append(*repos, owner+"/"+repo)
+		cfg := config.Default()
+		cfg.RepoOwner, cfg.RepoName = "acme", "widgets"
+		return generator.New(testFixture(), mock.New(), generator.WithConfig(cfg)), nil
+	}
+}
+
+// receiveAll reads a stream to its end
+func receiveAll[T any](t *testing.T, recv func() (*T, error)) []*T {
+	t.Helper()
+	var messages []*T
+	for {
+		message, err := recv()
+		if errors.Is(err, io.EOF) {
+			return messages
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		messages = append(messages, message)
+	}
+}
+
+func TestGenerateChangelogStreamsProgressThenChangelog(t *testing.T) {
+	var repos []string
+	client := dialServer(t, mockGenerators(&repos))
+
+	stream, err := client.GenerateChangelog(context.Background(), &changelogv1.GenerateChangelogRequest{
+		Owner: "acme", Repo: "widgets", From: "v1.0.0", To: "v1.1.0",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	messages := receiveAll(t, stream.Recv)
+
+	var stages []string
+	for _, message := range messages[:len(messages)-1] {
+		stages = append(stages, message.GetProgress().GetStage())
+	}
+	if len(stages) == 0 || stages[0] != generator.StageFetching || stages[len(stages)-1] != generator.StageDone {
+		t.Errorf("progress stages = %v", stages)
+	}
+
+	changelog := messages[len(messages)-1].GetChangelog()
+	if changelog == nil {
+		t.Fatalf("last message = %v, want the changelog", messages[len(messages)-1])
+	}
+	if changelog.GetCommitCount() != 2 || changelog.GetFromRef() != "v1.0.0" || changelog.GetMarkdown() == "" {
+		t.Errorf("changelog = %v", changelog)
+	}
+	var names []string
+	for _, category := range changelog.GetCategories() {
+		names = append(names, category.GetName())
+	}
+	if len(names) != 2 || names[0] != "Features" || names[1] != "Bug Fixes" {
+		t.Errorf("categories = %v, want Features then Bug Fixes", names)
+	}
+	var decoded generator.Changelog
+	if err := json.Unmarshal([]byte(changelog.GetJson()), &decoded); err != nil || decoded.ToRef != "v1.1.0" {
+		t.Errorf("json = %s (%v)", changelog.GetJson(), err)
+	}
+	if len(repos) != 1 || repos[0] != "acme/widgets" {
+		t.Errorf("generators built for %v", repos)
+	}
+}
+
+func TestGenerateTimelineStreamsProgressThenTimeline(t *testing.T) {
+	var repos []string
+	client := dialServer(t, mockGenerators(&repos))
+
+	stream, err := client.GenerateTimeline(context.Background(), &changelogv1.GenerateTimelineRequest{
+		FromDate: timestamppb.New(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)),
+		ToDate:   timestamppb.New(time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	messages := receiveAll(t, stream.Recv)
+
+	var releaseEvents int
+	for _, message := range messages[:len(messages)-1] {
+		if progress := message.GetProgress(); progress.GetStage() == generator.StageRelease {
+			releaseEvents++
+			if progress.GetCurrent() != 1 || progress.GetTotal() != 1 {
+				t.Errorf("release progress = %v", progress)
+			}
+		}
+	}
+	if releaseEvents != 1 {
+		t.Errorf("%d release progress events, want 1", releaseEvents)
+	}
+
+	timeline := messages[len(messages)-1].GetTimeline()
+	if len(timeline.GetReleases()) != 1 || timeline.GetReleases()[0].GetToRef() != "v1.1.0" || timeline.GetReleases()[0].GetPullRequests() != 1 {
+		t.Fatalf("timeline = %v", timeline)
+	}
+	if len(repos) != 1 || repos[0] != "/" {
+		t.Errorf("generators built for %v, want the server's repository", repos)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	var repos []string
+	client := dialServer(t, func(ctx context.Context, owner, repo string) (*generator.Generator, error) {
+		if owner == "unknown" {
+			return nil, errors.New("GitHub access validation failed")
+		}
+		return mockGenerators(&repos)(ctx, owner, repo)
+	})
+
+	tests := []struct {
+		name string
+		req  *changelogv1.GenerateChangelogRequest
+		want codes.Code
+	}{
+		{"missing range", &changelogv1.GenerateChangelogRequest{From: "v1.0.0"}, codes.InvalidArgument},
+		{"inaccessible repository", &changelogv1.GenerateChangelogRequest{Owner: "unknown", From: "v1.0.0", To: "v1.1.0"}, codes.FailedPrecondition},
+		{"unknown range", &changelogv1.GenerateChangelogRequest{From: "v0.9.0", To: "v1.0.0"}, codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, err := client.GenerateChangelog(context.Background(), tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for err == nil {
+				_, err = stream.Recv()
+			}
+			if status.Code(err) != tt.want {
+				t.Errorf("error = %v, want code %s", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Package hooks runs the shell commands configured under hooks: at the
+// generator's hook points. Commands read the stage's data as JSON on stdin and
+// may print a replacement on stdout; a non-zero exit vetoes the run.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/generator"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/logging"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/provider"
+)
+
+var logger = logging.Module("hooks")
+
+// FromConfig builds generator hooks from the configured commands; stages
+// without a command are left unset
+func FromConfig(cfg *config.Config) generator.Hooks {
+	var hooks generator.Hooks
+	if command := cfg.HookPreFetch; command != "" {
+		hooks.PreFetch = func(ctx context.Context, from, to string) error {
+			input := map[string]string{"from": from, "to": to}
+			_, err := Run(ctx, "pre_fetch", command, input)
+			return err
+		}
+	}
+	if command := cfg.HookPostFetch; command != "" {
+		hooks.PostFetch = func(ctx context.Context, commits []provider.CommitData) ([]provider.CommitData, error) {
+			return filter(ctx, "post_fetch", command, commits)
+		}
+	}
+	if command := cfg.HookPreLLM; command != "" {
+		hooks.PreLLM = func(ctx context.Context, commits []llm.CommitInfo) ([]llm.CommitInfo, error) {
+			return filter(ctx, "pre_llm", command, commits)
+		}
+	}
+	if command := cfg.HookPostLLM; command != "" {
+		hooks.PostLLM = func(ctx context.Context, response *llm.ChangelogResponse) error {
+			edited, err := filter(ctx, "post_llm", command, *response)
+			if err != nil {
+				return err
+			}
+			edited.Repaired = response.Repaired
+			*response = edited
+			return nil
+		}
+	}
+	if command := cfg.HookPreWrite; command != "" {
+		hooks.PreWrite = func(ctx context.Context, changelog *generator.Changelog) error {
+			output, err := RunText(ctx, "pre_write", command, changelog.Markdown)
+			if err != nil {
+				return err
+			}
+			if strings.TrimSpace(output) != "" {
+				changelog.Markdown = output
+			}
+			return nil
+		}
+	}
+	return hooks
+}
+
+// filter passes value through a command as JSON. Empty output keeps value.
+func filter[T any](ctx context.Context, stage, command string, value T) (T, error) {
+	output, err := Run(ctx, stage, command, value)
+	if err != nil || len(bytes.TrimSpace(output)) == 0 {
+		return value, err
+	}
+	var replaced T
+	if err := json.Unmarshal(output, &replaced); err != nil {
+		return value, fmt.Errorf("%s hook: decode output of %q: %w", stage, command, err)
+	}
+	return replaced, nil
+}
+
+// Run runs command with the shell, writing input as JSON to its stdin, and
+// returns its stdout. The stage name is passed in CHANGELOG_HOOK.
+func Run(ctx context.Context, stage, command string, input any) ([]byte, error) {
+	data, err := json.Marshal(input)
+	if err != nil {
+		return nil, fmt.Errorf("%s hook: encode input: %w", stage, err)
+	}
+	return run(ctx, stage, command, data, nil)
+}
+
+// RunText runs command like Run with plain text on stdin and stdout
+func RunText(ctx context.Context, stage, command, input string) (string, error) {
+	output, err := run(ctx, stage, command, []byte(input), nil)
+	return string(output), err
+}
+
+// run executes command with sh -c and extra environment variables; its stderr
+// goes to ours so scripts can log
+func run(ctx context.Context, stage, command string, input []byte, env []string) ([]byte, error) {
+	logger.Info("running hook", "stage", stage, "command", command)
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(append(os.Environ(), "CHANGELOG_HOOK="+stage), env...)
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%s hook %q: %w", stage, command, err)
+	}
+	return output, nil
+}
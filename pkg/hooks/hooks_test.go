@@ -0,0 +1,80 @@
+package hooks
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/generator"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+)
+
+func TestFromConfig(t *testing.T) {
+	ctx := context.Background()
+	hooks := FromConfig(&config.Config{
+		HookPreFetch: `grep -q '"to":"v2.0.0"' && exit 1 || exit 0`,                   // Vetoes major releases
+		HookPreLLM:   `grep -q wip && echo '[{"sha": "a1", "message": "Add login"}]'`, // Replaces the commits
+		HookPostLLM:  `cat >/dev/null`,                                                // Empty output keeps the entries
+		HookPreWrite: `sed "s/^# /# [$CHANGELOG_HOOK] /"`,
+	})
+	if hooks.PostFetch != nil {
+		t.Error("Expected no post-fetch hook without a command")
+	}
+
+	if err := hooks.PreFetch(ctx, "v1.0.0", "v1.1.0"); err != nil {
+		t.Errorf("Expected v1.1.0 to pass, got %v", err)
+	}
+	if err := hooks.PreFetch(ctx, "v1.1.0", "v2.0.0"); err == nil {
+		t.Error("Expected the pre-fetch hook to veto v2.0.0")
+	}
+
+	commits, err := hooks.PreLLM(ctx, []llm.CommitInfo{{SHA: "a1", Message: "Add login"}, {SHA: "b2", Message: "wip"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(commits) != 1 || commits[0].SHA != "a1" {
+		t.Errorf("Expected the hook's commits, got %+v", commits)
+	}
+
+	response := &llm.ChangelogResponse{Summary: "Kept", Repaired: true}
+	if err := hooks.PostLLM(ctx, response); err != nil || response.Summary != "Kept" || !response.Repaired {
+		t.Errorf("Expected empty output to keep the response, got %+v, %v", response, err)
+	}
+
+	changelog := &generator.Changelog{Markdown: "# Changelog: v1.0.0 → v1.1.0\n"}
+	if err := hooks.PreWrite(ctx, changelog); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(changelog.Markdown, "# [pre_write] Changelog") {
+		t.Errorf("Expected the pre-write hook to edit the markdown, got %q", changelog.Markdown)
+	}
+}
+
+func TestPostGenerate(t *testing.T) {
+	dir := t.TempDir()
+	data := PostGenerateData{OutputPath: dir + "/it's notes.md", Format: "markdown", Repo: "acme/api", From: "v1.0.0", To: "v1.1.0", Version: "v1.1.0"}
+	command := `printf '%s|%s|%s' {{ .OutputPath }} "$RELEASE" "$CHANGELOG_REPO" > ` + dir + `/out`
+
+	if err := PostGenerate(context.Background(), command, []string{"RELEASE=api-{{ .Version }}"}, data); err != nil {
+		t.Fatal(err)
+	}
+	out, err := os.ReadFile(dir + "/out")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := dir + "/it's notes.md|api-v1.1.0|acme/api"; string(out) != want {
+		t.Errorf("PostGenerate wrote %q, want %q", out, want)
+	}
+
+	if err := PostGenerate(context.Background(), "true {{ .Tag }}", nil, data); err == nil {
+		t.Error("Expected an error for an unknown template field")
+	}
+	if err := PostGenerate(context.Background(), "exit 3", nil, data); err == nil {
+		t.Error("Expected a failing command to return an error")
+	}
+	if err := PostGenerate(context.Background(), "true", []string{"NOVALUE"}, data); err == nil {
+		t.Error("Expected an error for an env entry without =")
+	}
+}
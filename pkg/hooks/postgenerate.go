@@ -0,0 +1,84 @@
+package hooks
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// PostGenerateData describes a finished run to the post_generate command's
+// template and environment
+type PostGenerateData struct {
+	OutputPath string // "-" when the changelog went to stdout
+	Format     string
+	Repo       string // owner/repo
+	From       string // Start ref, or start date in timeline mode
+	To         string // End ref, or end date in timeline mode
+	Version    string // The released version (same as To for ref ranges)
+}
+
+// environment returns the variables every post_generate command receives
+func (d PostGenerateData) environment() []string {
+	return []string{
+		"CHANGELOG_OUTPUT=" + d.OutputPath,
+		"CHANGELOG_FORMAT=" + d.Format,
+		"CHANGELOG_REPO=" + d.Repo,
+		"CHANGELOG_FROM=" + d.From,
+		"CHANGELOG_TO=" + d.To,
+		"CHANGELOG_VERSION=" + d.Version,
+	}
+}
+
+// PostGenerate runs the post_generate command once the changelog has been
+// written. Template fields such as {{ .OutputPath }} are replaced with
+// shell-quoted values, so they must not be quoted again in the command. env
+// holds extra KEY=value variables whose values are templates (not quoted).
+// The command's output goes to stderr, keeping stdout for the changelog.
+func PostGenerate(ctx context.Context, command string, env []string, data PostGenerateData) error {
+	quoted := data
+	for _, field := range []*string{&quoted.OutputPath, &quoted.Format, &quoted.Repo, &quoted.From, &quoted.To, &quoted.Version} {
+		*field = shellQuote(*field)
+	}
+	rendered, err := render("hooks.post_generate", command, quoted)
+	if err != nil {
+		return err
+	}
+
+	variables := data.environment()
+	for _, entry := range env {
+		name, value, ok := strings.Cut(entry, "=")
+		if !ok || name == "" {
+			return fmt.Errorf("hooks.env: expected KEY=value, got %q", entry)
+		}
+		if value, err = render("hooks.env."+name, value, data); err != nil {
+			return err
+		}
+		variables = append(variables, name+"="+value)
+	}
+
+	output, err := run(ctx, "post_generate", rendered, nil, variables)
+	if len(output) > 0 {
+		os.Stderr.Write(output)
+	}
+	return err
+}
+
+// render executes a command or variable template, failing on unknown fields
+func render(name, text string, data PostGenerateData) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("parse %s template: %w", name, err)
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("render %s template: %w", name, err)
+	}
+	return sb.String(), nil
+}
+
+// shellQuote quotes s as a single POSIX shell word
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
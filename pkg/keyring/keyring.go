@@ -0,0 +1,75 @@
+// Package keyring stores credentials in the operating system's keychain
+// through its command-line tools: security(1) on macOS and secret-tool(1)
+// (libsecret, e.g. GNOME Keyring or KWallet) on Linux
+package keyring
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Service is the keychain service name every secret is stored under
+const Service = "changelog-generator"
+
+// Accounts under which auth login stores tokens
+const (
+	GitHubToken  = "github_token"
+	OpenAIAPIKey = "openai_api_key"
+)
+
+// ErrNotFound is returned by Get when no secret is stored for an account
+var ErrNotFound = errors.New("secret not found in keychain")
+
+// ErrUnsupported is returned when this platform has no supported keychain tool
+var ErrUnsupported = errors.New("no supported keychain on this system")
+
+// Get returns the secret stored for account
+func Get(account string) (string, error) {
+	return get(account)
+}
+
+// Set stores secret for account, replacing any previous value
+func Set(account, secret string) error {
+	if secret == "" {
+		return fmt.Errorf("store %s: empty secret", account)
+	}
+	return set(account, secret)
+}
+
+// Delete removes the secret stored for account; a missing secret is not an error
+func Delete(account string) error {
+	return remove(account)
+}
+
+// Lookup returns the secret for account, or "" when none is stored or the
+// keychain is unavailable, for callers that fall back silently
+func Lookup(account string) string {
+	secret, err := Get(account)
+	if err != nil {
+		return ""
+	}
+	return secret
+}
+
+// run executes a keychain tool with input on stdin and returns its trimmed
+// stdout, folding stderr into the error
+func run(input, name string, args ...string) (string, error) {
+	if _, err := exec.LookPath(name); err != nil {
+		return "", fmt.Errorf("%w (%s not found)", ErrUnsupported, name)
+	}
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return "", fmt.Errorf("%s: %w: %s", name, err, message)
+		}
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+	return strings.TrimRight(stdout.String(), "\r\n"), nil
+}
@@ -0,0 +1,38 @@
+package keyring
+
+import (
+	"errors"
+	"os/exec"
+)
+
+// security exits with 44 when the item does not exist
+const securityItemNotFound = 44
+
+func get(account string) (string, error) {
+	secret, err := run("", "security", "find-generic-password", "-s", Service, "-a", account, "-w")
+	if isNotFound(err) {
+		return "", ErrNotFound
+	}
+	return secret, err
+}
+
+func set(account, secret string) error {
+	// -U updates an existing item instead of failing. A trailing -w without a
+	// value makes security(1) prompt for the secret, and then again to confirm
+	// it, so it is written to stdin twice and stays out of the process list.
+	_, err := run(secret+"\n"+secret+"\n", "security", "add-generic-password", "-U", "-s", Service, "-a", account, "-l", Service+" "+account, "-w")
+	return err
+}
+
+func remove(account string) error {
+	_, err := run("", "security", "delete-generic-password", "-s", Service, "-a", account)
+	if isNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func isNotFound(err error) bool {
+	var exitErr *exec.ExitError
+	return errors.As(err, &exitErr) && exitErr.ExitCode() == securityItemNotFound
+}
@@ -0,0 +1,24 @@
+package keyring
+
+const fakeToolName = "security"
+
+// fakeToolScript mimics security(1): add-generic-password prompts for the
+// secret twice on stdin, and missing items exit 44. It fails if the secret
+// is passed as an argument after -w.
+const fakeToolScript = `#!/bin/sh
+command=$1
+shift
+[ "$command" = add-generic-password ] && shift # -U
+file="$FAKE_KEYRING/$2-$4"
+case $command in
+add-generic-password)
+	[ "$7" = -w ] && [ $# -eq 7 ] || { echo "secret in argv" >&2; exit 2; }
+	read -r secret
+	read -r confirm
+	[ "$secret" = "$confirm" ] || exit 1
+	printf '%s' "$secret" > "$file" ;;
+find-generic-password) [ -f "$file" ] || exit 44; cat "$file"; echo ;;
+delete-generic-password) [ -f "$file" ] || exit 44; rm "$file" ;;
+*) exit 2 ;;
+esac
+`
@@ -0,0 +1,26 @@
+package keyring
+
+import (
+	"errors"
+	"os/exec"
+)
+
+func get(account string) (string, error) {
+	secret, err := run("", "secret-tool", "lookup", "service", Service, "account", account)
+	var exitErr *exec.ExitError
+	if (err == nil && secret == "") || errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return "", ErrNotFound
+	}
+	return secret, err
+}
+
+func set(account, secret string) error {
+	// secret-tool reads the secret from stdin, keeping it out of the process list
+	_, err := run(secret, "secret-tool", "store", "--label="+Service+" "+account, "service", Service, "account", account)
+	return err
+}
+
+func remove(account string) error {
+	_, err := run("", "secret-tool", "clear", "service", Service, "account", account)
+	return err
+}
@@ -0,0 +1,18 @@
+package keyring
+
+const fakeToolName = "secret-tool"
+
+// fakeToolScript mimics secret-tool: store reads the secret from stdin, and
+// lookup prints nothing and exits 1 for a missing secret
+const fakeToolScript = `#!/bin/sh
+command=$1
+shift
+[ "$command" = store ] && shift # --label
+file="$FAKE_KEYRING/$2-$4"
+case $command in
+store) cat > "$file" ;;
+lookup) [ -f "$file" ] || exit 1; cat "$file" ;;
+clear) rm -f "$file" ;;
+*) exit 2 ;;
+esac
+`
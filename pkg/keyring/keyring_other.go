@@ -0,0 +1,15 @@
+//go:build !darwin && !linux
+
+package keyring
+
+func get(account string) (string, error) {
+	return "", ErrUnsupported
+}
+
+func set(account, secret string) error {
+	return ErrUnsupported
+}
+
+func remove(account string) error {
+	return ErrUnsupported
+}
@@ -0,0 +1,72 @@
+//go:build darwin || linux
+
+package keyring
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fakeTool puts script on PATH as the platform's keychain tool, keeping
+// secrets as files in a temporary directory
+func fakeTool(t *testing.T) {
+	t.Helper()
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, fakeToolName), []byte(fakeToolScript), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("FAKE_KEYRING", t.TempDir())
+}
+
+func TestRoundTrip(t *testing.T) {
+	fakeTool(t)
+
+	if _, err := Get(GitHubToken); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get(missing) error = %v, want ErrNotFound", err)
+	}
+	if Lookup(GitHubToken) != "" {
+		t.Error("Lookup(missing) should be empty")
+	}
+	if err := Set(GitHubToken, "ghp_first"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Set(GitHubToken, "ghp_second"); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := Get(GitHubToken); err != nil || got != "ghp_second" {
+		t.Errorf("Get() = %q, %v; want the replaced secret", got, err)
+	}
+	if Lookup(OpenAIAPIKey) != "" {
+		t.Error("Secrets of other accounts should stay separate")
+	}
+
+	if err := Delete(GitHubToken); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Get(GitHubToken); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() after Delete error = %v, want ErrNotFound", err)
+	}
+	if err := Delete(GitHubToken); err != nil {
+		t.Errorf("Delete(missing) error = %v", err)
+	}
+}
+
+func TestSetRejectsEmptySecrets(t *testing.T) {
+	fakeTool(t)
+	if err := Set(GitHubToken, ""); err == nil {
+		t.Error("expected error for an empty secret")
+	}
+}
+
+func TestMissingTool(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	if _, err := Get(GitHubToken); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("Get() error = %v, want ErrUnsupported", err)
+	}
+	if Lookup(GitHubToken) != "" {
+		t.Error("Lookup() should be empty without a keychain")
+	}
+}
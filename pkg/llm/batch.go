@@ -0,0 +1,192 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/openai/openai-go"
+)
+
+// BatchItem is one PR changelog request of a Batch API job, identified by a
+// caller-chosen ID that keys its result
+type BatchItem struct {
+	ID      string
+	Request PRChangelogRequest
+}
+
+// BatchStatus is the progress of a Batch API job
+type BatchStatus struct {
+	ID        string
+	Status    string // validating, in_progress, finalizing, completed, failed, expired, cancelling, or cancelled
+	Total     int
+	Completed int
+	Failed    int
+}
+
+// Done reports whether the job has stopped running, successfully or not
+func (s BatchStatus) Done() bool {
+	switch openai.BatchStatus(s.Status) {
+	case openai.BatchStatusCompleted, openai.BatchStatusFailed, openai.BatchStatusExpired, openai.BatchStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// batchLine is one request of a Batch API input file
+type batchLine struct {
+	CustomID string         `json:"custom_id"`
+	Method   string         `json:"method"`
+	URL      string         `json:"url"`
+	Body     map[string]any `json:"body"`
+}
+
+// batchResult is one line of a Batch API output file
+type batchResult struct {
+	CustomID string `json:"custom_id"`
+	Response *struct {
+		StatusCode int                   `json:"status_code"`
+		Body       openai.ChatCompletion `json:"body"`
+	} `json:"response"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// BuildBatchInput renders items as the JSONL input file of a chat completions
+// batch, with the same prompts and sampling settings as live requests
+func (c *OpenAIClient) BuildBatchInput(items []BatchItem) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, item := range items {
+		body := map[string]any{
+			"model":       c.model,
+			"messages":    []map[string]string{{"role": "user", "content": BuildPRChangelogPrompt(item.Request)}},
+			"max_tokens":  c.maxTokens,
+			"temperature": c.temperature,
+		}
+		if c.seed != 0 {
+			body["seed"] = c.seed
+		}
+		line := batchLine{CustomID: item.ID, Method: "POST", URL: "/v1/chat/completions", Body: body}
+		if err := encoder.Encode(line); err != nil {
+			return nil, fmt.Errorf("encode batch request %s: %w", item.ID, err)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// SubmitBatch uploads items and starts a Batch API job for them, returning its
+// ID. Batches cost half as much as live requests and finish within 24 hours.
+func (c *OpenAIClient) SubmitBatch(ctx context.Context, items []BatchItem) (string, error) {
+	input, err := c.BuildBatchInput(items)
+	if err != nil {
+		return "", err
+	}
+	file, err := c.client.Files.New(ctx, openai.FileNewParams{
+		File:    openai.File(bytes.NewReader(input), "changelog-batch.jsonl", "application/jsonl"),
+		Purpose: openai.FilePurposeBatch,
+	})
+	if err != nil {
+		return "", fmt.Errorf("upload batch input: %w", err)
+	}
+	batch, err := c.client.Batches.New(ctx, openai.BatchNewParams{
+		CompletionWindow: openai.BatchNewParamsCompletionWindow24h,
+		Endpoint:         openai.BatchNewParamsEndpointV1ChatCompletions,
+		InputFileID:      file.ID,
+	})
+	if err != nil {
+		return "", fmt.Errorf("create batch: %w", err)
+	}
+	logger.Info("submitted batch", "id", batch.ID, "requests", len(items))
+	return batch.ID, nil
+}
+
+// BatchStatus looks up the progress of a Batch API job
+func (c *OpenAIClient) BatchStatus(ctx context.Context, id string) (BatchStatus, error) {
+	batch, err := c.client.Batches.Get(ctx, id)
+	if err != nil {
+		return BatchStatus{}, fmt.Errorf("get batch %s: %w", id, err)
+	}
+	return BatchStatus{
+		ID:        batch.ID,
+		Status:    string(batch.Status),
+		Total:     int(batch.RequestCounts.Total),
+		Completed: int(batch.RequestCounts.Completed),
+		Failed:    int(batch.RequestCounts.Failed),
+	}, nil
+}
+
+// BatchResults downloads the responses of a finished Batch API job, keyed by
+// item ID, and the token usage they report. Items that failed or did not
+// finish before the job expired are missing from the result.
+func (c *OpenAIClient) BatchResults(ctx context.Context, id string) (map[string]*PRChangelogResponse, Usage, error) {
+	batch, err := c.client.Batches.Get(ctx, id)
+	if err != nil {
+		return nil, Usage{}, fmt.Errorf("get batch %s: %w", id, err)
+	}
+	if batch.OutputFileID == "" {
+		return map[string]*PRChangelogResponse{}, Usage{}, nil
+	}
+	content, err := c.client.Files.Content(ctx, batch.OutputFileID)
+	if err != nil {
+		return nil, Usage{}, fmt.Errorf("download batch output: %w", err)
+	}
+	defer content.Body.Close()
+	return ParseBatchOutput(content.Body)
+}
+
+// ParseBatchOutput parses the JSONL output file of a PR changelog batch into
+// responses keyed by item ID, and the token usage they report. Failed items
+// are logged and left out.
+func ParseBatchOutput(r io.Reader) (map[string]*PRChangelogResponse, Usage, error) {
+	results := make(map[string]*PRChangelogResponse)
+	var usage Usage
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var line batchResult
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			return nil, usage, fmt.Errorf("parse batch output: %w", err)
+		}
+		if line.Error != nil || line.Response == nil || line.Response.StatusCode != 200 {
+			logger.Warn("batch request failed", "id", line.CustomID, "error", batchError(line))
+			continue
+		}
+		completion := line.Response.Body
+		usage.Calls++
+		usage.InputTokens += int(completion.Usage.PromptTokens)
+		usage.OutputTokens += int(completion.Usage.CompletionTokens)
+		if len(completion.Choices) == 0 {
+			logger.Warn("batch request has no response", "id", line.CustomID)
+			continue
+		}
+		response, err := ParsePRChangelogResponse(completion.Choices[0].Message.Content)
+		if err != nil {
+			logger.Warn("could not parse batch response", "id", line.CustomID, "error", err)
+			continue
+		}
+		results[line.CustomID] = response
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, usage, fmt.Errorf("read batch output: %w", err)
+	}
+	return results, usage, nil
+}
+
+// batchError describes why a batch output line has no usable response
+func batchError(line batchResult) string {
+	switch {
+	case line.Error != nil:
+		return line.Error.Message
+	case line.Response == nil:
+		return "no response"
+	}
+	return fmt.Sprintf("status %d", line.Response.StatusCode)
+}
@@ -0,0 +1,77 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestBuildBatchInput(t *testing.T) {
+	client := &OpenAIClient{model: "gpt-4o", maxTokens: 4000, temperature: 0, seed: 42}
+	items := []BatchItem{
+		{ID: "v1.1.0", Request: PRChangelogRequest{ToRef: "v1.1.0", PRs: []PRInfo{{Number: 1, Title: "Add SSO"}}}},
+		{ID: "v1.2.0", Request: PRChangelogRequest{ToRef: "v1.2.0", PRs: []PRInfo{{Number: 2, Title: "Fix login"}}}},
+	}
+
+	input, err := client.BuildBatchInput(items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(input))
+	var lines []map[string]any
+	for scanner.Scan() {
+		var line map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatal(err)
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2", len(lines))
+	}
+	first := lines[0]
+	if first["custom_id"] != "v1.1.0" || first["method"] != "POST" || first["url"] != "/v1/chat/completions" {
+		t.Errorf("line = %v", first)
+	}
+	body := first["body"].(map[string]any)
+	if body["model"] != "gpt-4o" || body["seed"] != float64(42) || body["temperature"] != float64(0) {
+		t.Errorf("body = %v", body)
+	}
+	content := body["messages"].([]any)[0].(map[string]any)["content"].(string)
+	if content != BuildPRChangelogPrompt(items[0].Request) {
+		t.Error("batch prompt differs from the live prompt")
+	}
+}
+
+func TestParseBatchOutput(t *testing.T) {
+	output := strings.Join([]string{
+		`{"custom_id":"v1.1.0","response":{"status_code":200,"body":{"model":"gpt-4o","choices":[{"message":{"role":"assistant","content":"{\"summary\":\"SSO arrives.\",\"entries\":[{\"number\":1,\"summary\":\"Adds SSO.\"}]}"}}],"usage":{"prompt_tokens":100,"completion_tokens":20}}},"error":null}`,
+		`{"custom_id":"v1.2.0","response":null,"error":{"code":"server_error","message":"internal error"}}`,
+		``,
+		`{"custom_id":"v1.3.0","response":{"status_code":200,"body":{"choices":[{"message":{"content":"not json"}}],"usage":{"prompt_tokens":50,"completion_tokens":5}}}}`,
+	}, "\n")
+
+	results, usage, err := ParseBatchOutput(strings.NewReader(output))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results["v1.1.0"] == nil || results["v1.1.0"].Summary != "SSO arrives." {
+		t.Errorf("results = %+v", results)
+	}
+	if entries := results["v1.1.0"].Entries; len(entries) != 1 || entries[0].Number != 1 {
+		t.Errorf("entries = %+v", entries)
+	}
+	if usage.Calls != 2 || usage.InputTokens != 150 || usage.OutputTokens != 25 {
+		t.Errorf("usage = %+v", usage)
+	}
+}
+
+func TestBatchStatusDone(t *testing.T) {
+	for status, want := range map[string]bool{"in_progress": false, "finalizing": false, "completed": true, "expired": true, "cancelled": true} {
+		if got := (BatchStatus{Status: status}).Done(); got != want {
+			t.Errorf("Done(%s) = %v, want %v", status, got, want)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package llm
+
+import "strings"
+
+// ModelPrice is the USD price per 1M tokens for a model
+type ModelPrice struct {
+	Input  float64
+	Output float64
+}
+
+// ModelPricing lists known per-1M-token prices (USD). Prices change; treat
+// estimates as a rough guide and check https://openai.com/api/pricing.
+var ModelPricing = map[string]ModelPrice{
+	"gpt-4o":        {Input: 2.50, Output: 10.00},
+	"gpt-4o-mini":   {Input: 0.15, Output: 0.60},
+	"gpt-4.1":       {Input: 2.00, Output: 8.00},
+	"gpt-4.1-mini":  {Input: 0.40, Output: 1.60},
+	"gpt-4.1-nano":  {Input: 0.10, Output: 0.40},
+	"gpt-4-turbo":   {Input: 10.00, Output: 30.00},
+	"gpt-4":         {Input: 30.00, Output: 60.00},
+	"gpt-3.5-turbo": {Input: 0.50, Output: 1.50},
+}
+
+// EstimateTokens approximates the token count of text (~4 characters per token
+// for English prose and code)
+func EstimateTokens(text string) int {
+	if text == "" {
+		return 0
+	}
+	return len(text)/4 + 1
+}
+
+// LookupPrice returns the price for a model, matching dated snapshots
+// (e.g., gpt-4o-2024-08-06) to their base model
+func LookupPrice(model string) (ModelPrice, bool) {
+	if price, ok := ModelPricing[model]; ok {
+		return price, true
+	}
+
+	best := ""
+	for name := range ModelPricing {
+		if strings.HasPrefix(model, name+"-") && len(name) > len(best) {
+			best = name
+		}
+	}
+	if best == "" {
+		return ModelPrice{}, false
+	}
+	return ModelPricing[best], true
+}
+
+// EstimateCost returns the USD cost for the given token counts and whether
+// the model's pricing is known
+func EstimateCost(model string, inputTokens, outputTokens int) (float64, bool) {
+	price, ok := LookupPrice(model)
+	if !ok {
+		return 0, false
+	}
+	return float64(inputTokens)/1e6*price.Input + float64(outputTokens)/1e6*price.Output, true
+}
@@ -0,0 +1,33 @@
+package llm
+
+import "testing"
+
+func TestLookupPrice(t *testing.T) {
+	if _, ok := LookupPrice("gpt-4o"); !ok {
+		t.Error("Expected pricing for gpt-4o")
+	}
+
+	// Dated snapshots resolve to the longest matching base model
+	price, ok := LookupPrice("gpt-4o-mini-2024-07-18")
+	if !ok || price != ModelPricing["gpt-4o-mini"] {
+		t.Errorf("Expected gpt-4o-mini pricing for snapshot, got %+v (ok=%v)", price, ok)
+	}
+
+	if _, ok := LookupPrice("my-finetune"); ok {
+		t.Error("Expected unknown model to have no pricing")
+	}
+}
+
+func TestEstimateCost(t *testing.T) {
+	cost, ok := EstimateCost("gpt-4o", 1_000_000, 100_000)
+	if !ok {
+		t.Fatal("Expected pricing for gpt-4o")
+	}
+	if cost < 3.49 || cost > 3.51 {
+		t.Errorf("Expected ~$3.50, got $%.4f", cost)
+	}
+
+	if EstimateTokens("") != 0 || EstimateTokens("abcdefgh") != 3 {
+		t.Error("Unexpected token estimate")
+	}
+}
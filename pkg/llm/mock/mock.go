@@ -0,0 +1,284 @@
+// Package mock provides a deterministic llm.Client that answers without
+// calling a model, so the generate pipeline can run offline in tests and
+// golden-file comparisons.
+package mock
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+)
+
+var _ llm.Client = (*Client)(nil)
+
+// Client answers every request from the request alone: commits are
+// categorized and scored by their conventional-commit type, summaries count
+// the changes, and translations and compressions return their input. The
+// same request always gets the same answer.
+//
+// Responses recorded with --save-prompts can be loaded with LoadRecords;
+// a recorded prompt is answered with its recorded reply instead.
+type Client struct {
+	mu       sync.Mutex
+	usage    llm.Usage
+	recorded map[string]string // Redacted prompt → raw reply
+	prompts  []string
+}
+
+// New returns a mock client without recorded responses
+func New() *Client {
+	return &Client{recorded: make(map[string]string)}
+}
+
+// LoadRecords loads the prompt records saved by --save-prompts in dir, so
+// their prompts are answered with the recorded replies
+func (c *Client) LoadRecords(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return fmt.Errorf("list prompt records: %w", err)
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("read prompt record: %w", err)
+		}
+		var record llm.PromptRecord
+		if err := json.Unmarshal(data, &record); err != nil {
+			return fmt.Errorf("parse prompt record %s: %w", file, err)
+		}
+		if record.Error == "" {
+			c.recorded[record.Prompt] = record.Response
+		}
+	}
+	return nil
+}
+
+// Usage counts the requests answered; the mock uses no tokens
+func (c *Client) Usage() llm.Usage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.usage
+}
+
+// Prompts returns the prompts of every request answered, in order
+func (c *Client) Prompts() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.prompts...)
+}
+
+// answer records a request and returns its recorded reply, if any
+func (c *Client) answer(prompt string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.usage.Calls++
+	c.prompts = append(c.prompts, prompt)
+	reply, ok := c.recorded[llm.RedactSecrets(prompt)]
+	return reply, ok
+}
+
+// GenerateChangelog files each commit under the category of its
+// conventional-commit type
+func (c *Client) GenerateChangelog(ctx context.Context, req llm.ChangelogRequest) (*llm.ChangelogResponse, error) {
+	if reply, ok := c.answer(llm.BuildChangelogPrompt(req)); ok {
+		return llm.ParseChangelogResponse(reply)
+	}
+
+	response := &llm.ChangelogResponse{Categories: make(map[string][]llm.ChangelogEntry)}
+	var entries []llm.ChangelogEntry
+	for _, commit := range req.Commits {
+		category, title, score := classify(commit.Message)
+		if commit.LabelCategory != "" {
+			category = commit.LabelCategory
+		}
+		entry := llm.ChangelogEntry{
+			SHA:             commit.SHA,
+			Title:           title,
+			Description:     body(commit.Message),
+			Author:          commit.Author,
+			ImportanceScore: score,
+		}
+		response.Categories[category] = append(response.Categories[category], entry)
+		entries = append(entries, entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].ImportanceScore > entries[j].ImportanceScore })
+	for _, entry := range entries[:min(3, len(entries))] {
+		if entry.ImportanceScore >= 6 {
+			response.Highlights = append(response.Highlights, entry.Title)
+		}
+	}
+	response.OneLiner = fmt.Sprintf("%s with %s.", releaseName(req.ToRef), countChanges(len(req.Commits)))
+	response.Summary = fmt.Sprintf("This release includes %s: %s.", countChanges(len(req.Commits)), categoryCounts(response.Categories))
+	return response, nil
+}
+
+// GeneratePRChangelog summarizes each pull request by its title
+func (c *Client) GeneratePRChangelog(ctx context.Context, req llm.PRChangelogRequest) (*llm.PRChangelogResponse, error) {
+	if reply, ok := c.answer(llm.BuildPRChangelogPrompt(req)); ok {
+		return llm.ParsePRChangelogResponse(reply)
+	}
+
+	response := &llm.PRChangelogResponse{
+		OneLiner: fmt.Sprintf("%s with %s.", releaseName(req.ToRef), countPRs(len(req.PRs))),
+		Summary:  fmt.Sprintf("This release merges %s.", countPRs(len(req.PRs))),
+	}
+	for _, pr := range req.PRs {
+		_, title, _ := classify(pr.Title)
+		response.Entries = append(response.Entries, llm.PRSummaryEntry{Number: pr.Number, Summary: title})
+	}
+	return response, nil
+}
+
+// RescoreEntries scores each item by the conventional-commit type of its title
+func (c *Client) RescoreEntries(ctx context.Context, req llm.RescoreRequest) (*llm.RescoreResponse, error) {
+	if reply, ok := c.answer(llm.BuildRescorePrompt(req)); ok {
+		return llm.ParseRescoreResponse(reply)
+	}
+
+	response := &llm.RescoreResponse{}
+	for _, item := range req.Items {
+		_, _, score := classify(item.Title)
+		response.Scores = append(response.Scores, llm.RescoreEntry{ID: item.ID, ImportanceScore: score})
+	}
+	return response, nil
+}
+
+// ReviewNotes reports no omissions or inaccuracies
+func (c *Client) ReviewNotes(ctx context.Context, req llm.ReviewRequest) (*llm.ReviewResponse, error) {
+	if reply, ok := c.answer(llm.BuildReviewPrompt(req)); ok {
+		return llm.ParseReviewResponse(reply)
+	}
+	return &llm.ReviewResponse{Summary: fmt.Sprintf("The notes cover the %s.", countChanges(len(req.Commits)))}, nil
+}
+
+// CompressSection returns the section unchanged
+func (c *Client) CompressSection(ctx context.Context, markdown string, limit int, unit string) (string, error) {
+	if reply, ok := c.answer(llm.BuildCompressionPrompt(markdown, limit, unit)); ok {
+		return llm.CleanMarkdownResponse(reply), nil
+	}
+	return markdown, nil
+}
+
+// Translate returns the texts unchanged
+func (c *Client) Translate(ctx context.Context, req llm.TranslateRequest) (*llm.TranslateResponse, error) {
+	if reply, ok := c.answer(llm.BuildTranslatePrompt(req)); ok {
+		return llm.ParseTranslateResponse(reply)
+	}
+	return &llm.TranslateResponse{Translations: append([]string(nil), req.Texts...)}, nil
+}
+
+// SummarizePeriod counts the releases and pull requests of the period
+func (c *Client) SummarizePeriod(ctx context.Context, req llm.PeriodSummaryRequest) (*llm.PeriodSummaryResponse, error) {
+	if reply, ok := c.answer(llm.BuildPeriodSummaryPrompt(req)); ok {
+		return llm.ParsePeriodSummaryResponse(reply)
+	}
+
+	prs := 0
+	for _, release := range req.Releases {
+		prs += len(release.PRTitles)
+	}
+	summary := fmt.Sprintf("%d releases shipped %s between %s and %s.", len(req.Releases), countPRs(prs),
+		req.FromDate.Format("2006-01-02"), req.ToDate.Format("2006-01-02"))
+	return &llm.PeriodSummaryResponse{Summary: summary}, nil
+}
+
+// conventionalRe matches a conventional-commit subject: "type(scope)!: description"
+var conventionalRe = regexp.MustCompile(`^(\w+)(?:\([^)]*\))?(!)?:\s*`)
+
+// conventionalTypes maps conventional-commit types to a category and score
+var conventionalTypes = map[string]struct {
+	category string
+	score    float64
+}{
+	"feat":     {"Features", 7},
+	"fix":      {"Bug Fixes", 6},
+	"perf":     {"Improvements", 5},
+	"security": {"Security", 8},
+	"docs":     {"Documentation", 2},
+	"refactor": {"Internal", 2},
+	"chore":    {"Internal", 1},
+	"build":    {"Internal", 1},
+	"ci":       {"Internal", 1},
+	"test":     {"Internal", 1},
+	"style":    {"Internal", 1},
+}
+
+// classify returns the category, title, and score of a commit message or pull
+// request title. Breaking changes (type! or a BREAKING CHANGE footer) score 9.
+func classify(message string) (string, string, float64) {
+	subject := strings.TrimSpace(strings.SplitN(message, "\n", 2)[0])
+	category, score := "Improvements", 4.0
+	m := conventionalRe.FindStringSubmatch(subject)
+	if m != nil {
+		if kind, ok := conventionalTypes[strings.ToLower(m[1])]; ok {
+			category, score = kind.category, kind.score
+		}
+		subject = subject[len(m[0]):]
+	}
+	if (m != nil && m[2] == "!") || strings.Contains(message, "BREAKING CHANGE") {
+		category, score = "Breaking Changes", 9
+	}
+	if subject != "" {
+		subject = strings.ToUpper(subject[:1]) + subject[1:]
+	}
+	return category, strings.TrimSuffix(subject, "."), score
+}
+
+// body returns the first paragraph after a commit message's subject
+func body(message string) string {
+	parts := strings.SplitN(strings.TrimSpace(message), "\n\n", 3)
+	if len(parts) < 2 || strings.HasPrefix(parts[1], "BREAKING CHANGE") {
+		return ""
+	}
+	return strings.Join(strings.Fields(parts[1]), " ")
+}
+
+// releaseName names a release by its ref
+func releaseName(ref string) string {
+	if ref == "" {
+		return "This release"
+	}
+	return ref
+}
+
+func countChanges(n int) string {
+	if n == 1 {
+		return "1 change"
+	}
+	return fmt.Sprintf("%d changes", n)
+}
+
+func countPRs(n int) string {
+	if n == 1 {
+		return "1 pull request"
+	}
+	return fmt.Sprintf("%d pull requests", n)
+}
+
+// categoryCounts lists the number of entries per category, largest first
+func categoryCounts(categories map[string][]llm.ChangelogEntry) string {
+	names := make([]string, 0, len(categories))
+	for name := range categories {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if len(categories[names[i]]) != len(categories[names[j]]) {
+			return len(categories[names[i]]) > len(categories[names[j]])
+		}
+		return names[i] < names[j]
+	})
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s (%d)", strings.ToLower(name), len(categories[name]))
+	}
+	return strings.Join(parts, ", ")
+}
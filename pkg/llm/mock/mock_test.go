@@ -0,0 +1,93 @@
+package mock
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		message, category, title string
+		score                    float64
+	}{
+		{"feat(auth): add SSO", "Features", "Add SSO", 7},
+		{"fix: handle nil config.", "Bug Fixes", "Handle nil config", 6},
+		{"feat!: drop Go 1.20", "Breaking Changes", "Drop Go 1.20", 9},
+		{"refactor: split parser\n\nBREAKING CHANGE: Parse moved", "Breaking Changes", "Split parser", 9},
+		{"Update README", "Improvements", "Update README", 4},
+		{"wip: try things", "Improvements", "Try things", 4},
+	}
+	for _, tt := range tests {
+		category, title, score := classify(tt.message)
+		if category != tt.category || title != tt.title || score != tt.score {
+			t.Errorf("classify(%q) = %q, %q, %v; want %q, %q, %v", tt.message, category, title, score, tt.category, tt.title, tt.score)
+		}
+	}
+}
+
+func TestGenerateChangelogIsDeterministic(t *testing.T) {
+	req := llm.ChangelogRequest{ToRef: "v2.0.0", Commits: []llm.CommitInfo{
+		{SHA: "a1", Message: "feat: add export", Author: "alice"},
+		{SHA: "b2", Message: "fix: correct totals\n\nMonthly totals skipped the last day.", Author: "bob"},
+		{SHA: "c3", Message: "chore: bump deps", Author: "bot", LabelCategory: "Security"},
+	}}
+	client := New()
+	first, err := client.GenerateChangelog(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := client.GenerateChangelog(context.Background(), req)
+	if first.Summary != second.Summary || first.Summary != "This release includes 3 changes: bug fixes (1), features (1), security (1)." {
+		t.Errorf("summary = %q", first.Summary)
+	}
+	if len(first.Highlights) != 2 || first.Highlights[0] != "Add export" {
+		t.Errorf("highlights = %v", first.Highlights)
+	}
+	if fixes := first.Categories["Bug Fixes"]; len(fixes) != 1 || fixes[0].Description != "Monthly totals skipped the last day." {
+		t.Errorf("bug fixes = %+v", fixes)
+	}
+	if len(first.Categories["Security"]) != 1 {
+		t.Errorf("label category ignored: %+v", first.Categories)
+	}
+	if client.Usage().Calls != 2 || len(client.Prompts()) != 2 {
+		t.Errorf("usage = %+v", client.Usage())
+	}
+}
+
+func TestLoadRecordsReplaysReplies(t *testing.T) {
+	req := llm.PRChangelogRequest{ToRef: "v1.1.0", PRs: []llm.PRInfo{{Number: 7, Title: "feat: add export"}}}
+	dir := t.TempDir()
+	log, err := llm.NewPromptLog(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := log.Record(llm.PromptRecord{
+		Kind:     "pr_changelog",
+		Prompt:   llm.BuildPRChangelogPrompt(req),
+		Response: `{"one_liner": "Recorded.", "summary": "From the model.", "entries": [{"number": 7, "summary": "Export reports"}]}`,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not a record"), 0o644)
+
+	client := New()
+	if err := client.LoadRecords(dir); err != nil {
+		t.Fatal(err)
+	}
+	response, err := client.GeneratePRChangelog(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Summary != "From the model." || response.Entries[0].Summary != "Export reports" {
+		t.Errorf("response = %+v", response)
+	}
+
+	req.PRs[0].Title = "feat: add import"
+	if response, _ := client.GeneratePRChangelog(context.Background(), req); response.Entries[0].Summary != "Add import" {
+		t.Errorf("unrecorded prompt got %+v", response)
+	}
+}
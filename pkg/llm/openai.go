@@ -2,26 +2,86 @@ package llm
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/openai/openai-go"
 	"github.com/openai/openai-go/option"
 	"github.com/openai/openai-go/packages/param"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/logging"
 )
 
+var logger = logging.Module("llm")
+
+// Client is the language model backend the generator calls. OpenAIClient is
+// the built-in implementation; programs using the generator as a library can
+// supply their own, e.g. for another vendor or a fake in tests. With
+// LLMConcurrency above 1, timelines call GeneratePRChangelog from several
+// goroutines at once.
+type Client interface {
+	GenerateChangelog(ctx context.Context, req ChangelogRequest) (*ChangelogResponse, error)
+	GeneratePRChangelog(ctx context.Context, req PRChangelogRequest) (*PRChangelogResponse, error)
+	RescoreEntries(ctx context.Context, req RescoreRequest) (*RescoreResponse, error)
+	ReviewNotes(ctx context.Context, req ReviewRequest) (*ReviewResponse, error)
+	CompressSection(ctx context.Context, markdown string, limit int, unit string) (string, error)
+	Translate(ctx context.Context, req TranslateRequest) (*TranslateResponse, error)
+	SummarizePeriod(ctx context.Context, req PeriodSummaryRequest) (*PeriodSummaryResponse, error)
+	Usage() Usage
+}
+
+var _ Client = (*OpenAIClient)(nil)
+
 // OpenAIClient wraps the OpenAI API client
 type OpenAIClient struct {
 	client      *openai.Client
 	model       string
 	maxTokens   int
 	temperature float64
+	seed        int64 // Sent with every request when non-zero
+	maxAttempts int   // Tries per completion on rate limits and server errors
+
+	prompts *PromptLog // Saves every prompt and reply when set (--save-prompts)
+
+	mu    sync.Mutex // Guards usage and trace across concurrent completions
+	usage Usage
+	trace Trace
+}
+
+// Usage accumulates the token usage reported by the API
+type Usage struct {
+	Calls        int `json:"calls"`
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+}
+
+// Trace records what a client sent and which model answered, so differences
+// between regenerated changelogs can be traced to their inputs
+type Trace struct {
+	PromptHashes []string // SHA-256 of each prompt sent, in order
+	Model        string   // Model snapshot that answered last, e.g. gpt-4o-2024-08-06
+	Fingerprint  string   // Backend configuration that answered last (OpenAI system_fingerprint)
+}
+
+// HashPrompts combines prompt hashes into one SHA-256, identifying the exact
+// sequence of prompts
+func HashPrompts(hashes []string) string {
+	h := sha256.New()
+	for _, hash := range hashes {
+		h.Write([]byte(hash))
+		h.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(h.Sum(nil))
 }
 
 // NewOpenAIClient creates a new OpenAI client
 func NewOpenAIClient(apiKey, model string, maxTokens int, temperature float64) *OpenAIClient {
 	client := openai.NewClient(
 		option.WithAPIKey(apiKey),
+		option.WithMaxRetries(0), // complete retries, with a configurable number of attempts
 	)
 
 	return &OpenAIClient{
@@ -29,37 +89,47 @@ func NewOpenAIClient(apiKey, model string, maxTokens int, temperature float64) *
 		model:       model,
 		maxTokens:   maxTokens,
 		temperature: temperature,
+		maxAttempts: DefaultMaxAttempts,
 	}
 }
 
+// Usage returns the token usage of every completion made by this client
+func (c *OpenAIClient) Usage() Usage {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.usage
+}
+
+// SetSeed asks the API to sample deterministically with seed (0 = no seed)
+func (c *OpenAIClient) SetSeed(seed int64) {
+	c.seed = seed
+}
+
+// SetPromptLog saves every prompt and raw reply to log (nil = off)
+func (c *OpenAIClient) SetPromptLog(log *PromptLog) {
+	c.prompts = log
+}
+
+// Trace returns the prompts sent and the model that answered, for every
+// completion made by this client
+func (c *OpenAIClient) Trace() Trace {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	trace := c.trace
+	trace.PromptHashes = append([]string(nil), c.trace.PromptHashes...)
+	return trace
+}
+
 // GenerateChangelog generates a changelog using OpenAI
-func (c *OpenAIClient) GenerateChangelog(req ChangelogRequest) (*ChangelogResponse, error) {
+func (c *OpenAIClient) GenerateChangelog(ctx context.Context, req ChangelogRequest) (*ChangelogResponse, error) {
 	// Build the prompt
 	prompt := BuildChangelogPrompt(req)
 
-	// Create chat completion request
-	ctx := context.Background()
-	params := openai.ChatCompletionNewParams{
-		Messages: []openai.ChatCompletionMessageParamUnion{
-			openai.UserMessage(prompt),
-		},
-		Model:       openai.ChatModel(c.model),
-		MaxTokens:   param.NewOpt(int64(c.maxTokens)),
-		Temperature: param.NewOpt(c.temperature),
-	}
-
-	chatCompletion, err := c.client.Chat.Completions.New(ctx, params)
+	content, err := c.complete(ctx, "changelog", prompt)
 	if err != nil {
-		return nil, fmt.Errorf("create chat completion: %w", err)
+		return nil, err
 	}
 
-	// Extract the response
-	if len(chatCompletion.Choices) == 0 {
-		return nil, fmt.Errorf("no response from OpenAI")
-	}
-
-	content := chatCompletion.Choices[0].Message.Content
-
 	// Parse the JSON response
 	response, err := ParseChangelogResponse(content)
 	if err != nil {
@@ -70,36 +140,200 @@ func (c *OpenAIClient) GenerateChangelog(req ChangelogRequest) (*ChangelogRespon
 }
 
 // GeneratePRChangelog generates PR-based release notes using OpenAI
-func (c *OpenAIClient) GeneratePRChangelog(req PRChangelogRequest) (*PRChangelogResponse, error) {
+func (c *OpenAIClient) GeneratePRChangelog(ctx context.Context, req PRChangelogRequest) (*PRChangelogResponse, error) {
 	prompt := BuildPRChangelogPrompt(req)
 
-	ctx := context.Background()
+	content, err := c.complete(ctx, "pr_changelog", prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := ParsePRChangelogResponse(content)
+	if err != nil {
+		return nil, fmt.Errorf("parse PR changelog response: %w", err)
+	}
+
+	return response, nil
+}
+
+// RescoreEntries scores entries from several releases in one call, so their
+// importance scores are comparable across releases
+func (c *OpenAIClient) RescoreEntries(ctx context.Context, req RescoreRequest) (*RescoreResponse, error) {
+	prompt := BuildRescorePrompt(req)
+
+	content, err := c.complete(ctx, "rescore", prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := ParseRescoreResponse(content)
+	if err != nil {
+		return nil, fmt.Errorf("parse rescore response: %w", err)
+	}
+
+	return response, nil
+}
+
+// ReviewNotes checks human-written release notes against the commits of the
+// release and reports omissions and inaccuracies
+func (c *OpenAIClient) ReviewNotes(ctx context.Context, req ReviewRequest) (*ReviewResponse, error) {
+	prompt := BuildReviewPrompt(req)
+
+	content, err := c.complete(ctx, "review", prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := ParseReviewResponse(content)
+	if err != nil {
+		return nil, fmt.Errorf("parse review response: %w", err)
+	}
+
+	return response, nil
+}
+
+// SummarizePeriod synthesizes the themes across all releases of a timeline
+func (c *OpenAIClient) SummarizePeriod(ctx context.Context, req PeriodSummaryRequest) (*PeriodSummaryResponse, error) {
+	prompt := BuildPeriodSummaryPrompt(req)
+
+	content, err := c.complete(ctx, "period_summary", prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := ParsePeriodSummaryResponse(content)
+	if err != nil {
+		return nil, fmt.Errorf("parse period summary response: %w", err)
+	}
+
+	return response, nil
+}
+
+// Translate translates changelog texts into another language in one call
+func (c *OpenAIClient) Translate(ctx context.Context, req TranslateRequest) (*TranslateResponse, error) {
+	prompt := BuildTranslatePrompt(req)
+
+	content, err := c.complete(ctx, "translate", prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := ParseTranslateResponse(content)
+	if err != nil {
+		return nil, fmt.Errorf("parse translate response: %w", err)
+	}
+	if len(response.Translations) != len(req.Texts) {
+		return nil, fmt.Errorf("translate: got %d translations for %d texts", len(response.Translations), len(req.Texts))
+	}
+
+	return response, nil
+}
+
+// CompressSection asks the model to condense a markdown release section to
+// fit within limit words or lines, preserving every breaking change
+func (c *OpenAIClient) CompressSection(ctx context.Context, markdown string, limit int, unit string) (string, error) {
+	prompt := BuildCompressionPrompt(markdown, limit, unit)
+
+	content, err := c.complete(ctx, "compress", prompt)
+	if err != nil {
+		return "", err
+	}
+
+	return CleanMarkdownResponse(content), nil
+}
+
+// modelKey is the context key of a per-request model
+type modelKey struct{}
+
+// WithModel returns a context whose completions use model instead of the
+// client's default, e.g. a cheaper model for a small release. Empty model
+// leaves ctx unchanged.
+func WithModel(ctx context.Context, model string) context.Context {
+	if model == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, modelKey{}, model)
+}
+
+// ModelFromContext returns the model set with WithModel, or fallback
+func ModelFromContext(ctx context.Context, fallback string) string {
+	if model, ok := ctx.Value(modelKey{}).(string); ok {
+		return model
+	}
+	return fallback
+}
+
+// complete sends a single-message chat completion and returns the reply text.
+// kind names the request type in saved prompts.
+func (c *OpenAIClient) complete(ctx context.Context, kind, prompt string) (string, error) {
+	model := ModelFromContext(ctx, c.model)
+
+	// Create chat completion request
 	params := openai.ChatCompletionNewParams{
 		Messages: []openai.ChatCompletionMessageParamUnion{
 			openai.UserMessage(prompt),
 		},
-		Model:       openai.ChatModel(c.model),
+		Model:       openai.ChatModel(model),
 		MaxTokens:   param.NewOpt(int64(c.maxTokens)),
 		Temperature: param.NewOpt(c.temperature),
 	}
+	if c.seed != 0 {
+		params.Seed = param.NewOpt(c.seed)
+	}
 
-	chatCompletion, err := c.client.Chat.Completions.New(ctx, params)
+	logger.Debug("sending chat completion", "model", model, "prompt_chars", len(prompt))
+	started := time.Now()
+	chatCompletion, err := withRetry(ctx, c.maxAttempts, func() (*openai.ChatCompletion, error) {
+		return c.client.Chat.Completions.New(ctx, params)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("create chat completion: %w", err)
+		c.savePrompt(PromptRecord{Kind: kind, Model: model, Time: started, DurationMs: time.Since(started).Milliseconds(), Prompt: prompt, Error: err.Error()})
+		return "", fmt.Errorf("create chat completion: %w", err)
 	}
+	logger.Debug("chat completion finished", "model", model, "duration", time.Since(started),
+		"input_tokens", chatCompletion.Usage.PromptTokens, "output_tokens", chatCompletion.Usage.CompletionTokens)
+
+	digest := sha256.Sum256([]byte(prompt))
+	c.mu.Lock()
+	c.usage.Calls++
+	c.usage.InputTokens += int(chatCompletion.Usage.PromptTokens)
+	c.usage.OutputTokens += int(chatCompletion.Usage.CompletionTokens)
+	c.trace.PromptHashes = append(c.trace.PromptHashes, hex.EncodeToString(digest[:]))
+	c.trace.Model = chatCompletion.Model
+	c.trace.Fingerprint = chatCompletion.SystemFingerprint
+	c.mu.Unlock()
 
+	record := PromptRecord{
+		Kind:         kind,
+		Model:        chatCompletion.Model,
+		Time:         started,
+		DurationMs:   time.Since(started).Milliseconds(),
+		InputTokens:  int(chatCompletion.Usage.PromptTokens),
+		OutputTokens: int(chatCompletion.Usage.CompletionTokens),
+		Prompt:       prompt,
+	}
+
+	// Extract the response
 	if len(chatCompletion.Choices) == 0 {
-		return nil, fmt.Errorf("no response from OpenAI")
+		record.Error = "no response from OpenAI"
+		c.savePrompt(record)
+		return "", fmt.Errorf("no response from OpenAI")
 	}
 
-	content := chatCompletion.Choices[0].Message.Content
+	record.Response = chatCompletion.Choices[0].Message.Content
+	c.savePrompt(record)
+	return record.Response, nil
+}
 
-	response, err := ParsePRChangelogResponse(content)
-	if err != nil {
-		return nil, fmt.Errorf("parse PR changelog response: %w", err)
+// savePrompt writes record to the prompt log, if any. Failing to save a
+// prompt is logged rather than failing the run.
+func (c *OpenAIClient) savePrompt(record PromptRecord) {
+	if c.prompts == nil {
+		return
+	}
+	if _, err := c.prompts.Record(record); err != nil {
+		logger.Warn("could not save prompt", "kind", record.Kind, "error", err)
 	}
-
-	return response, nil
 }
 
 // TruncateDiff truncates a diff to a reasonable size for token limits
@@ -113,8 +347,24 @@ func TruncateDiff(diff string, maxLines int) string {
 	return truncated + fmt.Sprintf("\n... (%d more lines truncated)", len(lines)-maxLines)
 }
 
-// SummarizeDiff creates a brief summary of changes from a diff
+// SummarizeDiff creates a brief summary of changes from a diff, naming the
+// functions and types it touches when its "+++" header names a Go,
+// TypeScript, or Python file
 func SummarizeDiff(diff string) string {
+	filename := ""
+	for _, line := range strings.Split(diff, "\n") {
+		if name, ok := strings.CutPrefix(line, "+++ "); ok {
+			filename = strings.TrimPrefix(strings.TrimSpace(name), "b/")
+			break
+		}
+	}
+	return SummarizeFileDiff(filename, diff, 10)
+}
+
+// SummarizeFileDiff creates a brief summary of changes from the diff of
+// filename: line counts, the functions and types added, removed, or
+// modified, and the first sampleLines lines of the patch
+func SummarizeFileDiff(filename, diff string, sampleLines int) string {
 	if diff == "" {
 		return ""
 	}
@@ -132,7 +382,10 @@ func SummarizeDiff(diff string) string {
 	}
 
 	// Get a sample of the changes
-	sample := TruncateDiff(diff, 10)
+	sample := TruncateDiff(diff, sampleLines)
 
+	if symbols := ExtractSymbols(filename, diff); !symbols.Empty() {
+		return fmt.Sprintf("+%d/-%d lines. %s Sample:\n%s", additions, deletions, symbols, sample)
+	}
 	return fmt.Sprintf("+%d/-%d lines. Sample:\n%s", additions, deletions, sample)
 }
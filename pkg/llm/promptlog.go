@@ -0,0 +1,103 @@
+package llm
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PromptRecord is one prompt and the raw reply to it, as saved by --save-prompts
+type PromptRecord struct {
+	Kind         string    `json:"kind"` // Request type, e.g. changelog or pr_changelog
+	Model        string    `json:"model"`
+	Time         time.Time `json:"time"`
+	DurationMs   int64     `json:"duration_ms"`
+	InputTokens  int       `json:"input_tokens,omitempty"`
+	OutputTokens int       `json:"output_tokens,omitempty"`
+	Prompt       string    `json:"prompt"`
+	Response     string    `json:"response,omitempty"`
+	Error        string    `json:"error,omitempty"`
+}
+
+// PromptLog writes every prompt and raw reply of a run to a directory, one
+// JSON file per completion, with secrets redacted
+type PromptLog struct {
+	dir     string
+	run     string   // Prefix of this run's file names, so runs sharing dir don't collide
+	secrets []string // Literal values to redact, e.g. the API keys of the run
+
+	mu  sync.Mutex
+	seq int
+}
+
+// NewPromptLog creates dir if needed and returns a log writing to it. Every
+// non-empty value in secrets is redacted besides the patterns RedactSecrets knows.
+func NewPromptLog(dir string, secrets ...string) (*PromptLog, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create prompt log directory: %w", err)
+	}
+	return &PromptLog{dir: dir, run: time.Now().UTC().Format("20060102T150405"), secrets: secrets}, nil
+}
+
+// Record redacts and writes one completion, returning the file it wrote
+func (l *PromptLog) Record(record PromptRecord) (string, error) {
+	record.Prompt = RedactSecrets(record.Prompt, l.secrets...)
+	record.Response = RedactSecrets(record.Response, l.secrets...)
+	record.Error = RedactSecrets(record.Error, l.secrets...)
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("encode prompt record: %w", err)
+	}
+
+	l.mu.Lock()
+	l.seq++
+	path := filepath.Join(l.dir, fmt.Sprintf("%s-%04d-%s.json", l.run, l.seq, record.Kind))
+	l.mu.Unlock()
+	if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return "", fmt.Errorf("write prompt record: %w", err)
+	}
+	return path, nil
+}
+
+// redacted replaces secrets in saved prompts
+const redacted = "[REDACTED]"
+
+// secretPatterns match credentials that commonly leak into commit messages and
+// diffs, each with the replacement that redacts them. Replacements keep the
+// context of a secret, such as the key name it is assigned to.
+var secretPatterns = []struct {
+	re   *regexp.Regexp
+	repl string
+}{
+	{regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?(?:-----END [A-Z ]*PRIVATE KEY-----|$)`), redacted},
+	{regexp.MustCompile(`\bsk-[A-Za-z0-9_-]{16,}`), redacted},                                         // OpenAI
+	{regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{20,}|github_pat_\w{20,})`), redacted},             // GitHub
+	{regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`), redacted},                                   // AWS access key ID
+	{regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`), redacted},                                 // Slack
+	{regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`), redacted},                                       // Google API key
+	{regexp.MustCompile(`\b(?:sk|rk)_live_[0-9A-Za-z]{16,}`), redacted},                               // Stripe
+	{regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`), redacted}, // JWT
+	{regexp.MustCompile(`(?i)(\bbearer\s+)[A-Za-z0-9._~+/-]{16,}=*`), "${1}" + redacted},
+	{regexp.MustCompile(`(://[^:/\s@]+:)[^@/\s]+(@)`), "${1}" + redacted + "${2}"}, // Password in a URL
+	{regexp.MustCompile(`(?i)(\b[\w.-]*(?:api[_-]?key|secret|token|passw(?:or)?d|credentials?)["']?\s*[:=]\s*["']?)[^\s"',;]{8,}`), "${1}" + redacted},
+}
+
+// RedactSecrets replaces credentials in text with [REDACTED]: API keys and
+// tokens of well-known services, private keys, passwords in URLs, values
+// assigned to secret-looking names, and every non-empty value in secrets
+func RedactSecrets(text string, secrets ...string) string {
+	for _, secret := range secrets {
+		if secret != "" {
+			text = strings.ReplaceAll(text, secret, redacted)
+		}
+	}
+	for _, pattern := range secretPatterns {
+		text = pattern.re.ReplaceAllString(text, pattern.repl)
+	}
+	return text
+}
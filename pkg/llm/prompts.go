@@ -6,12 +6,17 @@ import (
 	"strings"
 )
 
+// PromptVersion identifies the prompt templates in generation metadata. Bump
+// it whenever their wording or requested output changes.
+const PromptVersion = "1"
+
 // BuildChangelogPrompt creates the prompt for changelog generation
 func BuildChangelogPrompt(req ChangelogRequest) string {
 	var sb strings.Builder
 
 	sb.WriteString("You are a technical writer creating a changelog for a software release.\n\n")
 	sb.WriteString(fmt.Sprintf("Repository: %s\n", req.RepoName))
+	writeStack(&sb, req.Stack)
 	sb.WriteString(fmt.Sprintf("Range: %s → %s\n\n", req.FromRef, req.ToRef))
 	sb.WriteString(fmt.Sprintf("Total commits: %d\n\n", len(req.Commits)))
 
@@ -19,7 +24,7 @@ func BuildChangelogPrompt(req ChangelogRequest) string {
 	sb.WriteString("---\n\n")
 
 	for i, commit := range req.Commits {
-		sb.WriteString(fmt.Sprintf("%d. Commit: %s\n", i+1, commit.SHA[:8]))
+		sb.WriteString(fmt.Sprintf("%d. Commit: %s\n", i+1, shortSHA(commit.SHA)))
 		sb.WriteString(fmt.Sprintf("   Author: %s\n", commit.Author))
 		sb.WriteString(fmt.Sprintf("   Date: %s\n", commit.Date.Format("2006-01-02")))
 		sb.WriteString(fmt.Sprintf("   Message: %s\n", commit.Message))
@@ -36,9 +41,47 @@ func BuildChangelogPrompt(req ChangelogRequest) string {
 			sb.WriteString(fmt.Sprintf("   Changes: %s\n", commit.DiffSummary))
 		}
 
+		if len(commit.Labels) > 0 {
+			sb.WriteString(fmt.Sprintf("   Labels: %s\n", strings.Join(commit.Labels, ", ")))
+		}
+
+		if commit.LabelCategory != "" {
+			sb.WriteString(fmt.Sprintf("   Labeled category: %s\n", commit.LabelCategory))
+		}
+
+		if commit.SecuritySignals != "" {
+			sb.WriteString(fmt.Sprintf("   Security signals: %s\n", commit.SecuritySignals))
+		}
+
+		sb.WriteString("\n")
+	}
+
+	if len(req.Clusters) > 0 {
+		sb.WriteString("Related commits (each group touches the same subsystem):\n")
+		for _, cluster := range req.Clusters {
+			shas := make([]string, len(cluster.SHAs))
+			for i, sha := range cluster.SHAs {
+				shas[i] = shortSHA(sha)
+			}
+			sb.WriteString(fmt.Sprintf("- %s/ (%d commits): %s\n", cluster.Area, len(cluster.SHAs), strings.Join(shas, ", ")))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(req.Advisories) > 0 {
+		sb.WriteString("Published security advisories for this repository that this release may address:\n")
+		for _, advisory := range req.Advisories {
+			ids := advisory.ID
+			if advisory.CVE != "" {
+				ids += ", " + advisory.CVE
+			}
+			sb.WriteString(fmt.Sprintf("- %s (%s severity): %s %s\n", ids, advisory.Severity, advisory.Summary, advisory.URL))
+		}
 		sb.WriteString("\n")
 	}
 
+	writeExistingNotes(&sb, req.ExistingNotes)
+
 	sb.WriteString("---\n\n")
 	sb.WriteString("Generate a structured changelog with:\n\n")
 	sb.WriteString("1. **Categories**: Organize commits into these categories:\n")
@@ -46,28 +89,57 @@ func BuildChangelogPrompt(req ChangelogRequest) string {
 	sb.WriteString("   - Improvements: Enhancements to existing features\n")
 	sb.WriteString("   - Bug Fixes: Bug fixes and error corrections\n")
 	sb.WriteString("   - Breaking Changes: Changes that break backward compatibility\n")
+	if req.Security {
+		sb.WriteString("   - Security: Vulnerability fixes, hardening of auth/crypto code, and dependency updates that fix known issues\n")
+	}
 	sb.WriteString("   - Documentation: Documentation updates\n")
 	sb.WriteString("   - Internal: Internal changes, refactoring, or dependencies\n\n")
+	if hasLabeledCategories(req.Commits) {
+		sb.WriteString("   A \"Labeled category\" comes from the team's pull request labels: use it unless the change clearly\n")
+		sb.WriteString("   belongs elsewhere.\n\n")
+	}
 
 	sb.WriteString("2. **For each commit**:\n")
 	sb.WriteString("   - title: Concise, user-facing title (max 80 chars)\n")
 	sb.WriteString("   - description: Brief explanation of the impact (1-2 sentences)\n")
 	sb.WriteString("   - importance_score: Rate 0-10 (10=critical/major impact, 5=moderate, 1=minor)\n")
+	sb.WriteString("   - score_reason: One short sentence explaining why the change got that score\n")
 	sb.WriteString("   - Include the SHA and author\n\n")
+	if len(req.Clusters) > 0 {
+		sb.WriteString("   When a group of related commits together makes one change, write a single higher-level entry for the\n")
+		sb.WriteString("   group instead of one fragmented entry per commit, e.g. \"Overhauled the auth module (5 commits)\". Use the\n")
+		sb.WriteString("   SHA of the group's most significant commit; that entry covers every commit in the group.\n\n")
+	}
+
+	if req.Security {
+		sb.WriteString("   Commits with security signals deserve a close look: put genuine security fixes and hardening in the\n")
+		sb.WriteString("   Security category with a \"severity\" of critical, high, medium, or low, and name any CVE or GHSA ID they\n")
+		sb.WriteString("   fix. Routine dependency bumps or auth refactors without a security impact stay in their usual category.\n")
+		if len(req.Advisories) > 0 {
+			sb.WriteString("   When an entry fixes one of the published advisories, use the advisory's severity and link its URL.\n")
+		}
+		sb.WriteString("\n")
+	}
 
 	sb.WriteString("3. **Top highlights**: Select 3-5 most important changes across all categories\n\n")
 
 	sb.WriteString("4. **Release summary**: Write 2-3 sentences summarizing this release\n\n")
 
+	sb.WriteString("5. **One-liner**: Summarize the whole release in a single sentence (max 120 chars)\n\n")
+
 	sb.WriteString("Output ONLY valid JSON with this structure:\n")
 	sb.WriteString("{\n")
+	sb.WriteString("  \"one_liner\": \"Single sentence release summary\",\n")
 	sb.WriteString("  \"summary\": \"2-3 sentence release summary\",\n")
 	sb.WriteString("  \"highlights\": [\"highlight 1\", \"highlight 2\", \"highlight 3\"],\n")
 	sb.WriteString("  \"categories\": {\n")
 	sb.WriteString("    \"Features\": [\n")
-	sb.WriteString("      {\"sha\": \"abc123\", \"title\": \"...\", \"description\": \"...\", \"author\": \"...\", \"importance_score\": 8.5}\n")
+	sb.WriteString("      {\"sha\": \"abc123\", \"title\": \"...\", \"description\": \"...\", \"author\": \"...\", \"importance_score\": 8.5, \"score_reason\": \"...\"}\n")
 	sb.WriteString("    ],\n")
 	sb.WriteString("    \"Bug Fixes\": [...],\n")
+	if req.Security {
+		sb.WriteString("    \"Security\": [{\"sha\": \"def456\", \"title\": \"...\", \"description\": \"...\", \"author\": \"...\", \"importance_score\": 9, \"score_reason\": \"...\", \"severity\": \"high\"}],\n")
+	}
 	sb.WriteString("    ...\n")
 	sb.WriteString("  }\n")
 	sb.WriteString("}\n\n")
@@ -82,18 +154,67 @@ func BuildChangelogPrompt(req ChangelogRequest) string {
 	sb.WriteString("- Write from the user's perspective (what changed for them)\n")
 	sb.WriteString("- Be concise and clear\n")
 	sb.WriteString("- Use the exact category names listed above\n")
-	sb.WriteString("- Include importance_score for EVERY commit\n")
+	sb.WriteString("- Include importance_score and score_reason for EVERY commit\n")
+	sb.WriteString("- Wrap identifiers (functions, types, flags, config keys) and file paths in backticks, e.g. `--verbose`, `pkg/config/config.go`\n")
+	sb.WriteString("- Only name functions, flags, or files that appear in the commit messages, file lists, or diffs above\n")
 	sb.WriteString("- Output ONLY the JSON, no additional text\n")
 
 	return sb.String()
 }
 
+// shortSHA abbreviates a commit SHA to the 8 characters shown in prompts
+func shortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
+
+// writeStack describes the repository's technology stack so the model can
+// read file paths and jargon in context
+func writeStack(sb *strings.Builder, stack string) {
+	if stack == "" {
+		return
+	}
+	sb.WriteString(fmt.Sprintf("Tech stack: %s\n", stack))
+	sb.WriteString("(Interpret file paths, package names, and jargon in terms of this stack.)\n")
+}
+
+// maxExistingNotes caps how much of a published release body is quoted
+const maxExistingNotes = 2000
+
+// writeExistingNotes quotes the release notes maintainers already published
+// so the generated summary agrees with them and can be merged into them
+func writeExistingNotes(sb *strings.Builder, notes string) {
+	if notes == "" {
+		return
+	}
+	if len(notes) > maxExistingNotes {
+		notes = notes[:maxExistingNotes] + "..."
+	}
+	sb.WriteString("Release notes the maintainers already wrote for this release:\n")
+	sb.WriteString("<<<\n" + notes + "\n>>>\n")
+	sb.WriteString("Keep the summary and highlights consistent with these notes: use their names for features, match what they\n")
+	sb.WriteString("emphasize, and never contradict them. Do not copy them verbatim; they will be shown alongside your output.\n\n")
+}
+
+// hasReviews reports whether any pull request carries review stats
+func hasReviews(prs []PRInfo) bool {
+	for _, pr := range prs {
+		if pr.Reviews != nil {
+			return true
+		}
+	}
+	return false
+}
+
 // BuildPRChangelogPrompt creates the prompt for PR-based release notes
 func BuildPRChangelogPrompt(req PRChangelogRequest) string {
 	var sb strings.Builder
 
 	sb.WriteString("You are a technical writer creating release notes for a software release.\n\n")
 	sb.WriteString(fmt.Sprintf("Repository: %s\n", req.RepoName))
+	writeStack(&sb, req.Stack)
 	sb.WriteString(fmt.Sprintf("Release: %s\n\n", req.ToRef))
 	sb.WriteString(fmt.Sprintf("This release contains %d pull requests.\n\n", len(req.PRs)))
 
@@ -103,6 +224,10 @@ func BuildPRChangelogPrompt(req PRChangelogRequest) string {
 	for i, pr := range req.PRs {
 		sb.WriteString(fmt.Sprintf("%d. PR #%d: %s\n", i+1, pr.Number, pr.Title))
 		sb.WriteString(fmt.Sprintf("   Author: %s\n", pr.Author))
+		if pr.Reviews != nil {
+			sb.WriteString(fmt.Sprintf("   Reviews: %d approvals, %d change requests, %d comments\n",
+				pr.Reviews.Approvals, pr.Reviews.ChangesRequested, pr.Reviews.Comments))
+		}
 		if pr.Body != "" {
 			// Truncate long PR bodies
 			body := pr.Body
@@ -114,11 +239,20 @@ func BuildPRChangelogPrompt(req PRChangelogRequest) string {
 		sb.WriteString("\n")
 	}
 
+	writeExistingNotes(&sb, req.ExistingNotes)
+
 	sb.WriteString("---\n\n")
 	sb.WriteString("For each pull request, write a single concise sentence summarizing its user-facing impact.\n")
 	sb.WriteString("Focus on WHAT changed from the user's perspective, not implementation details.\n\n")
+	if hasReviews(req.PRs) {
+		sb.WriteString("Review activity hints at significance: pull requests with change requests or long discussions are often\n")
+		sb.WriteString("contentious or high-impact changes. Give them specific summaries and feature them in the release summary.\n\n")
+	}
+	sb.WriteString("Also summarize the release as a whole twice: a one-liner (max 120 chars) and a 2-3 sentence paragraph.\n\n")
 	sb.WriteString("Output ONLY valid JSON with this structure:\n")
 	sb.WriteString("{\n")
+	sb.WriteString("  \"one_liner\": \"Single sentence release summary\",\n")
+	sb.WriteString("  \"summary\": \"2-3 sentence release summary\",\n")
 	sb.WriteString("  \"entries\": [\n")
 	sb.WriteString("    {\"number\": 4208, \"summary\": \"One sentence describing what this PR does for users.\"},\n")
 	sb.WriteString("    ...\n")
@@ -128,23 +262,276 @@ func BuildPRChangelogPrompt(req PRChangelogRequest) string {
 	sb.WriteString("- Include an entry for EVERY pull request\n")
 	sb.WriteString("- Each summary must be a single concise sentence\n")
 	sb.WriteString("- Write from the user's perspective\n")
+	sb.WriteString("- Wrap identifiers (functions, flags, config keys) and file paths in backticks\n")
+	sb.WriteString("- Only name functions, flags, or files that appear in the pull request titles or descriptions\n")
+	sb.WriteString("- Output ONLY the JSON, no additional text\n")
+
+	return sb.String()
+}
+
+// BuildRescorePrompt creates the prompt for scoring entries from several
+// releases together, so their importance scores share one scale
+func BuildRescorePrompt(req RescoreRequest) string {
+	var sb strings.Builder
+
+	sb.WriteString("You are a release manager ranking changes across several releases of a software project.\n\n")
+	sb.WriteString(fmt.Sprintf("Repository: %s\n\n", req.RepoName))
+	sb.WriteString(fmt.Sprintf("These %d changes come from different releases:\n", len(req.Items)))
+	sb.WriteString("---\n\n")
+	for _, item := range req.Items {
+		sb.WriteString(fmt.Sprintf("[%s] %s: %s\n", item.ID, item.Release, item.Title))
+		if item.Summary != "" {
+			sb.WriteString(fmt.Sprintf("   %s\n", item.Summary))
+		}
+	}
+	sb.WriteString("\n---\n\n")
+
+	sb.WriteString("Rate the importance of EVERY change on a single 0-10 scale shared by all releases, so that a score of 7\n")
+	sb.WriteString("means the same in every release. Compare changes against each other, not against their own release.\n\n")
+	sb.WriteString("Importance Score Guidelines:\n")
+	sb.WriteString("- 9-10: Critical/Breaking changes, major new features, security fixes\n")
+	sb.WriteString("- 7-8: Significant features, important bug fixes, notable improvements\n")
+	sb.WriteString("- 5-6: Moderate features/fixes, useful enhancements\n")
+	sb.WriteString("- 3-4: Minor features/fixes, small improvements\n")
+	sb.WriteString("- 1-2: Trivial changes, documentation, internal refactoring\n\n")
+	sb.WriteString("Output ONLY valid JSON with this structure:\n")
+	sb.WriteString("{\n")
+	sb.WriteString("  \"scores\": [\n")
+	sb.WriteString("    {\"id\": \"v1.2.0#42\", \"importance_score\": 7.5, \"score_reason\": \"One short sentence\"},\n")
+	sb.WriteString("    ...\n")
+	sb.WriteString("  ]\n")
+	sb.WriteString("}\n\n")
+	sb.WriteString("Important:\n")
+	sb.WriteString("- Use each change's id exactly as given in brackets\n")
+	sb.WriteString("- Output ONLY the JSON, no additional text\n")
+
+	return sb.String()
+}
+
+// BuildReviewPrompt creates the prompt for checking human-written release
+// notes against the commits they describe
+func BuildReviewPrompt(req ReviewRequest) string {
+	var sb strings.Builder
+
+	sb.WriteString("You are a release manager reviewing hand-written release notes before they are published.\n\n")
+	sb.WriteString(fmt.Sprintf("Repository: %s\n", req.RepoName))
+	sb.WriteString(fmt.Sprintf("Range: %s → %s\n\n", req.FromRef, req.ToRef))
+
+	sb.WriteString("Release notes under review:\n")
+	sb.WriteString("---\n\n")
+	sb.WriteString(strings.TrimSpace(req.Notes))
+	sb.WriteString("\n\n---\n\n")
+
+	sb.WriteString(fmt.Sprintf("The %d commits actually in this release:\n", len(req.Commits)))
+	sb.WriteString("---\n\n")
+	for i, commit := range req.Commits {
+		sb.WriteString(fmt.Sprintf("%d. Commit: %s\n", i+1, shortSHA(commit.SHA)))
+		sb.WriteString(fmt.Sprintf("   Message: %s\n", commit.Message))
+		if len(commit.FilesChanged) > 0 {
+			sb.WriteString(fmt.Sprintf("   Files: %s\n", strings.Join(commit.FilesChanged, ", ")))
+		}
+		if commit.DiffSummary != "" {
+			sb.WriteString(fmt.Sprintf("   Changes: %s\n", commit.DiffSummary))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("---\n\n")
+
+	sb.WriteString("Compare the notes with the commits and report:\n")
+	sb.WriteString("1. Omissions: user-facing or otherwise notable commits the notes do not mention, directly or as part of a\n")
+	sb.WriteString("   broader item. Skip trivial internal changes (typos, CI tweaks, test-only refactors). Rate each one's\n")
+	sb.WriteString("   importance 0-10, where 9-10 means users will be surprised not to have been told (breaking changes,\n")
+	sb.WriteString("   security fixes) and 1-2 means barely worth a line.\n")
+	sb.WriteString("2. Inaccuracies: statements in the notes the commits contradict or do not support, such as features that\n")
+	sb.WriteString("   are not in this release, wrong names, flags, or defaults, or fixes described differently than made.\n\n")
+	sb.WriteString("Output ONLY valid JSON with this structure:\n")
+	sb.WriteString("{\n")
+	sb.WriteString("  \"summary\": \"One or two sentences on how complete and accurate the notes are\",\n")
+	sb.WriteString("  \"omissions\": [\n")
+	sb.WriteString("    {\"sha\": \"abc1234\", \"title\": \"Short description of the change\", \"importance_score\": 7.5, \"reason\": \"Why readers need to know\"}\n")
+	sb.WriteString("  ],\n")
+	sb.WriteString("  \"inaccuracies\": [\n")
+	sb.WriteString("    {\"claim\": \"Statement quoted from the notes\", \"problem\": \"What the commits show instead\", \"shas\": [\"abc1234\"]}\n")
+	sb.WriteString("  ]\n")
+	sb.WriteString("}\n\n")
+	sb.WriteString("Important:\n")
+	sb.WriteString("- Use the commit SHAs exactly as listed\n")
+	sb.WriteString("- Return empty lists when the notes are complete and accurate; do not invent problems\n")
+	sb.WriteString("- Output ONLY the JSON, no additional text\n")
+
+	return sb.String()
+}
+
+// BuildCompressionPrompt creates the prompt for condensing an over-long release section
+func BuildCompressionPrompt(markdown string, limit int, unit string) string {
+	var sb strings.Builder
+
+	sb.WriteString("You are a technical writer editing release notes to fit a length budget.\n\n")
+	sb.WriteString(fmt.Sprintf("The release section below must be condensed to at most %d %s.\n\n", limit, unit))
+
+	sb.WriteString("Release section:\n")
+	sb.WriteString("---\n\n")
+	sb.WriteString(markdown)
+	sb.WriteString("\n---\n\n")
+
+	sb.WriteString("Rules:\n")
+	sb.WriteString("- Keep EVERY breaking change, with enough detail for users to act on it\n")
+	sb.WriteString("- Keep the existing headings and their order; drop sections that become empty\n")
+	sb.WriteString("- Merge related entries and shorten descriptions before dropping anything\n")
+	sb.WriteString("- Drop the least important entries (internal, documentation, trivial fixes) first\n")
+	sb.WriteString("- Preserve commit, PR, and ticket links exactly as written for the entries you keep\n")
+	sb.WriteString("- Do not invent changes that are not in the original section\n")
+	sb.WriteString("- Output ONLY the condensed markdown, no additional text\n")
+
+	return sb.String()
+}
+
+// maxPeriodPRTitles caps how many pull request titles of one release the
+// period summary prompt lists
+const maxPeriodPRTitles = 15
+
+// BuildPeriodSummaryPrompt creates the prompt for the executive digest that
+// opens a timeline
+func BuildPeriodSummaryPrompt(req PeriodSummaryRequest) string {
+	var sb strings.Builder
+
+	sb.WriteString("You are a product lead writing an executive digest of a period of software releases.\n\n")
+	sb.WriteString(fmt.Sprintf("Repository: %s\n", req.RepoName))
+	sb.WriteString(fmt.Sprintf("Period: %s to %s\n", req.FromDate.Format("2006-01-02"), req.ToDate.Format("2006-01-02")))
+	sb.WriteString(fmt.Sprintf("Releases: %d\n\n", len(req.Releases)))
+
+	sb.WriteString("Releases (oldest first):\n")
+	sb.WriteString("---\n\n")
+	for _, release := range req.Releases {
+		sb.WriteString(fmt.Sprintf("%s (%s)\n", release.Name, release.Date.Format("2006-01-02")))
+		if release.Summary != "" {
+			sb.WriteString(fmt.Sprintf("   Summary: %s\n", release.Summary))
+		}
+		titles := release.PRTitles
+		if len(titles) > maxPeriodPRTitles {
+			titles = append(titles[:maxPeriodPRTitles:maxPeriodPRTitles], fmt.Sprintf("... and %d more", len(release.PRTitles)-maxPeriodPRTitles))
+		}
+		for _, title := range titles {
+			sb.WriteString(fmt.Sprintf("   - %s\n", title))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("---\n\n")
+
+	sb.WriteString("Synthesize the period as a whole, for readers who will not read every release:\n")
+	sb.WriteString("1. summary: 2-4 sentences on what the period focused on, e.g. \"Q1 focused on performance and the new auth system\"\n")
+	sb.WriteString("2. themes: 2-5 recurring themes across releases, one short sentence each, naming the releases involved\n\n")
+	sb.WriteString("Output ONLY valid JSON with this structure:\n")
+	sb.WriteString("{\n")
+	sb.WriteString("  \"summary\": \"2-4 sentence digest of the period\",\n")
+	sb.WriteString("  \"themes\": [\"Theme 1 (v1.2.0, v1.3.0)\", \"Theme 2\"]\n")
+	sb.WriteString("}\n\n")
+	sb.WriteString("Important:\n")
+	sb.WriteString("- Look for threads that span several releases rather than restating each release\n")
+	sb.WriteString("- Only mention changes listed above\n")
+	sb.WriteString("- Write from the user's perspective\n")
 	sb.WriteString("- Output ONLY the JSON, no additional text\n")
 
 	return sb.String()
 }
 
+// ParsePeriodSummaryResponse parses the JSON response of a period summary
+func ParsePeriodSummaryResponse(jsonStr string) (*PeriodSummaryResponse, error) {
+	jsonStr, repaired := cleanJSONResponse(jsonStr)
+
+	var response PeriodSummaryResponse
+	if err := json.Unmarshal([]byte(jsonStr), &response); err != nil {
+		return nil, fmt.Errorf("parse period summary JSON response: %w", err)
+	}
+	response.Repaired = repaired
+
+	return &response, nil
+}
+
+// BuildTranslatePrompt creates the prompt for translating changelog texts
+func BuildTranslatePrompt(req TranslateRequest) string {
+	var sb strings.Builder
+
+	sb.WriteString("You are a technical translator localizing software release notes.\n\n")
+	sb.WriteString(fmt.Sprintf("Translate each text in the JSON array below into %s.\n\n", req.Language))
+
+	texts, _ := json.MarshalIndent(req.Texts, "", "  ")
+	sb.WriteString("Texts:\n")
+	sb.WriteString(string(texts))
+	sb.WriteString("\n\n")
+
+	sb.WriteString("Rules:\n")
+	sb.WriteString("- Keep markdown structure exactly: headings, list markers, emphasis, tables, and blank lines\n")
+	sb.WriteString("- Never translate code spans, code blocks, URLs, link targets, HTML comments, commit SHAs, PR and ticket\n")
+	sb.WriteString("  numbers, version numbers, or product and project names\n")
+	sb.WriteString("- Keep emoji and punctuation markers such as \"⚠️\" or \"📦\" in place\n")
+	sb.WriteString("- Use the established technical terms of the target language; keep English terms developers would not translate\n")
+	sb.WriteString("- Do not add, drop, merge, or reorder content\n\n")
+
+	sb.WriteString("Output ONLY valid JSON with one translation per text, in the same order:\n")
+	sb.WriteString("{\"translations\": [\"translated text 1\", \"translated text 2\", ...]}\n")
+
+	return sb.String()
+}
+
+// ParseTranslateResponse parses the JSON response of a translation call
+func ParseTranslateResponse(jsonStr string) (*TranslateResponse, error) {
+	jsonStr, repaired := cleanJSONResponse(jsonStr)
+
+	var response TranslateResponse
+	if err := json.Unmarshal([]byte(jsonStr), &response); err != nil {
+		return nil, fmt.Errorf("parse translate JSON response: %w", err)
+	}
+	response.Repaired = repaired
+
+	return &response, nil
+}
+
+// CleanMarkdownResponse strips a surrounding ```markdown code fence from a model reply
+func CleanMarkdownResponse(content string) string {
+	content = strings.TrimSpace(content)
+	content = strings.TrimPrefix(content, "```markdown")
+	content = strings.TrimPrefix(content, "```md")
+	content = strings.TrimPrefix(content, "```")
+	content = strings.TrimSuffix(content, "```")
+	return strings.TrimSpace(content) + "\n"
+}
+
 // ParsePRChangelogResponse parses the JSON response for PR-based release notes
 func ParsePRChangelogResponse(jsonStr string) (*PRChangelogResponse, error) {
-	jsonStr = strings.TrimSpace(jsonStr)
-	jsonStr = strings.TrimPrefix(jsonStr, "```json")
-	jsonStr = strings.TrimPrefix(jsonStr, "```")
-	jsonStr = strings.TrimSuffix(jsonStr, "```")
-	jsonStr = strings.TrimSpace(jsonStr)
+	jsonStr, repaired := cleanJSONResponse(jsonStr)
 
 	var response PRChangelogResponse
 	if err := json.Unmarshal([]byte(jsonStr), &response); err != nil {
 		return nil, fmt.Errorf("parse PR changelog JSON response: %w", err)
 	}
+	response.Repaired = repaired
+
+	return &response, nil
+}
+
+// ParseRescoreResponse parses the JSON response of a calibration pass
+func ParseRescoreResponse(jsonStr string) (*RescoreResponse, error) {
+	jsonStr, repaired := cleanJSONResponse(jsonStr)
+
+	var response RescoreResponse
+	if err := json.Unmarshal([]byte(jsonStr), &response); err != nil {
+		return nil, fmt.Errorf("parse rescore JSON response: %w", err)
+	}
+	response.Repaired = repaired
+
+	return &response, nil
+}
+
+// ParseReviewResponse parses the JSON gap report of a release notes review
+func ParseReviewResponse(jsonStr string) (*ReviewResponse, error) {
+	jsonStr, repaired := cleanJSONResponse(jsonStr)
+
+	var response ReviewResponse
+	if err := json.Unmarshal([]byte(jsonStr), &response); err != nil {
+		return nil, fmt.Errorf("parse review JSON response: %w", err)
+	}
+	response.Repaired = repaired
 
 	return &response, nil
 }
@@ -152,16 +539,34 @@ func ParsePRChangelogResponse(jsonStr string) (*PRChangelogResponse, error) {
 // ParseChangelogResponse parses the JSON response from the LLM
 func ParseChangelogResponse(jsonStr string) (*ChangelogResponse, error) {
 	// Clean up the response - remove markdown code blocks if present
-	jsonStr = strings.TrimSpace(jsonStr)
-	jsonStr = strings.TrimPrefix(jsonStr, "```json")
-	jsonStr = strings.TrimPrefix(jsonStr, "```")
-	jsonStr = strings.TrimSuffix(jsonStr, "```")
-	jsonStr = strings.TrimSpace(jsonStr)
+	jsonStr, repaired := cleanJSONResponse(jsonStr)
 
 	var response ChangelogResponse
 	if err := json.Unmarshal([]byte(jsonStr), &response); err != nil {
 		return nil, fmt.Errorf("parse JSON response: %w", err)
 	}
+	response.Repaired = repaired
 
 	return &response, nil
 }
+
+// cleanJSONResponse strips markdown code fences around a JSON reply and
+// reports whether any cleanup was needed
+func cleanJSONResponse(content string) (string, bool) {
+	original := strings.TrimSpace(content)
+	cleaned := strings.TrimPrefix(original, "```json")
+	cleaned = strings.TrimPrefix(cleaned, "```")
+	cleaned = strings.TrimSuffix(cleaned, "```")
+	cleaned = strings.TrimSpace(cleaned)
+	return cleaned, cleaned != original
+}
+
+// hasLabeledCategories reports whether any commit carries a label category hint
+func hasLabeledCategories(commits []CommitInfo) bool {
+	for _, commit := range commits {
+		if commit.LabelCategory != "" {
+			return true
+		}
+	}
+	return false
+}
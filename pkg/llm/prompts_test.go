@@ -1,6 +1,7 @@
 package llm
 
 import (
+	"strings"
 	"testing"
 	"time"
 )
@@ -40,6 +41,7 @@ func TestBuildChangelogPrompt(t *testing.T) {
 		"johndoe",
 		"Features",
 		"Bug Fixes",
+		"one_liner",
 	}
 
 	for _, str := range requiredStrings {
@@ -49,6 +51,178 @@ func TestBuildChangelogPrompt(t *testing.T) {
 	}
 }
 
+func TestBuildPRChangelogPromptReviews(t *testing.T) {
+	req := PRChangelogRequest{
+		PRs: []PRInfo{
+			{Number: 1, Title: "Rewrite auth", Author: "alice", Reviews: &ReviewInfo{Approvals: 2, ChangesRequested: 3, Comments: 41}},
+			{Number: 2, Title: "Fix typo", Author: "bob"},
+		},
+		RepoName: "acme/api",
+		ToRef:    "v2.0.0",
+	}
+
+	prompt := BuildPRChangelogPrompt(req)
+	if !strings.Contains(prompt, "Reviews: 2 approvals, 3 change requests, 41 comments") {
+		t.Errorf("Expected review stats in prompt\nGot:\n%s", prompt)
+	}
+	if !strings.Contains(prompt, "Review activity hints at significance") {
+		t.Error("Expected review guidance when stats are present")
+	}
+
+	req.PRs[0].Reviews = nil
+	if strings.Contains(BuildPRChangelogPrompt(req), "Review activity") {
+		t.Error("Expected no review guidance without stats")
+	}
+}
+
+func TestPromptsIncludeStack(t *testing.T) {
+	stack := "Go (82%); frameworks: Cobra"
+	prompts := []string{
+		BuildChangelogPrompt(ChangelogRequest{RepoName: "acme/api", Stack: stack}),
+		BuildPRChangelogPrompt(PRChangelogRequest{RepoName: "acme/api", Stack: stack}),
+	}
+	for _, prompt := range prompts {
+		if !strings.Contains(prompt, "Tech stack: "+stack) {
+			t.Errorf("Expected tech stack in prompt\nGot:\n%s", prompt)
+		}
+	}
+	if strings.Contains(BuildChangelogPrompt(ChangelogRequest{RepoName: "acme/api"}), "Tech stack") {
+		t.Error("Expected no tech stack line when none was detected")
+	}
+}
+
+func TestPromptsIncludeExistingNotes(t *testing.T) {
+	notes := "## Upgrade notes\nRun `migrate up` before restarting."
+	prompts := []string{
+		BuildChangelogPrompt(ChangelogRequest{RepoName: "acme/api", ExistingNotes: notes}),
+		BuildPRChangelogPrompt(PRChangelogRequest{RepoName: "acme/api", ExistingNotes: notes}),
+	}
+	for _, prompt := range prompts {
+		if !strings.Contains(prompt, "<<<\n"+notes+"\n>>>") || !strings.Contains(prompt, "never contradict them") {
+			t.Errorf("Expected existing notes in prompt\nGot:\n%s", prompt)
+		}
+	}
+	if strings.Contains(BuildPRChangelogPrompt(PRChangelogRequest{RepoName: "acme/api"}), "already wrote") {
+		t.Error("Expected no existing notes section without notes")
+	}
+}
+
+func TestBuildChangelogPromptClusters(t *testing.T) {
+	req := ChangelogRequest{
+		Commits:  []CommitInfo{{SHA: "abc123def456", Message: "Rotate tokens"}},
+		RepoName: "acme/api",
+		Clusters: []CommitCluster{{Area: "pkg/auth", SHAs: []string{"abc123def456", "0123456789ab", "fedcba987654"}}},
+	}
+
+	prompt := BuildChangelogPrompt(req)
+	if !strings.Contains(prompt, "- pkg/auth/ (3 commits): abc123de, 01234567, fedcba98") {
+		t.Errorf("Expected commit group in prompt\nGot:\n%s", prompt)
+	}
+	if !strings.Contains(prompt, "single higher-level entry") {
+		t.Error("Expected grouping instructions when clusters are present")
+	}
+
+	req.Clusters = nil
+	if strings.Contains(BuildChangelogPrompt(req), "Related commits") {
+		t.Error("Expected no commit groups without clusters")
+	}
+}
+
+func TestBuildChangelogPromptSecurity(t *testing.T) {
+	req := ChangelogRequest{
+		Commits:    []CommitInfo{{SHA: "abc123def456", Message: "Bump x/net", SecuritySignals: "changes dependencies"}},
+		RepoName:   "acme/api",
+		Security:   true,
+		Advisories: []AdvisoryInfo{{ID: "GHSA-abcd-efgh-ijkm", CVE: "CVE-2024-0001", Severity: "high", Summary: "Open redirect", URL: "https://github.com/acme/api/security/advisories/GHSA-abcd-efgh-ijkm"}},
+	}
+
+	prompt := BuildChangelogPrompt(req)
+	for _, want := range []string{
+		"Security signals: changes dependencies",
+		"- Security: Vulnerability fixes",
+		"- GHSA-abcd-efgh-ijkm, CVE-2024-0001 (high severity): Open redirect",
+		`"severity": "high"`,
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("Expected %q in prompt\nGot:\n%s", want, prompt)
+		}
+	}
+
+	req.Security, req.Advisories = false, nil
+	if strings.Contains(BuildChangelogPrompt(req), "Security:") {
+		t.Error("Expected no Security category when the security pass is off")
+	}
+}
+
+func TestBuildChangelogPromptLabels(t *testing.T) {
+	req := ChangelogRequest{
+		Commits:  []CommitInfo{{SHA: "abc123def456", Message: "Add login (#12)", Labels: []string{"kind/feature", "area/auth"}, LabelCategory: "Features"}},
+		RepoName: "acme/api",
+	}
+
+	prompt := BuildChangelogPrompt(req)
+	for _, want := range []string{
+		"   Labels: kind/feature, area/auth\n",
+		"   Labeled category: Features\n",
+		"pull request labels",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("Expected %q in prompt\nGot:\n%s", want, prompt)
+		}
+	}
+
+	req.Commits[0].LabelCategory = ""
+	if strings.Contains(BuildChangelogPrompt(req), "Labeled category") {
+		t.Error("Expected no label category instructions without hints")
+	}
+}
+
+func TestRescorePrompt(t *testing.T) {
+	prompt := BuildRescorePrompt(RescoreRequest{
+		RepoName: "acme/api",
+		Items: []RescoreItem{
+			{ID: "v1.1.0#12", Release: "v1.1.0", Title: "Add login", Summary: "Users can sign in with SSO."},
+			{ID: "v1.2.0#20", Release: "v1.2.0", Title: "Fix typo"},
+		},
+	})
+	for _, want := range []string{"[v1.1.0#12] v1.1.0: Add login\n   Users can sign in with SSO.\n", "[v1.2.0#20] v1.2.0: Fix typo\n", "single 0-10 scale"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("Expected %q in prompt\nGot:\n%s", want, prompt)
+		}
+	}
+
+	response, err := ParseRescoreResponse("```json\n{\"scores\": [{\"id\": \"v1.1.0#12\", \"importance_score\": 8, \"score_reason\": \"New sign-in\"}]}\n```")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !response.Repaired || len(response.Scores) != 1 || response.Scores[0].ImportanceScore != 8 {
+		t.Errorf("Unexpected rescore response: %+v", response)
+	}
+}
+
+func TestReviewPrompt(t *testing.T) {
+	prompt := BuildReviewPrompt(ReviewRequest{
+		RepoName: "acme/api",
+		FromRef:  "v1.0.0",
+		ToRef:    "v1.1.0",
+		Notes:    "## v1.1.0\n\n- Added SSO login\n",
+		Commits:  []CommitInfo{{SHA: "abc1234567", Message: "Drop the legacy /v1 endpoints", FilesChanged: []string{"api/v1.go"}}},
+	})
+	for _, want := range []string{"Range: v1.0.0 → v1.1.0\n", "---\n\n## v1.1.0\n\n- Added SSO login\n\n---", "1. Commit: abc12345\n   Message: Drop the legacy /v1 endpoints\n   Files: api/v1.go\n", "Inaccuracies"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("Expected %q in prompt\nGot:\n%s", want, prompt)
+		}
+	}
+
+	response, err := ParseReviewResponse(`{"summary": "Misses a breaking change.", "omissions": [{"sha": "abc1234", "title": "Removed /v1", "importance_score": 9, "reason": "Breaks clients"}], "inaccuracies": []}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.Repaired || len(response.Omissions) != 1 || response.Omissions[0].ImportanceScore != 9 || len(response.Inaccuracies) != 0 {
+		t.Errorf("Unexpected review response: %+v", response)
+	}
+}
+
 func TestParseChangelogResponse(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -142,3 +316,24 @@ func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 ||
 		(len(s) > 0 && (s[0:1] == substr[0:1] && contains(s[1:], substr[1:])) || contains(s[1:], substr)))
 }
+
+func TestParseChangelogResponseSoftFailures(t *testing.T) {
+	clean, err := ParseChangelogResponse(`{"summary": "s", "categories": {"Features": [{"sha": "abc", "title": "t", "importance_score": 7}]}}`)
+	if err != nil {
+		t.Fatalf("ParseChangelogResponse() error = %v", err)
+	}
+	if clean.Repaired || clean.Categories["Features"][0].ScoreMissing {
+		t.Errorf("Expected clean response, got %+v", clean)
+	}
+
+	repaired, err := ParseChangelogResponse("```json\n" + `{"summary": "s", "categories": {"Features": [{"sha": "abc", "title": "t"}]}}` + "\n```")
+	if err != nil {
+		t.Fatalf("ParseChangelogResponse() error = %v", err)
+	}
+	if !repaired.Repaired {
+		t.Error("Expected fenced response to be marked as repaired")
+	}
+	if !repaired.Categories["Features"][0].ScoreMissing {
+		t.Error("Expected entry without importance_score to be marked")
+	}
+}
@@ -0,0 +1,94 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/openai/openai-go"
+)
+
+// DefaultMaxAttempts is how many times a completion is tried before a rate
+// limit or server error fails the run
+const DefaultMaxAttempts = 4
+
+const (
+	baseRetryDelay = time.Second
+	maxRetryDelay  = 30 * time.Second
+	// maxRetryAfter caps how long a Retry-After header can make a run wait
+	maxRetryAfter = 2 * time.Minute
+)
+
+// SetMaxAttempts sets how many times a completion is tried when the API
+// answers with a rate limit (429) or server error (5xx); 1 disables retries
+func (c *OpenAIClient) SetMaxAttempts(attempts int) {
+	c.maxAttempts = max(attempts, 1)
+}
+
+// withRetry calls create until it succeeds, fails with an error that is not
+// worth retrying, or has been tried maxAttempts times
+func withRetry[T any](ctx context.Context, maxAttempts int, create func() (T, error)) (T, error) {
+	for attempt := 1; ; attempt++ {
+		result, err := create()
+		if err == nil || attempt >= maxAttempts || !retryable(err) {
+			return result, err
+		}
+
+		delay := retryDelay(err, attempt)
+		logger.Warn("retrying chat completion", "attempt", attempt, "max_attempts", maxAttempts, "delay", delay, "error", err)
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return result, err
+		}
+	}
+}
+
+// retryable reports whether an API error is transient: a rate limit or a
+// server error
+func retryable(err error) bool {
+	var apiErr *openai.Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500
+}
+
+// retryDelay returns how long to wait before the next attempt: what the API
+// asks for in Retry-After, or exponential backoff with jitter
+func retryDelay(err error, attempt int) time.Duration {
+	var apiErr *openai.Error
+	if errors.As(err, &apiErr) && apiErr.Response != nil {
+		if delay, ok := parseRetryAfter(apiErr.Response.Header); ok {
+			return min(delay, maxRetryAfter)
+		}
+	}
+	backoff := min(baseRetryDelay<<(attempt-1), maxRetryDelay)
+	// Equal jitter keeps at least half the backoff while keeping concurrent
+	// requests from retrying in lockstep
+	return backoff/2 + rand.N(backoff/2+1)
+}
+
+// parseRetryAfter reads the retry-after-ms header OpenAI sends, or a standard
+// Retry-After header in seconds or as an HTTP date
+func parseRetryAfter(header http.Header) (time.Duration, bool) {
+	if ms, err := strconv.ParseFloat(header.Get("Retry-After-Ms"), 64); err == nil && ms >= 0 {
+		return time.Duration(ms * float64(time.Millisecond)), true
+	}
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds >= 0 {
+		return time.Duration(seconds * float64(time.Second)), true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
@@ -0,0 +1,114 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/openai/openai-go"
+	"github.com/openai/openai-go/option"
+)
+
+// testClient returns a client for an API served by handler
+func testClient(t *testing.T, handler http.HandlerFunc) *OpenAIClient {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+	client := openai.NewClient(option.WithAPIKey("test"), option.WithBaseURL(server.URL), option.WithMaxRetries(0))
+	return &OpenAIClient{client: &client, model: "gpt-4o", maxTokens: 100, maxAttempts: DefaultMaxAttempts}
+}
+
+const completionJSON = `{"id":"1","object":"chat.completion","model":"gpt-4o-2024-08-06","choices":[{"index":0,"finish_reason":"stop","message":{"role":"assistant","content":"done"}}],"usage":{"prompt_tokens":5,"completion_tokens":1,"total_tokens":6}}`
+
+func TestCompleteRetriesTransientErrors(t *testing.T) {
+	statuses := []int{http.StatusTooManyRequests, http.StatusBadGateway}
+	requests := 0
+	client := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests <= len(statuses) {
+			w.Header().Set("Retry-After-Ms", "1")
+			w.WriteHeader(statuses[requests-1])
+			fmt.Fprint(w, `{"error":{"message":"slow down"}}`)
+			return
+		}
+		fmt.Fprint(w, completionJSON)
+	})
+
+	content, err := client.complete(context.Background(), "test", "hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content != "done" || requests != 3 {
+		t.Errorf("content = %q after %d requests, want done after 3", content, requests)
+	}
+	if usage := client.Usage(); usage.Calls != 1 {
+		t.Errorf("usage = %+v, want one call", usage)
+	}
+}
+
+func TestCompleteGivesUp(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		attempts int
+		want     int // Requests made
+	}{
+		{"max attempts", http.StatusServiceUnavailable, 2, 2},
+		{"no retries", http.StatusTooManyRequests, 1, 1},
+		{"client error", http.StatusBadRequest, 4, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			client := testClient(t, func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(tt.status)
+				fmt.Fprint(w, `{"error":{"message":"no"}}`)
+			})
+			client.SetMaxAttempts(tt.attempts)
+
+			if _, err := client.complete(context.Background(), "test", "hello"); err == nil {
+				t.Fatal("expected an error")
+			}
+			if requests != tt.want {
+				t.Errorf("requests = %d, want %d", requests, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		header http.Header
+		want   time.Duration
+		ok     bool
+	}{
+		{http.Header{"Retry-After-Ms": {"250"}, "Retry-After": {"1"}}, 250 * time.Millisecond, true},
+		{http.Header{"Retry-After": {"2"}}, 2 * time.Second, true},
+		{http.Header{"Retry-After": {"Mon, 01 Jan 2001 00:00:00 GMT"}}, 0, true},
+		{http.Header{"Retry-After": {"soon"}}, 0, false},
+		{http.Header{}, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.header)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseRetryAfter(%v) = %v, %v; want %v, %v", tt.header, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestRetryDelayBackoff(t *testing.T) {
+	for attempt, want := range map[int]time.Duration{1: time.Second, 3: 4 * time.Second, 10: maxRetryDelay} {
+		delay := retryDelay(fmt.Errorf("network"), attempt)
+		if delay < want/2 || delay > want {
+			t.Errorf("retryDelay(attempt %d) = %v, want between %v and %v", attempt, delay, want/2, want)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package llm
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+// maxSymbolsPerKind caps how many names of each kind a diff summary lists
+const maxSymbolsPerKind = 10
+
+// DiffSymbols lists the functions and types a patch adds, removes, or changes
+type DiffSymbols struct {
+	Added    []string
+	Removed  []string
+	Modified []string
+}
+
+// Empty reports whether no declarations were found
+func (s DiffSymbols) Empty() bool {
+	return len(s.Added) == 0 && len(s.Removed) == 0 && len(s.Modified) == 0
+}
+
+// declarationPatterns match one declaration per line; the last non-empty
+// submatch is its name, and a receiver submatch (Go) qualifies it
+var declarationPatterns = map[string][]*regexp.Regexp{
+	"go": {
+		regexp.MustCompile(`^func\s+\(\s*(?:\w+\s+)?\*?(\w+)(?:\[[^\]]*\])?\s*\)\s*(\w+)`),
+		regexp.MustCompile(`^func\s+(\w+)`),
+		regexp.MustCompile(`^type\s+(\w+)`),
+	},
+	"typescript": {
+		regexp.MustCompile(`^\s*(?:export\s+)?(?:default\s+)?(?:async\s+)?function\s*\*?\s*(\w+)`),
+		regexp.MustCompile(`^\s*(?:export\s+)?(?:default\s+)?(?:declare\s+)?(?:abstract\s+)?(?:class|interface|enum|type)\s+(\w+)`),
+		regexp.MustCompile(`^\s*(?:export\s+)?(?:const|let)\s+(\w+)\s*(?::[^=]+)?=\s*(?:async\s+)?(?:function\b|(?:\([^)]*\)|\w+)\s*(?::[^=]+)?=>)`),
+	},
+	"python": {
+		regexp.MustCompile(`^\s*(?:async\s+)?def\s+(\w+)`),
+		regexp.MustCompile(`^\s*class\s+(\w+)`),
+	},
+}
+
+// diffLanguage names the declaration patterns for a file, or "" when its
+// language is not supported
+func diffLanguage(filename string) string {
+	switch strings.ToLower(path.Ext(filename)) {
+	case ".go":
+		return "go"
+	case ".ts", ".tsx", ".mts", ".cts", ".js", ".jsx", ".mjs", ".cjs":
+		return "typescript"
+	case ".py", ".pyi":
+		return "python"
+	}
+	return ""
+}
+
+// declaredName returns the function or type a source line declares
+func declaredName(language, line string) string {
+	for _, pattern := range declarationPatterns[language] {
+		m := pattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		if len(m) == 3 {
+			return m[1] + "." + m[2] // Go method: Receiver.Method
+		}
+		return m[1]
+	}
+	return ""
+}
+
+// ExtractSymbols lists the functions and types a unified diff of filename
+// adds, removes, or modifies. A declaration whose signature line changes, or
+// whose body has changed lines, counts as modified. Files in languages other
+// than Go, TypeScript/JavaScript, and Python yield no symbols.
+func ExtractSymbols(filename, patch string) DiffSymbols {
+	language := diffLanguage(filename)
+	if language == "" || patch == "" {
+		return DiffSymbols{}
+	}
+
+	var order []string
+	added, removed, touched := map[string]bool{}, map[string]bool{}, map[string]bool{}
+	see := func(name string) {
+		if !added[name] && !removed[name] && !touched[name] {
+			order = append(order, name)
+		}
+	}
+
+	current := "" // Declaration enclosing the lines being read
+	inHunk := false
+	for _, line := range strings.Split(patch, "\n") {
+		if strings.HasPrefix(line, "@@") {
+			inHunk = true
+			// Git names the enclosing declaration after the hunk range
+			current = ""
+			if _, context, ok := strings.Cut(strings.TrimPrefix(line, "@@"), "@@"); ok {
+				current = declaredName(language, strings.TrimSpace(context))
+			}
+			continue
+		}
+		if !inHunk || line == "" {
+			continue
+		}
+
+		marker, text := line[0], line[1:]
+		name := declaredName(language, text)
+		switch marker {
+		case '+', '-':
+			if name != "" {
+				see(name)
+				if marker == '+' {
+					added[name] = true
+				} else {
+					removed[name] = true
+				}
+				current = name
+			} else if current != "" && strings.TrimSpace(text) != "" {
+				see(current)
+				touched[current] = true
+			}
+		case ' ':
+			if name != "" {
+				current = name
+			}
+		}
+	}
+
+	var symbols DiffSymbols
+	for _, name := range order {
+		switch {
+		case added[name] && removed[name]:
+			symbols.Modified = appendCapped(symbols.Modified, name)
+		case added[name]:
+			symbols.Added = appendCapped(symbols.Added, name)
+		case removed[name]:
+			symbols.Removed = appendCapped(symbols.Removed, name)
+		default:
+			symbols.Modified = appendCapped(symbols.Modified, name)
+		}
+	}
+	return symbols
+}
+
+// appendCapped appends name unless the list already holds maxSymbolsPerKind
+func appendCapped(names []string, name string) []string {
+	if len(names) >= maxSymbolsPerKind {
+		return names
+	}
+	return append(names, name)
+}
+
+// String renders the symbols for a prompt, e.g.
+// "Added: Foo, Bar. Modified: Client.Do."
+func (s DiffSymbols) String() string {
+	var parts []string
+	for _, kind := range []struct {
+		label string
+		names []string
+	}{{"Added", s.Added}, {"Removed", s.Removed}, {"Modified", s.Modified}} {
+		if len(kind.names) > 0 {
+			parts = append(parts, kind.label+": "+strings.Join(kind.names, ", ")+".")
+		}
+	}
+	return strings.Join(parts, " ")
+}
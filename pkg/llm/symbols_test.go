@@ -0,0 +1,67 @@
+package llm
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExtractSymbolsGo(t *testing.T) {
+	patch := `@@ -10,12 +10,16 @@ func (c *Client) Do(req *Request) error {
+ 	if req == nil {
+-		return nil
++		return ErrNilRequest
+ 	}
+@@ -40,6 +44,10 @@ type Options struct {
+-func parse(s string) int {
++func parse(s string, base int) int {
+ 	return 0
+ }
++
++type Retry struct {
++	Max int
++}
+-func legacy() {}
+@@ -80,3 +88,3 @@ import (
+ func untouched() {
+ }`
+	got := ExtractSymbols("pkg/client.go", patch)
+	want := DiffSymbols{Added: []string{"Retry"}, Removed: []string{"legacy"}, Modified: []string{"Client.Do", "parse"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExtractSymbols() = %+v, want %+v", got, want)
+	}
+}
+
+func TestExtractSymbolsTypeScriptAndPython(t *testing.T) {
+	ts := `@@ -1,3 +1,6 @@
++export async function fetchUser(id: string) {
++}
++export const retry = async (fn: () => void) => fn()
+-export interface Options {
+-}`
+	got := ExtractSymbols("src/api.ts", ts)
+	if strings.Join(got.Added, ",") != "fetchUser,retry" || strings.Join(got.Removed, ",") != "Options" {
+		t.Errorf("TypeScript symbols = %+v", got)
+	}
+
+	py := `@@ -5,4 +5,4 @@ class Cache:
+     def get(self, key):
+-        return self._data[key]
++        return self._data.get(key)
++async def warm():`
+	got = ExtractSymbols("cache.py", py)
+	if strings.Join(got.Added, ",") != "warm" || strings.Join(got.Modified, ",") != "get" {
+		t.Errorf("Python symbols = %+v", got)
+	}
+
+	if got := ExtractSymbols("README.md", ts); !got.Empty() {
+		t.Errorf("unsupported language should yield nothing, got %+v", got)
+	}
+}
+
+func TestSummarizeDiffNamesSymbols(t *testing.T) {
+	diff := "--- a/file.go\n+++ b/file.go\n@@ -1,3 +1,4 @@\n+func NewCache() *Cache {\n+}\n"
+	if summary := SummarizeDiff(diff); !strings.HasPrefix(summary, "+2/-0 lines. Added: NewCache. Sample:") {
+		t.Errorf("SummarizeDiff() = %q", summary)
+	}
+}
@@ -0,0 +1,60 @@
+package llm
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// TrainingDataFile is the JSONL file training examples are appended to
+const TrainingDataFile = "changelog-training.jsonl"
+
+// TrainingMessage is a chat message in the fine-tuning format
+type TrainingMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// TrainingExample is one prompt/response pair in the chat fine-tuning format
+type TrainingExample struct {
+	Messages []TrainingMessage `json:"messages"`
+}
+
+// NewTrainingExample pairs a prompt with the response that should be learned.
+// The response is JSON-encoded so the example matches the format prompts ask for.
+func NewTrainingExample(prompt string, response any) (TrainingExample, error) {
+	content, err := json.Marshal(response)
+	if err != nil {
+		return TrainingExample{}, fmt.Errorf("encode training response: %w", err)
+	}
+	return TrainingExample{Messages: []TrainingMessage{
+		{Role: "user", Content: prompt},
+		{Role: "assistant", Content: string(content)},
+	}}, nil
+}
+
+// AppendTrainingData appends examples as JSONL to TrainingDataFile in dir,
+// creating the directory if needed
+func AppendTrainingData(dir string, examples []TrainingExample) error {
+	if len(examples) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create training data directory: %w", err)
+	}
+
+	file, err := os.OpenFile(filepath.Join(dir, TrainingDataFile), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("open training data file: %w", err)
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+	for _, example := range examples {
+		if err := encoder.Encode(example); err != nil {
+			return fmt.Errorf("write training example: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package llm
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAppendTrainingData(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "training")
+
+	example, err := NewTrainingExample("prompt", PRChangelogResponse{Summary: "s", Repaired: true})
+	if err != nil {
+		t.Fatalf("NewTrainingExample() error = %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := AppendTrainingData(dir, []TrainingExample{example}); err != nil {
+			t.Fatalf("AppendTrainingData() error = %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, TrainingDataFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 appended lines, got %d", len(lines))
+	}
+
+	var decoded TrainingExample
+	if err := json.Unmarshal([]byte(lines[0]), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Messages[1].Role != "assistant" || strings.Contains(decoded.Messages[1].Content, "Repaired") {
+		t.Errorf("Unexpected assistant message: %+v", decoded.Messages[1])
+	}
+}
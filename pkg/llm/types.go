@@ -1,6 +1,11 @@
 package llm
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/tickets"
+)
 
 // ChangelogRequest represents a request to generate a changelog
 type ChangelogRequest struct {
@@ -8,41 +13,111 @@ type ChangelogRequest struct {
 	RepoName string
 	FromRef  string
 	ToRef    string
+	Stack    string // Languages and frameworks, e.g. "Go (82%); frameworks: Cobra" (empty = unknown)
+	Clusters []CommitCluster
+
+	Security   bool           // Ask for a Security category with severities
+	Advisories []AdvisoryInfo // Published advisories to cross-reference
+
+	ExistingNotes string // Release notes maintainers already wrote for ToRef (empty = none)
+}
+
+// CommitCluster groups commits that touch the same subsystem
+type CommitCluster struct {
+	Area string   // Shared directory prefix, e.g. "pkg/auth"
+	SHAs []string // Member commits, in request order
 }
 
 // CommitInfo contains the information about a commit for LLM processing
 type CommitInfo struct {
-	SHA          string
-	Message      string
-	Author       string
-	Date         time.Time
-	FilesChanged []string
-	DiffSummary  string
-	Stats        string
+	SHA          string    `json:"sha"`
+	Message      string    `json:"message"`
+	Author       string    `json:"author"`
+	Date         time.Time `json:"date"`
+	FilesChanged []string  `json:"files_changed"`
+	DiffSummary  string    `json:"diff_summary"` // One "path: summary" line per significant file
+	Stats        string    `json:"stats"`        // "+additions/-deletions"
+
+	Labels        []string `json:"labels,omitempty"` // Labels of the commit's pull request
+	LabelCategory string   `json:"-"`                // Category suggested by the labels (label hint mode)
+
+	SecuritySignals string `json:"-"` // Why the commit may be security-relevant (set by the security pass)
+}
+
+// AdvisoryInfo is a published security advisory the release may address
+type AdvisoryInfo struct {
+	ID       string // GHSA identifier
+	CVE      string
+	Severity string
+	Summary  string
+	URL      string
 }
 
 // ChangelogResponse represents the structured response from the LLM
 type ChangelogResponse struct {
-	Summary    string                       `json:"summary"`
-	Highlights []string                     `json:"highlights"`
-	Categories map[string][]ChangelogEntry  `json:"categories"`
+	OneLiner   string                      `json:"one_liner"`
+	Summary    string                      `json:"summary"`
+	Highlights []string                    `json:"highlights"`
+	Categories map[string][]ChangelogEntry `json:"categories"`
+	Repaired   bool                        `json:"-"` // Reply needed cleanup (e.g., code fences) before it parsed
 }
 
 // ChangelogEntry represents a single entry in the changelog
 type ChangelogEntry struct {
-	SHA             string  `json:"sha"`
-	Title           string  `json:"title"`
-	Description     string  `json:"description"`
-	Author          string  `json:"author"`
-	ImportanceScore float64 `json:"importance_score"` // 0-10 scale, 10 being most important
+	SHA             string           `json:"sha"`
+	Title           string           `json:"title"`
+	Description     string           `json:"description"`
+	Author          string           `json:"author"`
+	ImportanceScore float64          `json:"importance_score"`       // 0-10 scale, 10 being most important
+	ScoreReason     string           `json:"score_reason,omitempty"` // Why the model chose this score
+	Severity        string           `json:"severity,omitempty"`     // Security entries: critical, high, medium, or low
+	Areas           []string         `json:"areas,omitempty"`        // Product areas of the entry's files (filled in after generation)
+	DocsURL         string           `json:"docs_url,omitempty"`     // Documentation of the first area that has docs
+	Image           string           `json:"image,omitempty"`        // First image in the body of the entry's pull request (filled in after generation)
+	Tickets         []tickets.Ticket `json:"tickets,omitempty"`      // Linked issue tracker tickets (filled in after generation)
+	CoAuthors       []string         `json:"co_authors,omitempty"`   // Co-authored-by trailers of the entry's commit (filled in after generation)
+	Bot             bool             `json:"bot,omitempty"`          // The commit's author is a bot account (filled in after generation)
+	Unverified      bool             `json:"unverified,omitempty"`   // The provider reports the commit's signature as unverified (filled in after generation)
+	PR              int              `json:"pr,omitempty"`           // Pull request the commit merged (filled in after generation)
+	PRURL           string           `json:"pr_url,omitempty"`       // Web URL of that pull request, when the provider builds one
+	Reverts         string           `json:"reverts,omitempty"`      // SHA of the commit in the range this one reverts (annotated reverts only)
+	RevertedBy      string           `json:"reverted_by,omitempty"`  // SHA of the commit in the range that reverts this one (annotated reverts only)
+	PickedFrom      string           `json:"picked_from,omitempty"`  // SHA of the original of a cherry-picked duplicate (annotated reverts only)
+	ScoreMissing    bool             `json:"-"`                      // The model omitted importance_score
+}
+
+// UnmarshalJSON decodes an entry, recording whether importance_score was present
+func (e *ChangelogEntry) UnmarshalJSON(data []byte) error {
+	type entryAlias ChangelogEntry
+	aux := struct {
+		*entryAlias
+		ImportanceScore *float64 `json:"importance_score"`
+	}{entryAlias: (*entryAlias)(e)}
+
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if aux.ImportanceScore != nil {
+		e.ImportanceScore = *aux.ImportanceScore
+	}
+	e.ScoreMissing = aux.ImportanceScore == nil
+	return nil
 }
 
 // PRInfo contains pull request information for LLM processing
 type PRInfo struct {
-	Number int
-	Title  string
-	Author string
-	Body   string
+	Number  int
+	Title   string
+	Author  string
+	Body    string
+	Reviews *ReviewInfo // Nil when review stats were not fetched
+}
+
+// ReviewInfo summarizes a pull request's review activity for LLM processing
+type ReviewInfo struct {
+	Approvals        int
+	ChangesRequested int
+	Comments         int
 }
 
 // PRChangelogRequest represents a request to generate PR-based release notes
@@ -51,11 +126,17 @@ type PRChangelogRequest struct {
 	RepoName string
 	FromRef  string
 	ToRef    string
+	Stack    string // Languages and frameworks (empty = unknown)
+
+	ExistingNotes string // Release notes maintainers already wrote for ToRef (empty = none)
 }
 
 // PRChangelogResponse represents the LLM response for PR-based release notes
 type PRChangelogResponse struct {
-	Entries []PRSummaryEntry `json:"entries"`
+	OneLiner string           `json:"one_liner"`
+	Summary  string           `json:"summary"`
+	Entries  []PRSummaryEntry `json:"entries"`
+	Repaired bool             `json:"-"` // Reply needed cleanup (e.g., code fences) before it parsed
 }
 
 // PRSummaryEntry represents a single PR summary from the LLM
@@ -63,3 +144,102 @@ type PRSummaryEntry struct {
 	Number  int    `json:"number"`
 	Summary string `json:"summary"`
 }
+
+// RescoreItem is one entry to score in a calibration pass
+type RescoreItem struct {
+	ID      string // Echoed back in the reply, e.g. "v1.2.0#42"
+	Release string
+	Title   string
+	Summary string
+}
+
+// RescoreRequest asks for importance scores of entries from several releases
+// on one shared scale
+type RescoreRequest struct {
+	RepoName string
+	Items    []RescoreItem
+}
+
+// RescoreResponse represents the LLM response for a calibration pass
+type RescoreResponse struct {
+	Scores   []RescoreEntry `json:"scores"`
+	Repaired bool           `json:"-"` // Reply needed cleanup (e.g., code fences) before it parsed
+}
+
+// RescoreEntry is the calibrated score of one item
+type RescoreEntry struct {
+	ID              string  `json:"id"`
+	ImportanceScore float64 `json:"importance_score"`
+	ScoreReason     string  `json:"score_reason,omitempty"`
+}
+
+// PeriodSummaryRequest asks for an executive digest of every release in a
+// timeline
+type PeriodSummaryRequest struct {
+	RepoName string
+	FromDate time.Time
+	ToDate   time.Time
+	Releases []PeriodRelease
+}
+
+// PeriodRelease is what the digest sees of one release: its summary and the
+// titles of its pull requests
+type PeriodRelease struct {
+	Name     string
+	Date     time.Time
+	Summary  string
+	PRTitles []string
+}
+
+// PeriodSummaryResponse is the digest of a timeline
+type PeriodSummaryResponse struct {
+	Summary  string   `json:"summary"` // 2-4 sentences on what the period was about
+	Themes   []string `json:"themes"`  // Recurring themes, each one short sentence
+	Repaired bool     `json:"-"`       // Reply needed cleanup (e.g., code fences) before it parsed
+}
+
+// ReviewRequest asks for a check of human-written release notes against the
+// commits of the release they describe
+type ReviewRequest struct {
+	RepoName string
+	FromRef  string
+	ToRef    string
+	Notes    string
+	Commits  []CommitInfo
+}
+
+// ReviewResponse represents the LLM's gap report for release notes
+type ReviewResponse struct {
+	Summary      string             `json:"summary"`
+	Omissions    []ReviewOmission   `json:"omissions"`
+	Inaccuracies []ReviewInaccuracy `json:"inaccuracies"`
+	Repaired     bool               `json:"-"` // Reply needed cleanup (e.g., code fences) before it parsed
+}
+
+// TranslateRequest asks for texts from a generated changelog to be
+// translated into another language
+type TranslateRequest struct {
+	Language string   // Target language, e.g. "German" or "pt-BR"
+	Texts    []string // Markdown or plain text, translated independently
+}
+
+// TranslateResponse holds the translations in request order
+type TranslateResponse struct {
+	Translations []string `json:"translations"`
+	Repaired     bool     `json:"-"` // Reply needed cleanup (e.g., code fences) before it parsed
+}
+
+// ReviewOmission is a notable commit the release notes do not mention
+type ReviewOmission struct {
+	SHA             string  `json:"sha"`
+	Title           string  `json:"title"`
+	ImportanceScore float64 `json:"importance_score"`
+	Reason          string  `json:"reason"` // Why readers of the notes should know about it
+}
+
+// ReviewInaccuracy is a statement in the release notes the commits do not support
+type ReviewInaccuracy struct {
+	Claim   string   `json:"claim"`   // The statement, quoted from the notes
+	Problem string   `json:"problem"` // What the commits show instead
+	SHAs    []string `json:"shas,omitempty"`
+}
@@ -0,0 +1,67 @@
+// Package logging configures the process-wide slog logger and hands out
+// per-module loggers (github, llm, generator, cli)
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// Setup installs the default logger writing to w at the given level
+// (debug, info, warn, error) in the given format (text or json)
+func Setup(w io.Writer, level, format string) error {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return fmt.Errorf("unsupported log level %q (expected debug, info, warn, or error)", level)
+	}
+
+	opts := &slog.HandlerOptions{Level: lvl}
+	switch strings.ToLower(format) {
+	case "text", "":
+		slog.SetDefault(slog.New(slog.NewTextHandler(w, opts)))
+	case "json":
+		slog.SetDefault(slog.New(slog.NewJSONHandler(w, opts)))
+	default:
+		return fmt.Errorf("unsupported log format %q (expected text or json)", format)
+	}
+	return nil
+}
+
+// Module returns a logger tagged with module=name. It resolves the default
+// logger on every call, so package-level loggers honour a later Setup.
+func Module(name string) *slog.Logger {
+	return slog.New(lazyHandler{wrap: func(h slog.Handler) slog.Handler {
+		return h.WithAttrs([]slog.Attr{slog.String("module", name)})
+	}})
+}
+
+// lazyHandler applies its attributes and groups to whatever handler the
+// default logger has at the time a record is logged
+type lazyHandler struct {
+	wrap func(slog.Handler) slog.Handler
+}
+
+func (h lazyHandler) handler() slog.Handler {
+	return h.wrap(slog.Default().Handler())
+}
+
+func (h lazyHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler().Enabled(ctx, level)
+}
+
+func (h lazyHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.handler().Handle(ctx, r)
+}
+
+func (h lazyHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	prev := h.wrap
+	return lazyHandler{wrap: func(base slog.Handler) slog.Handler { return prev(base).WithAttrs(attrs) }}
+}
+
+func (h lazyHandler) WithGroup(name string) slog.Handler {
+	prev := h.wrap
+	return lazyHandler{wrap: func(base slog.Handler) slog.Handler { return prev(base).WithGroup(name) }}
+}
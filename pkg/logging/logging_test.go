@@ -0,0 +1,45 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestModuleHonoursLaterSetup(t *testing.T) {
+	defer slog.SetDefault(slog.Default())
+
+	logger := Module("github") // created before Setup, like a package-level var
+
+	var buf bytes.Buffer
+	if err := Setup(&buf, "info", "json"); err != nil {
+		t.Fatal(err)
+	}
+	logger.Debug("hidden")
+	logger.Info("fetched commits", "count", 3)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 record above debug, got %d: %q", len(lines), buf.String())
+	}
+	var record map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &record); err != nil {
+		t.Fatal(err)
+	}
+	if record["module"] != "github" || record["msg"] != "fetched commits" || record["count"] != float64(3) {
+		t.Errorf("unexpected record %v", record)
+	}
+}
+
+func TestSetupRejectsUnknownValues(t *testing.T) {
+	defer slog.SetDefault(slog.Default())
+
+	if err := Setup(&bytes.Buffer{}, "loud", "text"); err == nil {
+		t.Error("expected error for unknown level")
+	}
+	if err := Setup(&bytes.Buffer{}, "info", "xml"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
@@ -0,0 +1,59 @@
+// Package network configures the HTTP transport shared by every API client,
+// for corporate proxies and TLS-intercepting firewalls
+package network
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// Transport returns a copy of the default transport that sends requests
+// through proxyURL (empty = the HTTPS_PROXY, HTTP_PROXY, and NO_PROXY
+// variables) and also trusts the PEM certificates in caBundle (empty = the
+// system roots only)
+func Transport(proxyURL, caBundle string) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if proxyURL != "" {
+		proxy, err := url.Parse(proxyURL)
+		if err != nil || proxy.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q (expected e.g. http://proxy.example.com:3128)", proxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	if caBundle != "" {
+		pem, err := os.ReadFile(caBundle)
+		if err != nil {
+			return nil, fmt.Errorf("read CA bundle: %w", err)
+		}
+		roots, err := x509.SystemCertPool()
+		if err != nil || roots == nil {
+			roots = x509.NewCertPool()
+		}
+		if !roots.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("read CA bundle: no PEM certificates in %s", caBundle)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: roots}
+	}
+	return transport, nil
+}
+
+// Configure installs the transport from Transport as http.DefaultTransport,
+// which the GitHub, OpenAI, issue tracker, and sink clients all send through.
+// With neither setting the default transport is left alone.
+func Configure(proxyURL, caBundle string) error {
+	if proxyURL == "" && caBundle == "" {
+		return nil
+	}
+	transport, err := Transport(proxyURL, caBundle)
+	if err != nil {
+		return err
+	}
+	http.DefaultTransport = transport
+	return nil
+}
@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"regexp"
+	"strings"
+)
+
+// imageDigestRe matches container image digests in release notes, with an
+// optional image reference: "ghcr.io/acme/api@sha256:…" or "sha256:…"
+var imageDigestRe = regexp.MustCompile(`(?:([\w.\-/:]+)@)?(sha256:[0-9a-f]{64})`)
+
+// ReleaseArtifacts lists a release's attached files followed by the container
+// image digests mentioned in its notes
+func ReleaseArtifacts(release ReleaseInfo) []ReleaseAsset {
+	artifacts := append([]ReleaseAsset(nil), release.Assets...)
+
+	seen := make(map[string]bool)
+	for _, line := range strings.Split(release.Body, "\n") {
+		for _, m := range imageDigestRe.FindAllStringSubmatch(line, -1) {
+			image, digest := m[1], m[2]
+			if seen[digest] {
+				continue
+			}
+			seen[digest] = true
+			if image == "" {
+				image = "Container image"
+			}
+			artifacts = append(artifacts, ReleaseAsset{Name: image, Digest: digest})
+		}
+	}
+	return artifacts
+}
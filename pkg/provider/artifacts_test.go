@@ -0,0 +1,24 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestReleaseArtifacts(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("ab", 32)
+	release := ReleaseInfo{
+		Body: "## Images\n- ghcr.io/acme/api@" + digest + "\n- same again: " + digest + "\n",
+		Assets: []ReleaseAsset{
+			{Name: "api_linux_amd64.tar.gz", URL: "https://example.com/api.tar.gz", Size: 4404019},
+		},
+	}
+
+	artifacts := ReleaseArtifacts(release)
+	if len(artifacts) != 2 {
+		t.Fatalf("Expected asset plus one deduplicated digest, got %+v", artifacts)
+	}
+	if artifacts[1].Name != "ghcr.io/acme/api" || artifacts[1].Digest != digest {
+		t.Errorf("Unexpected image artifact %+v", artifacts[1])
+	}
+}
@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"regexp"
+	"strings"
+)
+
+// coAuthorRe matches Co-authored-by trailers: "Co-authored-by: Jane Doe <jane@example.com>"
+var coAuthorRe = regexp.MustCompile(`(?im)^co-authored-by:\s*(.*?)\s*<([^>]*)>\s*$`)
+
+// noreplyRe extracts the login from GitHub noreply addresses, e.g.
+// "49699333+dependabot[bot]@users.noreply.github.com"
+var noreplyRe = regexp.MustCompile(`(?i)^(?:\d+\+)?([^@]+)@users\.noreply\.github\.com$`)
+
+// CoAuthors returns the co-authors named in a commit message's Co-authored-by
+// trailers, in order and without duplicates. GitHub noreply addresses yield
+// the account login; otherwise the trailer's name is used.
+func CoAuthors(message string) []string {
+	var authors []string
+	seen := make(map[string]bool)
+	for _, m := range coAuthorRe.FindAllStringSubmatch(message, -1) {
+		author := m[1]
+		if login := noreplyRe.FindStringSubmatch(strings.TrimSpace(m[2])); login != nil {
+			author = login[1]
+		}
+		if author == "" || seen[strings.ToLower(author)] {
+			continue
+		}
+		seen[strings.ToLower(author)] = true
+		authors = append(authors, author)
+	}
+	return authors
+}
+
+// IsBot reports whether an author is a bot account, which GitHub and Gitea
+// name with a "[bot]" suffix (e.g. dependabot[bot])
+func IsBot(author string) bool {
+	return strings.HasSuffix(strings.ToLower(author), "[bot]")
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCoAuthors(t *testing.T) {
+	message := "Add login (#12)\n\n" +
+		"Co-authored-by: Jane Doe <jane@example.com>\n" +
+		"co-authored-by: bob <1234+bob@users.noreply.github.com>\n" +
+		"Co-Authored-By: Jane Doe <jane@other.example.com>\n" +
+		"Signed-off-by: Carol <carol@example.com>\n"
+
+	want := []string{"Jane Doe", "bob"}
+	if got := CoAuthors(message); !slices.Equal(got, want) {
+		t.Errorf("CoAuthors() = %v, want %v", got, want)
+	}
+	if got := CoAuthors("Fix typo"); got != nil {
+		t.Errorf("Expected no co-authors, got %v", got)
+	}
+}
+
+func TestIsBot(t *testing.T) {
+	for author, want := range map[string]bool{
+		"dependabot[bot]":     true,
+		"Renovate[Bot]":       true,
+		"alice":               false,
+		"robot":               false,
+		"github-actions[bot]": true,
+	} {
+		if got := IsBot(author); got != want {
+			t.Errorf("IsBot(%q) = %v, want %v", author, got, want)
+		}
+	}
+}
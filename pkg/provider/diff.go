@@ -0,0 +1,37 @@
+package provider
+
+import "strings"
+
+// SplitDiff splits a multi-file unified diff into per-file patches keyed by
+// the new file path
+func SplitDiff(diff string) map[string]string {
+	patches := make(map[string]string)
+	var current string
+	var sb strings.Builder
+
+	flush := func() {
+		if current != "" {
+			patches[current] = sb.String()
+		}
+		sb.Reset()
+	}
+
+	for _, line := range strings.Split(diff, "\n") {
+		if rest, ok := strings.CutPrefix(line, "diff --git "); ok {
+			flush()
+			current = ""
+			if i := strings.LastIndex(rest, " b/"); i >= 0 {
+				current = rest[i+3:]
+			}
+			continue
+		}
+		if current == "" {
+			continue
+		}
+		sb.WriteString(line)
+		sb.WriteString("\n")
+	}
+	flush()
+
+	return patches
+}
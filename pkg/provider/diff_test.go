@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitDiff(t *testing.T) {
+	diff := strings.Join([]string{
+		"diff --git a/main.go b/main.go",
+		"index 1..2 100644",
+		"--- a/main.go",
+		"+++ b/main.go",
+		"@@ -1 +1 @@",
+		"-old",
+		"+new",
+		"diff --git a/old.txt b/docs/new.txt",
+		"similarity index 100%",
+	}, "\n")
+
+	patches := SplitDiff(diff)
+	if len(patches) != 2 {
+		t.Fatalf("Expected 2 patches, got %d", len(patches))
+	}
+	if !strings.Contains(patches["main.go"], "+new") || strings.Contains(patches["main.go"], "similarity") {
+		t.Errorf("Unexpected main.go patch: %q", patches["main.go"])
+	}
+	if _, ok := patches["docs/new.txt"]; !ok {
+		t.Error("Expected renamed file keyed by its new path")
+	}
+}
@@ -0,0 +1,265 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	_ Provider          = (*Fixture)(nil)
+	_ Provider          = (*Recorder)(nil)
+	_ PullRequestLinker = (*Fixture)(nil)
+	_ PullRequestLinker = (*Recorder)(nil)
+)
+
+// Fixture is a recorded set of provider responses that replays a repository
+// offline, e.g. in tests. Responses are keyed by the arguments they were
+// fetched with; a request that was not recorded fails.
+type Fixture struct {
+	Repo          string                       `json:"repo"`
+	CommitURLs    string                       `json:"commit_url"`              // Commit URL with {sha} for the SHA
+	CompareURLs   string                       `json:"compare_url"`             // Compare URL with {from} and {to} for the refs
+	PullURLs      string                       `json:"pull_url,omitempty"`      // Pull request URL with {number} for the number
+	Commits       map[string][]CommitData      `json:"commits,omitempty"`       // Keyed "from..to"
+	PullRequests  map[string][]PullRequestData `json:"pull_requests,omitempty"` // Keyed "from..to"
+	Releases      []ReleaseInfo                `json:"releases,omitempty"`
+	Tags          []TagInfo                    `json:"tags,omitempty"`
+	Timelines     map[string][]TimelineRelease `json:"timelines,omitempty"`      // Keyed by RFC 3339 "from..to"
+	PriorCommits  map[string]bool              `json:"prior_commits,omitempty"`  // Keyed "author@RFC 3339 time"
+	ListsReleases bool                         `json:"lists_releases,omitempty"` // ListReleases was recorded, even if empty
+	ListsTags     bool                         `json:"lists_tags,omitempty"`     // ListTags was recorded, even if empty
+}
+
+// LoadFixture reads a fixture written by Recorder.Save
+func LoadFixture(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read fixture: %w", err)
+	}
+	var fixture Fixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("parse fixture %s: %w", path, err)
+	}
+	return &fixture, nil
+}
+
+// rangeKey keys responses for a ref range
+func rangeKey(from, to string) string {
+	return from + ".." + to
+}
+
+// timelineKey keys timelines by their date range
+func timelineKey(from, to time.Time) string {
+	return from.UTC().Format(time.RFC3339) + ".." + to.UTC().Format(time.RFC3339)
+}
+
+// priorKey keys HasCommitsBefore answers
+func priorKey(author string, before time.Time) string {
+	return author + "@" + before.UTC().Format(time.RFC3339)
+}
+
+// GetCommitRange replays the commits recorded for from..to
+func (f *Fixture) GetCommitRange(ctx context.Context, from, to string) ([]CommitData, error) {
+	commits, ok := f.Commits[rangeKey(from, to)]
+	if !ok {
+		return nil, fmt.Errorf("fixture has no commits for %s..%s", from, to)
+	}
+	return commits, nil
+}
+
+// ListReleases replays the recorded releases
+func (f *Fixture) ListReleases(ctx context.Context) ([]ReleaseInfo, error) {
+	if !f.ListsReleases {
+		return nil, fmt.Errorf("fixture has no releases")
+	}
+	return f.Releases, nil
+}
+
+// ListTags replays the recorded tags
+func (f *Fixture) ListTags(ctx context.Context) ([]TagInfo, error) {
+	if !f.ListsTags {
+		return nil, fmt.Errorf("fixture has no tags")
+	}
+	return f.Tags, nil
+}
+
+// GetPRsBetween replays the pull requests recorded for from..to
+func (f *Fixture) GetPRsBetween(ctx context.Context, from, to string) ([]PullRequestData, error) {
+	prs, ok := f.PullRequests[rangeKey(from, to)]
+	if !ok {
+		return nil, fmt.Errorf("fixture has no pull requests for %s..%s", from, to)
+	}
+	return prs, nil
+}
+
+// GetTimelineReleases replays the timeline recorded for the date range
+func (f *Fixture) GetTimelineReleases(ctx context.Context, from, to time.Time) ([]TimelineRelease, error) {
+	releases, ok := f.Timelines[timelineKey(from, to)]
+	if !ok {
+		return nil, fmt.Errorf("fixture has no timeline from %s to %s", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	}
+	if len(releases) == 0 {
+		return nil, ErrNoReleases
+	}
+	return releases, nil
+}
+
+// HasCommitsBefore replays the recorded answer, reporting false for authors
+// that were not looked up
+func (f *Fixture) HasCommitsBefore(ctx context.Context, author string, before time.Time) (bool, error) {
+	return f.PriorCommits[priorKey(author, before)], nil
+}
+
+// CommitURL expands the recorded commit URL template
+func (f *Fixture) CommitURL(sha string) string {
+	return strings.ReplaceAll(f.CommitURLs, "{sha}", sha)
+}
+
+// PullRequestURL expands the recorded pull request URL template, returning ""
+// when the fixture's provider builds no pull request URLs
+func (f *Fixture) PullRequestURL(number int) string {
+	return strings.ReplaceAll(f.PullURLs, "{number}", strconv.Itoa(number))
+}
+
+// CompareURL expands the recorded compare URL template
+func (f *Fixture) CompareURL(from, to string) string {
+	return strings.NewReplacer("{from}", from, "{to}", to).Replace(f.CompareURLs)
+}
+
+// Recorder wraps a provider and records its responses as a Fixture
+type Recorder struct {
+	source Provider
+
+	mu      sync.Mutex
+	fixture Fixture
+}
+
+// NewRecorder records the responses of source for repo
+func NewRecorder(source Provider, repo string) *Recorder {
+	recorder := &Recorder{source: source, fixture: Fixture{
+		Repo:         repo,
+		CommitURLs:   source.CommitURL("{sha}"),
+		CompareURLs:  source.CompareURL("{from}", "{to}"),
+		Commits:      make(map[string][]CommitData),
+		PullRequests: make(map[string][]PullRequestData),
+		Timelines:    make(map[string][]TimelineRelease),
+		PriorCommits: make(map[string]bool),
+	}}
+	if linker, ok := source.(PullRequestLinker); ok {
+		// Providers end pull request URLs with the number
+		recorder.fixture.PullURLs = strings.TrimSuffix(linker.PullRequestURL(0), "0") + "{number}"
+	}
+	return recorder
+}
+
+// Source returns the provider whose responses are recorded
+func (r *Recorder) Source() Provider {
+	return r.source
+}
+
+// Save writes the recorded fixture to path
+func (r *Recorder) Save(path string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	data, err := json.MarshalIndent(r.fixture, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode fixture: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write fixture: %w", err)
+	}
+	return nil
+}
+
+// GetCommitRange fetches and records the commits between two refs
+func (r *Recorder) GetCommitRange(ctx context.Context, from, to string) ([]CommitData, error) {
+	commits, err := r.source.GetCommitRange(ctx, from, to)
+	if err == nil {
+		r.mu.Lock()
+		r.fixture.Commits[rangeKey(from, to)] = commits
+		r.mu.Unlock()
+	}
+	return commits, err
+}
+
+// ListReleases fetches and records all releases
+func (r *Recorder) ListReleases(ctx context.Context) ([]ReleaseInfo, error) {
+	releases, err := r.source.ListReleases(ctx)
+	if err == nil {
+		r.mu.Lock()
+		r.fixture.Releases, r.fixture.ListsReleases = releases, true
+		r.mu.Unlock()
+	}
+	return releases, err
+}
+
+// ListTags fetches and records all tags
+func (r *Recorder) ListTags(ctx context.Context) ([]TagInfo, error) {
+	tags, err := r.source.ListTags(ctx)
+	if err == nil {
+		r.mu.Lock()
+		r.fixture.Tags, r.fixture.ListsTags = tags, true
+		r.mu.Unlock()
+	}
+	return tags, err
+}
+
+// GetPRsBetween fetches and records the pull requests merged between two refs
+func (r *Recorder) GetPRsBetween(ctx context.Context, from, to string) ([]PullRequestData, error) {
+	prs, err := r.source.GetPRsBetween(ctx, from, to)
+	if err == nil {
+		r.mu.Lock()
+		r.fixture.PullRequests[rangeKey(from, to)] = prs
+		r.mu.Unlock()
+	}
+	return prs, err
+}
+
+// GetTimelineReleases fetches and records the releases in a date range. A
+// range without releases is recorded as empty and replays as ErrNoReleases.
+func (r *Recorder) GetTimelineReleases(ctx context.Context, from, to time.Time) ([]TimelineRelease, error) {
+	releases, err := r.source.GetTimelineReleases(ctx, from, to)
+	if err == nil || errors.Is(err, ErrNoReleases) {
+		r.mu.Lock()
+		r.fixture.Timelines[timelineKey(from, to)] = releases
+		r.mu.Unlock()
+	}
+	return releases, err
+}
+
+// HasCommitsBefore looks up and records whether author committed before a time
+func (r *Recorder) HasCommitsBefore(ctx context.Context, author string, before time.Time) (bool, error) {
+	prior, err := r.source.HasCommitsBefore(ctx, author, before)
+	if err == nil {
+		r.mu.Lock()
+		r.fixture.PriorCommits[priorKey(author, before)] = prior
+		r.mu.Unlock()
+	}
+	return prior, err
+}
+
+// CommitURL returns the web URL of a commit
+func (r *Recorder) CommitURL(sha string) string {
+	return r.source.CommitURL(sha)
+}
+
+// CompareURL returns the web URL comparing two refs
+func (r *Recorder) CompareURL(from, to string) string {
+	return r.source.CompareURL(from, to)
+}
+
+// PullRequestURL returns the web URL of a pull request, or "" when the source
+// builds none
+func (r *Recorder) PullRequestURL(number int) string {
+	if linker, ok := r.source.(PullRequestLinker); ok {
+		return linker.PullRequestURL(number)
+	}
+	return ""
+}
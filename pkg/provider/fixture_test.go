@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// stubProvider serves canned responses
+type stubProvider struct {
+	Provider
+}
+
+func (stubProvider) GetCommitRange(ctx context.Context, from, to string) ([]CommitData, error) {
+	return []CommitData{{SHA: "abc1234", Message: "fix: " + from + ".." + to}}, nil
+}
+
+func (stubProvider) ListTags(ctx context.Context) ([]TagInfo, error) {
+	return nil, nil
+}
+
+func (stubProvider) GetTimelineReleases(ctx context.Context, from, to time.Time) ([]TimelineRelease, error) {
+	return nil, ErrNoReleases
+}
+
+func (stubProvider) HasCommitsBefore(ctx context.Context, author string, before time.Time) (bool, error) {
+	return author == "alice", nil
+}
+
+func (stubProvider) CommitURL(sha string) string {
+	return "https://git.example.com/acme/api/commit/" + sha
+}
+
+func (stubProvider) CompareURL(from, to string) string {
+	return "https://git.example.com/acme/api/compare/" + from + "..." + to
+}
+
+func (stubProvider) PullRequestURL(number int) string {
+	return fmt.Sprintf("https://git.example.com/acme/api/pulls/%d", number)
+}
+
+func TestRecorderRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	recorder := NewRecorder(stubProvider{}, "acme/api")
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 3, 0)
+	recorder.GetCommitRange(ctx, "v1", "v2")
+	recorder.ListTags(ctx)
+	recorder.GetTimelineReleases(ctx, from, to)
+	recorder.HasCommitsBefore(ctx, "alice", from)
+
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	if err := recorder.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	fixture, err := LoadFixture(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commits, err := fixture.GetCommitRange(ctx, "v1", "v2")
+	if err != nil || len(commits) != 1 || commits[0].Message != "fix: v1..v2" {
+		t.Errorf("commits = %+v, %v", commits, err)
+	}
+	if _, err := fixture.GetCommitRange(ctx, "v2", "v3"); err == nil {
+		t.Error("unrecorded range replayed without error")
+	}
+	if tags, err := fixture.ListTags(ctx); err != nil || len(tags) != 0 {
+		t.Errorf("tags = %v, %v; want the recorded empty list", tags, err)
+	}
+	if _, err := fixture.ListReleases(ctx); err == nil {
+		t.Error("unrecorded releases replayed without error")
+	}
+	if _, err := fixture.GetTimelineReleases(ctx, from, to); !errors.Is(err, ErrNoReleases) {
+		t.Errorf("empty timeline err = %v, want ErrNoReleases", err)
+	}
+	if prior, _ := fixture.HasCommitsBefore(ctx, "alice", from); !prior {
+		t.Error("recorded prior commit not replayed")
+	}
+	if got := fixture.CommitURL("abc1234"); got != "https://git.example.com/acme/api/commit/abc1234" {
+		t.Errorf("CommitURL = %q", got)
+	}
+	if got := fixture.CompareURL("v1", "v2"); got != "https://git.example.com/acme/api/compare/v1...v2" {
+		t.Errorf("CompareURL = %q", got)
+	}
+	if got := fixture.PullRequestURL(1234); got != "https://git.example.com/acme/api/pulls/1234" {
+		t.Errorf("PullRequestURL = %q", got)
+	}
+}
@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNoReleases is returned when a timeline contains no tags or releases
+var ErrNoReleases = errors.New("no tags or releases found")
+
+// Provider is a Git hosting backend (GitHub, Bitbucket, Gitea, ...) that the
+// generator reads commits, tags, releases, and pull requests from
+type Provider interface {
+	// GetCommitRange fetches the commits between two refs with their file changes
+	GetCommitRange(ctx context.Context, from, to string) ([]CommitData, error)
+	// ListReleases fetches all releases
+	ListReleases(ctx context.Context) ([]ReleaseInfo, error)
+	// ListTags fetches all tags with the commits they point to
+	ListTags(ctx context.Context) ([]TagInfo, error)
+	// GetPRsBetween fetches the pull requests merged between two refs
+	GetPRsBetween(ctx context.Context, from, to string) ([]PullRequestData, error)
+	// GetTimelineReleases builds the releases published in a date range
+	GetTimelineReleases(ctx context.Context, from, to time.Time) ([]TimelineRelease, error)
+	// HasCommitsBefore reports whether author committed before the given time
+	HasCommitsBefore(ctx context.Context, author string, before time.Time) (bool, error)
+	// CommitURL returns the web URL of a commit
+	CommitURL(sha string) string
+	// CompareURL returns the web URL comparing two refs
+	CompareURL(from, to string) string
+}
+
+// PullRequestLinker is implemented by providers that build web URLs of pull
+// requests, so entries can link to the pull request that merged them
+type PullRequestLinker interface {
+	PullRequestURL(number int) string
+}
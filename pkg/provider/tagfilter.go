@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/semver"
+)
+
+// prereleaseNameRe matches the usual prerelease markers in tags that are
+// not semantic versions, e.g. nightly-2024-05-01 or release-2.0-beta
+var prereleaseNameRe = regexp.MustCompile(`(?i)(^|[-._/])(nightly|alpha|beta|rc|snapshot|canary|preview|dev|pre)([-._\d]|$)`)
+
+// TagFilter selects which tags and releases count as timeline releases
+type TagFilter struct {
+	Pattern            *regexp.Regexp // Names must match in full (nil = any)
+	ExcludePrereleases bool           // Skip prerelease releases and tags such as v2.0.0-rc.1 or nightly
+}
+
+// NewTagFilter compiles pattern, anchored so that it must match the whole
+// tag name, into a filter
+func NewTagFilter(pattern string, excludePrereleases bool) (TagFilter, error) {
+	filter := TagFilter{ExcludePrereleases: excludePrereleases}
+	if pattern == "" {
+		return filter, nil
+	}
+	re, err := regexp.Compile(`^(?:` + pattern + `)$`)
+	if err != nil {
+		return TagFilter{}, fmt.Errorf("invalid tag pattern %q: %w", pattern, err)
+	}
+	filter.Pattern = re
+	return filter, nil
+}
+
+// Keep reports whether ref passes the filter
+func (f TagFilter) Keep(ref ReleaseRef) bool {
+	if f.Pattern != nil && !f.Pattern.MatchString(ref.Name) {
+		return false
+	}
+	if f.ExcludePrereleases && IsPrereleaseRef(ref) {
+		return false
+	}
+	return true
+}
+
+// Apply returns the refs that pass the filter, logging how many were dropped
+func (f TagFilter) Apply(refs []ReleaseRef) []ReleaseRef {
+	if f.Pattern == nil && !f.ExcludePrereleases {
+		return refs
+	}
+	kept := make([]ReleaseRef, 0, len(refs))
+	for _, ref := range refs {
+		if f.Keep(ref) {
+			kept = append(kept, ref)
+		}
+	}
+	if dropped := len(refs) - len(kept); dropped > 0 {
+		logger.Info("filtered timeline tags", "kept", len(kept), "dropped", dropped)
+	}
+	return kept
+}
+
+// IsPrereleaseRef reports whether a release is marked as a prerelease or its
+// name is a prerelease version (v2.0.0-rc.1) or carries a marker like nightly
+func IsPrereleaseRef(ref ReleaseRef) bool {
+	if ref.IsPrerelease {
+		return true
+	}
+	if version, ok := semver.Parse(ref.Name); ok {
+		return version.IsPrerelease()
+	}
+	return prereleaseNameRe.MatchString(ref.Name)
+}
@@ -0,0 +1,49 @@
+package provider
+
+import "testing"
+
+func TestTagFilter(t *testing.T) {
+	refs := []ReleaseRef{
+		{Name: "v1.0.0"},
+		{Name: "v1.1.0-rc.1"},
+		{Name: "nightly-2024-05-01"},
+		{Name: "v1.1.0", IsPrerelease: true},
+		{Name: "v1.2.0"},
+		{Name: "docs-v1"},
+	}
+
+	cases := []struct {
+		pattern            string
+		excludePrereleases bool
+		want               []string
+	}{
+		{"", false, []string{"v1.0.0", "v1.1.0-rc.1", "nightly-2024-05-01", "v1.1.0", "v1.2.0", "docs-v1"}},
+		{`v[0-9]+\.[0-9]+\.[0-9]+`, false, []string{"v1.0.0", "v1.1.0", "v1.2.0"}},
+		{"", true, []string{"v1.0.0", "v1.2.0", "docs-v1"}},
+		{`v.*`, true, []string{"v1.0.0", "v1.2.0"}},
+	}
+	for _, c := range cases {
+		filter, err := NewTagFilter(c.pattern, c.excludePrereleases)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, ref := range filter.Apply(refs) {
+			got = append(got, ref.Name)
+		}
+		if len(got) != len(c.want) {
+			t.Errorf("pattern %q, exclude %v: got %v, want %v", c.pattern, c.excludePrereleases, got, c.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != c.want[i] {
+				t.Errorf("pattern %q, exclude %v: got %v, want %v", c.pattern, c.excludePrereleases, got, c.want)
+				break
+			}
+		}
+	}
+
+	if _, err := NewTagFilter("v[0-9", false); err == nil {
+		t.Error("expected an error for an invalid pattern")
+	}
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/logging"
+)
+
+var logger = logging.Module("provider")
+
+// ReleaseRefsInRange merges tags and non-draft releases dated within
+// [from, to] into release references sorted by date ascending. A release
+// overrides a tag with the same name; releases whose tag has been deleted are
+// skipped with a warning instead of failing the later commit comparison.
+func ReleaseRefsInRange(tags []TagInfo, releases []ReleaseInfo, from, to time.Time) []ReleaseRef {
+	inRange := func(t time.Time) bool {
+		return !t.Before(from) && !t.After(to)
+	}
+
+	tagSHAs := make(map[string]string, len(tags))
+	refMap := make(map[string]ReleaseRef) // Deduplicate by name
+	for _, tag := range tags {
+		tagSHAs[tag.Name] = tag.CommitSHA
+		if inRange(tag.CommitDate) {
+			refMap[tag.Name] = ReleaseRef{
+				Name: tag.Name,
+				SHA:  tag.CommitSHA,
+				Date: tag.CommitDate,
+				Type: "tag",
+			}
+		}
+	}
+	for _, release := range releases {
+		if release.Draft || !inRange(release.PublishedAt) {
+			continue
+		}
+		sha, tagged := tagSHAs[release.TagName]
+		if len(tags) > 0 && !tagged {
+			logger.Warn("skipping release whose tag no longer exists", "release", release.Name, "tag", release.TagName)
+			continue
+		}
+		refMap[release.TagName] = ReleaseRef{
+			Name:         release.TagName,
+			SHA:          sha,
+			Date:         release.PublishedAt,
+			Type:         "release",
+			IsPrerelease: release.Prerelease,
+			Artifacts:    ReleaseArtifacts(release),
+			Notes:        release.Body,
+		}
+	}
+
+	refs := make([]ReleaseRef, 0, len(refMap))
+	for _, ref := range refMap {
+		refs = append(refs, ref)
+	}
+	sort.Slice(refs, func(i, j int) bool {
+		return refs[i].Date.Before(refs[j].Date)
+	})
+	return refs
+}
+
+// HeadRef returns the reference for an untagged branch head commit
+func HeadRef(sha string, date time.Time) ReleaseRef {
+	return ReleaseRef{Name: sha, SHA: sha, Date: date, Type: "head", Unreleased: true}
+}
+
+// WithBoundaries extends timeline refs so that commits at the edges of the
+// window are not skipped: the newest tag before from that passes filter
+// opens the timeline, and head, the newest commit on or before the end of
+// the window, closes it unless it is already tagged. A zero head leaves the
+// end as is.
+func WithBoundaries(refs []ReleaseRef, tags []TagInfo, filter TagFilter, from time.Time, head ReleaseRef) []ReleaseRef {
+	var start *ReleaseRef
+	for _, tag := range tags {
+		ref := ReleaseRef{Name: tag.Name, SHA: tag.CommitSHA, Date: tag.CommitDate, Type: "tag"}
+		if !ref.Date.Before(from) || !filter.Keep(ref) {
+			continue
+		}
+		if start == nil || ref.Date.After(start.Date) {
+			start = &ref
+		}
+	}
+
+	bounded := make([]ReleaseRef, 0, len(refs)+2)
+	if start != nil && (len(refs) == 0 || refs[0].Name != start.Name) {
+		logger.Info("opening timeline at earlier tag", "tag", start.Name)
+		bounded = append(bounded, *start)
+	}
+	bounded = append(bounded, refs...)
+	if head.SHA != "" && (len(bounded) == 0 || bounded[len(bounded)-1].SHA != head.SHA) {
+		logger.Info("closing timeline at untagged head", "sha", head.SHA)
+		bounded = append(bounded, head)
+	}
+	return bounded
+}
+
+// BuildTimeline turns consecutive release references into timeline releases,
+// fetching each pair's commits and pull requests with fetch
+func BuildTimeline(refs []ReleaseRef, fetch func(from, to string) ([]CommitData, []PullRequestData, error)) ([]TimelineRelease, error) {
+	var releases []TimelineRelease
+	for i := 0; i < len(refs)-1; i++ {
+		fromRef, toRef := refs[i], refs[i+1]
+
+		commits, prs, err := fetch(fromRef.Name, toRef.Name)
+		if err != nil {
+			return nil, fmt.Errorf("fetch %s..%s: %w", fromRef.Name, toRef.Name, err)
+		}
+
+		releases = append(releases, TimelineRelease{
+			FromRef:      fromRef.Name,
+			ToRef:        toRef.Name,
+			ToSHA:        toRef.SHA,
+			FromDate:     fromRef.Date,
+			ToDate:       toRef.Date,
+			CommitCount:  len(commits),
+			Commits:      commits,
+			PullRequests: prs,
+			Artifacts:    toRef.Artifacts,
+			Unreleased:   toRef.Unreleased,
+			Notes:        toRef.Notes,
+		})
+	}
+	return releases, nil
+}
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReleaseRefsInRange(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	tags := []TagInfo{
+		{Name: "v1.0.0", CommitDate: day(2)},
+		{Name: "v1.1.0", CommitDate: day(10)},
+		{Name: "v0.9.0", CommitDate: day(1).AddDate(0, -1, 0)},
+	}
+	releases := []ReleaseInfo{
+		{TagName: "v1.1.0", PublishedAt: day(11), Prerelease: true},
+		{TagName: "v1.2.0", PublishedAt: day(20), Draft: true},
+	}
+
+	refs := ReleaseRefsInRange(tags, releases, day(1), day(31))
+	if len(refs) != 2 {
+		t.Fatalf("Expected 2 refs, got %+v", refs)
+	}
+	if refs[0].Name != "v1.0.0" || refs[1].Name != "v1.1.0" {
+		t.Errorf("Expected refs sorted by date, got %+v", refs)
+	}
+	if refs[1].Type != "release" || !refs[1].IsPrerelease || !refs[1].Date.Equal(day(11)) {
+		t.Errorf("Expected release to override tag, got %+v", refs[1])
+	}
+}
+
+func TestReleaseRefsInRangeSkipsDeletedTags(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	tags := []TagInfo{{Name: "v1.0.0", CommitSHA: "aaa", CommitDate: day(2)}}
+	releases := []ReleaseInfo{
+		{TagName: "v1.0.0", PublishedAt: day(3)},
+		{TagName: "v1.1.0", PublishedAt: day(12)}, // Tag deleted after publishing
+	}
+
+	refs := ReleaseRefsInRange(tags, releases, day(1), day(31))
+	if len(refs) != 1 || refs[0].Name != "v1.0.0" {
+		t.Fatalf("Expected only v1.0.0, got %+v", refs)
+	}
+	if refs[0].SHA != "aaa" {
+		t.Errorf("Expected release ref to carry the tag commit, got %q", refs[0].SHA)
+	}
+}
+
+func TestWithBoundaries(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	tags := []TagInfo{
+		{Name: "v0.8.0", CommitSHA: "a", CommitDate: day(1)},
+		{Name: "v0.9.0", CommitSHA: "b", CommitDate: day(5)},
+		{Name: "nightly", CommitSHA: "c", CommitDate: day(8)},
+		{Name: "v1.0.0", CommitSHA: "d", CommitDate: day(12)},
+	}
+	filter, _ := NewTagFilter("", true)
+	refs := filter.Apply(ReleaseRefsInRange(tags, nil, day(10), day(31)))
+
+	bounded := WithBoundaries(refs, tags, filter, day(10), HeadRef("e", day(20)))
+	var names []string
+	for _, ref := range bounded {
+		names = append(names, ref.Name)
+	}
+	if len(names) != 3 || names[0] != "v0.9.0" || names[1] != "v1.0.0" || names[2] != "e" {
+		t.Fatalf("refs = %v, want [v0.9.0 v1.0.0 e]", names)
+	}
+	if !bounded[2].Unreleased || bounded[0].Unreleased {
+		t.Errorf("only the head should be unreleased: %+v", bounded)
+	}
+
+	// A tagged head and a window with no earlier tag add nothing
+	bounded = WithBoundaries(refs, tags, filter, day(1), HeadRef("d", day(12)))
+	if len(bounded) != 1 || bounded[0].Name != "v1.0.0" {
+		t.Errorf("refs = %+v, want only v1.0.0", bounded)
+	}
+
+	// With no tags in the window the edges still make one range
+	bounded = WithBoundaries(nil, tags, filter, day(13), HeadRef("e", day(20)))
+	if len(bounded) != 2 || bounded[0].Name != "v1.0.0" || bounded[1].Name != "e" {
+		t.Errorf("refs = %+v, want v1.0.0 then head", bounded)
+	}
+}
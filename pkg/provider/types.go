@@ -0,0 +1,129 @@
+package provider
+
+import "time"
+
+// CommitData represents a commit with all its details
+type CommitData struct {
+	SHA          string
+	Message      string
+	Author       string
+	Date         time.Time
+	CoAuthors    []string            // From Co-authored-by trailers
+	Verification *CommitVerification // Signature status; nil when the provider does not report it
+	Parents      []string            // Parent SHAs, first parent first; merges have two or more
+	FilesChanged []FileChange
+	Stats        CommitStats
+}
+
+// CommitVerification is a commit's signature status as reported by the provider
+type CommitVerification struct {
+	Verified bool   `json:"verified"`
+	Reason   string `json:"reason,omitempty"` // Provider's status, e.g. "valid", "unsigned", "unknown_key"
+}
+
+// FileChange represents a file modification in a commit
+type FileChange struct {
+	Filename  string
+	Status    string // "added", "modified", "deleted", "renamed"
+	Additions int
+	Deletions int
+	Patch     string // The diff content
+}
+
+// CommitStats provides aggregate statistics for a commit
+type CommitStats struct {
+	Additions int
+	Deletions int
+	Total     int
+	Files     int // Changed files, counted when FilesChanged is not fetched (e.g. --fast-fetch)
+}
+
+// TagInfo represents a Git tag with metadata
+type TagInfo struct {
+	Name       string    // Tag name (e.g., "v1.0.0")
+	SHA        string    // Tag object SHA
+	CommitSHA  string    // Commit SHA the tag points to
+	CommitDate time.Time // Date of the commit
+	Message    string    // Tag message (for annotated tags)
+}
+
+// ReleaseInfo represents a GitHub release
+type ReleaseInfo struct {
+	TagName     string         // Associated tag name
+	Name        string         // Release name/title
+	PublishedAt time.Time      // When the release was published
+	CreatedAt   time.Time      // When the release was created
+	Body        string         // Release notes
+	Author      string         // Release author
+	Draft       bool           // Is draft?
+	Prerelease  bool           // Is prerelease?
+	Assets      []ReleaseAsset // Files attached to the release
+}
+
+// SecurityAdvisory is a published security advisory for the repository
+type SecurityAdvisory struct {
+	ID              string // GHSA identifier
+	CVE             string // CVE identifier, when assigned
+	Severity        string // critical, high, medium, or low
+	Summary         string
+	URL             string
+	PatchedVersions string // Comma-separated patched versions across affected packages
+}
+
+// ReleaseAsset is a downloadable artifact of a release: an attached file, or
+// a container image digest listed in the release notes
+type ReleaseAsset struct {
+	Name   string `json:"name"`
+	URL    string `json:"url,omitempty"`
+	Size   int64  `json:"size,omitempty"`   // Bytes (0 when unknown)
+	Digest string `json:"digest,omitempty"` // e.g. sha256:… for container images
+}
+
+// ReleaseRef represents a unified tag or release reference
+type ReleaseRef struct {
+	Name         string         // Tag/release name (e.g., "v1.0.0")
+	SHA          string         // Commit the tag points at (empty when unknown)
+	Date         time.Time      // Date of tag commit or release publication
+	Type         string         // "tag" or "release"
+	IsPrerelease bool           // For releases
+	Artifacts    []ReleaseAsset // Release assets and image digests (releases only)
+	Unreleased   bool           // Untagged branch head closing a timeline with boundaries
+	Notes        string         // Body of the published release (releases only)
+}
+
+// PullRequestData represents a pull request with its details
+type PullRequestData struct {
+	Number    int          `json:"number"`
+	Title     string       `json:"title"`
+	Author    string       `json:"author"`
+	URL       string       `json:"url"`
+	Body      string       `json:"body,omitempty"` // PR description (for LLM context)
+	Labels    []string     `json:"labels,omitempty"`
+	CreatedAt time.Time    `json:"created_at,omitzero"`
+	MergedAt  time.Time    `json:"merged_at,omitzero"`
+	Reviews   *ReviewStats `json:"reviews,omitempty"` // Set when review stats are enabled
+}
+
+// ReviewStats summarizes the review activity on a pull request
+type ReviewStats struct {
+	Approvals        int `json:"approvals"`
+	ChangesRequested int `json:"changes_requested"`
+	Comments         int `json:"comments"` // Conversation and review comments
+}
+
+// TimelineRelease represents a release period with its commits and PRs
+type TimelineRelease struct {
+	FromRef      string            // Starting tag/release name
+	ToRef        string            // Ending tag/release name
+	ToSHA        string            // Commit the ending tag points at (empty when unknown)
+	FromDate     time.Time         // Date of from ref
+	ToDate       time.Time         // Date of to ref
+	CommitCount  int               // Number of commits
+	Commits      []CommitData      // Actual commits
+	PullRequests []PullRequestData // PRs in this release
+	Artifacts    []ReleaseAsset    // Artifacts of the ending release
+	Series       string            // Series label when releases are grouped, e.g. "v1.4.x"
+	Versions     []string          // Releases folded into the series, oldest first
+	Unreleased   bool              // Ends at the untagged branch head rather than a release
+	Notes        string            // Body of the ending release, as published
+}
@@ -0,0 +1,128 @@
+package semver
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// versionRe matches tag names like v1.2.3, 1.2, release-1.2.3-rc.1
+var versionRe = regexp.MustCompile(`^(?:[A-Za-z][A-Za-z0-9_]*[-_/]?)?v?([0-9]+)(?:\.([0-9]+))?(?:\.([0-9]+))?(?:-([0-9A-Za-z.\-]+))?(?:\+[0-9A-Za-z.\-]+)?$`)
+
+// Version is a parsed semantic version
+type Version struct {
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string // e.g., "rc.1"; empty for stable releases
+	Original   string // The tag name the version was parsed from
+}
+
+// Parse parses a tag name as a semantic version. Missing minor/patch
+// components default to zero. It returns false for non-version tags.
+func Parse(tag string) (Version, bool) {
+	m := versionRe.FindStringSubmatch(tag)
+	if m == nil {
+		return Version{}, false
+	}
+
+	v := Version{Prerelease: m[4], Original: tag}
+	v.Major, _ = strconv.Atoi(m[1])
+	if m[2] != "" {
+		v.Minor, _ = strconv.Atoi(m[2])
+	}
+	if m[3] != "" {
+		v.Patch, _ = strconv.Atoi(m[3])
+	}
+	return v, true
+}
+
+// IsPrerelease reports whether the version has a prerelease suffix
+func (v Version) IsPrerelease() bool {
+	return v.Prerelease != ""
+}
+
+// Compare returns -1, 0, or 1 if v is lower than, equal to, or higher than other
+func (v Version) Compare(other Version) int {
+	for _, diff := range []int{v.Major - other.Major, v.Minor - other.Minor, v.Patch - other.Patch} {
+		if diff < 0 {
+			return -1
+		}
+		if diff > 0 {
+			return 1
+		}
+	}
+	return comparePrerelease(v.Prerelease, other.Prerelease)
+}
+
+// comparePrerelease orders prerelease suffixes per semver: a release without
+// a suffix outranks any prerelease; numeric identifiers compare numerically
+func comparePrerelease(a, b string) int {
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+
+	partsA, partsB := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(partsA) && i < len(partsB); i++ {
+		numA, errA := strconv.Atoi(partsA[i])
+		numB, errB := strconv.Atoi(partsB[i])
+		switch {
+		case errA == nil && errB == nil:
+			if numA != numB {
+				if numA < numB {
+					return -1
+				}
+				return 1
+			}
+		case errA == nil:
+			return -1 // Numeric identifiers sort before alphanumeric ones
+		case errB == nil:
+			return 1
+		default:
+			if c := strings.Compare(partsA[i], partsB[i]); c != 0 {
+				return c
+			}
+		}
+	}
+
+	switch {
+	case len(partsA) < len(partsB):
+		return -1
+	case len(partsA) > len(partsB):
+		return 1
+	}
+	return 0
+}
+
+// Sort parses tags as versions and returns them in descending order, skipping
+// tags that are not versions. Prereleases are only kept when includePrereleases is set.
+func Sort(tags []string, includePrereleases bool) []Version {
+	var versions []Version
+	for _, tag := range tags {
+		v, ok := Parse(tag)
+		if !ok || (v.IsPrerelease() && !includePrereleases) {
+			continue
+		}
+		versions = append(versions, v)
+	}
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].Compare(versions[j]) > 0
+	})
+	return versions
+}
+
+// Latest returns the highest version among tags that parse as semver.
+// Prereleases are only considered when includePrereleases is set.
+func Latest(tags []string, includePrereleases bool) (Version, bool) {
+	versions := Sort(tags, includePrereleases)
+	if len(versions) == 0 {
+		return Version{}, false
+	}
+	return versions[0], true
+}
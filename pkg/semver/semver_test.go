@@ -0,0 +1,57 @@
+package semver
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		tag        string
+		ok         bool
+		major      int
+		minor      int
+		patch      int
+		prerelease string
+	}{
+		{"v1.2.3", true, 1, 2, 3, ""},
+		{"1.2", true, 1, 2, 0, ""},
+		{"v2.0.0-rc.1", true, 2, 0, 0, "rc.1"},
+		{"release-3.4.5", true, 3, 4, 5, ""},
+		{"nightly", false, 0, 0, 0, ""},
+	}
+
+	for _, tt := range tests {
+		v, ok := Parse(tt.tag)
+		if ok != tt.ok {
+			t.Errorf("Parse(%q) ok = %v, want %v", tt.tag, ok, tt.ok)
+			continue
+		}
+		if ok && (v.Major != tt.major || v.Minor != tt.minor || v.Patch != tt.patch || v.Prerelease != tt.prerelease) {
+			t.Errorf("Parse(%q) = %+v", tt.tag, v)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	ordered := []string{"v1.0.0-alpha", "v1.0.0-alpha.1", "v1.0.0-beta.2", "v1.0.0-beta.11", "v1.0.0-rc.1", "v1.0.0", "v1.0.1", "v1.10.0"}
+
+	for i := 0; i < len(ordered)-1; i++ {
+		a, _ := Parse(ordered[i])
+		b, _ := Parse(ordered[i+1])
+		if a.Compare(b) != -1 || b.Compare(a) != 1 {
+			t.Errorf("Expected %s < %s", ordered[i], ordered[i+1])
+		}
+	}
+}
+
+func TestLatest(t *testing.T) {
+	tags := []string{"v1.9.0", "v1.10.0", "v2.0.0-rc.1", "nightly"}
+
+	latest, ok := Latest(tags, false)
+	if !ok || latest.Original != "v1.10.0" {
+		t.Errorf("Expected v1.10.0, got %+v", latest)
+	}
+
+	latest, ok = Latest(tags, true)
+	if !ok || latest.Original != "v2.0.0-rc.1" {
+		t.Errorf("Expected v2.0.0-rc.1 with prereleases, got %+v", latest)
+	}
+}
@@ -0,0 +1,192 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/generator"
+)
+
+// defaultPostTitle is the blog post title pattern; placeholders are expanded
+// like sink paths
+const defaultPostTitle = "What's new in {tag}"
+
+// blogPost is the draft a blog sink publishes
+type blogPost struct {
+	title string
+	html  string
+}
+
+// newBlogPost renders the changelog for a blog: the customer-facing view by
+// default, or the full changelog for audience "developers"
+func newBlogPost(changelog *generator.Changelog, titlePattern, audience string, minScore float64) blogPost {
+	if titlePattern == "" {
+		titlePattern = defaultPostTitle
+	}
+	markdown := changelog.Markdown
+	if audience != "developers" {
+		markdown = generator.FormatEndUserMarkdown(changelog, minScore)
+	}
+	return blogPost{
+		title: expandPath(titlePattern, changelog),
+		html:  generator.MarkdownToHTML(markdown),
+	}
+}
+
+// validateBlogConfig checks the options shared by blog sinks
+func validateBlogConfig(cfg config.SinkConfig) error {
+	if cfg.URL == "" {
+		return fmt.Errorf("%s sink: url is required", cfg.Type)
+	}
+	switch cfg.Audience {
+	case "", "end-users", "developers":
+		return nil
+	default:
+		return fmt.Errorf("%s sink: unsupported audience %q (expected end-users or developers)", cfg.Type, cfg.Audience)
+	}
+}
+
+// wordpressSink creates a draft post through the WordPress REST API,
+// authenticated with an application password
+type wordpressSink struct {
+	siteURL  string
+	username string
+	password string
+	title    string
+	audience string
+	minScore float64
+}
+
+func newWordPressSink(cfg config.SinkConfig) (*wordpressSink, error) {
+	if err := validateBlogConfig(cfg); err != nil {
+		return nil, err
+	}
+	if cfg.Username == "" || cfg.Password == "" {
+		return nil, fmt.Errorf("wordpress sink: username and application password are required (set WORDPRESS_USERNAME and WORDPRESS_APP_PASSWORD or username and password)")
+	}
+	return &wordpressSink{
+		siteURL:  strings.TrimRight(cfg.URL, "/"),
+		username: cfg.Username,
+		password: cfg.Password,
+		title:    cfg.Title,
+		audience: cfg.Audience,
+		minScore: cfg.MinScore,
+	}, nil
+}
+
+func (s *wordpressSink) Name() string { return "wordpress" }
+
+func (s *wordpressSink) Write(ctx context.Context, changelog *generator.Changelog) error {
+	post := newBlogPost(changelog, s.title, s.audience, s.minScore)
+	payload, err := json.Marshal(map[string]string{
+		"title":   post.title,
+		"content": post.html,
+		"status":  "draft",
+	})
+	if err != nil {
+		return fmt.Errorf("encode WordPress post: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.siteURL+"/wp-json/wp/v2/posts", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.SetBasicAuth(s.username, s.password)
+	req.Header.Set("Content-Type", "application/json")
+	if err := send(req); err != nil {
+		return fmt.Errorf("create WordPress draft: %w", err)
+	}
+	return nil
+}
+
+// ghostSink creates a draft post through the Ghost Admin API
+type ghostSink struct {
+	siteURL  string
+	keyID    string
+	secret   []byte
+	title    string
+	audience string
+	minScore float64
+	now      func() time.Time
+}
+
+func newGhostSink(cfg config.SinkConfig) (*ghostSink, error) {
+	if err := validateBlogConfig(cfg); err != nil {
+		return nil, err
+	}
+	if cfg.AdminAPIKey == "" {
+		return nil, fmt.Errorf("ghost sink: admin API key is required (set GHOST_ADMIN_API_KEY or admin_api_key)")
+	}
+	id, hexSecret, ok := strings.Cut(cfg.AdminAPIKey, ":")
+	secret, err := hex.DecodeString(hexSecret)
+	if !ok || id == "" || err != nil {
+		return nil, fmt.Errorf("ghost sink: admin API key must have the form <id>:<hex secret>")
+	}
+	return &ghostSink{
+		siteURL:  strings.TrimRight(cfg.URL, "/"),
+		keyID:    id,
+		secret:   secret,
+		title:    cfg.Title,
+		audience: cfg.Audience,
+		minScore: cfg.MinScore,
+		now:      time.Now,
+	}, nil
+}
+
+func (s *ghostSink) Name() string { return "ghost" }
+
+func (s *ghostSink) Write(ctx context.Context, changelog *generator.Changelog) error {
+	post := newBlogPost(changelog, s.title, s.audience, s.minScore)
+	payload, err := json.Marshal(map[string]any{
+		"posts": []map[string]string{{
+			"title":  post.title,
+			"html":   post.html,
+			"status": "draft",
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("encode Ghost post: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.siteURL+"/ghost/api/admin/posts/?source=html", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	token, err := s.token()
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Ghost "+token)
+	req.Header.Set("Content-Type", "application/json")
+	if err := send(req); err != nil {
+		return fmt.Errorf("create Ghost draft: %w", err)
+	}
+	return nil
+}
+
+// token signs the short-lived HS256 JWT the Ghost Admin API expects
+func (s *ghostSink) token() (string, error) {
+	now := s.now().Unix()
+	header, err := json.Marshal(map[string]string{"alg": "HS256", "typ": "JWT", "kid": s.keyID})
+	if err != nil {
+		return "", fmt.Errorf("encode token header: %w", err)
+	}
+	claims, err := json.Marshal(map[string]any{"iat": now, "exp": now + 300, "aud": "/admin/"})
+	if err != nil {
+		return "", fmt.Errorf("encode token claims: %w", err)
+	}
+
+	encoding := base64.RawURLEncoding
+	signingInput := encoding.EncodeToString(header) + "." + encoding.EncodeToString(claims)
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(signingInput))
+	return signingInput + "." + encoding.EncodeToString(mac.Sum(nil)), nil
+}
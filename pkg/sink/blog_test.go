@@ -0,0 +1,98 @@
+package sink
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+)
+
+func TestWordPressSinkCreatesDraft(t *testing.T) {
+	var path, user, password string
+	var post map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		user, password, _ = r.BasicAuth()
+		json.NewDecoder(r.Body).Decode(&post)
+	}))
+	defer server.Close()
+
+	s, err := New(config.SinkConfig{Type: "wordpress", URL: server.URL + "/", Username: "ci", Password: "app pass"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	changelog := testChangelog()
+	changelog.Categories = map[string][]llm.ChangelogEntry{
+		"Features": {{Title: "Dark mode", Description: "Switch themes in settings.", SHA: "abcdef1"}},
+		"Internal": {{Title: "Bump linter"}},
+	}
+	if err := s.Write(context.Background(), changelog); err != nil {
+		t.Fatal(err)
+	}
+
+	if path != "/wp-json/wp/v2/posts" || user != "ci" || password != "app pass" {
+		t.Errorf("unexpected request path=%s user=%s password=%s", path, user, password)
+	}
+	if post["status"] != "draft" || post["title"] != "What's new in v1.2.0" {
+		t.Errorf("unexpected post %v", post)
+	}
+	if !strings.Contains(post["content"], "Dark mode") || strings.Contains(post["content"], "linter") || strings.Contains(post["content"], "abcdef1") {
+		t.Errorf("expected customer-facing content only, got:\n%s", post["content"])
+	}
+}
+
+func TestGhostSinkSignsToken(t *testing.T) {
+	var auth, query string
+	var body struct {
+		Posts []map[string]string `json:"posts"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth, query = r.Header.Get("Authorization"), r.URL.RawQuery
+		json.NewDecoder(r.Body).Decode(&body)
+	}))
+	defer server.Close()
+
+	s, err := newGhostSink(config.SinkConfig{Type: "ghost", URL: server.URL, AdminAPIKey: "key1:00ff", Title: "{repo} {version}"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.now = func() time.Time { return time.Unix(1700000000, 0) }
+	if err := s.Write(context.Background(), testChangelog()); err != nil {
+		t.Fatal(err)
+	}
+
+	token, ok := strings.CutPrefix(auth, "Ghost ")
+	parts := strings.Split(token, ".")
+	if !ok || len(parts) != 3 {
+		t.Fatalf("unexpected Authorization %q", auth)
+	}
+	mac := hmac.New(sha256.New, []byte{0x00, 0xff})
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if parts[2] != base64.RawURLEncoding.EncodeToString(mac.Sum(nil)) {
+		t.Error("token signature does not verify")
+	}
+	claims, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	if string(claims) != `{"aud":"/admin/","exp":1700000300,"iat":1700000000}` {
+		t.Errorf("unexpected claims %s", claims)
+	}
+	if query != "source=html" || len(body.Posts) != 1 || body.Posts[0]["title"] != "acme-api 1.2.0" || body.Posts[0]["status"] != "draft" {
+		t.Errorf("unexpected post query=%s body=%v", query, body)
+	}
+}
+
+func TestNewGhostSinkRejectsMalformedKeys(t *testing.T) {
+	for _, key := range []string{"", "no-secret", "id:not-hex"} {
+		if _, err := newGhostSink(config.SinkConfig{Type: "ghost", URL: "https://blog.example.com", AdminAPIKey: key}); err == nil {
+			t.Errorf("expected error for key %q", key)
+		}
+	}
+}
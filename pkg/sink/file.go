@@ -0,0 +1,62 @@
+package sink
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/generator"
+)
+
+// fileSink writes each changelog to a file
+type fileSink struct {
+	path   string
+	format string
+}
+
+func (s *fileSink) Name() string { return "file" }
+
+func (s *fileSink) Write(ctx context.Context, changelog *generator.Changelog) error {
+	content, err := render(changelog, s.format)
+	if err != nil {
+		return err
+	}
+	path := expandPath(s.path, changelog)
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("create output directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("write output file: %w", err)
+	}
+	return nil
+}
+
+// stdoutSink prints each changelog
+type stdoutSink struct {
+	format string
+}
+
+func (s *stdoutSink) Name() string { return "stdout" }
+
+func (s *stdoutSink) Write(ctx context.Context, changelog *generator.Changelog) error {
+	content, err := render(changelog, s.format)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(content)
+	return err
+}
+
+// releaseSink sets the hosted release notes of the changelog's tag
+type releaseSink struct {
+	releases ReleaseUpdater
+}
+
+func (s *releaseSink) Name() string { return "github-release" }
+
+func (s *releaseSink) Write(ctx context.Context, changelog *generator.Changelog) error {
+	return s.releases.UpsertReleaseNotes(ctx, changelog.ToRef, changelog.Markdown)
+}
@@ -0,0 +1,115 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/generator"
+	"golang.org/x/oauth2/jwt"
+)
+
+// gcsScope grants object uploads
+const gcsScope = "https://www.googleapis.com/auth/devstorage.read_write"
+
+// gcsSink uploads each rendered changelog to a Google Cloud Storage bucket
+// through the JSON API, authenticated as a service account
+type gcsSink struct {
+	bucket   string
+	key      string
+	format   string
+	endpoint string
+	auth     *jwt.Config
+	client   *http.Client // Overrides auth (tests)
+}
+
+func newGCSSink(cfg config.SinkConfig) (*gcsSink, error) {
+	switch {
+	case cfg.Bucket == "":
+		return nil, fmt.Errorf("gcs sink: bucket is required")
+	case cfg.Key == "":
+		return nil, fmt.Errorf("gcs sink: key is required")
+	case cfg.CredentialsFile == "":
+		return nil, fmt.Errorf("gcs sink: credentials are required (set GOOGLE_APPLICATION_CREDENTIALS or credentials_file)")
+	}
+	auth, err := loadServiceAccount(cfg.CredentialsFile)
+	if err != nil {
+		return nil, fmt.Errorf("gcs sink: %w", err)
+	}
+	return &gcsSink{
+		bucket:   cfg.Bucket,
+		key:      strings.TrimPrefix(cfg.Key, "/"),
+		format:   cfg.Format,
+		endpoint: "https://storage.googleapis.com",
+		auth:     auth,
+	}, nil
+}
+
+// loadServiceAccount reads a service account key file into a JWT token config
+func loadServiceAccount(path string) (*jwt.Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read credentials: %w", err)
+	}
+	var key struct {
+		Type         string `json:"type"`
+		ClientEmail  string `json:"client_email"`
+		PrivateKey   string `json:"private_key"`
+		PrivateKeyID string `json:"private_key_id"`
+		TokenURI     string `json:"token_uri"`
+	}
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, fmt.Errorf("parse credentials %s: %w", path, err)
+	}
+	if key.Type != "service_account" || key.ClientEmail == "" || key.PrivateKey == "" {
+		return nil, fmt.Errorf("%s is not a service account key", path)
+	}
+	if key.TokenURI == "" {
+		key.TokenURI = "https://oauth2.googleapis.com/token"
+	}
+	return &jwt.Config{
+		Email:        key.ClientEmail,
+		PrivateKey:   []byte(key.PrivateKey),
+		PrivateKeyID: key.PrivateKeyID,
+		Scopes:       []string{gcsScope},
+		TokenURL:     key.TokenURI,
+	}, nil
+}
+
+func (s *gcsSink) Name() string { return "gcs" }
+
+func (s *gcsSink) Write(ctx context.Context, changelog *generator.Changelog) error {
+	content, err := render(changelog, s.format)
+	if err != nil {
+		return err
+	}
+
+	key := expandPath(s.key, changelog)
+	endpoint := fmt.Sprintf("%s/upload/storage/v1/b/%s/o?uploadType=media&name=%s",
+		s.endpoint, url.PathEscape(s.bucket), url.QueryEscape(key))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(content))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType(s.format))
+
+	client := s.client
+	if client == nil {
+		client = s.auth.Client(ctx)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("upload gs://%s/%s: %w", s.bucket, key, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("upload gs://%s/%s: unexpected status %s", s.bucket, key, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/generator"
+)
+
+// httpSink POSTs each rendered changelog to a URL
+type httpSink struct {
+	url     string
+	headers map[string]string
+	format  string
+}
+
+func (s *httpSink) Name() string { return "http" }
+
+func (s *httpSink) Write(ctx context.Context, changelog *generator.Changelog) error {
+	content, err := render(changelog, s.format)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(content))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType(s.format))
+	for name, value := range s.headers {
+		req.Header.Set(name, value)
+	}
+	return send(req)
+}
+
+// slackSink posts the release summary to a Slack incoming webhook
+type slackSink struct {
+	webhookURL string
+}
+
+func (s *slackSink) Name() string { return "slack" }
+
+func (s *slackSink) Write(ctx context.Context, changelog *generator.Changelog) error {
+	summary := changelog.Zoom.Paragraph
+	if summary == "" {
+		summary = changelog.Zoom.OneLiner
+	}
+	return PostSlack(ctx, s.webhookURL, fmt.Sprintf("*%s %s*\n%s", changelog.RepoName, changelog.ToRef, summary))
+}
+
+// PostSlack sends a message to a Slack incoming webhook
+func PostSlack(ctx context.Context, webhookURL, text string) error {
+	payload, err := json.Marshal(map[string]string{"text": text})
+	if err != nil {
+		return fmt.Errorf("encode Slack message: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if err := send(req); err != nil {
+		return fmt.Errorf("post to Slack: %w", err)
+	}
+	return nil
+}
+
+// send performs req and fails on any non-2xx status
+func send(req *http.Request) error {
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/generator"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+)
+
+const (
+	notionAPIVersion = "2022-06-28"
+	notionMaxBlocks  = 100  // Children per create-page request
+	notionMaxText    = 2000 // Characters per rich text object
+)
+
+// notionFields lists the changelog fields that can be mapped to database
+// properties, with the Notion property type each is written as
+var notionFields = map[string]string{
+	"title":      "title",
+	"version":    "rich_text",
+	"repository": "rich_text",
+	"date":       "date",
+	"summary":    "rich_text",
+	"highlights": "rich_text",
+	"categories": "multi_select",
+	"score":      "number",
+	"entries":    "number",
+}
+
+// notionSink creates one page per release in a Notion database. Only the
+// title property is required; other fields are written to the properties
+// they are mapped to, and the page body carries the full entry list.
+type notionSink struct {
+	token      string
+	databaseID string
+	properties map[string]string // Changelog field → database property name
+	endpoint   string
+}
+
+func newNotionSink(cfg config.SinkConfig) (*notionSink, error) {
+	switch {
+	case cfg.DatabaseID == "":
+		return nil, fmt.Errorf("notion sink: database_id is required")
+	case cfg.Token == "":
+		return nil, fmt.Errorf("notion sink: token is required (set NOTION_TOKEN or token)")
+	}
+	properties := map[string]string{"title": "Name"}
+	for field, property := range cfg.Properties {
+		if _, ok := notionFields[field]; !ok {
+			return nil, fmt.Errorf("notion sink: unknown property field %q (expected title, version, repository, date, summary, highlights, categories, score, or entries)", field)
+		}
+		properties[field] = property
+	}
+	return &notionSink{
+		token:      cfg.Token,
+		databaseID: cfg.DatabaseID,
+		properties: properties,
+		endpoint:   "https://api.notion.com",
+	}, nil
+}
+
+func (s *notionSink) Name() string { return "notion" }
+
+func (s *notionSink) Write(ctx context.Context, changelog *generator.Changelog) error {
+	payload, err := json.Marshal(map[string]any{
+		"parent":     map[string]string{"database_id": s.databaseID},
+		"properties": s.pageProperties(changelog),
+		"children":   notionBlocks(changelog),
+	})
+	if err != nil {
+		return fmt.Errorf("encode Notion page: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint+"/v1/pages", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+s.token)
+	req.Header.Set("Notion-Version", notionAPIVersion)
+	req.Header.Set("Content-Type", "application/json")
+	if err := send(req); err != nil {
+		return fmt.Errorf("create Notion page: %w", err)
+	}
+	return nil
+}
+
+// pageProperties maps changelog fields to the configured database properties
+func (s *notionSink) pageProperties(changelog *generator.Changelog) map[string]any {
+	categories := orderedCategories(changelog.Categories)
+	entries, topScore := 0, 0.0
+	for _, category := range categories {
+		for _, entry := range changelog.Categories[category] {
+			entries++
+			topScore = max(topScore, entry.ImportanceScore)
+		}
+	}
+
+	values := map[string]any{
+		"title":      fmt.Sprintf("%s %s", changelog.RepoName, changelog.ToRef),
+		"version":    changelog.ToRef,
+		"repository": changelog.RepoName,
+		"summary":    changelog.Summary,
+		"highlights": strings.Join(changelog.Highlights, "\n"),
+		"categories": categories,
+		"score":      topScore,
+		"entries":    entries,
+	}
+	if !changelog.Date.IsZero() {
+		values["date"] = changelog.Date.Format("2006-01-02")
+	}
+
+	properties := make(map[string]any, len(s.properties))
+	for field, name := range s.properties {
+		value, ok := values[field]
+		if !ok {
+			continue
+		}
+		switch notionFields[field] {
+		case "title":
+			properties[name] = map[string]any{"title": notionText(value.(string))}
+		case "rich_text":
+			properties[name] = map[string]any{"rich_text": notionText(value.(string))}
+		case "date":
+			properties[name] = map[string]any{"date": map[string]string{"start": value.(string)}}
+		case "multi_select":
+			var options []map[string]string
+			for _, option := range value.([]string) {
+				// Select option names cannot contain commas
+				options = append(options, map[string]string{"name": strings.ReplaceAll(option, ",", "")})
+			}
+			properties[name] = map[string]any{"multi_select": options}
+		case "number":
+			properties[name] = map[string]any{"number": value}
+		}
+	}
+	return properties
+}
+
+// notionBlocks renders the summary, highlights, and categorized entries as
+// page content, within Notion's per-request block limit
+func notionBlocks(changelog *generator.Changelog) []map[string]any {
+	var blocks []map[string]any
+	if changelog.Summary != "" {
+		blocks = append(blocks, notionBlock("paragraph", changelog.Summary))
+	}
+	if len(changelog.Highlights) > 0 {
+		blocks = append(blocks, notionBlock("heading_2", "Highlights"))
+		for _, highlight := range changelog.Highlights {
+			blocks = append(blocks, notionBlock("bulleted_list_item", highlight))
+		}
+	}
+	for _, category := range orderedCategories(changelog.Categories) {
+		heading := category
+		if emoji := generator.CategoryEmojis[category]; emoji != "" {
+			heading = emoji + " " + category
+		}
+		blocks = append(blocks, notionBlock("heading_2", heading))
+		for _, entry := range changelog.Categories[category] {
+			blocks = append(blocks, notionBlock("bulleted_list_item", notionEntryText(entry)))
+		}
+	}
+
+	if len(blocks) > notionMaxBlocks {
+		omitted := len(blocks) - notionMaxBlocks + 1
+		blocks = append(blocks[:notionMaxBlocks-1],
+			notionBlock("paragraph", fmt.Sprintf("… %d more blocks omitted; see the full changelog.", omitted)))
+	}
+	return blocks
+}
+
+// notionEntryText renders one entry as "Title (score 8.5) · abc1234"
+func notionEntryText(entry llm.ChangelogEntry) string {
+	text := entry.Title
+	if !entry.ScoreMissing {
+		text += fmt.Sprintf(" (score %.1f)", entry.ImportanceScore)
+	}
+	if len(entry.SHA) >= 7 {
+		text += " · " + entry.SHA[:7]
+	}
+	return text
+}
+
+// notionBlock builds a text block of the given type
+func notionBlock(kind, text string) map[string]any {
+	return map[string]any{
+		"object": "block",
+		"type":   kind,
+		kind:     map[string]any{"rich_text": notionText(text)},
+	}
+}
+
+// notionText builds a rich text array, truncated to Notion's length limit
+func notionText(text string) []map[string]any {
+	if runes := []rune(text); len(runes) > notionMaxText {
+		text = string(runes[:notionMaxText-1]) + "…"
+	}
+	return []map[string]any{{"type": "text", "text": map[string]string{"content": text}}}
+}
+
+// orderedCategories returns the non-empty categories in generator.CategoryOrder,
+// followed by any others alphabetically
+func orderedCategories(categories map[string][]llm.ChangelogEntry) []string {
+	var ordered, others []string
+	known := make(map[string]bool)
+	for _, category := range generator.CategoryOrder {
+		known[category] = true
+		if len(categories[category]) > 0 {
+			ordered = append(ordered, category)
+		}
+	}
+	for category, entries := range categories {
+		if !known[category] && len(entries) > 0 {
+			others = append(others, category)
+		}
+	}
+	sort.Strings(others)
+	return append(ordered, others...)
+}
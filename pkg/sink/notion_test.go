@@ -0,0 +1,83 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/generator"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/llm"
+)
+
+func TestNotionSinkCreatesPage(t *testing.T) {
+	var auth, version string
+	var page struct {
+		Parent     map[string]string          `json:"parent"`
+		Properties map[string]json.RawMessage `json:"properties"`
+		Children   []map[string]any           `json:"children"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth, version = r.Header.Get("Authorization"), r.Header.Get("Notion-Version")
+		if r.URL.Path != "/v1/pages" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&page)
+	}))
+	defer server.Close()
+
+	s, err := newNotionSink(config.SinkConfig{
+		Type:       "notion",
+		Token:      "secret_abc",
+		DatabaseID: "db123",
+		Properties: map[string]string{"categories": "Categories", "score": "Top score"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.endpoint = server.URL
+
+	changelog := testChangelog()
+	changelog.Highlights = []string{"Webhooks"}
+	changelog.Categories = map[string][]llm.ChangelogEntry{
+		"Bug Fixes": {{Title: "Fix retries", ImportanceScore: 4}},
+		"Features":  {{Title: "Add webhooks", ImportanceScore: 8.5, SHA: "abcdef1234"}},
+	}
+	if err := s.Write(context.Background(), changelog); err != nil {
+		t.Fatal(err)
+	}
+
+	if auth != "Bearer secret_abc" || version != notionAPIVersion || page.Parent["database_id"] != "db123" {
+		t.Errorf("unexpected request auth=%q version=%q parent=%v", auth, version, page.Parent)
+	}
+	for name, want := range map[string]string{
+		"Name":       `{"title":[{"text":{"content":"acme/api v1.2.0"},"type":"text"}]}`,
+		"Categories": `{"multi_select":[{"name":"Features"},{"name":"Bug Fixes"}]}`,
+		"Top score":  `{"number":8.5}`,
+	} {
+		if got := string(page.Properties[name]); got != want {
+			t.Errorf("property %s = %s, want %s", name, got, want)
+		}
+	}
+	// Highlights heading + item, then each category heading + entry
+	if len(page.Children) != 6 {
+		t.Errorf("expected 6 blocks, got %d", len(page.Children))
+	}
+}
+
+func TestNotionBlocksStayWithinLimit(t *testing.T) {
+	entries := make([]llm.ChangelogEntry, 150)
+	changelog := &generator.Changelog{Categories: map[string][]llm.ChangelogEntry{"Features": entries}}
+	if blocks := notionBlocks(changelog); len(blocks) != notionMaxBlocks {
+		t.Errorf("expected %d blocks, got %d", notionMaxBlocks, len(blocks))
+	}
+}
+
+func TestNewNotionSinkRejectsUnknownFields(t *testing.T) {
+	_, err := newNotionSink(config.SinkConfig{Token: "t", DatabaseID: "db", Properties: map[string]string{"owner": "Owner"}})
+	if err == nil {
+		t.Error("expected error for unknown property field")
+	}
+}
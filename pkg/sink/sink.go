@@ -0,0 +1,169 @@
+// Package sink publishes generated changelogs to output destinations. One run
+// can fan out to several sinks configured as a list under sinks: in
+// .changelog.yaml.
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/generator"
+)
+
+// Sink publishes a changelog to one destination
+type Sink interface {
+	Name() string
+	Write(ctx context.Context, changelog *generator.Changelog) error
+}
+
+// ReleaseUpdater sets the notes of a hosted release (implemented by the GitHub client)
+type ReleaseUpdater interface {
+	UpsertReleaseNotes(ctx context.Context, tag, body string) error
+}
+
+// New builds the sink described by cfg. releases backs the github-release
+// sink and may be nil when the provider has no releases.
+func New(cfg config.SinkConfig, releases ReleaseUpdater) (Sink, error) {
+	switch cfg.Format {
+	case "", "markdown", "html", "json", "csv", "xlsx", "announcement", "widget-json":
+	default:
+		return nil, fmt.Errorf("%s sink: unsupported format %q (expected markdown, html, json, csv, xlsx, announcement, or widget-json)", cfg.Type, cfg.Format)
+	}
+
+	switch cfg.Type {
+	case "file":
+		if cfg.Path == "" {
+			return nil, fmt.Errorf("file sink: path is required")
+		}
+		return &fileSink{path: cfg.Path, format: cfg.Format}, nil
+	case "stdout":
+		if cfg.Format == "xlsx" {
+			return nil, fmt.Errorf("stdout sink: xlsx needs a file")
+		}
+		return &stdoutSink{format: cfg.Format}, nil
+	case "github-release":
+		if releases == nil {
+			return nil, fmt.Errorf("github-release sink requires --provider=github")
+		}
+		return &releaseSink{releases: releases}, nil
+	case "s3":
+		return newS3Sink(cfg)
+	case "gcs":
+		return newGCSSink(cfg)
+	case "http":
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("http sink: url is required")
+		}
+		return &httpSink{url: cfg.URL, headers: cfg.Headers, format: cfg.Format}, nil
+	case "slack":
+		if cfg.URL == "" {
+			return nil, fmt.Errorf("slack sink: url is required")
+		}
+		return &slackSink{webhookURL: cfg.URL}, nil
+	case "notion":
+		return newNotionSink(cfg)
+	case "wordpress":
+		return newWordPressSink(cfg)
+	case "ghost":
+		return newGhostSink(cfg)
+	default:
+		return nil, fmt.Errorf("unsupported sink type %q (expected file, stdout, github-release, s3, gcs, http, slack, notion, wordpress, or ghost)", cfg.Type)
+	}
+}
+
+// Build builds every configured sink
+func Build(cfgs []config.SinkConfig, releases ReleaseUpdater) ([]Sink, error) {
+	sinks := make([]Sink, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		s, err := New(cfg, releases)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, s)
+	}
+	return sinks, nil
+}
+
+// WriteAll publishes changelog to every sink. A failing sink does not stop
+// the others; all failures are returned together.
+func WriteAll(ctx context.Context, sinks []Sink, changelog *generator.Changelog) error {
+	var errs []error
+	for _, s := range sinks {
+		if err := s.Write(ctx, changelog); err != nil {
+			errs = append(errs, fmt.Errorf("%s sink: %w", s.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// render encodes a changelog in the given output format
+func render(changelog *generator.Changelog, format string) ([]byte, error) {
+	switch format {
+	case "html":
+		title := fmt.Sprintf("%s %s", changelog.RepoName, changelog.ToRef)
+		return []byte(generator.FormatHTML(title, changelog.Markdown)), nil
+	case "json":
+		data, err := json.MarshalIndent(changelog, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("encode JSON output: %w", err)
+		}
+		return append(data, '\n'), nil
+	case "csv", "xlsx":
+		rows, err := generator.ExportRows(changelog)
+		if err != nil {
+			return nil, err
+		}
+		if format == "xlsx" {
+			return generator.FormatXLSX(rows)
+		}
+		content, err := generator.FormatCSV(rows)
+		return []byte(content), err
+	case "announcement":
+		announcements, err := generator.Announcements(changelog)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(generator.FormatAnnouncements(announcements)), nil
+	case "widget-json":
+		items, err := generator.WidgetItems(changelog)
+		if err != nil {
+			return nil, err
+		}
+		content, err := generator.FormatWidgetJSON(items)
+		return []byte(content), err
+	default:
+		return []byte(changelog.Markdown), nil
+	}
+}
+
+// contentType returns the MIME type of an output format
+func contentType(format string) string {
+	switch format {
+	case "html":
+		return "text/html; charset=utf-8"
+	case "json", "widget-json":
+		return "application/json"
+	case "csv":
+		return "text/csv"
+	case "xlsx":
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "text/markdown; charset=utf-8"
+	}
+}
+
+// expandPath fills {repo} (owner-repo), {tag} or {to_ref}, {from_ref}, and
+// {version} (tag without a leading v) in a destination pattern
+func expandPath(pattern string, changelog *generator.Changelog) string {
+	return strings.NewReplacer(
+		"{repo}", strings.ReplaceAll(changelog.RepoName, "/", "-"),
+		"{tag}", changelog.ToRef,
+		"{to_ref}", changelog.ToRef,
+		"{from_ref}", changelog.FromRef,
+		"{version}", strings.TrimPrefix(changelog.ToRef, "v"),
+	).Replace(pattern)
+}
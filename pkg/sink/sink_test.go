@@ -0,0 +1,88 @@
+package sink
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+	"github.com/rakshaksatsangi/changelog-generator/pkg/generator"
+)
+
+func testChangelog() *generator.Changelog {
+	return &generator.Changelog{RepoName: "acme/api", ToRef: "v1.2.0", Markdown: "# Changelog\n"}
+}
+
+func TestWriteAllFansOut(t *testing.T) {
+	var posted, token string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		posted, token = string(body), r.Header.Get("X-Token")
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	sinks, err := Build([]config.SinkConfig{
+		{Type: "file", Path: filepath.Join(dir, "{repo}", "{version}.json"), Format: "json"},
+		{Type: "http", URL: server.URL, Headers: map[string]string{"X-Token": "secret"}},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteAll(context.Background(), sinks, testChangelog()); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "acme-api", "1.2.0.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"to_ref": "v1.2.0"`) {
+		t.Errorf("unexpected file content:\n%s", data)
+	}
+	if posted != "# Changelog\n" || token != "secret" {
+		t.Errorf("http sink posted %q with token %q", posted, token)
+	}
+}
+
+func TestWriteAllContinuesPastFailures(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+
+	path := filepath.Join(t.TempDir(), "out.md")
+	sinks, err := Build([]config.SinkConfig{
+		{Type: "http", URL: failing.URL},
+		{Type: "file", Path: path},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = WriteAll(context.Background(), sinks, testChangelog())
+	if err == nil || !strings.Contains(err.Error(), "http sink: unexpected status 502") {
+		t.Errorf("expected http sink failure, got %v", err)
+	}
+	if _, statErr := os.Stat(path); statErr != nil {
+		t.Errorf("file sink should still run: %v", statErr)
+	}
+}
+
+func TestNewRejectsInvalidConfig(t *testing.T) {
+	for _, cfg := range []config.SinkConfig{
+		{Type: "ftp"},
+		{Type: "file"},
+		{Type: "github-release"},
+		{Type: "s3", Bucket: "b", Key: "k"},
+		{Type: "stdout", Format: "yaml"},
+	} {
+		if _, err := New(cfg, nil); err == nil {
+			t.Errorf("New(%+v) should fail", cfg)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+// Package stack detects a repository's primary languages and frameworks so
+// prompts can tell the model what kind of codebase a diff comes from.
+package stack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// maxLanguages caps how many languages a profile lists
+const maxLanguages = 4
+
+// Source reads the repository metadata used for detection
+type Source interface {
+	// Languages returns the number of bytes of code per language
+	Languages(ctx context.Context) (map[string]int, error)
+	// FileContent returns a file from the default branch, or nil when it does not exist
+	FileContent(ctx context.Context, path string) ([]byte, error)
+}
+
+// Language is one language's share of the codebase
+type Language struct {
+	Name    string
+	Percent float64
+}
+
+// Profile describes a repository's technology stack
+type Profile struct {
+	Languages  []Language
+	Frameworks []string
+}
+
+// framework maps a dependency name in a manifest to a display name
+type framework struct {
+	dependency string
+	name       string
+}
+
+// manifests lists the root files inspected for frameworks, with the
+// dependencies recognized in each
+var manifests = []struct {
+	path       string
+	frameworks []framework
+}{
+	{"go.mod", []framework{
+		{"github.com/spf13/cobra", "Cobra"},
+		{"github.com/gin-gonic/gin", "Gin"},
+		{"github.com/labstack/echo", "Echo"},
+		{"github.com/gofiber/fiber", "Fiber"},
+		{"github.com/go-chi/chi", "chi"},
+		{"google.golang.org/grpc", "gRPC"},
+		{"gorm.io/gorm", "GORM"},
+		{"k8s.io/client-go", "Kubernetes client-go"},
+	}},
+	{"package.json", []framework{
+		{"next", "Next.js"},
+		{"react-native", "React Native"},
+		{"react", "React"},
+		{"nuxt", "Nuxt"},
+		{"vue", "Vue"},
+		{"@angular/core", "Angular"},
+		{"svelte", "Svelte"},
+		{"@nestjs/core", "NestJS"},
+		{"express", "Express"},
+		{"electron", "Electron"},
+	}},
+	{"requirements.txt", pythonFrameworks},
+	{"pyproject.toml", pythonFrameworks},
+	{"Gemfile", []framework{
+		{"rails", "Ruby on Rails"},
+		{"sinatra", "Sinatra"},
+	}},
+	{"Cargo.toml", []framework{
+		{"actix-web", "Actix Web"},
+		{"axum", "Axum"},
+		{"rocket", "Rocket"},
+		{"tokio", "Tokio"},
+	}},
+	{"pom.xml", jvmFrameworks},
+	{"build.gradle", jvmFrameworks},
+	{"build.gradle.kts", jvmFrameworks},
+	{"composer.json", []framework{
+		{"laravel/framework", "Laravel"},
+		{"symfony/framework-bundle", "Symfony"},
+	}},
+}
+
+var pythonFrameworks = []framework{
+	{"django", "Django"},
+	{"flask", "Flask"},
+	{"fastapi", "FastAPI"},
+	{"torch", "PyTorch"},
+	{"tensorflow", "TensorFlow"},
+}
+
+var jvmFrameworks = []framework{
+	{"spring-boot", "Spring Boot"},
+	{"quarkus", "Quarkus"},
+	{"ktor", "Ktor"},
+}
+
+// Detect builds a profile from the language breakdown and root manifests.
+// Missing manifests are skipped; an empty profile means nothing was detected.
+func Detect(ctx context.Context, source Source) (*Profile, error) {
+	languages, err := source.Languages(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list languages: %w", err)
+	}
+	profile := &Profile{Languages: topLanguages(languages)}
+
+	seen := make(map[string]bool)
+	for _, manifest := range manifests {
+		content, err := source.FileContent(ctx, manifest.path)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", manifest.path, err)
+		}
+		if content == nil {
+			continue
+		}
+		for _, name := range Frameworks(manifest.path, content) {
+			if !seen[name] {
+				seen[name] = true
+				profile.Frameworks = append(profile.Frameworks, name)
+			}
+		}
+	}
+	return profile, nil
+}
+
+// String renders the profile for a prompt: "Go (82%), TypeScript (15%);
+// frameworks: Cobra, React". It is empty when nothing was detected.
+func (p *Profile) String() string {
+	if p == nil {
+		return ""
+	}
+	var parts []string
+	if len(p.Languages) > 0 {
+		languages := make([]string, len(p.Languages))
+		for i, language := range p.Languages {
+			languages[i] = fmt.Sprintf("%s (%.0f%%)", language.Name, language.Percent)
+		}
+		parts = append(parts, strings.Join(languages, ", "))
+	}
+	if len(p.Frameworks) > 0 {
+		parts = append(parts, "frameworks: "+strings.Join(p.Frameworks, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// topLanguages converts byte counts to percentages, keeping the largest
+// languages that make up at least 1% of the code
+func topLanguages(bytes map[string]int) []Language {
+	total := 0
+	for _, n := range bytes {
+		total += n
+	}
+	if total == 0 {
+		return nil
+	}
+
+	var languages []Language
+	for name, n := range bytes {
+		percent := float64(n) * 100 / float64(total)
+		if percent >= 1 {
+			languages = append(languages, Language{Name: name, Percent: percent})
+		}
+	}
+	sort.Slice(languages, func(i, j int) bool {
+		if languages[i].Percent != languages[j].Percent {
+			return languages[i].Percent > languages[j].Percent
+		}
+		return languages[i].Name < languages[j].Name
+	})
+	if len(languages) > maxLanguages {
+		languages = languages[:maxLanguages]
+	}
+	return languages
+}
+
+// Frameworks returns the known frameworks a manifest depends on, in the
+// order they are listed for that manifest type
+func Frameworks(path string, content []byte) []string {
+	deps := dependencies(path, content)
+
+	var names []string
+	for _, manifest := range manifests {
+		if manifest.path != path {
+			continue
+		}
+		for _, fw := range manifest.frameworks {
+			if deps[fw.dependency] {
+				names = append(names, fw.name)
+			}
+		}
+	}
+	return names
+}
+
+// dependencyTokenRe matches package-name-like tokens in text manifests;
+// Maven coordinates such as io.quarkus:quarkus-core split into their parts
+var dependencyTokenRe = regexp.MustCompile(`[a-z0-9_@-]+`)
+
+// dependencies extracts the dependency names declared in a manifest.
+// JSON manifests are parsed; go.mod paths are matched by module prefix; other
+// formats are scanned for name-like tokens, which is enough to spot the
+// frameworks above.
+func dependencies(path string, content []byte) map[string]bool {
+	deps := make(map[string]bool)
+	switch path {
+	case "package.json", "composer.json":
+		var manifest struct {
+			Dependencies    map[string]any `json:"dependencies"`
+			DevDependencies map[string]any `json:"devDependencies"`
+			Require         map[string]any `json:"require"`
+		}
+		if json.Unmarshal(content, &manifest) != nil {
+			return deps
+		}
+		for _, section := range []map[string]any{manifest.Dependencies, manifest.DevDependencies, manifest.Require} {
+			for name := range section {
+				deps[name] = true
+			}
+		}
+	case "go.mod":
+		for _, line := range strings.Split(string(content), "\n") {
+			fields := strings.Fields(strings.TrimPrefix(strings.TrimSpace(line), "require "))
+			if len(fields) < 2 || !strings.Contains(fields[0], ".") {
+				continue
+			}
+			// Record every prefix so major-version suffixes (/v5) still match
+			module := fields[0]
+			for module != "" {
+				deps[module] = true
+				idx := strings.LastIndex(module, "/")
+				if idx < 0 {
+					break
+				}
+				module = module[:idx]
+			}
+		}
+	default:
+		for _, token := range dependencyTokenRe.FindAllString(strings.ToLower(string(content)), -1) {
+			// Record dash-separated prefixes so artifact families match:
+			// spring-boot-starter-web → spring-boot, flask-cors → flask
+			parts := strings.Split(token, "-")
+			for i := range parts {
+				deps[strings.Join(parts[:i+1], "-")] = true
+			}
+		}
+	}
+	return deps
+}
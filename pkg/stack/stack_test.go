@@ -0,0 +1,63 @@
+package stack
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+type fakeSource struct {
+	languages map[string]int
+	files     map[string]string
+}
+
+func (f fakeSource) Languages(ctx context.Context) (map[string]int, error) {
+	return f.languages, nil
+}
+
+func (f fakeSource) FileContent(ctx context.Context, path string) ([]byte, error) {
+	content, ok := f.files[path]
+	if !ok {
+		return nil, nil
+	}
+	return []byte(content), nil
+}
+
+func TestDetect(t *testing.T) {
+	source := fakeSource{
+		languages: map[string]int{"Go": 8000, "TypeScript": 1800, "Shell": 150, "Makefile": 50},
+		files: map[string]string{
+			"go.mod":       "module github.com/acme/api\n\ngo 1.22\n\nrequire (\n\tgithub.com/spf13/cobra v1.8.0\n\tgithub.com/labstack/echo/v4 v4.11.0\n)\n",
+			"package.json": `{"dependencies": {"react": "^18.0.0"}, "devDependencies": {"vite": "^5.0.0"}}`,
+		},
+	}
+
+	profile, err := Detect(context.Background(), source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "Go (80%), TypeScript (18%), Shell (2%); frameworks: Cobra, Echo, React"
+	if got := profile.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestFrameworks(t *testing.T) {
+	tests := []struct {
+		path    string
+		content string
+		want    []string
+	}{
+		{"requirements.txt", "Django>=4.2\nflask-cors==4.0\nrequests\n", []string{"Django", "Flask"}},
+		{"Gemfile", "source 'https://rubygems.org'\ngem 'rails', '~> 7.1'\n", []string{"Ruby on Rails"}},
+		{"pom.xml", "<artifactId>spring-boot-starter-web</artifactId>", []string{"Spring Boot"}},
+		{"build.gradle.kts", `implementation("io.ktor:ktor-server-core:2.3.0")`, []string{"Ktor"}},
+		{"Cargo.toml", "[dependencies]\naxum = \"0.7\"\ntokio = { version = \"1\" }\n", []string{"Axum", "Tokio"}},
+		{"package.json", `{"dependencies": {"react-dom": "^18"}}`, nil},
+	}
+	for _, tt := range tests {
+		if got := Frameworks(tt.path, []byte(tt.content)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Frameworks(%s) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
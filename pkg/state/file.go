@@ -0,0 +1,156 @@
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// lockRetryDelay is how often a blocked update checks the lock file again
+const lockRetryDelay = 10 * time.Millisecond
+
+// FileStore keeps state as a JSON object in a local file. Every call re-reads
+// the file, and updates hold a lock file, so separate processes on one machine
+// see each other's updates.
+type FileStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileStore creates a store backed by path; the file is created on first Set
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+func (s *FileStore) Get(ctx context.Context, key string) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	values, err := s.read()
+	if err != nil {
+		return "", false, err
+	}
+	value, ok := values[key]
+	return value, ok, nil
+}
+
+func (s *FileStore) Set(ctx context.Context, key, value string) error {
+	return s.update(ctx, func(values map[string]string) bool {
+		values[key] = value
+		return true
+	})
+}
+
+func (s *FileStore) Claim(ctx context.Context, key, value string) (bool, error) {
+	claimed := false
+	err := s.update(ctx, func(values map[string]string) bool {
+		if _, ok := values[key]; ok {
+			return false
+		}
+		values[key] = value
+		claimed = true
+		return true
+	})
+	return claimed && err == nil, err
+}
+
+func (s *FileStore) Delete(ctx context.Context, key string) error {
+	return s.update(ctx, func(values map[string]string) bool {
+		if _, ok := values[key]; !ok {
+			return false
+		}
+		delete(values, key)
+		return true
+	})
+}
+
+func (s *FileStore) Close() error { return nil }
+
+// read loads the state file; a missing file is an empty state
+func (s *FileStore) read() (map[string]string, error) {
+	values := make(map[string]string)
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return values, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read state: %w", err)
+	}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("parse state %s: %w", s.path, err)
+	}
+	return values, nil
+}
+
+// update applies change to the state while holding the lock file, so
+// concurrent updates from other processes are not lost, and writes the result
+// if change reports a modification
+func (s *FileStore) update(ctx context.Context, change func(map[string]string) bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unlock, err := s.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	values, err := s.read()
+	if err != nil {
+		return err
+	}
+	if !change(values) {
+		return nil
+	}
+	return s.write(values)
+}
+
+// lock creates the lock file next to the state file, waiting while another
+// process holds it
+func (s *FileStore) lock(ctx context.Context) (func(), error) {
+	path := s.path + ".lock"
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("lock state: %w", err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("lock state (remove %s if no run is active): %w", path, ctx.Err())
+		case <-time.After(lockRetryDelay):
+		}
+	}
+}
+
+// write replaces the state file with values
+func (s *FileStore) write(values map[string]string) error {
+	data, err := json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode state: %w", err)
+	}
+	// Write then rename so a crash never leaves a truncated file
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".state-*")
+	if err != nil {
+		return fmt.Errorf("write state: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write state: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("write state: %w", err)
+	}
+	return nil
+}
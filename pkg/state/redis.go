@@ -0,0 +1,181 @@
+package state
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// redisKeyPrefix namespaces every key in a shared Redis database
+const redisKeyPrefix = "changelog-generator:"
+
+// RedisStore keeps state in Redis over a single RESP connection, so every
+// replica pointed at the same server shares it
+type RedisStore struct {
+	mu     sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// DialRedis connects to a redis://[[user]:password@]host:port[/db] URL, or a
+// rediss:// one over TLS
+func DialRedis(rawURL string) (*RedisStore, error) {
+	return dialRedis(rawURL, nil)
+}
+
+// dialRedis is DialRedis with the TLS settings of rediss:// URLs (nil = those
+// of http.DefaultTransport, so --ca-bundle applies)
+func dialRedis(rawURL string, tlsConfig *tls.Config) (*RedisStore, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "redis" && u.Scheme != "rediss") || u.Host == "" {
+		return nil, fmt.Errorf("invalid Redis URL %q (expected redis[s]://[[user]:password@]host:port[/db])", rawURL)
+	}
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), "6379")
+	}
+
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	var conn net.Conn
+	if u.Scheme == "rediss" {
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{}
+			if transport, ok := http.DefaultTransport.(*http.Transport); ok && transport.TLSClientConfig != nil {
+				tlsConfig = transport.TLSClientConfig
+			}
+		}
+		if tlsConfig.ServerName == "" {
+			tlsConfig = tlsConfig.Clone()
+			tlsConfig.ServerName = u.Hostname()
+		}
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("connect to Redis: %w", err)
+	}
+	s := &RedisStore{conn: conn, reader: bufio.NewReader(conn)}
+
+	ctx := context.Background()
+	if password, ok := u.User.Password(); ok {
+		args := []string{"AUTH", password}
+		if username := u.User.Username(); username != "" {
+			args = []string{"AUTH", username, password}
+		}
+		if _, err := s.do(ctx, args...); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("authenticate to Redis: %w", err)
+		}
+	}
+	if db := strings.Trim(u.Path, "/"); db != "" {
+		if _, err := s.do(ctx, "SELECT", db); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("select Redis database %s: %w", db, err)
+		}
+	}
+	return s, nil
+}
+
+func (s *RedisStore) Get(ctx context.Context, key string) (string, bool, error) {
+	reply, err := s.do(ctx, "GET", redisKeyPrefix+key)
+	if err != nil {
+		return "", false, fmt.Errorf("read state: %w", err)
+	}
+	if reply == nil {
+		return "", false, nil
+	}
+	return *reply, true, nil
+}
+
+func (s *RedisStore) Set(ctx context.Context, key, value string) error {
+	if _, err := s.do(ctx, "SET", redisKeyPrefix+key, value); err != nil {
+		return fmt.Errorf("write state: %w", err)
+	}
+	return nil
+}
+
+func (s *RedisStore) Claim(ctx context.Context, key, value string) (bool, error) {
+	reply, err := s.do(ctx, "SET", redisKeyPrefix+key, value, "NX")
+	if err != nil {
+		return false, fmt.Errorf("claim state: %w", err)
+	}
+	// SET NX replies OK when it set the key, nil when the key existed
+	return reply != nil, nil
+}
+
+func (s *RedisStore) Delete(ctx context.Context, key string) error {
+	if _, err := s.do(ctx, "DEL", redisKeyPrefix+key); err != nil {
+		return fmt.Errorf("delete state: %w", err)
+	}
+	return nil
+}
+
+func (s *RedisStore) Close() error { return s.conn.Close() }
+
+// do sends one command and reads its reply; a nil reply is a Redis nil
+func (s *RedisStore) do(ctx context.Context, args ...string) (*string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(30 * time.Second)
+	}
+	s.conn.SetDeadline(deadline)
+
+	var cmd strings.Builder
+	fmt.Fprintf(&cmd, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&cmd, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := io.WriteString(s.conn, cmd.String()); err != nil {
+		return nil, err
+	}
+	return s.readReply()
+}
+
+// readReply parses a simple string, error, integer, or bulk string reply
+func (s *RedisStore) readReply() (*string, error) {
+	line, err := s.reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, fmt.Errorf("empty Redis reply")
+	}
+
+	switch line[0] {
+	case '+', ':':
+		value := line[1:]
+		return &value, nil
+	case '-':
+		return nil, fmt.Errorf("redis: %s", line[1:])
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("malformed Redis reply %q", line)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(s.reader, buf); err != nil {
+			return nil, err
+		}
+		value := string(buf[:n])
+		return &value, nil
+	default:
+		return nil, fmt.Errorf("unsupported Redis reply %q", line)
+	}
+}
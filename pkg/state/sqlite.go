@@ -0,0 +1,85 @@
+package state
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	_ "modernc.org/sqlite" // Registers the "sqlite" database/sql driver
+)
+
+// sqliteBusyTimeout is how long a write waits, in milliseconds, while
+// another process holds the database lock
+const sqliteBusyTimeout = 10000
+
+// SQLiteStore keeps state in a key/value table of a SQLite database, which
+// processes sharing the file (e.g. replicas on one volume) see together
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// OpenSQLite opens the SQLite database at dsn (a file path or file: URI),
+// creating it and the state table if needed
+func OpenSQLite(dsn string) (*SQLiteStore, error) {
+	separator := "?"
+	if strings.Contains(dsn, "?") {
+		separator = "&"
+	}
+	db, err := sql.Open("sqlite", fmt.Sprintf("%s%s_pragma=busy_timeout(%d)", dsn, separator, sqliteBusyTimeout))
+	if err != nil {
+		return nil, fmt.Errorf("open state database: %w", err)
+	}
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS changelog_state (key TEXT PRIMARY KEY, value TEXT NOT NULL)`); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("create state table: %w", err)
+	}
+	return &SQLiteStore{db: db}, nil
+}
+
+func (s *SQLiteStore) Get(ctx context.Context, key string) (string, bool, error) {
+	var value string
+	err := s.db.QueryRowContext(ctx, `SELECT value FROM changelog_state WHERE key = ?`, key).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("read state: %w", err)
+	}
+	return value, true, nil
+}
+
+func (s *SQLiteStore) Set(ctx context.Context, key, value string) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO changelog_state (key, value) VALUES (?, ?) ON CONFLICT(key) DO UPDATE SET value = excluded.value`,
+		key, value)
+	if err != nil {
+		return fmt.Errorf("write state: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLiteStore) Claim(ctx context.Context, key, value string) (bool, error) {
+	result, err := s.db.ExecContext(ctx,
+		`INSERT INTO changelog_state (key, value) VALUES (?, ?) ON CONFLICT(key) DO NOTHING`,
+		key, value)
+	if err != nil {
+		return false, fmt.Errorf("claim state: %w", err)
+	}
+	// The insert affects no row when the key was already set
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("claim state: %w", err)
+	}
+	return rows == 1, nil
+}
+
+func (s *SQLiteStore) Delete(ctx context.Context, key string) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM changelog_state WHERE key = ?`, key); err != nil {
+		return fmt.Errorf("delete state: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLiteStore) Close() error { return s.db.Close() }
@@ -0,0 +1,40 @@
+// Package state persists small pieces of progress between runs, such as the
+// last processed tag of a watched repository, and cached lookups in a
+// pluggable backend so several replicas can share them
+package state
+
+import (
+	"context"
+	"fmt"
+)
+
+// Store is a string key-value store
+type Store interface {
+	// Get returns the value of key and whether it was set
+	Get(ctx context.Context, key string) (string, bool, error)
+	Set(ctx context.Context, key, value string) error
+	// Claim sets key to value only if it is not set yet and reports whether it
+	// did; of several concurrent callers, exactly one wins
+	Claim(ctx context.Context, key, value string) (bool, error)
+	Delete(ctx context.Context, key string) error
+	Close() error
+}
+
+// Open connects to a backend: "file" (location is a JSON file path),
+// "sqlite" (location is a database file path or file: URI), or "redis"
+// (location is a redis[s]://[[user]:password@]host:port[/db] URL)
+func Open(backend, location string) (Store, error) {
+	if location == "" {
+		return nil, fmt.Errorf("%s state backend needs a location", backend)
+	}
+	switch backend {
+	case "", "file":
+		return NewFileStore(location), nil
+	case "sqlite":
+		return OpenSQLite(location)
+	case "redis":
+		return DialRedis(location)
+	default:
+		return nil, fmt.Errorf("unsupported state backend %q (expected file, sqlite, or redis)", backend)
+	}
+}
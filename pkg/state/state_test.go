@@ -0,0 +1,337 @@
+package state
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestFileStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "state.json")
+	if err := os.WriteFile(path, []byte(`{"acme/web": "v2.0.0"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewFileStore(path)
+	if _, ok, err := store.Get(ctx, "acme/api"); err != nil || ok {
+		t.Fatalf("Get(missing) = %v, %v", ok, err)
+	}
+	if err := store.Set(ctx, "acme/api", "v1.2.0"); err != nil {
+		t.Fatal(err)
+	}
+
+	// A second store on the same file sees the update and the existing key
+	other := NewFileStore(path)
+	for key, want := range map[string]string{"acme/api": "v1.2.0", "acme/web": "v2.0.0"} {
+		if got, ok, err := other.Get(ctx, key); err != nil || !ok || got != want {
+			t.Errorf("Get(%s) = %q, %v, %v; want %q", key, got, ok, err, want)
+		}
+	}
+}
+
+func TestOpenRejectsUnknownBackends(t *testing.T) {
+	if _, err := Open("etcd", "localhost:2379"); err == nil {
+		t.Error("expected error for unknown backend")
+	}
+	if _, err := Open("redis", "http://localhost:6379"); err == nil {
+		t.Error("expected error for a URL that is not redis:// or rediss://")
+	}
+}
+
+// raceClaims has every store claim key at once and returns how many won
+func raceClaims(t *testing.T, stores []Store, key string) int {
+	t.Helper()
+	var wg sync.WaitGroup
+	results := make(chan bool, len(stores))
+	for i, store := range stores {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			claimed, err := store.Claim(context.Background(), key, strconv.Itoa(i))
+			if err != nil {
+				t.Error(err)
+			}
+			results <- claimed
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	winners := 0
+	for claimed := range results {
+		if claimed {
+			winners++
+		}
+	}
+	return winners
+}
+
+func TestFileStoreClaim(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	// Separate stores stand in for separate processes sharing the file
+	var stores []Store
+	for range 8 {
+		stores = append(stores, NewFileStore(path))
+	}
+	if winners := raceClaims(t, stores, "claim:acme/api@v1.2.0"); winners != 1 {
+		t.Fatalf("%d claimers won, want 1", winners)
+	}
+
+	ctx := context.Background()
+	if err := stores[0].Delete(ctx, "claim:acme/api@v1.2.0"); err != nil {
+		t.Fatal(err)
+	}
+	if claimed, err := stores[1].Claim(ctx, "claim:acme/api@v1.2.0", "retry"); err != nil || !claimed {
+		t.Errorf("Claim() after Delete = %v, %v; want a new claim", claimed, err)
+	}
+	if _, err := os.Stat(path + ".lock"); !os.IsNotExist(err) {
+		t.Errorf("lock file left behind: %v", err)
+	}
+}
+
+func TestSQLiteStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "state.db")
+
+	store, err := Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	if _, ok, err := store.Get(ctx, "acme/api"); err != nil || ok {
+		t.Fatalf("Get(missing) = %v, %v", ok, err)
+	}
+	for _, value := range []string{"v1.1.0", "v1.2.0"} {
+		if err := store.Set(ctx, "acme/api", value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A second store on the same database sees the update
+	other, err := OpenSQLite(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Close()
+	if got, ok, err := other.Get(ctx, "acme/api"); err != nil || !ok || got != "v1.2.0" {
+		t.Errorf("Get() = %q, %v, %v; want v1.2.0", got, ok, err)
+	}
+}
+
+func TestSQLiteStoreClaim(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.db")
+	// Separate database handles stand in for separate processes sharing the file
+	var stores []Store
+	for range 8 {
+		store, err := OpenSQLite(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer store.Close()
+		stores = append(stores, store)
+	}
+	if winners := raceClaims(t, stores, "claim:acme/api@v1.2.0"); winners != 1 {
+		t.Fatalf("%d claimers won, want 1", winners)
+	}
+
+	ctx := context.Background()
+	if err := stores[0].Delete(ctx, "claim:acme/api@v1.2.0"); err != nil {
+		t.Fatal(err)
+	}
+	if claimed, err := stores[1].Claim(ctx, "claim:acme/api@v1.2.0", "retry"); err != nil || !claimed {
+		t.Errorf("Claim() after Delete = %v, %v; want a new claim", claimed, err)
+	}
+}
+
+func TestRedisStoreClaim(t *testing.T) {
+	addr, _ := fakeRedis(t)
+	var stores []Store
+	for range 4 {
+		store, err := DialRedis("redis://" + addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer store.Close()
+		stores = append(stores, store)
+	}
+	if winners := raceClaims(t, stores, "claim:acme/api@v1.2.0"); winners != 1 {
+		t.Fatalf("%d claimers won, want 1", winners)
+	}
+
+	ctx := context.Background()
+	if err := stores[0].Delete(ctx, "claim:acme/api@v1.2.0"); err != nil {
+		t.Fatal(err)
+	}
+	if claimed, err := stores[1].Claim(ctx, "claim:acme/api@v1.2.0", "retry"); err != nil || !claimed {
+		t.Errorf("Claim() after Delete = %v, %v; want a new claim", claimed, err)
+	}
+}
+
+func TestRedisStore(t *testing.T) {
+	addr, commands := fakeRedis(t)
+
+	store, err := DialRedis("redis://:hunter2@" + addr + "/2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	if _, ok, err := store.Get(ctx, "acme/api"); err != nil || ok {
+		t.Fatalf("Get(missing) = %v, %v", ok, err)
+	}
+	if err := store.Set(ctx, "acme/api", "v1.2.0"); err != nil {
+		t.Fatal(err)
+	}
+	if got, ok, err := store.Get(ctx, "acme/api"); err != nil || !ok || got != "v1.2.0" {
+		t.Errorf("Get() = %q, %v, %v", got, ok, err)
+	}
+	store.Close()
+
+	want := []string{
+		"AUTH hunter2",
+		"SELECT 2",
+		"GET changelog-generator:acme/api",
+		"SET changelog-generator:acme/api v1.2.0",
+		"GET changelog-generator:acme/api",
+	}
+	if got := <-commands; strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("commands =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestRedisStoreTLS(t *testing.T) {
+	// The httptest server only lends its certificate for 127.0.0.1
+	server := httptest.NewTLSServer(nil)
+	server.Close()
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, commands := serveFakeRedis(t, tls.NewListener(listener, &tls.Config{Certificates: server.TLS.Certificates}))
+
+	if _, err := DialRedis("rediss://" + addr); err == nil {
+		t.Fatal("expected an error for a server certificate outside the trusted roots")
+	}
+	<-commands
+	store, err := dialRedis("rediss://user:hunter2@"+addr, &tls.Config{RootCAs: roots})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if err := store.Set(ctx, "acme/api", "v1.2.0"); err != nil {
+		t.Fatal(err)
+	}
+	if got, ok, err := store.Get(ctx, "acme/api"); err != nil || !ok || got != "v1.2.0" {
+		t.Errorf("Get() = %q, %v, %v", got, ok, err)
+	}
+	store.Close()
+
+	want := "AUTH user hunter2\nSET changelog-generator:acme/api v1.2.0\nGET changelog-generator:acme/api"
+	if got := strings.Join(<-commands, "\n"); got != want {
+		t.Errorf("commands =\n%s\nwant\n%s", got, want)
+	}
+}
+
+// fakeRedis serves a fake Redis over plain TCP
+func fakeRedis(t *testing.T) (string, <-chan []string) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return serveFakeRedis(t, listener)
+}
+
+// serveFakeRedis serves AUTH, SELECT, GET, SET (with NX), and DEL on
+// listener, with a database shared by every connection, and reports the
+// commands of each connection once its client disconnects
+func serveFakeRedis(t *testing.T, listener net.Listener) (string, <-chan []string) {
+	t.Helper()
+	t.Cleanup(func() { listener.Close() })
+
+	commands := make(chan []string, 16)
+	var mu sync.Mutex
+	values := map[string]string{}
+	serve := func(conn net.Conn) {
+		defer conn.Close()
+
+		var seen []string
+		defer func() { commands <- seen }()
+		reader := bufio.NewReader(conn)
+		for {
+			args, err := readCommand(reader)
+			if err != nil {
+				return
+			}
+			seen = append(seen, strings.Join(args, " "))
+			mu.Lock()
+			switch strings.ToUpper(args[0]) {
+			case "GET":
+				if value, ok := values[args[1]]; ok {
+					fmt.Fprintf(conn, "$%d\r\n%s\r\n", len(value), value)
+				} else {
+					io.WriteString(conn, "$-1\r\n")
+				}
+			case "SET":
+				if _, ok := values[args[1]]; ok && len(args) > 3 && strings.ToUpper(args[3]) == "NX" {
+					io.WriteString(conn, "$-1\r\n")
+					break
+				}
+				values[args[1]] = args[2]
+				io.WriteString(conn, "+OK\r\n")
+			case "DEL":
+				delete(values, args[1])
+				io.WriteString(conn, ":1\r\n")
+			default:
+				io.WriteString(conn, "+OK\r\n")
+			}
+			mu.Unlock()
+		}
+	}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serve(conn)
+		}
+	}()
+	return listener.Addr().String(), commands
+}
+
+// readCommand parses one RESP array of bulk strings
+func readCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	count, _ := strconv.Atoi(strings.TrimSpace(line)[1:])
+	args := make([]string, count)
+	for i := range args {
+		if _, err := reader.ReadString('\n'); err != nil {
+			return nil, err
+		}
+		value, err := reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		args[i] = strings.TrimSuffix(value, "\r\n")
+	}
+	return args, nil
+}
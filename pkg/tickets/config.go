@@ -0,0 +1,44 @@
+package tickets
+
+import (
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+)
+
+// FromConfig builds a linker for every issue tracker configured in cfg.
+// It returns a disabled linker when no tracker is configured.
+//
+// Providers with narrow patterns are registered first so that, for example,
+// a Linear ENG-42 is not claimed by Jira's generic KEY-123 pattern.
+func FromConfig(cfg *config.Config) (*Linker, error) {
+	var providers []Provider
+
+	if cfg.LinearWorkspace != "" {
+		linear, err := NewLinearProvider(cfg.LinearWorkspace, cfg.LinearTeamKeys, cfg.LinearToken)
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, linear)
+	}
+
+	if cfg.ShortcutWorkspace != "" {
+		shortcut, err := NewShortcutProvider(cfg.ShortcutWorkspace, cfg.ShortcutToken)
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, shortcut)
+	}
+
+	if cfg.JiraBaseURL != "" {
+		pattern := cfg.JiraProjectPattern
+		if pattern == "" && len(cfg.JiraProjects) > 0 {
+			pattern = JiraProjectsPattern(cfg.JiraProjects)
+		}
+		jira, err := NewJiraProvider(cfg.JiraBaseURL, pattern, cfg.JiraEmail, cfg.JiraToken)
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, jira)
+	}
+
+	return NewLinker(cfg.FetchTicketSummaries, providers...), nil
+}
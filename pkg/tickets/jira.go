@@ -0,0 +1,130 @@
+package tickets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// DefaultJiraPattern matches Jira-style ticket IDs such as PROJ-123
+const DefaultJiraPattern = `\b[A-Z][A-Z0-9]+-[0-9]+\b`
+
+// nonTicketPrefixes are identifiers that DefaultJiraPattern matches but are
+// not tickets, e.g. UTF-8, SHA-256, CVE-2024, ISO-8601, or TLS-13
+var nonTicketPrefixes = map[string]bool{
+	"AES": true, "CRC": true, "CVE": true, "CWE": true, "ECMA": true, "GPT": true,
+	"HTTP": true, "IEC": true, "IPV": true, "ISO": true, "MD": true, "PEP": true,
+	"RFC": true, "RSA": true, "SHA": true, "SSL": true, "TLS": true, "UCS": true,
+	"UTF": true,
+}
+
+// JiraProjectsPattern matches the ticket IDs of the given project keys only,
+// e.g. PROJ-123 and OPS-7 for [PROJ, OPS]
+func JiraProjectsPattern(projects []string) string {
+	quoted := make([]string, 0, len(projects))
+	for _, key := range projects {
+		quoted = append(quoted, regexp.QuoteMeta(strings.ToUpper(strings.TrimSpace(key))))
+	}
+	return `\b(?:` + strings.Join(quoted, "|") + `)-[0-9]+\b`
+}
+
+// JiraProvider links Jira issue keys to a Jira instance
+type JiraProvider struct {
+	baseURL    string
+	pattern    *regexp.Regexp
+	denylist   bool // Skip nonTicketPrefixes, which only the default pattern matches
+	email      string
+	token      string
+	httpClient *http.Client
+}
+
+// NewJiraProvider creates a Jira provider. An empty pattern uses DefaultJiraPattern,
+// leaving out common identifiers such as UTF-8 that are not tickets.
+// email and token are only needed when fetching summaries; with an empty email the
+// token is sent as a bearer token (Jira Data Center personal access tokens).
+func NewJiraProvider(baseURL, pattern, email, token string) (*JiraProvider, error) {
+	if baseURL == "" {
+		return nil, fmt.Errorf("jira base URL is required")
+	}
+	denylist := pattern == ""
+	if denylist {
+		pattern = DefaultJiraPattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid jira project pattern %q: %w", pattern, err)
+	}
+
+	return &JiraProvider{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		pattern:    re,
+		denylist:   denylist,
+		email:      email,
+		token:      token,
+		httpClient: newHTTPClient(),
+	}, nil
+}
+
+// Name returns the provider identifier
+func (p *JiraProvider) Name() string {
+	return "jira"
+}
+
+// Detect returns the Jira keys referenced in text
+func (p *JiraProvider) Detect(text string) []string {
+	ids := p.pattern.FindAllString(text, -1)
+	if !p.denylist {
+		return ids
+	}
+	var tickets []string
+	for _, id := range ids {
+		project, _, _ := strings.Cut(id, "-")
+		if !nonTicketPrefixes[project] {
+			tickets = append(tickets, id)
+		}
+	}
+	return tickets
+}
+
+// URL returns the browse URL for a Jira key
+func (p *JiraProvider) URL(id string) string {
+	return fmt.Sprintf("%s/browse/%s", p.baseURL, id)
+}
+
+// FetchSummary fetches the issue summary via the Jira REST API
+func (p *JiraProvider) FetchSummary(ctx context.Context, id string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		fmt.Sprintf("%s/rest/api/2/issue/%s?fields=summary", p.baseURL, id), nil)
+	if err != nil {
+		return "", fmt.Errorf("build jira request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	switch {
+	case p.email != "" && p.token != "":
+		req.SetBasicAuth(p.email, p.token)
+	case p.token != "":
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fetch jira issue %s: %w", id, err)
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp, "fetch jira issue "+id); err != nil {
+		return "", err
+	}
+
+	var issue struct {
+		Fields struct {
+			Summary string `json:"summary"`
+		} `json:"fields"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&issue); err != nil {
+		return "", fmt.Errorf("decode jira issue %s: %w", id, err)
+	}
+	return issue.Fields.Summary, nil
+}
@@ -0,0 +1,104 @@
+package tickets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+const linearAPIURL = "https://api.linear.app/graphql"
+
+// LinearProvider links Linear issue identifiers (e.g., ENG-42) to a Linear workspace.
+// Linear identifiers share Jira's KEY-123 shape, so detection is restricted to the
+// configured team keys.
+type LinearProvider struct {
+	workspace  string
+	pattern    *regexp.Regexp
+	token      string
+	httpClient *http.Client
+}
+
+// NewLinearProvider creates a Linear provider for the given workspace slug and team keys
+func NewLinearProvider(workspace string, teamKeys []string, token string) (*LinearProvider, error) {
+	if workspace == "" {
+		return nil, fmt.Errorf("linear workspace is required")
+	}
+	if len(teamKeys) == 0 {
+		return nil, fmt.Errorf("linear team_keys are required (e.g., [ENG, DES])")
+	}
+
+	quoted := make([]string, 0, len(teamKeys))
+	for _, key := range teamKeys {
+		quoted = append(quoted, regexp.QuoteMeta(strings.ToUpper(key)))
+	}
+	re := regexp.MustCompile(`\b(?:` + strings.Join(quoted, "|") + `)-[0-9]+\b`)
+
+	return &LinearProvider{
+		workspace:  workspace,
+		pattern:    re,
+		token:      token,
+		httpClient: newHTTPClient(),
+	}, nil
+}
+
+// Name returns the provider identifier
+func (p *LinearProvider) Name() string {
+	return "linear"
+}
+
+// Detect returns the Linear identifiers referenced in text
+func (p *LinearProvider) Detect(text string) []string {
+	return p.pattern.FindAllString(text, -1)
+}
+
+// URL returns the browse URL for a Linear identifier
+func (p *LinearProvider) URL(id string) string {
+	return fmt.Sprintf("https://linear.app/%s/issue/%s", p.workspace, id)
+}
+
+// FetchSummary fetches the issue title via the Linear GraphQL API
+func (p *LinearProvider) FetchSummary(ctx context.Context, id string) (string, error) {
+	if p.token == "" {
+		return "", fmt.Errorf("linear API key is required to fetch summaries (set LINEAR_API_KEY)")
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"query":     `query($id: String!) { issue(id: $id) { title } }`,
+		"variables": map[string]string{"id": id},
+	})
+	if err != nil {
+		return "", fmt.Errorf("encode linear query: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, linearAPIURL, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("build linear request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", p.token)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fetch linear issue %s: %w", id, err)
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp, "fetch linear issue "+id); err != nil {
+		return "", err
+	}
+
+	var result struct {
+		Data struct {
+			Issue struct {
+				Title string `json:"title"`
+			} `json:"issue"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("decode linear issue %s: %w", id, err)
+	}
+	return result.Data.Issue.Title, nil
+}
@@ -0,0 +1,91 @@
+package tickets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+const shortcutAPIURL = "https://api.app.shortcut.com/api/v3"
+
+// shortcutPattern matches Shortcut story references: sc-123, [sc-123], ch123 (legacy Clubhouse)
+var shortcutPattern = regexp.MustCompile(`(?i)\b(?:sc|ch)-?([0-9]+)\b`)
+
+// ShortcutProvider links Shortcut story references to a Shortcut workspace
+type ShortcutProvider struct {
+	workspace  string
+	token      string
+	httpClient *http.Client
+}
+
+// NewShortcutProvider creates a Shortcut provider for the given workspace slug
+func NewShortcutProvider(workspace, token string) (*ShortcutProvider, error) {
+	if workspace == "" {
+		return nil, fmt.Errorf("shortcut workspace is required")
+	}
+
+	return &ShortcutProvider{
+		workspace:  workspace,
+		token:      token,
+		httpClient: newHTTPClient(),
+	}, nil
+}
+
+// Name returns the provider identifier
+func (p *ShortcutProvider) Name() string {
+	return "shortcut"
+}
+
+// Detect returns the story references in text, normalized to "sc-<number>"
+func (p *ShortcutProvider) Detect(text string) []string {
+	var ids []string
+	for _, match := range shortcutPattern.FindAllStringSubmatch(text, -1) {
+		ids = append(ids, "sc-"+match[1])
+	}
+	return ids
+}
+
+// URL returns the browse URL for a story
+func (p *ShortcutProvider) URL(id string) string {
+	return fmt.Sprintf("https://app.shortcut.com/%s/story/%s", p.workspace, storyNumber(id))
+}
+
+// FetchSummary fetches the story name via the Shortcut REST API
+func (p *ShortcutProvider) FetchSummary(ctx context.Context, id string) (string, error) {
+	if p.token == "" {
+		return "", fmt.Errorf("shortcut API token is required to fetch summaries (set SHORTCUT_API_TOKEN)")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		fmt.Sprintf("%s/stories/%s", shortcutAPIURL, storyNumber(id)), nil)
+	if err != nil {
+		return "", fmt.Errorf("build shortcut request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Shortcut-Token", p.token)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("fetch shortcut story %s: %w", id, err)
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp, "fetch shortcut story "+id); err != nil {
+		return "", err
+	}
+
+	var story struct {
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&story); err != nil {
+		return "", fmt.Errorf("decode shortcut story %s: %w", id, err)
+	}
+	return story.Name, nil
+}
+
+// storyNumber strips the "sc-" prefix from a normalized story ID
+func storyNumber(id string) string {
+	return strings.TrimPrefix(id, "sc-")
+}
@@ -0,0 +1,134 @@
+package tickets
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Ticket is an issue tracker reference detected in a commit message or PR
+type Ticket struct {
+	ID       string `json:"id"`
+	Provider string `json:"provider"`
+	URL      string `json:"url"`
+	Summary  string `json:"summary,omitempty"`
+}
+
+// Provider detects and resolves ticket references for a single issue tracker
+type Provider interface {
+	// Name returns the provider identifier (e.g., "jira")
+	Name() string
+	// Detect returns the ticket IDs referenced in text, in order of appearance
+	Detect(text string) []string
+	// URL returns the browse URL for a ticket ID
+	URL(id string) string
+	// FetchSummary fetches the ticket title from the tracker API
+	FetchSummary(ctx context.Context, id string) (string, error)
+}
+
+// Cache keeps fetched summaries beyond one run, e.g. a state.Store that
+// several replicas share
+type Cache interface {
+	Get(ctx context.Context, key string) (string, bool, error)
+	Set(ctx context.Context, key, value string) error
+}
+
+// Linker runs every configured provider over a piece of text and
+// caches fetched summaries so each ticket is only looked up once
+type Linker struct {
+	providers      []Provider
+	fetchSummaries bool
+	summaries      map[string]string
+	cache          Cache // Optional; consulted before fetching
+}
+
+// NewLinker creates a linker for the given providers
+func NewLinker(fetchSummaries bool, providers ...Provider) *Linker {
+	return &Linker{
+		providers:      providers,
+		fetchSummaries: fetchSummaries,
+		summaries:      make(map[string]string),
+	}
+}
+
+// SetCache makes the linker look summaries up in cache before fetching them,
+// and store the ones it fetches there
+func (l *Linker) SetCache(cache Cache) {
+	l.cache = cache
+}
+
+// Enabled reports whether any provider is configured
+func (l *Linker) Enabled() bool {
+	return l != nil && len(l.providers) > 0
+}
+
+// Link returns the deduplicated tickets referenced in text. When two providers
+// match the same ID, the provider registered first wins.
+// Summary lookup failures are not fatal; the ticket is returned without a summary.
+func (l *Linker) Link(ctx context.Context, text string) []Ticket {
+	if !l.Enabled() {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var found []Ticket
+	for _, provider := range l.providers {
+		for _, id := range provider.Detect(text) {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			key := provider.Name() + ":" + id
+
+			ticket := Ticket{
+				ID:       id,
+				Provider: provider.Name(),
+				URL:      provider.URL(id),
+			}
+			if l.fetchSummaries {
+				ticket.Summary = l.summary(ctx, provider, key, id)
+			}
+			found = append(found, ticket)
+		}
+	}
+	return found
+}
+
+// summary returns the cached summary for a ticket, fetching it on first use
+func (l *Linker) summary(ctx context.Context, provider Provider, key, id string) string {
+	if summary, ok := l.summaries[key]; ok {
+		return summary
+	}
+	// Cache failures only cost a lookup
+	if l.cache != nil {
+		if summary, ok, err := l.cache.Get(ctx, "ticket:"+key); err == nil && ok {
+			l.summaries[key] = summary
+			return summary
+		}
+	}
+	summary, err := provider.FetchSummary(ctx, id)
+	if ctx.Err() != nil {
+		return "" // Cancelled: leave the ticket for a later lookup
+	}
+	if err != nil {
+		summary = "" // Not cached beyond this run, so the next one retries
+	} else if l.cache != nil {
+		l.cache.Set(ctx, "ticket:"+key, summary)
+	}
+	l.summaries[key] = summary
+	return summary
+}
+
+// newHTTPClient returns the HTTP client shared by tracker providers
+func newHTTPClient() *http.Client {
+	return &http.Client{Timeout: 15 * time.Second}
+}
+
+// checkStatus turns a non-2xx response into an error
+func checkStatus(resp *http.Response, what string) error {
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s: unexpected status %s", what, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+package tickets
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/config"
+)
+
+func TestJiraDetect(t *testing.T) {
+	jira, err := NewJiraProvider("https://acme.atlassian.net/", "", "", "")
+	if err != nil {
+		t.Fatalf("NewJiraProvider() error = %v", err)
+	}
+
+	ids := jira.Detect("PROJ-123: fix login (also closes OPS-7, see lowercase abc-1)")
+	expected := []string{"PROJ-123", "OPS-7"}
+	if len(ids) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, ids)
+	}
+	for i, id := range expected {
+		if ids[i] != id {
+			t.Errorf("Expected ID at position %d to be %s, got %s", i, id, ids[i])
+		}
+	}
+
+	if url := jira.URL("PROJ-123"); url != "https://acme.atlassian.net/browse/PROJ-123" {
+		t.Errorf("Unexpected browse URL: %s", url)
+	}
+}
+
+func TestJiraCustomPattern(t *testing.T) {
+	if _, err := NewJiraProvider("https://jira.example.com", "([", "", ""); err == nil {
+		t.Error("Expected error for invalid pattern")
+	}
+
+	jira, err := NewJiraProvider("https://jira.example.com", `\bCORE-\d+\b`, "", "")
+	if err != nil {
+		t.Fatalf("NewJiraProvider() error = %v", err)
+	}
+	ids := jira.Detect("CORE-1 and WEB-2")
+	if len(ids) != 1 || ids[0] != "CORE-1" {
+		t.Errorf("Expected only CORE-1, got %v", ids)
+	}
+}
+
+func TestJiraSkipsNonTicketIdentifiers(t *testing.T) {
+	jira, _ := NewJiraProvider("https://jira.example.com", "", "", "")
+	text := "PROJ-12: encode as UTF-8, hash with SHA-256, pin TLS-13, patch CVE-2024 and RFC-9110, parse ISO-8601 (OPS-7)"
+	ids := jira.Detect(text)
+	if strings.Join(ids, ",") != "PROJ-12,OPS-7" {
+		t.Errorf("Detect() = %v, want only PROJ-12 and OPS-7", ids)
+	}
+
+	// An explicit pattern is taken as is
+	custom, _ := NewJiraProvider("https://jira.example.com", `\bSHA-[0-9]+\b`, "", "")
+	if ids := custom.Detect(text); len(ids) != 1 || ids[0] != "SHA-256" {
+		t.Errorf("Detect() with a custom pattern = %v, want SHA-256", ids)
+	}
+}
+
+func TestJiraProjectsPattern(t *testing.T) {
+	jira, err := NewJiraProvider("https://jira.example.com", JiraProjectsPattern([]string{"proj", " OPS"}), "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := jira.Detect("PROJ-12 and OPS-7, not WEB-3, UTF-8, or XPROJ-1")
+	if strings.Join(ids, ",") != "PROJ-12,OPS-7" {
+		t.Errorf("Detect() = %v, want only the configured projects", ids)
+	}
+}
+
+func TestFromConfigJiraProjects(t *testing.T) {
+	cfg := config.Default()
+	cfg.JiraBaseURL = "https://jira.example.com"
+	cfg.JiraProjects = []string{"PROJ"}
+	linker, err := FromConfig(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	linked := linker.Link(context.Background(), "PROJ-1 and OPS-2")
+	if len(linked) != 1 || linked[0].ID != "PROJ-1" {
+		t.Errorf("Link() = %+v, want only PROJ-1", linked)
+	}
+}
+
+func TestLinkerDeduplicates(t *testing.T) {
+	jira, _ := NewJiraProvider("https://jira.example.com", "", "", "")
+	linker := NewLinker(false, jira)
+
+	linked := linker.Link(context.Background(), "PROJ-1 follow-up for PROJ-1")
+	if len(linked) != 1 {
+		t.Fatalf("Expected 1 ticket, got %d", len(linked))
+	}
+	if linked[0].Provider != "jira" || linked[0].URL != "https://jira.example.com/browse/PROJ-1" {
+		t.Errorf("Unexpected ticket: %+v", linked[0])
+	}
+}
+
+func TestDisabledLinker(t *testing.T) {
+	var linker *Linker
+	if linker.Enabled() {
+		t.Error("Expected nil linker to be disabled")
+	}
+	if linked := NewLinker(true).Link(context.Background(), "PROJ-1"); linked != nil {
+		t.Errorf("Expected no tickets without providers, got %v", linked)
+	}
+}
+
+func TestShortcutDetect(t *testing.T) {
+	shortcut, err := NewShortcutProvider("acme", "")
+	if err != nil {
+		t.Fatalf("NewShortcutProvider() error = %v", err)
+	}
+
+	ids := shortcut.Detect("Fix export [sc-1234] (legacy ch99)")
+	if len(ids) != 2 || ids[0] != "sc-1234" || ids[1] != "sc-99" {
+		t.Errorf("Expected [sc-1234 sc-99], got %v", ids)
+	}
+	if url := shortcut.URL("sc-1234"); url != "https://app.shortcut.com/acme/story/1234" {
+		t.Errorf("Unexpected story URL: %s", url)
+	}
+}
+
+func TestLinearTakesPrecedenceOverJira(t *testing.T) {
+	linear, err := NewLinearProvider("acme", []string{"eng"}, "")
+	if err != nil {
+		t.Fatalf("NewLinearProvider() error = %v", err)
+	}
+	jira, _ := NewJiraProvider("https://jira.example.com", "", "", "")
+	linker := NewLinker(false, linear, jira)
+
+	linked := linker.Link(context.Background(), "ENG-42 depends on OPS-7")
+	if len(linked) != 2 {
+		t.Fatalf("Expected 2 tickets, got %v", linked)
+	}
+	if linked[0].ID != "ENG-42" || linked[0].Provider != "linear" {
+		t.Errorf("Expected ENG-42 to link to linear, got %+v", linked[0])
+	}
+	if linked[0].URL != "https://linear.app/acme/issue/ENG-42" {
+		t.Errorf("Unexpected Linear URL: %s", linked[0].URL)
+	}
+	if linked[1].ID != "OPS-7" || linked[1].Provider != "jira" {
+		t.Errorf("Expected OPS-7 to link to jira, got %+v", linked[1])
+	}
+}
+
+func TestFetchSummaryHonorsContext(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"fields": {"summary": "Fix login"}}`)
+	}))
+	defer server.Close()
+
+	jira, _ := NewJiraProvider(server.URL, "", "", "")
+	if summary, err := jira.FetchSummary(context.Background(), "PROJ-1"); err != nil || summary != "Fix login" {
+		t.Fatalf("FetchSummary() = %q, %v", summary, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := jira.FetchSummary(ctx, "PROJ-2"); err == nil {
+		t.Error("Expected an error for a cancelled context")
+	}
+	linked := NewLinker(true, jira).Link(ctx, "PROJ-3")
+	if len(linked) != 1 || linked[0].Summary != "" {
+		t.Errorf("Link() with a cancelled context = %+v", linked)
+	}
+	if requests != 1 {
+		t.Errorf("%d requests reached the tracker, want only the uncancelled one", requests)
+	}
+}
+
+// mapCache is an in-memory Cache
+type mapCache map[string]string
+
+func (c mapCache) Get(ctx context.Context, key string) (string, bool, error) {
+	value, ok := c[key]
+	return value, ok, nil
+}
+
+func (c mapCache) Set(ctx context.Context, key, value string) error {
+	c[key] = value
+	return nil
+}
+
+func TestLinkerSharesSummariesThroughCache(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if strings.HasSuffix(r.URL.Path, "/PROJ-2") {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"fields": {"summary": "Fix login"}}`)
+	}))
+	defer server.Close()
+
+	jira, _ := NewJiraProvider(server.URL, "", "", "")
+	cache := mapCache{}
+	// Separate linkers stand in for separate runs or replicas
+	for range 2 {
+		linker := NewLinker(true, jira)
+		linker.SetCache(cache)
+		linked := linker.Link(context.Background(), "PROJ-1 and PROJ-2")
+		if len(linked) != 2 || linked[0].Summary != "Fix login" || linked[1].Summary != "" {
+			t.Fatalf("Link() = %+v", linked)
+		}
+	}
+	if requests != 3 {
+		t.Errorf("%d requests reached the tracker, want PROJ-1 once and the failed PROJ-2 twice", requests)
+	}
+	if len(cache) != 1 || cache["ticket:jira:PROJ-1"] != "Fix login" {
+		t.Errorf("cache = %v", cache)
+	}
+}
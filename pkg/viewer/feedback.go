@@ -0,0 +1,71 @@
+package viewer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Feedback is one reader's reaction to a changelog entry
+type Feedback struct {
+	Time       time.Time `json:"time"`
+	Vote       string    `json:"vote"` // "up" or "down"
+	Repository string    `json:"repository,omitempty"`
+	Version    string    `json:"version,omitempty"`
+	Category   string    `json:"category,omitempty"`
+	Title      string    `json:"title"`
+	Score      float64   `json:"score,omitempty"`
+	SHA        string    `json:"sha,omitempty"`
+	PR         int       `json:"pr,omitempty"`
+}
+
+// FeedbackLog appends reactions to a JSON Lines file
+type FeedbackLog struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFeedbackLog creates a log writing to path
+func NewFeedbackLog(path string) *FeedbackLog {
+	return &FeedbackLog{path: path}
+}
+
+// Validate checks that a reaction names an entry and a known vote
+func (f Feedback) Validate() error {
+	if f.Vote != "up" && f.Vote != "down" {
+		return fmt.Errorf("unsupported vote %q (expected up or down)", f.Vote)
+	}
+	if f.Title == "" {
+		return fmt.Errorf("feedback is missing the entry title")
+	}
+	return nil
+}
+
+// Record validates a reaction and appends it to the log
+func (l *FeedbackLog) Record(feedback Feedback) error {
+	if err := feedback.Validate(); err != nil {
+		return err
+	}
+	if feedback.Time.IsZero() {
+		feedback.Time = time.Now().UTC()
+	}
+
+	line, err := json.Marshal(feedback)
+	if err != nil {
+		return fmt.Errorf("encode feedback: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("open feedback log: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("write feedback log: %w", err)
+	}
+	return nil
+}
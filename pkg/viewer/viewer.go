@@ -0,0 +1,93 @@
+// Package viewer serves generated changelogs as a small searchable web page
+package viewer
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/generator"
+)
+
+//go:embed index.html
+var indexHTML []byte
+
+// maxFeedbackBytes bounds the size of a posted reaction
+const maxFeedbackBytes = 16 << 10
+
+// Handler serves the single-page viewer at / and the entries it renders as
+// JSON at /api/entries. With a non-nil feedback log, readers can rate entries
+// and each reaction posted to /api/feedback is recorded.
+func Handler(title string, rows []generator.ExportRow, feedback *FeedbackLog) http.Handler {
+	if rows == nil {
+		rows = []generator.ExportRow{}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(indexHTML)
+	})
+	mux.HandleFunc("GET /api/entries", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(struct {
+			Title    string                `json:"title"`
+			Feedback bool                  `json:"feedback"`
+			Entries  []generator.ExportRow `json:"entries"`
+		}{title, feedback != nil, rows})
+	})
+	if feedback != nil {
+		mux.HandleFunc("POST /api/feedback", func(w http.ResponseWriter, r *http.Request) {
+			if err := checkSameOrigin(r); err != nil {
+				http.Error(w, err.Error(), http.StatusForbidden)
+				return
+			}
+			if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "application/json" {
+				http.Error(w, "feedback must be posted as application/json", http.StatusUnsupportedMediaType)
+				return
+			}
+			var reaction Feedback
+			if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxFeedbackBytes)).Decode(&reaction); err != nil {
+				http.Error(w, "invalid feedback: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+			if err := reaction.Validate(); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			reaction.Time = time.Time{} // Always stamped by the server
+			if err := feedback.Record(reaction); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+	return mux
+}
+
+// checkSameOrigin rejects requests that other web pages make from the
+// reader's browser, which would otherwise reach a viewer on localhost.
+// Requests without Origin, Referer, or Sec-Fetch-Site come from outside a
+// browser and are allowed.
+func checkSameOrigin(r *http.Request) error {
+	for _, header := range []string{"Origin", "Referer"} {
+		value := r.Header.Get(header)
+		if value == "" {
+			continue
+		}
+		u, err := url.Parse(value)
+		if err != nil || u.Host != r.Host {
+			return fmt.Errorf("cross-origin request from %s rejected", value)
+		}
+		return nil
+	}
+	if site := r.Header.Get("Sec-Fetch-Site"); site != "" && site != "same-origin" && site != "none" {
+		return fmt.Errorf("cross-site request rejected")
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package viewer
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rakshaksatsangi/changelog-generator/pkg/generator"
+)
+
+func TestHandler(t *testing.T) {
+	server := httptest.NewServer(Handler("api v1.1.0", []generator.ExportRow{
+		{Repository: "api", Version: "v1.1.0", Category: "Features", Title: "Add OAuth2", Score: 8, HasScore: true},
+	}, nil))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/")
+	if err != nil {
+		t.Fatalf("GET / error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html") {
+		t.Errorf("GET / = %s %s", resp.Status, resp.Header.Get("Content-Type"))
+	}
+
+	resp, err = http.Get(server.URL + "/api/entries")
+	if err != nil {
+		t.Fatalf("GET /api/entries error = %v", err)
+	}
+	defer resp.Body.Close()
+	var payload struct {
+		Title   string                `json:"title"`
+		Entries []generator.ExportRow `json:"entries"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		t.Fatalf("decode entries: %v", err)
+	}
+	if payload.Title != "api v1.1.0" || len(payload.Entries) != 1 || payload.Entries[0].Title != "Add OAuth2" {
+		t.Errorf("Unexpected payload: %+v", payload)
+	}
+
+	resp, err = http.Get(server.URL + "/missing")
+	if err != nil {
+		t.Fatalf("GET /missing error = %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("GET /missing = %s, want 404", resp.Status)
+	}
+}
+
+func TestHandlerFeedback(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "feedback.jsonl")
+	server := httptest.NewServer(Handler("api", nil, NewFeedbackLog(path)))
+	defer server.Close()
+
+	post := func(body string) int {
+		resp, err := http.Post(server.URL+"/api/feedback", "application/json", strings.NewReader(body))
+		if err != nil {
+			t.Fatalf("POST /api/feedback error = %v", err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	if status := post(`{"vote":"up","version":"v1.1.0","title":"Add OAuth2","sha":"abc1234"}`); status != http.StatusNoContent {
+		t.Errorf("valid feedback status = %d", status)
+	}
+	if status := post(`{"vote":"meh","title":"Add OAuth2"}`); status != http.StatusBadRequest {
+		t.Errorf("invalid vote status = %d", status)
+	}
+	if status := post(`{"vote":"down"}`); status != http.StatusBadRequest {
+		t.Errorf("missing title status = %d", status)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 recorded reaction, got %q", data)
+	}
+	var recorded Feedback
+	if err := json.Unmarshal([]byte(lines[0]), &recorded); err != nil {
+		t.Fatal(err)
+	}
+	if recorded.Vote != "up" || recorded.SHA != "abc1234" || recorded.Time.IsZero() {
+		t.Errorf("unexpected recorded feedback %+v", recorded)
+	}
+}
+
+func TestHandlerFeedbackRejectsOtherOrigins(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "feedback.jsonl")
+	server := httptest.NewServer(Handler("api", nil, NewFeedbackLog(path)))
+	defer server.Close()
+
+	body := `{"vote":"up","title":"Add OAuth2"}`
+	cases := []struct {
+		name    string
+		headers map[string]string
+		status  int
+	}{
+		{"same origin", map[string]string{"Content-Type": "application/json", "Origin": server.URL}, http.StatusNoContent},
+		{"same-origin referer", map[string]string{"Content-Type": "application/json", "Referer": server.URL + "/"}, http.StatusNoContent},
+		{"other origin", map[string]string{"Content-Type": "application/json", "Origin": "https://evil.example"}, http.StatusForbidden},
+		{"other referer", map[string]string{"Content-Type": "application/json", "Referer": "https://evil.example/page"}, http.StatusForbidden},
+		{"cross-site fetch", map[string]string{"Content-Type": "application/json", "Sec-Fetch-Site": "cross-site"}, http.StatusForbidden},
+		{"form post", map[string]string{"Content-Type": "text/plain", "Origin": server.URL}, http.StatusUnsupportedMediaType},
+	}
+	accepted := 0
+	for _, c := range cases {
+		req, _ := http.NewRequest(http.MethodPost, server.URL+"/api/feedback", strings.NewReader(body))
+		for key, value := range c.headers {
+			req.Header.Set(key, value)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != c.status {
+			t.Errorf("%s: status = %d, want %d", c.name, resp.StatusCode, c.status)
+		}
+		if resp.StatusCode == http.StatusNoContent {
+			accepted++
+		}
+	}
+
+	data, _ := os.ReadFile(path)
+	if n := strings.Count(string(data), "\n"); n != accepted {
+		t.Errorf("%d reactions recorded, want %d", n, accepted)
+	}
+}
+
+func TestHandlerWithoutFeedback(t *testing.T) {
+	server := httptest.NewServer(Handler("api", nil, nil))
+	defer server.Close()
+
+	resp, err := http.Post(server.URL+"/api/feedback", "application/json", strings.NewReader(`{"vote":"up","title":"x"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusNoContent {
+		t.Error("feedback should be disabled without a log")
+	}
+}